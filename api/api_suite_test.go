@@ -40,6 +40,7 @@ var (
 	userContextReader             *authfakes.FakeUserContextReader
 	fakeTokenGenerator            *authfakes.FakeTokenGenerator
 	providerFactory               *authfakes.FakeProviderFactory
+	workerRegistrationVerifier    *authfakes.FakeWorkerRegistrationVerifier
 	fakeEngine                    *enginefakes.FakeEngine
 	fakeWorkerClient              *workerfakes.FakeClient
 	authDB                        *authfakes.FakeAuthDB
@@ -103,6 +104,7 @@ var _ = BeforeEach(func() {
 	userContextReader = new(authfakes.FakeUserContextReader)
 	fakeTokenGenerator = new(authfakes.FakeTokenGenerator)
 	providerFactory = new(authfakes.FakeProviderFactory)
+	workerRegistrationVerifier = new(authfakes.FakeWorkerRegistrationVerifier)
 
 	configValidationErrorMessages = []string{}
 	configValidationWarnings = []config.Warning{}
@@ -136,6 +138,7 @@ var _ = BeforeEach(func() {
 		fakeTokenGenerator,
 		providerFactory,
 		oAuthBaseURL,
+		workerRegistrationVerifier,
 
 		pipelineDBFactory,
 		configDB,
@@ -17,7 +17,10 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	dbfakes "github.com/concourse/atc/db/fakes"
+	"github.com/concourse/atc/engine"
 	enginefakes "github.com/concourse/atc/engine/fakes"
+	"github.com/concourse/atc/event"
 )
 
 var _ = Describe("Builds API", func() {
@@ -843,6 +846,314 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/builds/:build_id/rerun", func() {
+		var from string
+
+		var response *http.Response
+
+		BeforeEach(func() {
+			from = "some-plan-id"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/rerun?from="+from, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				var original db.Build
+				var fakePipelineDB *dbfakes.FakePipelineDB
+
+				BeforeEach(func() {
+					original = db.Build{
+						ID:           128,
+						Name:         "3",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusFailed,
+					}
+
+					buildsDB.GetBuildReturns(original, true, nil)
+
+					fakePipelineDB = new(dbfakes.FakePipelineDB)
+					pipelineDBFactory.BuildWithTeamNameAndNameReturns(fakePipelineDB, nil)
+
+					buildsDB.GetConfigByBuildIDReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-job", Serial: true},
+						},
+					}, 1, nil)
+				})
+
+				Context("when creating the re-run succeeds", func() {
+					var rerun db.Build
+
+					BeforeEach(func() {
+						rerun = db.Build{
+							ID:           129,
+							Name:         "4",
+							JobName:      "some-job",
+							PipelineName: "some-pipeline",
+							Status:       db.StatusPending,
+							RerunOf:      128,
+						}
+
+						buildsDB.CreateRerunBuildReturns(rerun, nil)
+					})
+
+					Context("and the engine re-runs it", func() {
+						var fakeBuild *enginefakes.FakeBuild
+						var resumed <-chan struct{}
+
+						BeforeEach(func() {
+							fakeBuild = new(enginefakes.FakeBuild)
+
+							r := make(chan struct{})
+							resumed = r
+							fakeBuild.ResumeStub = func(lager.Logger) {
+								close(r)
+							}
+
+							fakeEngine.RerunBuildReturns(fakeBuild, nil)
+						})
+
+						It("returns 201 Created", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						})
+
+						It("returns the new build", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 129,
+								"name": "4",
+								"status": "pending",
+								"job_name": "some-job",
+								"pipeline_name": "some-pipeline",
+								"url": "/pipelines/some-pipeline/jobs/some-job/builds/4",
+								"api_url": "/api/v1/builds/129",
+								"rerun_of": 128
+							}`))
+						})
+
+						It("re-runs the original build from the given step and resumes it", func() {
+							Expect(buildsDB.CreateRerunBuildCallCount()).To(Equal(1))
+							Expect(buildsDB.CreateRerunBuildArgsForCall(0)).To(Equal(original))
+
+							Expect(fakeEngine.RerunBuildCallCount()).To(Equal(1))
+							_, build, rerunOriginal, rerunFrom := fakeEngine.RerunBuildArgsForCall(0)
+							Expect(build).To(Equal(rerun))
+							Expect(rerunOriginal).To(Equal(original))
+							Expect(rerunFrom).To(Equal(atc.PlanID("some-plan-id")))
+
+							<-resumed
+						})
+
+						It("looks up the original build's pipeline", func() {
+							Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(Equal(1))
+							teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+							Expect(teamName).To(Equal(atc.DefaultTeamName))
+							Expect(pipelineName).To(Equal("some-pipeline"))
+						})
+
+						It("checks the job's serial groups for running builds", func() {
+							Expect(fakePipelineDB.GetRunningBuildsBySerialGroupCallCount()).To(Equal(1))
+							jobName, serialGroups := fakePipelineDB.GetRunningBuildsBySerialGroupArgsForCall(0)
+							Expect(jobName).To(Equal("some-job"))
+							Expect(serialGroups).To(Equal(map[string]int{"some-job": 1}))
+						})
+
+						Context("when the original build is a one-off", func() {
+							BeforeEach(func() {
+								original.JobName = ""
+								original.PipelineName = ""
+								buildsDB.GetBuildReturns(original, true, nil)
+							})
+
+							It("does not check a job", func() {
+								Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(BeZero())
+								Expect(response.StatusCode).To(Equal(http.StatusCreated))
+							})
+						})
+					})
+
+					Context("and the plan does not have the step", func() {
+						BeforeEach(func() {
+							fakeEngine.RerunBuildReturns(nil, engine.UnknownPlanIDError{PlanID: "some-plan-id"})
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("finishes the new build as errored", func() {
+							Expect(buildsDB.FinishBuildCallCount()).To(Equal(1))
+
+							buildID, status := buildsDB.FinishBuildArgsForCall(0)
+							Expect(buildID).To(Equal(129))
+							Expect(status).To(Equal(db.StatusErrored))
+						})
+					})
+
+					Context("and the original build did not record a version needed by the re-run", func() {
+						BeforeEach(func() {
+							fakeEngine.RerunBuildReturns(nil, engine.UnrecordedVersionError{PlanID: "some-put-id"})
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("and the engine fails", func() {
+						BeforeEach(func() {
+							fakeEngine.RerunBuildReturns(nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+
+						It("finishes the new build as errored", func() {
+							Expect(buildsDB.FinishBuildCallCount()).To(Equal(1))
+						})
+					})
+				})
+
+				Context("when creating the re-run fails", func() {
+					BeforeEach(func() {
+						buildsDB.CreateRerunBuildReturns(db.Build{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not re-run anything", func() {
+						Expect(fakeEngine.RerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the pipeline is paused", func() {
+					BeforeEach(func() {
+						fakePipelineDB.IsPausedReturns(true, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not create a build", func() {
+						Expect(buildsDB.CreateRerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the job is paused", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetJobReturns(db.SavedJob{Paused: true}, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not create a build", func() {
+						Expect(buildsDB.CreateRerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the job's serial group or max in flight is full", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetRunningBuildsBySerialGroupReturns([]db.Build{{ID: 127}}, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not create a build", func() {
+						Expect(buildsDB.CreateRerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when checking the job fails", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetJobReturns(db.SavedJob{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not create a build", func() {
+						Expect(buildsDB.CreateRerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build is still running", func() {
+					BeforeEach(func() {
+						original.Status = db.StatusStarted
+						buildsDB.GetBuildReturns(original, true, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not create a build", func() {
+						Expect(buildsDB.CreateRerunBuildCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when no step is given", func() {
+				BeforeEach(func() {
+					from = ""
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{}, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not re-run the build", func() {
+				Expect(buildsDB.CreateRerunBuildCallCount()).To(BeZero())
+				Expect(fakeEngine.RerunBuildCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -861,6 +1172,7 @@ var _ = Describe("Builds API", func() {
 					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
 					PausedJob:        db.BuildPreparationStatusNotBlocking,
 					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					Schedule:         db.BuildPreparationStatusNotBlocking,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusUnknown,
 						"bar": db.BuildPreparationStatusBlocking,
@@ -888,6 +1200,7 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"schedule": "not_blocking",
 					"inputs": {
 						"foo": "unknown",
 						"bar": "blocking"
@@ -918,6 +1231,222 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/test-results", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/test-results")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build has test results", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildTestResultsReturns(atc.TestResults{
+					Passed:      3,
+					Failed:      1,
+					Skipped:     2,
+					FailedTests: []string{"some.TestThing"},
+				}, true, nil)
+			})
+
+			It("looks up the results for the build", func() {
+				Expect(buildsDB.GetBuildTestResultsCallCount()).To(Equal(1))
+				Expect(buildsDB.GetBuildTestResultsArgsForCall(0)).To(Equal(42))
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the test results", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"passed": 3,
+					"failed": 1,
+					"skipped": 2,
+					"failed_tests": ["some.TestThing"]
+				}`))
+			})
+		})
+
+		Context("when the build has no test results", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildTestResultsReturns(atc.TestResults{}, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when looking up the test results fails", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildTestResultsReturns(atc.TestResults{}, false, errors.New("nope"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/log", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/128/log")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				var fakeEventSource *dbfakes.FakeEventSource
+
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{
+						ID:      128,
+						JobName: "some-job",
+					}, true, nil)
+
+					logged := []atc.Event{
+						event.Log{Time: 1462330921, Payload: "some "},
+						event.InitializeTask{},
+						event.Log{Time: 1462330922, Payload: "output\nmore"},
+						event.Log{Time: 1462330983, Payload: " output\n"},
+						event.LogV50{Payload: "untimed output\n"},
+						event.LogV40{Payload: "older "},
+						event.LogV30{Payload: "untimed "},
+						event.LogV20{Payload: "output\n"},
+						event.LogV10{Payload: "ancient output\n"},
+						event.Log{Time: 1462330984, Payload: "cut"},
+						event.LogTruncated{Time: 1462330984, Scope: event.LogLimitScopeStep, Limit: 1024},
+					}
+
+					fakeEventSource = new(dbfakes.FakeEventSource)
+					fakeEventSource.NextStub = func() (atc.Event, error) {
+						call := fakeEventSource.NextCallCount() - 1
+						if call < len(logged) {
+							return logged[call], nil
+						}
+
+						return nil, db.ErrEndOfBuildEventStream
+					}
+
+					buildsDB.GetBuildEventsReturns(fakeEventSource, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns plain text", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+				})
+
+				It("reads the build's events from the start", func() {
+					buildID, from := buildsDB.GetBuildEventsArgsForCall(0)
+					Expect(buildID).To(Equal(128))
+					Expect(from).To(BeZero())
+				})
+
+				It("returns the log, with each line prefixed by the time it was started", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(Equal(
+						"2016-05-04T03:02:01Z some output\n" +
+							"2016-05-04T03:02:02Z more output\n" +
+							"untimed output\n" +
+							"older untimed output\n" +
+							"ancient output\n" +
+							"2016-05-04T03:03:04Z cut\n" +
+							"2016-05-04T03:03:04Z log truncated: step output exceeded 1024 bytes; the rest was discarded\n",
+					))
+				})
+
+				It("closes the event source", func() {
+					Eventually(fakeEventSource.CloseCallCount).Should(Equal(1))
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{}, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the build's events fails", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{ID: 128}, true, nil)
+					buildsDB.GetBuildEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+
+				buildsDB.GetBuildReturns(db.Build{
+					ID:      128,
+					JobName: "some-job",
+				}, true, nil)
+			})
+
+			Context("and the build is private", func() {
+				BeforeEach(func() {
+					buildsDB.GetConfigByBuildIDReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-job", Public: false},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not read the build's events", func() {
+					Expect(buildsDB.GetBuildEventsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("and the build is public", func() {
+				BeforeEach(func() {
+					buildsDB.GetConfigByBuildIDReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-job", Public: true},
+						},
+					}, 1, nil)
+
+					fakeEventSource := new(dbfakes.FakeEventSource)
+					fakeEventSource.NextReturns(nil, db.ErrEndOfBuildEventStream)
+					buildsDB.GetBuildEventsReturns(fakeEventSource, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan", func() {
 		var publicPlan atc.PublicBuildPlan
 
@@ -1011,4 +1540,226 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/plan/graph", func() {
+		var format string
+
+		var response *http.Response
+
+		BeforeEach(func() {
+			format = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/42/plan/graph?format=" + format)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build is found", func() {
+				var engineBuild *enginefakes.FakeBuild
+				var fakeEventSource *dbfakes.FakeEventSource
+
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{
+						ID:           42,
+						Name:         "7",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusFailed,
+					}, true, nil)
+
+					engineBuild = new(enginefakes.FakeBuild)
+					fakeEngine.LookupBuildReturns(engineBuild, nil)
+
+					plan := atc.Plan{
+						ID: "1",
+						Do: &atc.DoPlan{
+							{ID: "2", Get: &atc.GetPlan{Name: "some-input", Resource: "some-resource"}},
+							{ID: "3", Task: &atc.TaskPlan{Name: "some-task"}},
+							{ID: "4", Put: &atc.PutPlan{Resource: "some-output"}},
+						},
+					}
+
+					engineBuild.PublicPlanReturns(atc.PublicBuildPlan{
+						Schema: "exec.v2",
+						Plan:   plan.Public(),
+					}, true, nil)
+
+					emitted := []atc.Event{
+						event.StartStep{Type: event.StepTypeDo, Origin: event.Origin{ID: "1"}},
+						event.StartStep{Type: event.StepTypeGet, Origin: event.Origin{ID: "2"}},
+						event.FinishStep{Type: event.StepTypeGet, Succeeded: true, Origin: event.Origin{ID: "2"}},
+						event.StartStep{Type: event.StepTypeTask, Origin: event.Origin{ID: "3"}},
+						event.FinishStep{Type: event.StepTypeTask, Succeeded: false, Origin: event.Origin{ID: "3"}},
+						event.FinishStep{Type: event.StepTypeDo, Succeeded: false, Origin: event.Origin{ID: "1"}},
+					}
+
+					fakeEventSource = new(dbfakes.FakeEventSource)
+					fakeEventSource.NextStub = func() (atc.Event, error) {
+						call := fakeEventSource.NextCallCount() - 1
+						if call < len(emitted) {
+							return emitted[call], nil
+						}
+
+						return nil, db.ErrEndOfBuildEventStream
+					}
+
+					buildsDB.GetBuildEventsReturns(fakeEventSource, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns DOT", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz; charset=utf-8"))
+				})
+
+				It("graphs the plan, colored by each step's status", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(HavePrefix(`digraph "some-pipeline/some-job #7" {`))
+					Expect(string(body)).To(ContainSubstring(`"step:1" [label="do", shape=ellipse, fillcolor="#E74C3C"];`))
+					Expect(string(body)).To(ContainSubstring(`"step:2" [label="get: some-input", shape=box, fillcolor="#2ECC71"];`))
+					Expect(string(body)).To(ContainSubstring(`"step:3" [label="task: some-task", shape=box, fillcolor="#E74C3C"];`))
+					Expect(string(body)).To(ContainSubstring(`"step:4" [label="put: some-output", shape=box, fillcolor="#BDC3C7"];`))
+					Expect(string(body)).To(ContainSubstring(`"step:1" -> "step:2";`))
+					Expect(string(body)).To(ContainSubstring(`"step:1" -> "step:3";`))
+					Expect(string(body)).To(ContainSubstring(`"step:1" -> "step:4";`))
+				})
+
+				It("closes the event source", func() {
+					Expect(fakeEventSource.CloseCallCount()).To(Equal(1))
+				})
+
+				Context("when SVG is requested", func() {
+					BeforeEach(func() {
+						format = "svg"
+					})
+
+					It("returns SVG", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("image/svg+xml"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(ContainSubstring("<svg"))
+						Expect(string(body)).To(ContainSubstring("task: some-task"))
+					})
+				})
+
+				Context("when an unknown format is requested", func() {
+					BeforeEach(func() {
+						format = "png"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the build is still running", func() {
+					BeforeEach(func() {
+						buildsDB.GetBuildReturns(db.Build{
+							ID:      42,
+							JobName: "some-job",
+							Status:  db.StatusStarted,
+						}, true, nil)
+
+						buildsDB.GetBuildLifecycleEventsReturns([]atc.Event{
+							event.StartStep{Type: event.StepTypeDo, Origin: event.Origin{ID: "1"}},
+						}, nil)
+					})
+
+					It("graphs the events emitted so far", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(ContainSubstring(`"step:1" [label="do", shape=ellipse, fillcolor="#F1C40F"];`))
+					})
+
+					It("reads only the events saved so far, rather than streaming them", func() {
+						Expect(buildsDB.GetBuildLifecycleEventsCallCount()).To(Equal(1))
+						Expect(buildsDB.GetBuildLifecycleEventsArgsForCall(0)).To(Equal(42))
+						Expect(buildsDB.GetBuildEventsCallCount()).To(BeZero())
+					})
+
+					Context("when getting the build's events fails", func() {
+						BeforeEach(func() {
+							buildsDB.GetBuildLifecycleEventsReturns(nil, errors.New("oh no!"))
+						})
+
+						It("returns 500 Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when getting the build's events fails", func() {
+					BeforeEach(func() {
+						buildsDB.GetBuildEventsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the build has no plan", func() {
+					BeforeEach(func() {
+						engineBuild.PublicPlanReturns(atc.PublicBuildPlan{}, false, nil)
+					})
+
+					It("returns Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Context("when the build is not found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{}, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated and the build is private", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+
+				buildsDB.GetBuildReturns(db.Build{
+					ID:      42,
+					JobName: "some-job",
+				}, true, nil)
+
+				buildsDB.GetConfigByBuildIDReturns(atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: "some-job", Public: false},
+					},
+				}, 1, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not read the build's events", func() {
+				Expect(buildsDB.GetBuildEventsCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
 )
 
 func (s *Server) BuildEvents(w http.ResponseWriter, r *http.Request) {
@@ -28,30 +29,8 @@ func (s *Server) BuildEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !auth.IsAuthenticated(r) {
-		if build.OneOff() {
-			s.rejector.Unauthorized(w, r)
-			return
-		}
-
-		config, _, err := s.db.GetConfigByBuildID(build.ID)
-		if err != nil {
-			s.logger.Error("failed-to-get-config", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		public, err := config.JobIsPublic(build.JobName)
-		if err != nil {
-			s.logger.Error("failed-to-see-job-is-public", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		if !public {
-			s.rejector.Unauthorized(w, r)
-			return
-		}
+	if !s.allowedToViewBuild(w, r, build) {
+		return
 	}
 
 	streamDone := make(chan struct{})
@@ -66,3 +45,38 @@ func (s *Server) BuildEvents(w http.ResponseWriter, r *http.Request) {
 	case <-s.drain:
 	}
 }
+
+// allowedToViewBuild checks whether the request may see the build's output,
+// responding appropriately if not. Unauthenticated requests may only see the
+// builds of public jobs.
+func (s *Server) allowedToViewBuild(w http.ResponseWriter, r *http.Request, build db.Build) bool {
+	if auth.IsAuthenticated(r) {
+		return true
+	}
+
+	if build.OneOff() {
+		s.rejector.Unauthorized(w, r)
+		return false
+	}
+
+	config, _, err := s.db.GetConfigByBuildID(build.ID)
+	if err != nil {
+		s.logger.Error("failed-to-get-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	public, err := config.JobIsPublic(build.JobName)
+	if err != nil {
+		s.logger.Error("failed-to-see-job-is-public", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	if !public {
+		s.rejector.Unauthorized(w, r)
+		return false
+	}
+
+	return true
+}
@@ -77,6 +77,43 @@ type FakeBuildsDB struct {
 		result2 db.ConfigVersion
 		result3 error
 	}
+	CreateRerunBuildStub        func(original db.Build) (db.Build, error)
+	createRerunBuildMutex       sync.RWMutex
+	createRerunBuildArgsForCall []struct {
+		original db.Build
+	}
+	createRerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	FinishBuildStub        func(buildID int, status db.Status) error
+	finishBuildMutex       sync.RWMutex
+	finishBuildArgsForCall []struct {
+		buildID int
+		status  db.Status
+	}
+	finishBuildReturns struct {
+		result1 error
+	}
+	GetBuildTestResultsStub        func(buildID int) (atc.TestResults, bool, error)
+	getBuildTestResultsMutex       sync.RWMutex
+	getBuildTestResultsArgsForCall []struct {
+		buildID int
+	}
+	getBuildTestResultsReturns struct {
+		result1 atc.TestResults
+		result2 bool
+		result3 error
+	}
+	GetBuildLifecycleEventsStub        func(buildID int) ([]atc.Event, error)
+	getBuildLifecycleEventsMutex       sync.RWMutex
+	getBuildLifecycleEventsArgsForCall []struct {
+		buildID int
+	}
+	getBuildLifecycleEventsReturns struct {
+		result1 []atc.Event
+		result2 error
+	}
 }
 
 func (fake *FakeBuildsDB) GetBuild(buildID int) (db.Build, bool, error) {
@@ -308,4 +345,137 @@ func (fake *FakeBuildsDB) GetConfigByBuildIDReturns(result1 atc.Config, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildsDB) CreateRerunBuild(original db.Build) (db.Build, error) {
+	fake.createRerunBuildMutex.Lock()
+	fake.createRerunBuildArgsForCall = append(fake.createRerunBuildArgsForCall, struct {
+		original db.Build
+	}{original})
+	fake.createRerunBuildMutex.Unlock()
+	if fake.CreateRerunBuildStub != nil {
+		return fake.CreateRerunBuildStub(original)
+	} else {
+		return fake.createRerunBuildReturns.result1, fake.createRerunBuildReturns.result2
+	}
+}
+
+func (fake *FakeBuildsDB) CreateRerunBuildCallCount() int {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return len(fake.createRerunBuildArgsForCall)
+}
+
+func (fake *FakeBuildsDB) CreateRerunBuildArgsForCall(i int) db.Build {
+	fake.createRerunBuildMutex.RLock()
+	defer fake.createRerunBuildMutex.RUnlock()
+	return fake.createRerunBuildArgsForCall[i].original
+}
+
+func (fake *FakeBuildsDB) CreateRerunBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateRerunBuildStub = nil
+	fake.createRerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildsDB) FinishBuild(buildID int, status db.Status) error {
+	fake.finishBuildMutex.Lock()
+	fake.finishBuildArgsForCall = append(fake.finishBuildArgsForCall, struct {
+		buildID int
+		status  db.Status
+	}{buildID, status})
+	fake.finishBuildMutex.Unlock()
+	if fake.FinishBuildStub != nil {
+		return fake.FinishBuildStub(buildID, status)
+	} else {
+		return fake.finishBuildReturns.result1
+	}
+}
+
+func (fake *FakeBuildsDB) FinishBuildCallCount() int {
+	fake.finishBuildMutex.RLock()
+	defer fake.finishBuildMutex.RUnlock()
+	return len(fake.finishBuildArgsForCall)
+}
+
+func (fake *FakeBuildsDB) FinishBuildArgsForCall(i int) (int, db.Status) {
+	fake.finishBuildMutex.RLock()
+	defer fake.finishBuildMutex.RUnlock()
+	return fake.finishBuildArgsForCall[i].buildID, fake.finishBuildArgsForCall[i].status
+}
+
+func (fake *FakeBuildsDB) FinishBuildReturns(result1 error) {
+	fake.FinishBuildStub = nil
+	fake.finishBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildsDB) GetBuildTestResults(buildID int) (atc.TestResults, bool, error) {
+	fake.getBuildTestResultsMutex.Lock()
+	fake.getBuildTestResultsArgsForCall = append(fake.getBuildTestResultsArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.getBuildTestResultsMutex.Unlock()
+	if fake.GetBuildTestResultsStub != nil {
+		return fake.GetBuildTestResultsStub(buildID)
+	} else {
+		return fake.getBuildTestResultsReturns.result1, fake.getBuildTestResultsReturns.result2, fake.getBuildTestResultsReturns.result3
+	}
+}
+
+func (fake *FakeBuildsDB) GetBuildTestResultsCallCount() int {
+	fake.getBuildTestResultsMutex.RLock()
+	defer fake.getBuildTestResultsMutex.RUnlock()
+	return len(fake.getBuildTestResultsArgsForCall)
+}
+
+func (fake *FakeBuildsDB) GetBuildTestResultsArgsForCall(i int) int {
+	fake.getBuildTestResultsMutex.RLock()
+	defer fake.getBuildTestResultsMutex.RUnlock()
+	return fake.getBuildTestResultsArgsForCall[i].buildID
+}
+
+func (fake *FakeBuildsDB) GetBuildTestResultsReturns(result1 atc.TestResults, result2 bool, result3 error) {
+	fake.GetBuildTestResultsStub = nil
+	fake.getBuildTestResultsReturns = struct {
+		result1 atc.TestResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildsDB) GetBuildLifecycleEvents(buildID int) ([]atc.Event, error) {
+	fake.getBuildLifecycleEventsMutex.Lock()
+	fake.getBuildLifecycleEventsArgsForCall = append(fake.getBuildLifecycleEventsArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.getBuildLifecycleEventsMutex.Unlock()
+	if fake.GetBuildLifecycleEventsStub != nil {
+		return fake.GetBuildLifecycleEventsStub(buildID)
+	} else {
+		return fake.getBuildLifecycleEventsReturns.result1, fake.getBuildLifecycleEventsReturns.result2
+	}
+}
+
+func (fake *FakeBuildsDB) GetBuildLifecycleEventsCallCount() int {
+	fake.getBuildLifecycleEventsMutex.RLock()
+	defer fake.getBuildLifecycleEventsMutex.RUnlock()
+	return len(fake.getBuildLifecycleEventsArgsForCall)
+}
+
+func (fake *FakeBuildsDB) GetBuildLifecycleEventsArgsForCall(i int) int {
+	fake.getBuildLifecycleEventsMutex.RLock()
+	defer fake.getBuildLifecycleEventsMutex.RUnlock()
+	return fake.getBuildLifecycleEventsArgsForCall[i].buildID
+}
+
+func (fake *FakeBuildsDB) GetBuildLifecycleEventsReturns(result1 []atc.Event, result2 error) {
+	fake.GetBuildLifecycleEventsStub = nil
+	fake.getBuildLifecycleEventsReturns = struct {
+		result1 []atc.Event
+		result2 error
+	}{result1, result2}
+}
+
 var _ buildserver.BuildsDB = new(FakeBuildsDB)
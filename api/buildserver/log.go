@@ -0,0 +1,172 @@
+package buildserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) GetBuildLog(w http.ResponseWriter, r *http.Request) {
+	buildIDStr := r.FormValue(":build_id")
+	log := s.logger.Session("build-log", lager.Data{"build-id": buildIDStr})
+
+	buildID, err := strconv.Atoi(buildIDStr)
+	if err != nil {
+		log.Error("cannot-parse-build-id", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	build, found, err := s.db.GetBuild(buildID)
+	if err != nil {
+		log.Error("failed-to-get-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !s.allowedToViewBuild(w, r, build) {
+		return
+	}
+
+	events, err := s.db.GetBuildEvents(buildID, 0)
+	if err != nil {
+		log.Error("failed-to-get-build-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	defer events.Close()
+
+	flusher := w.(http.Flusher)
+	closed := w.(http.CloseNotifier).CloseNotify()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	es := make(chan atc.Event)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+
+	defer close(done)
+
+	go func() {
+		for {
+			ev, err := events.Next()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case es <- ev:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	writer := &timestampedLogWriter{w: w, lineStart: true}
+
+	for {
+		select {
+		case ev := <-es:
+			var err error
+
+			switch e := ev.(type) {
+			case event.Log:
+				err = writer.Write(time.Unix(e.Time, 0), e.Payload)
+			case event.LogV50:
+				err = writer.Write(time.Time{}, e.Payload)
+			case event.LogV40:
+				err = writer.Write(time.Time{}, e.Payload)
+			case event.LogV30:
+				err = writer.Write(time.Time{}, e.Payload)
+			case event.LogV20:
+				err = writer.Write(time.Time{}, e.Payload)
+			case event.LogV10:
+				err = writer.Write(time.Time{}, e.Payload)
+			case event.LogTruncated:
+				err = writer.WriteLine(time.Unix(e.Time, 0), fmt.Sprintf(
+					"log truncated: %s output exceeded %d bytes; the rest was discarded",
+					e.Scope,
+					e.Limit,
+				))
+			default:
+				continue
+			}
+
+			if err != nil {
+				return
+			}
+
+			flusher.Flush()
+		case err := <-errs:
+			if err != db.ErrEndOfBuildEventStream {
+				log.Error("failed-to-read-build-events", err)
+			}
+
+			return
+		case <-closed:
+			return
+		case <-s.drain:
+			return
+		}
+	}
+}
+
+// timestampedLogWriter prefixes each line of output with the time at which
+// the chunk that began it was logged. Output logged before log events were
+// timestamped is written as-is.
+type timestampedLogWriter struct {
+	w         io.Writer
+	lineStart bool
+}
+
+func (writer *timestampedLogWriter) Write(logged time.Time, payload string) error {
+	for _, line := range strings.SplitAfter(payload, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if writer.lineStart && !logged.IsZero() {
+			_, err := io.WriteString(writer.w, logged.UTC().Format(time.RFC3339)+" ")
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := io.WriteString(writer.w, line)
+		if err != nil {
+			return err
+		}
+
+		writer.lineStart = strings.HasSuffix(line, "\n")
+	}
+
+	return nil
+}
+
+// WriteLine writes the given text on a line of its own.
+func (writer *timestampedLogWriter) WriteLine(logged time.Time, text string) error {
+	if !writer.lineStart {
+		err := writer.Write(time.Time{}, "\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Write(logged, text+"\n")
+}
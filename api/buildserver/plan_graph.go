@@ -0,0 +1,132 @@
+package buildserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/graph"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) GetBuildPlanGraph(w http.ResponseWriter, r *http.Request) {
+	buildIDStr := r.FormValue(":build_id")
+	log := s.logger.Session("get-build-plan-graph", lager.Data{"build-id": buildIDStr})
+
+	buildID, err := strconv.Atoi(buildIDStr)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	format, err := graph.ParseFormat(r.FormValue("format"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	build, found, err := s.db.GetBuild(buildID)
+	if err != nil {
+		log.Error("failed-to-get-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// step statuses come from the build's events, so this is as private as
+	// the events themselves
+	if !s.allowedToViewBuild(w, r, build) {
+		return
+	}
+
+	engineBuild, err := s.engine.LookupBuild(log, build)
+	if err != nil {
+		log.Error("failed-to-lookup-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	plan, found, err := engineBuild.PublicPlan(log)
+	if err != nil {
+		log.Error("failed-to-generate-plan", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	statuses, err := s.stepStatuses(build)
+	if err != nil {
+		log.Error("failed-to-get-step-statuses", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	planGraph, err := graph.BuildPlan(buildGraphName(build), plan, statuses)
+	if err != nil {
+		log.Error("failed-to-graph-plan", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+
+	planGraph.Write(w, format)
+}
+
+func (s *Server) stepStatuses(build db.Build) (graph.StepStatuses, error) {
+	statuses := graph.StepStatuses{}
+
+	// a running build's event stream waits for more events, so read just the
+	// ones saved so far instead
+	if build.IsRunning() {
+		events, err := s.db.GetBuildLifecycleEvents(build.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ev := range events {
+			statuses.Observe(ev)
+		}
+
+		return statuses, nil
+	}
+
+	events, err := s.db.GetBuildEvents(build.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	defer events.Close()
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				return statuses, nil
+			}
+
+			return nil, err
+		}
+
+		statuses.Observe(ev)
+	}
+}
+
+func buildGraphName(build db.Build) string {
+	if build.OneOff() {
+		return fmt.Sprintf("build %d", build.ID)
+	}
+
+	return fmt.Sprintf("%s/%s #%s", build.PipelineName, build.JobName, build.Name)
+}
@@ -0,0 +1,152 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) RerunBuild(w http.ResponseWriter, r *http.Request) {
+	buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	from := atc.PlanID(r.FormValue("from"))
+	if from == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	hLog := s.logger.Session("rerun-build", lager.Data{
+		"build": buildID,
+		"from":  from,
+	})
+
+	original, found, err := s.db.GetBuild(buildID)
+	if err != nil {
+		hLog.Error("failed-to-get-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if original.IsRunning() {
+		hLog.Info("build-still-running")
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if !original.OneOff() {
+		blocked, err := s.rerunBlocked(original)
+		if err != nil {
+			hLog.Error("failed-to-check-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if blocked != "" {
+			hLog.Info("rerun-blocked", lager.Data{"reason": blocked})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	}
+
+	build, err := s.db.CreateRerunBuild(original)
+	if err != nil {
+		hLog.Error("failed-to-create-rerun-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	engineBuild, err := s.engine.RerunBuild(hLog, build, original, from)
+	if err != nil {
+		hLog.Error("failed-to-start-build", err)
+
+		// the scheduler never picks up re-runs, so don't leave it pending
+		finishErr := s.db.FinishBuild(build.ID, db.StatusErrored)
+		if finishErr != nil {
+			hLog.Error("failed-to-finish-build", finishErr)
+		}
+
+		switch err.(type) {
+		case engine.UnknownPlanIDError, engine.UnrecordedVersionError:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	go engineBuild.Resume(hLog)
+
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(present.Build(build))
+}
+
+// rerunBlocked returns why the scheduler would hold back a new build of the
+// original build's job, if it would. Re-runs are started straight away rather
+// than going through the scheduler, so they are refused instead.
+func (s *Server) rerunBlocked(original db.Build) (string, error) {
+	pipelineDB, err := s.pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, original.PipelineName)
+	if err != nil {
+		return "", err
+	}
+
+	paused, err := pipelineDB.IsPaused()
+	if err != nil {
+		return "", err
+	}
+
+	if paused {
+		return "pipeline-paused", nil
+	}
+
+	job, err := pipelineDB.GetJob(original.JobName)
+	if err != nil {
+		return "", err
+	}
+
+	if job.Paused {
+		return "job-paused", nil
+	}
+
+	config, _, err := s.db.GetConfigByBuildID(original.ID)
+	if err != nil {
+		return "", err
+	}
+
+	jobConfig, found := config.Jobs.Lookup(original.JobName)
+	if !found {
+		return "", nil
+	}
+
+	serialGroupLimits := config.SerialGroupLimits(jobConfig)
+	if len(serialGroupLimits) == 0 {
+		return "", nil
+	}
+
+	running, err := pipelineDB.GetRunningBuildsBySerialGroup(original.JobName, serialGroupLimits)
+	if err != nil {
+		return "", err
+	}
+
+	if len(running) > 0 {
+		return "max-in-flight-reached", nil
+	}
+
+	return "", nil
+}
@@ -23,6 +23,7 @@ type Server struct {
 	workerClient        worker.Client
 	db                  BuildsDB
 	configDB            db.ConfigDB
+	pipelineDBFactory   db.PipelineDBFactory
 	eventHandlerFactory EventHandlerFactory
 	drain               <-chan struct{}
 	rejector            auth.Rejector
@@ -35,12 +36,16 @@ type Server struct {
 type BuildsDB interface {
 	GetBuild(buildID int) (db.Build, bool, error)
 	GetBuildEvents(buildID int, from uint) (db.EventSource, error)
+	GetBuildLifecycleEvents(buildID int) ([]atc.Event, error)
 	GetBuildResources(buildID int) ([]db.BuildInput, []db.BuildOutput, error)
 	GetBuildPreparation(buildID int) (db.BuildPreparation, bool, error)
+	GetBuildTestResults(buildID int) (atc.TestResults, bool, error)
 
 	GetBuilds(db.Page) ([]db.Build, db.Pagination, error)
 
 	CreateOneOffBuild() (db.Build, error)
+	CreateRerunBuild(original db.Build) (db.Build, error)
+	FinishBuild(buildID int, status db.Status) error
 	GetConfigByBuildID(buildID int) (atc.Config, db.ConfigVersion, error)
 }
 
@@ -51,6 +56,7 @@ func NewServer(
 	workerClient worker.Client,
 	db BuildsDB,
 	configDB db.ConfigDB,
+	pipelineDBFactory db.PipelineDBFactory,
 	eventHandlerFactory EventHandlerFactory,
 	drain <-chan struct{},
 ) *Server {
@@ -63,6 +69,7 @@ func NewServer(
 		workerClient:        workerClient,
 		db:                  db,
 		configDB:            configDB,
+		pipelineDBFactory:   pipelineDBFactory,
 		eventHandlerFactory: eventHandlerFactory,
 		drain:               drain,
 
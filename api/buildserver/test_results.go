@@ -0,0 +1,36 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) GetBuildTestResults(w http.ResponseWriter, r *http.Request) {
+	buildIDStr := r.FormValue(":build_id")
+	log := s.logger.Session("build-test-results", lager.Data{"build-id": buildIDStr})
+
+	buildID, err := strconv.Atoi(buildIDStr)
+	if err != nil {
+		log.Error("cannot-parse-build-id", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	results, found, err := s.db.GetBuildTestResults(buildID)
+	if err != nil {
+		log.Error("cannot-find-build-test-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(results)
+}
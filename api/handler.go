@@ -40,6 +40,7 @@ func NewHandler(
 	tokenGenerator auth.TokenGenerator,
 	providerFactory auth.ProviderFactory,
 	oAuthBaseURL string,
+	workerRegistrationVerifier auth.WorkerRegistrationVerifier,
 
 	pipelineDBFactory db.PipelineDBFactory,
 	configDB db.ConfigDB,
@@ -90,11 +91,12 @@ func NewHandler(
 		workerClient,
 		buildsDB,
 		configDB,
+		pipelineDBFactory,
 		eventHandlerFactory,
 		drain,
 	)
 
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, engine)
 	resourceServer := resourceserver.NewServer(logger)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
@@ -103,7 +105,7 @@ func NewHandler(
 
 	configServer := configserver.NewServer(logger, configDB, configValidator)
 
-	workerServer := workerserver.NewServer(logger, workerDB)
+	workerServer := workerserver.NewServer(logger, workerDB, workerRegistrationVerifier)
 
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 
@@ -128,8 +130,12 @@ func NewHandler(
 		atc.BuildEvents:         http.HandlerFunc(buildServer.BuildEvents),
 		atc.BuildResources:      http.HandlerFunc(buildServer.BuildResources),
 		atc.AbortBuild:          http.HandlerFunc(buildServer.AbortBuild),
+		atc.RerunBuild:          http.HandlerFunc(buildServer.RerunBuild),
 		atc.GetBuildPlan:        http.HandlerFunc(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: http.HandlerFunc(buildServer.GetBuildPreparation),
+		atc.GetBuildTestResults: http.HandlerFunc(buildServer.GetBuildTestResults),
+		atc.GetBuildLog:         http.HandlerFunc(buildServer.GetBuildLog),
+		atc.GetBuildPlanGraph:   http.HandlerFunc(buildServer.GetBuildPlanGraph),
 
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
 		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
@@ -140,19 +146,29 @@ func NewHandler(
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
 
-		atc.ListPipelines:   http.HandlerFunc(pipelineServer.ListPipelines),
-		atc.GetPipeline:     http.HandlerFunc(pipelineServer.GetPipeline),
-		atc.DeletePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
-		atc.OrderPipelines:  http.HandlerFunc(pipelineServer.OrderPipelines),
-		atc.PausePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
-		atc.UnpausePipeline: pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
-		atc.GetVersionsDB:   pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
+		atc.ListPendingBuilds:      pipelineHandlerFactory.HandlerFor(jobServer.ListPendingBuilds),
+		atc.PrioritizePendingBuild: pipelineHandlerFactory.HandlerFor(jobServer.PrioritizePendingBuild),
+		atc.CancelPendingBuild:     pipelineHandlerFactory.HandlerFor(jobServer.CancelPendingBuild),
+
+		atc.ListPipelines:    http.HandlerFunc(pipelineServer.ListPipelines),
+		atc.GetPipeline:      http.HandlerFunc(pipelineServer.GetPipeline),
+		atc.DeletePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
+		atc.OrderPipelines:   http.HandlerFunc(pipelineServer.OrderPipelines),
+		atc.PausePipeline:    pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
+		atc.UnpausePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
+		atc.GetVersionsDB:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
+		atc.GetPipelineGraph: http.HandlerFunc(pipelineServer.GetPipelineGraph),
 
 		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
 		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
 		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
 
+		atc.ListResourceChecks: pipelineHandlerFactory.HandlerFor(resourceServer.ListResourceChecks),
+
+		atc.PinResourceTypeVersion:   pipelineHandlerFactory.HandlerFor(resourceServer.PinResourceTypeVersion),
+		atc.UnpinResourceTypeVersion: pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResourceTypeVersion),
+
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	dbfakes "github.com/concourse/atc/db/fakes"
+	enginefakes "github.com/concourse/atc/engine/fakes"
 	schedulerfakes "github.com/concourse/atc/scheduler/fakes"
 )
 
@@ -644,7 +645,7 @@ var _ = Describe("Jobs API", func() {
 					It("triggers using the current config", func() {
 						Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
 
-						_, job, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+						_, job, pipelineConfig, overrideSchedule := fakeScheduler.TriggerImmediatelyArgsForCall(0)
 						Expect(job).To(Equal(atc.JobConfig{
 							Name: "some-job",
 							Plan: atc.PlanSequence{
@@ -653,13 +654,30 @@ var _ = Describe("Jobs API", func() {
 								},
 							},
 						}))
-						Expect(resources).To(Equal(atc.ResourceConfigs{
+						Expect(pipelineConfig.Resources).To(Equal(atc.ResourceConfigs{
 							{Name: "resource-1", Type: "some-type"},
 							{Name: "resource-2", Type: "some-other-type"},
 						}))
-						Expect(resourceTypes).To(Equal(atc.ResourceTypes{
+						Expect(pipelineConfig.ResourceTypes).To(Equal(atc.ResourceTypes{
 							{Name: "custom-resource", Type: "custom-type"},
 						}))
+						Expect(overrideSchedule).To(BeFalse())
+					})
+
+					Context("when the schedule is explicitly overridden", func() {
+						BeforeEach(func() {
+							var err error
+
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/pipelines/some-pipeline/jobs/some-job/builds?override_schedule=true", nil)
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("triggers the build regardless of the schedule", func() {
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+							_, _, _, overrideSchedule := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							Expect(overrideSchedule).To(BeTrue())
+						})
 					})
 
 					It("returns 200 OK", func() {
@@ -1149,4 +1167,315 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/pipelines/:pipeline_name/pending-builds", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/pending-builds")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when getting the config succeeds", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:         "some-job",
+							SerialGroups: []string{"some-group"},
+						},
+						{
+							Name:         "some-other-job",
+							SerialGroups: []string{"some-group"},
+						},
+					},
+				}, 1, true, nil)
+
+				pipelineDB.GetRunningBuildsReturns([]db.Build{
+					{
+						ID:           1,
+						Name:         "1",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusStarted,
+					},
+				}, nil)
+
+				pipelineDB.GetPendingBuildsReturns([]db.Build{
+					{
+						ID:               2,
+						Name:             "1",
+						JobName:          "some-other-job",
+						PipelineName:     "some-pipeline",
+						Status:           db.StatusPending,
+						InputsDetermined: true,
+					},
+					{
+						ID:           3,
+						Name:         "2",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusPending,
+					},
+				}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the queue for each serial group", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"serial_group": "some-group",
+						"running_builds": [
+							{
+								"id": 1,
+								"name": "1",
+								"status": "started",
+								"job_name": "some-job",
+								"url": "/pipelines/some-pipeline/jobs/some-job/builds/1",
+								"api_url": "/api/v1/builds/1",
+								"pipeline_name": "some-pipeline"
+							}
+						],
+						"pending_builds": [
+							{
+								"build": {
+									"id": 2,
+									"name": "1",
+									"status": "pending",
+									"job_name": "some-other-job",
+									"url": "/pipelines/some-pipeline/jobs/some-other-job/builds/1",
+									"api_url": "/api/v1/builds/2",
+									"pipeline_name": "some-pipeline"
+								},
+								"reason": "serial_group_held",
+								"blocking_build_ids": [1]
+							},
+							{
+								"build": {
+									"id": 3,
+									"name": "2",
+									"status": "pending",
+									"job_name": "some-job",
+									"url": "/pipelines/some-pipeline/jobs/some-job/builds/2",
+									"api_url": "/api/v1/builds/3",
+									"pipeline_name": "some-pipeline"
+								},
+								"reason": "inputs_not_determined"
+							}
+						]
+					}
+				]`))
+			})
+
+			Context("when getting the pending builds fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetPendingBuildsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the running builds fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetRunningBuildsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the config is not found", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{}, 0, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/pending-builds/:build_id/prioritize", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/some-pipeline/pending-builds/42/prioritize", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build is pending", func() {
+				BeforeEach(func() {
+					pipelineDB.PrioritizePendingBuildReturns(true, nil)
+				})
+
+				It("prioritizes the right build", func() {
+					Expect(pipelineDB.PrioritizePendingBuildCallCount()).To(Equal(1))
+					Expect(pipelineDB.PrioritizePendingBuildArgsForCall(0)).To(Equal(42))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the build is not pending", func() {
+				BeforeEach(func() {
+					pipelineDB.PrioritizePendingBuildReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when prioritizing fails", func() {
+				BeforeEach(func() {
+					pipelineDB.PrioritizePendingBuildReturns(false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/pipelines/:pipeline_name/pending-builds/:build_id", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/pipelines/some-pipeline/pending-builds/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				pipelineDB.GetPipelineNameReturns("some-pipeline")
+			})
+
+			Context("when the build is pending", func() {
+				var engineBuild *enginefakes.FakeBuild
+
+				BeforeEach(func() {
+					pipelineDB.GetBuildReturns(db.Build{
+						ID:           42,
+						PipelineName: "some-pipeline",
+						Status:       db.StatusPending,
+					}, true, nil)
+
+					engineBuild = new(enginefakes.FakeBuild)
+					fakeEngine.LookupBuildReturns(engineBuild, nil)
+				})
+
+				It("aborts the build", func() {
+					Expect(fakeEngine.LookupBuildCallCount()).To(Equal(1))
+					_, build := fakeEngine.LookupBuildArgsForCall(0)
+					Expect(build.ID).To(Equal(42))
+
+					Expect(engineBuild.AbortCallCount()).To(Equal(1))
+				})
+
+				It("returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				Context("when aborting fails", func() {
+					BeforeEach(func() {
+						engineBuild.AbortReturns(errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the build has already started", func() {
+				BeforeEach(func() {
+					pipelineDB.GetBuildReturns(db.Build{
+						ID:           42,
+						PipelineName: "some-pipeline",
+						Status:       db.StatusStarted,
+					}, true, nil)
+				})
+
+				It("returns 409 and does not abort it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(fakeEngine.LookupBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the build belongs to another pipeline", func() {
+				BeforeEach(func() {
+					pipelineDB.GetBuildReturns(db.Build{
+						ID:           42,
+						PipelineName: "some-other-pipeline",
+						Status:       db.StatusPending,
+					}, true, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is not found", func() {
+				BeforeEach(func() {
+					pipelineDB.GetBuildReturns(db.Build{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,56 @@
+package jobserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) CancelPendingBuild(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		logger := s.logger.Session("cancel-pending-build", lager.Data{
+			"build": buildID,
+		})
+
+		build, found, err := pipelineDB.GetBuild(buildID)
+		if err != nil {
+			logger.Error("failed-to-get-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found || build.PipelineName != pipelineDB.GetPipelineName() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if build.Status != db.StatusPending {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		engineBuild, err := s.engine.LookupBuild(logger, build)
+		if err != nil {
+			logger.Error("failed-to-lookup-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = engineBuild.Abort(logger)
+		if err != nil {
+			logger.Error("failed-to-abort-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -35,7 +35,9 @@ func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
 
 		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, config.Resources, config.ResourceTypes)
+		overrideSchedule := r.FormValue("override_schedule") == "true"
+
+		build, _, err := scheduler.TriggerImmediately(logger, job, config, overrideSchedule)
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
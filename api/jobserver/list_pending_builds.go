@@ -0,0 +1,51 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler"
+)
+
+func (s *Server) ListPendingBuilds(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("list-pending-builds")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		config, _, found, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		runningBuilds, err := pipelineDB.GetRunningBuilds()
+		if err != nil {
+			logger.Error("failed-to-get-running-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		pendingBuilds, err := pipelineDB.GetPendingBuilds()
+		if err != nil {
+			logger.Error("failed-to-get-pending-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		queues := []atc.SerialGroupQueue{}
+		for _, queue := range scheduler.SerialGroupQueues(config, runningBuilds, pendingBuilds) {
+			queues = append(queues, present.SerialGroupQueue(queue))
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(queues)
+	})
+}
@@ -0,0 +1,37 @@
+package jobserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) PrioritizePendingBuild(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		logger := s.logger.Session("prioritize-pending-build", lager.Data{
+			"build": buildID,
+		})
+
+		prioritized, err := pipelineDB.PrioritizePendingBuild(buildID)
+		if err != nil {
+			logger.Error("failed-to-prioritize-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !prioritized {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
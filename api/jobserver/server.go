@@ -2,6 +2,7 @@ package jobserver
 
 import (
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/scheduler"
 	"github.com/pivotal-golang/lager"
 )
@@ -17,16 +18,19 @@ type Server struct {
 
 	schedulerFactory SchedulerFactory
 	externalURL      string
+	engine           engine.Engine
 }
 
 func NewServer(
 	logger lager.Logger,
 	schedulerFactory SchedulerFactory,
 	externalURL string,
+	engine engine.Engine,
 ) *Server {
 	return &Server{
 		logger:           logger,
 		schedulerFactory: schedulerFactory,
 		externalURL:      externalURL,
+		engine:           engine,
 	}
 }
@@ -624,4 +624,115 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/pipelines/:pipeline_name/graph", func() {
+		var format string
+
+		var response *http.Response
+
+		BeforeEach(func() {
+			format = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/graph?format=" + format)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the pipeline exists", func() {
+			BeforeEach(func() {
+				configDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-repo", Type: "git"},
+						{Name: "some-release", Type: "s3"},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "unit",
+							Plan: atc.PlanSequence{
+								{Get: "some-repo", Trigger: true},
+							},
+						},
+						{
+							Name: "ship",
+							Plan: atc.PlanSequence{
+								{Get: "some-repo", Passed: []string{"unit"}},
+								{Put: "some-release"},
+							},
+						},
+					},
+				}, 42, nil)
+			})
+
+			It("gets the default team's pipeline config", func() {
+				teamName, pipelineName := configDB.GetConfigArgsForCall(0)
+				Expect(teamName).To(Equal(atc.DefaultTeamName))
+				Expect(pipelineName).To(Equal("some-pipeline"))
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the graph as DOT", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz; charset=utf-8"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).To(HavePrefix(`digraph "some-pipeline" {`))
+				Expect(string(body)).To(ContainSubstring(`"resource:some-repo" -> "job:unit";`))
+				Expect(string(body)).To(ContainSubstring(`"job:unit" -> "job:ship" [label="some-repo", style=dashed];`))
+				Expect(string(body)).To(ContainSubstring(`"job:ship" -> "resource:some-release";`))
+			})
+
+			Context("when SVG is requested", func() {
+				BeforeEach(func() {
+					format = "svg"
+				})
+
+				It("returns the graph as SVG", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("image/svg+xml"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(ContainSubstring("<svg"))
+					Expect(string(body)).To(ContainSubstring(">ship</text>"))
+				})
+			})
+
+			Context("when an unknown format is requested", func() {
+				BeforeEach(func() {
+					format = "png"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				configDB.GetConfigReturns(atc.Config{}, 0, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the config fails", func() {
+			BeforeEach(func() {
+				configDB.GetConfigReturns(atc.Config{}, 0, errors.New("oh no!"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
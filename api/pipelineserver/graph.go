@@ -0,0 +1,37 @@
+package pipelineserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/graph"
+)
+
+func (s *Server) GetPipelineGraph(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-pipeline-graph")
+	pipelineName := r.FormValue(":pipeline_name")
+
+	format, err := graph.ParseFormat(r.FormValue("format"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	config, version, err := s.configDB.GetConfig(atc.DefaultTeamName, pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if version == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+
+	graph.Pipeline(pipelineName, config).Write(w, format)
+}
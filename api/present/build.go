@@ -40,6 +40,7 @@ func Build(build db.Build) atc.Build {
 		PipelineName: build.PipelineName,
 		URL:          reqURL,
 		APIURL:       apiURL,
+		RerunOf:      build.RerunOf,
 	}
 
 	if !build.StartTime.IsZero() {
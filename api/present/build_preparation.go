@@ -17,6 +17,7 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		PausedPipeline:   atc.BuildPreparationStatus(preparation.PausedPipeline),
 		PausedJob:        atc.BuildPreparationStatus(preparation.PausedJob),
 		MaxRunningBuilds: atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		Schedule:         atc.BuildPreparationStatus(preparation.Schedule),
 		Inputs:           inputs,
 		InputsSatisfied:  atc.BuildPreparationStatus(preparation.InputsSatisfied),
 	}
@@ -63,6 +63,7 @@ func Job(dbJob db.SavedJob, job atc.JobConfig, groups atc.GroupConfigs, finished
 		Name:          job.Name,
 		URL:           req.URL.String(),
 		Paused:        dbJob.Paused,
+		PausedReason:  dbJob.PausedReason,
 		FinishedBuild: presentedFinishedBuild,
 		NextBuild:     presentedNextBuild,
 
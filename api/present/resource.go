@@ -33,6 +33,16 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 		checkErrString = dbResource.CheckError.Error()
 	}
 
+	var checkBackoff string
+	if dbResource.CheckBackoff > 0 {
+		checkBackoff = dbResource.CheckBackoff.String()
+	}
+
+	var nextCheck int64
+	if !dbResource.NextCheck.IsZero() {
+		nextCheck = dbResource.NextCheck.Unix()
+	}
+
 	return atc.Resource{
 		Name:   resource.Name,
 		Type:   resource.Type,
@@ -43,5 +53,7 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 
 		FailingToCheck: dbResource.FailingToCheck(),
 		CheckError:     checkErrString,
+		CheckBackoff:   checkBackoff,
+		NextCheck:      nextCheck,
 	}
 }
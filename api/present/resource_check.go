@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ResourceCheck(check db.ResourceCheck) atc.ResourceCheck {
+	return atc.ResourceCheck{
+		ID:            check.ID,
+		StartTime:     check.StartTime.Unix(),
+		EndTime:       check.EndTime.Unix(),
+		WorkerName:    check.WorkerName,
+		VersionsFound: check.VersionsFound,
+		ExitStatus:    check.ExitStatus,
+		Stderr:        check.Stderr,
+		Error:         check.Error,
+	}
+}
@@ -0,0 +1,28 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler"
+)
+
+func SerialGroupQueue(queue scheduler.SerialGroupQueue) atc.SerialGroupQueue {
+	runningBuilds := []atc.Build{}
+	for _, build := range queue.RunningBuilds {
+		runningBuilds = append(runningBuilds, Build(build))
+	}
+
+	pendingBuilds := []atc.PendingBuild{}
+	for _, queued := range queue.PendingBuilds {
+		pendingBuilds = append(pendingBuilds, atc.PendingBuild{
+			Build:            Build(queued.Build),
+			Reason:           queued.Reason,
+			BlockingBuildIDs: queued.BlockingBuildIDs,
+		})
+	}
+
+	return atc.SerialGroupQueue{
+		SerialGroup:   queue.SerialGroup,
+		RunningBuilds: runningBuilds,
+		PendingBuilds: pendingBuilds,
+	}
+}
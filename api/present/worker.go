@@ -5,14 +5,19 @@ import (
 	"github.com/concourse/atc/db"
 )
 
-func Worker(workerInfo db.WorkerInfo) atc.Worker {
+func Worker(savedWorker db.SavedWorker) atc.Worker {
+	workerInfo := savedWorker.WorkerInfo
+
 	return atc.Worker{
 		GardenAddr:       workerInfo.GardenAddr,
 		BaggageclaimURL:  workerInfo.BaggageclaimURL,
 		ActiveContainers: workerInfo.ActiveContainers,
+		MaxContainers:    workerInfo.MaxContainers,
 		ResourceTypes:    workerInfo.ResourceTypes,
 		Platform:         workerInfo.Platform,
 		Tags:             workerInfo.Tags,
 		Name:             workerInfo.Name,
+		Team:             workerInfo.TeamName,
+		Unhealthy:        savedWorker.UnhealthyFor > 0,
 	}
 }
@@ -1,10 +1,12 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -70,6 +72,9 @@ var _ = Describe("Resources API", func() {
 								Resource: db.Resource{
 									Name: name,
 								},
+								CheckFailures: 2,
+								CheckBackoff:  4 * time.Minute,
+								NextCheck:     time.Unix(100, 0),
 							}, nil
 						} else {
 							return db.SavedResource{
@@ -111,7 +116,9 @@ var _ = Describe("Resources API", func() {
 								"groups": ["group-2"],
 								"url": "/pipelines/a-pipeline/resources/resource-2",
 								"failing_to_check": true,
-								"check_error": "sup"
+								"check_error": "sup",
+								"check_backoff": "4m0s",
+								"next_check": 100
 							},
 							{
 								"name": "resource-3",
@@ -146,7 +153,9 @@ var _ = Describe("Resources API", func() {
 								"type": "type-2",
 								"groups": ["group-2"],
 								"url": "/pipelines/a-pipeline/resources/resource-2",
-								"failing_to_check": true
+								"failing_to_check": true,
+								"check_backoff": "4m0s",
+								"next_check": 100
 							},
 							{
 								"name": "resource-3",
@@ -347,6 +356,112 @@ var _ = Describe("Resources API", func() {
 
 	})
 
+	Describe("GET /api/v1/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/pipelines/a-pipeline/resources/resource-name/checks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when getting the checks succeeds", func() {
+				BeforeEach(func() {
+					exitStatus := 1
+
+					fakePipelineDB.GetResourceChecksReturns([]db.ResourceCheck{
+						{
+							ID:            2,
+							StartTime:     time.Unix(10, 0),
+							EndTime:       time.Unix(20, 0),
+							WorkerName:    "some-worker",
+							VersionsFound: 0,
+							ExitStatus:    &exitStatus,
+							Stderr:        "some-stderr",
+							Error:         "some-error",
+						},
+						{
+							ID:            1,
+							StartTime:     time.Unix(1, 0),
+							EndTime:       time.Unix(2, 0),
+							WorkerName:    "some-other-worker",
+							VersionsFound: 3,
+						},
+					}, true, nil)
+				})
+
+				It("looks up the checks for the right resource", func() {
+					Expect(fakePipelineDB.GetResourceChecksArgsForCall(0)).To(Equal("resource-name"))
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the checks", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"start_time": 10,
+							"end_time": 20,
+							"worker_name": "some-worker",
+							"versions_found": 0,
+							"exit_status": 1,
+							"stderr": "some-stderr",
+							"error": "some-error"
+						},
+						{
+							"id": 1,
+							"start_time": 1,
+							"end_time": 2,
+							"worker_name": "some-other-worker",
+							"versions_found": 3
+						}
+					]`))
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceChecksReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the checks fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceChecksReturns(nil, false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/pause", func() {
 		var response *http.Response
 
@@ -468,4 +583,184 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resource-types/:resource_type_name/pin", func() {
+		var requestBody string
+		var response *http.Response
+
+		BeforeEach(func() {
+			requestBody = `{"digest":"sha256:some-digest"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resource-types/some-type/pin", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				fakePipelineDB.GetResourceTypeReturns(db.SavedResourceType{Name: "some-type"}, true, nil)
+			})
+
+			Context("when the resource type does not exist", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceTypeReturns(db.SavedResourceType{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not pin anything", func() {
+					Expect(fakePipelineDB.PinResourceTypeVersionCallCount()).To(BeZero())
+				})
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Expect(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).To(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Expect(pipelineName).To(Equal("a-pipeline"))
+				Expect(teamName).To(Equal(atc.DefaultTeamName))
+			})
+
+			Context("when pinning the version succeeds", func() {
+				BeforeEach(func() {
+					fakePipelineDB.PinResourceTypeVersionReturns(nil)
+				})
+
+				It("pins the given version of the right resource type", func() {
+					Expect(fakePipelineDB.PinResourceTypeVersionCallCount()).To(Equal(1))
+
+					resourceTypeName, version := fakePipelineDB.PinResourceTypeVersionArgsForCall(0)
+					Expect(resourceTypeName).To(Equal("some-type"))
+					Expect(version).To(Equal(atc.Version{"digest": "sha256:some-digest"}))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when pinning the version fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.PinResourceTypeVersionReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the request body is not a version", func() {
+				BeforeEach(func() {
+					requestBody = `not json`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not pin anything", func() {
+					Expect(fakePipelineDB.PinResourceTypeVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the version is empty", func() {
+				BeforeEach(func() {
+					requestBody = `{}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resource-types/:resource_type_name/unpin", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resource-types/some-type/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				fakePipelineDB.GetResourceTypeReturns(db.SavedResourceType{Name: "some-type"}, true, nil)
+			})
+
+			Context("when the resource type does not exist", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetResourceTypeReturns(db.SavedResourceType{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not unpin anything", func() {
+					Expect(fakePipelineDB.UnpinResourceTypeVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when unpinning the version succeeds", func() {
+				BeforeEach(func() {
+					fakePipelineDB.UnpinResourceTypeVersionReturns(nil)
+				})
+
+				It("unpins the right resource type", func() {
+					Expect(fakePipelineDB.UnpinResourceTypeVersionArgsForCall(0)).To(Equal("some-type"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when unpinning the version fails", func() {
+				BeforeEach(func() {
+					fakePipelineDB.UnpinResourceTypeVersionReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
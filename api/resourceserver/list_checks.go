@@ -0,0 +1,39 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("list-resource-checks")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		checks, found, err := pipelineDB.GetResourceChecks(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		presented := make([]atc.ResourceCheck, len(checks))
+		for i, check := range checks {
+			presented[i] = present.ResourceCheck(check)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presented)
+	})
+}
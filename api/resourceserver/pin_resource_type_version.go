@@ -0,0 +1,46 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) PinResourceTypeVersion(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("pin-resource-type-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceTypeName := rata.Param(r, "resource_type_name")
+
+		var version atc.Version
+		err := json.NewDecoder(r.Body).Decode(&version)
+		if err != nil || len(version) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, found, err := pipelineDB.GetResourceType(resourceTypeName)
+		if err != nil {
+			logger.Error("failed-to-get-resource-type", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-type-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = pipelineDB.PinResourceTypeVersion(resourceTypeName, version)
+		if err != nil {
+			logger.Error("failed-to-pin-resource-type-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
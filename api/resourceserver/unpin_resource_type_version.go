@@ -0,0 +1,37 @@
+package resourceserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) UnpinResourceTypeVersion(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("unpin-resource-type-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceTypeName := rata.Param(r, "resource_type_name")
+
+		_, found, err := pipelineDB.GetResourceType(resourceTypeName)
+		if err != nil {
+			logger.Error("failed-to-get-resource-type", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-type-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = pipelineDB.UnpinResourceTypeVersion(resourceTypeName)
+		if err != nil {
+			logger.Error("failed-to-unpin-resource-type-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -51,12 +52,14 @@ var _ = Describe("Workers API", func() {
 							WorkerInfo: db.WorkerInfo{
 								GardenAddr:       "1.2.3.4:8888",
 								ActiveContainers: 2,
+								MaxContainers:    250,
 								ResourceTypes: []atc.WorkerResourceType{
 									{Type: "some-resource", Image: "some-resource-image"},
 								},
 								Platform: "beos",
 								Tags:     []string{"best", "os", "ever", "rip"},
 							},
+							UnhealthyFor: 30 * time.Second,
 						},
 					}, nil)
 				})
@@ -84,17 +87,101 @@ var _ = Describe("Workers API", func() {
 						{
 							GardenAddr:       "1.2.3.4:8888",
 							ActiveContainers: 2,
+							MaxContainers:    250,
 							ResourceTypes: []atc.WorkerResourceType{
 								{Type: "some-resource", Image: "some-resource-image"},
 							},
-							Platform: "beos",
-							Tags:     []string{"best", "os", "ever", "rip"},
+							Platform:  "beos",
+							Tags:      []string{"best", "os", "ever", "rip"},
+							Unhealthy: true,
 						},
 					}))
 
 				})
 			})
 
+			Context("when some workers belong to teams", func() {
+				BeforeEach(func() {
+					workerDB.WorkersReturns([]db.SavedWorker{
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:7777",
+								Name:       "global-worker",
+							},
+						},
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:8888",
+								Name:       "some-team-worker",
+								TeamID:     5,
+								TeamName:   "some-team",
+							},
+						},
+						{
+							WorkerInfo: db.WorkerInfo{
+								GardenAddr: "1.2.3.4:9999",
+								Name:       "other-team-worker",
+								TeamID:     6,
+								TeamName:   "other-team",
+							},
+						},
+					}, nil)
+				})
+
+				returnedWorkerNames := func() []string {
+					var returnedWorkers []atc.Worker
+					err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+					Expect(err).NotTo(HaveOccurred())
+
+					names := []string{}
+					for _, worker := range returnedWorkers {
+						names = append(names, worker.Name)
+					}
+
+					return names
+				}
+
+				Context("when the caller is on one of the teams", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 5, false, true)
+					})
+
+					It("returns the global workers and the team's own workers", func() {
+						Expect(returnedWorkerNames()).To(Equal([]string{"global-worker", "some-team-worker"}))
+					})
+
+					It("includes the team of the team's own workers", func() {
+						var returnedWorkers []atc.Worker
+						err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(returnedWorkers).To(HaveLen(2))
+						Expect(returnedWorkers[0].Team).To(BeEmpty())
+						Expect(returnedWorkers[1].Team).To(Equal("some-team"))
+					})
+				})
+
+				Context("when the caller is an admin", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+					})
+
+					It("returns all of the workers", func() {
+						Expect(returnedWorkerNames()).To(Equal([]string{"global-worker", "some-team-worker", "other-team-worker"}))
+					})
+				})
+
+				Context("when the caller's team is not known", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("", 0, false, false)
+					})
+
+					It("returns only the global workers", func() {
+						Expect(returnedWorkerNames()).To(Equal([]string{"global-worker"}))
+					})
+				})
+			})
+
 			Context("when getting the workers fails", func() {
 				BeforeEach(func() {
 					workerDB.WorkersReturns(nil, errors.New("oh no!"))
@@ -217,6 +304,119 @@ var _ = Describe("Workers API", func() {
 					})
 				})
 
+				Context("when the max containers is provided", func() {
+					BeforeEach(func() {
+						worker.MaxContainers = 250
+					})
+
+					It("saves it", func() {
+						Expect(workerDB.SaveWorkerCallCount()).To(Equal(1))
+
+						savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+						Expect(savedInfo.MaxContainers).To(Equal(250))
+					})
+				})
+
+				It("verifies the registration with the worker's name and the request body", func() {
+					Expect(workerRegistrationVerifier.VerifyRegistrationCallCount()).To(Equal(1))
+
+					workerName, _, body := workerRegistrationVerifier.VerifyRegistrationArgsForCall(0)
+					Expect(workerName).To(Equal("1.2.3.4:7777"))
+
+					var registration atc.Worker
+					err := json.Unmarshal(body, &registration)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(registration).To(Equal(worker))
+				})
+
+				Context("when the registration cannot be verified", func() {
+					BeforeEach(func() {
+						workerRegistrationVerifier.VerifyRegistrationReturns(errors.New("bad signature"))
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("bad signature")))
+					})
+
+					It("does not save it", func() {
+						Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when a team is provided", func() {
+					BeforeEach(func() {
+						worker.Team = "some-team"
+					})
+
+					Context("when the team exists", func() {
+						BeforeEach(func() {
+							workerDB.GetTeamByNameReturns(db.SavedTeam{ID: 5, Team: db.Team{Name: "some-team"}}, true, nil)
+						})
+
+						It("saves the worker with the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							Expect(workerDB.GetTeamByNameCallCount()).To(Equal(1))
+							Expect(workerDB.GetTeamByNameArgsForCall(0)).To(Equal("some-team"))
+
+							Expect(workerDB.SaveWorkerCallCount()).To(Equal(1))
+							savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+							Expect(savedInfo.TeamID).To(Equal(5))
+						})
+
+						Context("when the caller is on another team", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("other-team", 6, false, true)
+							})
+
+							It("returns 403", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							})
+
+							It("does not save it", func() {
+								Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the caller is an admin", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+							})
+
+							It("saves the worker with the team", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(workerDB.SaveWorkerCallCount()).To(Equal(1))
+							})
+						})
+					})
+
+					Context("when the team does not exist", func() {
+						BeforeEach(func() {
+							workerDB.GetTeamByNameReturns(db.SavedTeam{}, false, nil)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("unknown team")))
+						})
+
+						It("does not save it", func() {
+							Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when looking up the team fails", func() {
+						BeforeEach(func() {
+							workerDB.GetTeamByNameReturns(db.SavedTeam{}, false, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
 				Context("and saving it fails", func() {
 					BeforeEach(func() {
 						workerDB.SaveWorkerReturns(db.SavedWorker{}, errors.New("oh no!"))
@@ -263,6 +463,24 @@ var _ = Describe("Workers API", func() {
 					Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
 				})
 			})
+
+			Context("when the worker has a local address", func() {
+				BeforeEach(func() {
+					worker.GardenAddr = "local:/etc"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("returns the validation error in the response body", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("local workers cannot be registered")))
+				})
+
+				It("does not save it", func() {
+					Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -27,6 +27,16 @@ type FakeWorkerDB struct {
 		result1 []db.SavedWorker
 		result2 error
 	}
+	GetTeamByNameStub        func(teamName string) (db.SavedTeam, bool, error)
+	getTeamByNameMutex       sync.RWMutex
+	getTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	getTeamByNameReturns struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}
 }
 
 func (fake *FakeWorkerDB) SaveWorker(arg1 db.WorkerInfo, arg2 time.Duration) (db.SavedWorker, error) {
@@ -88,4 +98,38 @@ func (fake *FakeWorkerDB) WorkersReturns(result1 []db.SavedWorker, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerDB) GetTeamByName(teamName string) (db.SavedTeam, bool, error) {
+	fake.getTeamByNameMutex.Lock()
+	fake.getTeamByNameArgsForCall = append(fake.getTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.getTeamByNameMutex.Unlock()
+	if fake.GetTeamByNameStub != nil {
+		return fake.GetTeamByNameStub(teamName)
+	} else {
+		return fake.getTeamByNameReturns.result1, fake.getTeamByNameReturns.result2, fake.getTeamByNameReturns.result3
+	}
+}
+
+func (fake *FakeWorkerDB) GetTeamByNameCallCount() int {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return len(fake.getTeamByNameArgsForCall)
+}
+
+func (fake *FakeWorkerDB) GetTeamByNameArgsForCall(i int) string {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return fake.getTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeWorkerDB) GetTeamByNameReturns(result1 db.SavedTeam, result2 bool, result3 error) {
+	fake.GetTeamByNameStub = nil
+	fake.getTeamByNameReturns = struct {
+		result1 db.SavedTeam
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 var _ workerserver.WorkerDB = new(FakeWorkerDB)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 )
 
 func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
@@ -17,9 +18,15 @@ func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	workers := make([]atc.Worker, len(savedWorkers))
-	for i, savedWorker := range savedWorkers {
-		workers[i] = present.Worker(savedWorker.WorkerInfo)
+	_, teamID, isAdmin, found := auth.GetTeam(r)
+
+	workers := []atc.Worker{}
+	for _, savedWorker := range savedWorkers {
+		if !isAdmin && savedWorker.TeamID != 0 && (!found || savedWorker.TeamID != teamID) {
+			continue
+		}
+
+		workers = append(workers, present.Worker(savedWorker))
 	}
 
 	json.NewEncoder(w).Encode(workers)
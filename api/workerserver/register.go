@@ -3,13 +3,17 @@ package workerserver
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/lager"
 )
 
 type IntMetric int
@@ -20,8 +24,14 @@ func (i IntMetric) String() string {
 
 func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("register-worker")
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	var registration atc.Worker
-	err := json.NewDecoder(r.Body).Decode(&registration)
+	err = json.Unmarshal(body, &registration)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -33,6 +43,12 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if worker.IsLocalAddr(registration.GardenAddr) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "local workers cannot be registered")
+		return
+	}
+
 	var ttl time.Duration
 
 	ttlStr := r.URL.Query().Get("ttl")
@@ -45,23 +61,57 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if registration.Name == "" {
+		registration.Name = registration.GardenAddr
+	}
+
+	err = s.registrationVerifier.VerifyRegistration(registration.Name, r, body)
+	if err != nil {
+		logger.Error("failed-to-verify-registration", err, lager.Data{"worker": registration.Name})
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, "%s", err)
+		return
+	}
+
 	metric.WorkerContainers{
 		WorkerAddr: registration.GardenAddr,
 		Containers: registration.ActiveContainers,
 	}.Emit(s.logger)
 
-	if registration.Name == "" {
-		registration.Name = registration.GardenAddr
+	var teamID int
+	if registration.Team != "" {
+		callerTeamName, _, isAdmin, found := auth.GetTeam(r)
+		if found && !isAdmin && callerTeamName != registration.Team {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		team, found, err := s.db.GetTeamByName(registration.Team)
+		if err != nil {
+			logger.Error("failed-to-get-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown team")
+			return
+		}
+
+		teamID = team.ID
 	}
 
 	_, err = s.db.SaveWorker(db.WorkerInfo{
 		GardenAddr:       registration.GardenAddr,
 		BaggageclaimURL:  registration.BaggageclaimURL,
 		ActiveContainers: registration.ActiveContainers,
+		MaxContainers:    registration.MaxContainers,
 		ResourceTypes:    registration.ResourceTypes,
 		Platform:         registration.Platform,
 		Tags:             registration.Tags,
 		Name:             registration.Name,
+		TeamID:           teamID,
 	}, ttl)
 	if err != nil {
 		logger.Error("failed-to-save-worker", err)
@@ -3,6 +3,7 @@ package workerserver
 import (
 	"time"
 
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/pivotal-golang/lager"
 )
@@ -10,7 +11,8 @@ import (
 type Server struct {
 	logger lager.Logger
 
-	db WorkerDB
+	db                   WorkerDB
+	registrationVerifier auth.WorkerRegistrationVerifier
 }
 
 //go:generate counterfeiter . WorkerDB
@@ -18,14 +20,17 @@ type Server struct {
 type WorkerDB interface {
 	SaveWorker(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
 	Workers() ([]db.SavedWorker, error)
+	GetTeamByName(teamName string) (db.SavedTeam, bool, error)
 }
 
 func NewServer(
 	logger lager.Logger,
 	db WorkerDB,
+	registrationVerifier auth.WorkerRegistrationVerifier,
 ) *Server {
 	return &Server{
-		logger: logger,
-		db:     db,
+		logger:               logger,
+		db:                   db,
+		registrationVerifier: registrationVerifier,
 	}
 }
@@ -21,10 +21,12 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/eventstore"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/lostandfound"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/pipelines"
+	"github.com/concourse/atc/pruner"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
@@ -64,9 +66,25 @@ type ATCCommand struct {
 
 	SessionSigningKey FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens."`
 
+	WorkerKeys map[string]FileFlag `long:"worker-key" description:"File containing the RSA public key that the named worker must sign its registrations with. Can be specified multiple times. If any are given, workers without a key cannot register." value-name:"NAME:PATH"`
+
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
+	ResourceCheckingMaxBackoff   time.Duration `long:"resource-checking-max-backoff" default:"1h" description:"Maximum interval to back off to while a resource's checks are failing. Set to 0 to disable backing off."`
+	ResourceCheckingTimeout      time.Duration `long:"resource-checking-timeout" default:"1h" description:"How long a resource check may run before it is aborted, unless the resource configures its own check_timeout."`
+	ResourceCheckingMaxInFlight  int           `long:"resource-checking-max-in-flight" default:"32" description:"Maximum number of resource checks this ATC may run at once. Set to 0 for no limit."`
+	ResourceCheckingJitter       time.Duration `long:"resource-checking-jitter" default:"1m" description:"Maximum random delay before each resource's first check, to spread out checking when the ATC starts."`
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
+	VersionPruningInterval       time.Duration `long:"version-pruning-interval" default:"1h" description:"Interval on which to prune old versions of resources that configure a version_retention."`
+
+	EnableP2PVolumeStreaming bool `long:"enable-p2p-volume-streaming" description:"Have workers pull task inputs directly from each other rather than streaming them through the ATC. Inputs are still streamed through the ATC when workers cannot reach each other."`
+
+	WorkerFailureThreshold  int           `long:"worker-failure-threshold"   default:"5"  description:"Number of consecutive container creation failures after which a worker is taken out of rotation. Set to 0 to disable."`
+	WorkerUnhealthyCoolDown time.Duration `long:"worker-unhealthy-cool-down" default:"1m" description:"How long a worker is kept out of rotation after reaching the failure threshold."`
+
+	MaxStepLogSize         int64 `long:"max-step-log-size"          default:"0" description:"Maximum number of bytes of output each step of a build may log. Output beyond this is discarded. Set to 0 for no limit."`
+	MaxBuildLogSize        int64 `long:"max-build-log-size"         default:"0" description:"Maximum number of bytes of output all steps of a build may log between them. Output beyond this is discarded. Set to 0 for no limit."`
+	FailStepsOnLogOverflow bool  `long:"fail-steps-on-log-overflow" description:"Fail any step whose output is cut short by a log size limit."`
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
@@ -79,8 +97,22 @@ type ATCCommand struct {
 		GardenURL       URLFlag            `long:"garden-url"       description:"A Garden API endpoint to register as a worker."`
 		BaggageclaimURL URLFlag            `long:"baggageclaim-url" description:"A Baggageclaim API endpoint to register with the worker."`
 		ResourceTypes   map[string]URLFlag `long:"resource"         description:"A resource type to advertise for the worker. Can be specified multiple times." value-name:"TYPE:IMAGE"`
+		LocalDir        DirFlag            `long:"local-dir"        description:"Instead of registering a Garden server, run the worker's containers as plain processes in this directory on the ATC's host. Nothing is isolated; only for development and testing."`
 	} `group:"Static Worker (optional)" namespace:"worker"`
 
+	BuildEvents struct {
+		Dir DirFlag `long:"dir" description:"Directory in which to keep the events of completed builds, rather than in the database."`
+
+		S3Endpoint        string `long:"s3-endpoint"          default:"https://s3.amazonaws.com" description:"Endpoint of the S3-compatible object store in which to keep the events of completed builds."`
+		S3Bucket          string `long:"s3-bucket"            description:"Bucket in which to keep the events of completed builds, rather than in the database."`
+		S3Region          string `long:"s3-region"            default:"us-east-1" description:"Region of the bucket."`
+		S3AccessKeyID     string `long:"s3-access-key-id"     description:"Access key ID used to sign requests to the object store."`
+		S3SecretAccessKey string `long:"s3-secret-access-key" description:"Secret access key used to sign requests to the object store."`
+		S3Prefix          string `long:"s3-prefix"            description:"Prefix to prepend to the name of each object."`
+
+		CompactionInterval time.Duration `long:"compaction-interval" default:"1m" description:"Interval on which to move the events of completed builds out of the database."`
+	} `group:"Build Event Storage (optional)" namespace:"build-events"`
+
 	BasicAuth struct {
 		Username string `long:"username" description:"Username to use for basic auth."`
 		Password string `long:"password" description:"Password to use for basic auth."`
@@ -140,6 +172,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceCheckingMaxBackoff,
+		cmd.ResourceCheckingTimeout,
+		cmd.ResourceCheckingJitter,
+		cmd.ResourceCheckingMaxInFlight,
 		engine,
 		sqlDB,
 	)
@@ -149,6 +185,11 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	workerRegistrationVerifier, err := cmd.loadWorkerRegistrationVerifier()
+	if err != nil {
+		return nil, err
+	}
+
 	err = sqlDB.CreateDefaultTeamIfNotExists()
 	if err != nil {
 		return nil, err
@@ -190,6 +231,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		jwtReader,
 		providerFactory,
 		signingKey,
+		workerRegistrationVerifier,
 		pipelineDBFactory,
 		engine,
 		workerClient,
@@ -272,9 +314,22 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			clock.NewClock(),
 			cmd.ResourceCacheCleanupInterval,
 		)},
+
+		{"pruner", pruner.NewRunner(
+			logger.Session("pruner"),
+			pruner.NewPruner(
+				logger.Session("version-pruner"),
+				sqlDB,
+				pipelineDBFactory,
+			),
+			sqlDB,
+			clock.NewClock(),
+			cmd.VersionPruningInterval,
+		)},
 	}
 
 	members = cmd.appendStaticWorker(logger, sqlDB, members)
+	members = cmd.appendBuildEventCompactor(logger, sqlDB, members)
 
 	return onReady(grouper.NewParallel(os.Interrupt, members), func() {
 		logger.Info("listening", lager.Data{
@@ -368,6 +423,20 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.Worker.GardenURL.URL() != nil && cmd.Worker.LocalDir != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --worker-garden-url and --worker-local-dir"),
+		)
+	}
+
+	if cmd.BuildEvents.Dir != "" && cmd.BuildEvents.S3Bucket != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --build-events-dir and --build-events-s3-bucket"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -443,6 +512,11 @@ func (cmd *ATCCommand) constructWorkerPool(logger lager.Logger, sqlDB *db.SQLDB,
 				Timeout: 5 * time.Minute,
 			},
 			image.NewFetcher(trackerFactory),
+			worker.CircuitBreakerPolicy{
+				FailureThreshold: cmd.WorkerFailureThreshold,
+				CoolDown:         cmd.WorkerUnhealthyCoolDown,
+			},
+			cmd.PeerURL.String(),
 		),
 	)
 }
@@ -472,6 +546,29 @@ func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
 	return signingKey, nil
 }
 
+func (cmd *ATCCommand) loadWorkerRegistrationVerifier() (auth.WorkerRegistrationVerifier, error) {
+	if len(cmd.WorkerKeys) == 0 {
+		return auth.NoopWorkerRegistrationVerifier{}, nil
+	}
+
+	workerKeys := map[string]*rsa.PublicKey{}
+	for workerName, keyFile := range cmd.WorkerKeys {
+		rsaKeyBlob, err := ioutil.ReadFile(string(keyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file for worker '%s': %s", workerName, err)
+		}
+
+		workerKey, err := jwt.ParseRSAPublicKeyFromPEM(rsaKeyBlob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key for worker '%s' as RSA: %s", workerName, err)
+		}
+
+		workerKeys[workerName] = workerKey
+	}
+
+	return auth.NewWorkerKeyVerifier(workerKeys, clock.NewClock()), nil
+}
+
 func (cmd *ATCCommand) configureOAuthProviders(logger lager.Logger, sqlDB db.DB) error {
 	var err error
 	team := db.Team{
@@ -557,11 +654,15 @@ func (cmd *ATCCommand) constructEngine(
 	tracker resource.Tracker,
 	externalUrl string,
 ) engine.Engine {
-	gardenFactory := exec.NewGardenFactory(workerClient, tracker)
+	gardenFactory := exec.NewGardenFactory(workerClient, tracker, cmd.EnableP2PVolumeStreaming)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(sqlDB),
+		engine.NewBuildDelegateFactory(sqlDB, engine.LogLimits{
+			MaxStepBytes:       cmd.MaxStepLogSize,
+			MaxBuildBytes:      cmd.MaxBuildLogSize,
+			FailStepOnOverflow: cmd.FailStepsOnLogOverflow,
+		}),
 		sqlDB,
 		externalUrl,
 	)
@@ -605,6 +706,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	userContextReader auth.UserContextReader,
 	providerFactory provider.OAuthFactory,
 	signingKey *rsa.PrivateKey,
+	workerRegistrationVerifier auth.WorkerRegistrationVerifier,
 	pipelineDBFactory db.PipelineDBFactory,
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -625,6 +727,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		auth.NewTokenGenerator(signingKey),
 		providerFactory,
 		cmd.oauthBaseURL(),
+		workerRegistrationVerifier,
 
 		pipelineDBFactory,
 
@@ -716,12 +819,66 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 	)
 }
 
+func (cmd *ATCCommand) constructBuildEventStore() db.BuildEventStore {
+	if cmd.BuildEvents.Dir != "" {
+		return eventstore.NewLocal(cmd.BuildEvents.Dir.Path())
+	}
+
+	if cmd.BuildEvents.S3Bucket != "" {
+		return eventstore.NewS3(
+			eventstore.S3Config{
+				Endpoint:        cmd.BuildEvents.S3Endpoint,
+				Bucket:          cmd.BuildEvents.S3Bucket,
+				Region:          cmd.BuildEvents.S3Region,
+				AccessKeyID:     cmd.BuildEvents.S3AccessKeyID,
+				SecretAccessKey: cmd.BuildEvents.S3SecretAccessKey,
+				Prefix:          cmd.BuildEvents.S3Prefix,
+			},
+			http.DefaultClient,
+			clock.NewClock(),
+		)
+	}
+
+	return nil
+}
+
+func (cmd *ATCCommand) appendBuildEventCompactor(
+	logger lager.Logger,
+	sqlDB *db.SQLDB,
+	members []grouper.Member,
+) []grouper.Member {
+	store := cmd.constructBuildEventStore()
+	if store == nil {
+		return members
+	}
+
+	sqlDB.UseBuildEventStore(store)
+
+	return append(members, grouper.Member{
+		Name: "build-event-compactor",
+		Runner: eventstore.NewCompactor(
+			logger.Session("build-event-compactor"),
+			sqlDB,
+			clock.NewClock(),
+			cmd.BuildEvents.CompactionInterval,
+		),
+	})
+}
+
 func (cmd *ATCCommand) appendStaticWorker(
 	logger lager.Logger,
 	sqlDB *db.SQLDB,
 	members []grouper.Member,
 ) []grouper.Member {
-	if cmd.Worker.GardenURL.URL() == nil {
+	var gardenAddr string
+	var baggageclaimURL string
+
+	if cmd.Worker.LocalDir != "" {
+		gardenAddr = worker.LocalAddr(cmd.PeerURL.String(), cmd.Worker.LocalDir.Path())
+	} else if cmd.Worker.GardenURL.URL() != nil {
+		gardenAddr = cmd.Worker.GardenURL.URL().Host
+		baggageclaimURL = cmd.Worker.BaggageclaimURL.String()
+	} else {
 		return members
 	}
 
@@ -740,8 +897,8 @@ func (cmd *ATCCommand) appendStaticWorker(
 				logger,
 				sqlDB,
 				clock.NewClock(),
-				cmd.Worker.GardenURL.URL().Host,
-				cmd.Worker.BaggageclaimURL.String(),
+				gardenAddr,
+				baggageclaimURL,
 				resourceTypes,
 			),
 		},
@@ -0,0 +1,58 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/auth"
+)
+
+type FakeWorkerRegistrationVerifier struct {
+	VerifyRegistrationStub        func(workerName string, r *http.Request, body []byte) error
+	verifyRegistrationMutex       sync.RWMutex
+	verifyRegistrationArgsForCall []struct {
+		workerName string
+		r          *http.Request
+		body       []byte
+	}
+	verifyRegistrationReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeWorkerRegistrationVerifier) VerifyRegistration(workerName string, r *http.Request, body []byte) error {
+	fake.verifyRegistrationMutex.Lock()
+	fake.verifyRegistrationArgsForCall = append(fake.verifyRegistrationArgsForCall, struct {
+		workerName string
+		r          *http.Request
+		body       []byte
+	}{workerName, r, body})
+	fake.verifyRegistrationMutex.Unlock()
+	if fake.VerifyRegistrationStub != nil {
+		return fake.VerifyRegistrationStub(workerName, r, body)
+	} else {
+		return fake.verifyRegistrationReturns.result1
+	}
+}
+
+func (fake *FakeWorkerRegistrationVerifier) VerifyRegistrationCallCount() int {
+	fake.verifyRegistrationMutex.RLock()
+	defer fake.verifyRegistrationMutex.RUnlock()
+	return len(fake.verifyRegistrationArgsForCall)
+}
+
+func (fake *FakeWorkerRegistrationVerifier) VerifyRegistrationArgsForCall(i int) (string, *http.Request, []byte) {
+	fake.verifyRegistrationMutex.RLock()
+	defer fake.verifyRegistrationMutex.RUnlock()
+	return fake.verifyRegistrationArgsForCall[i].workerName, fake.verifyRegistrationArgsForCall[i].r, fake.verifyRegistrationArgsForCall[i].body
+}
+
+func (fake *FakeWorkerRegistrationVerifier) VerifyRegistrationReturns(result1 error) {
+	fake.VerifyRegistrationStub = nil
+	fake.verifyRegistrationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ auth.WorkerRegistrationVerifier = new(FakeWorkerRegistrationVerifier)
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+)
+
+const WorkerSignatureHeader = "X-Concourse-Worker-Signature"
+const WorkerTimestampHeader = "X-Concourse-Worker-Timestamp"
+
+// MaxWorkerSignatureAge is how far a signed registration's timestamp may be
+// from the ATC's clock before it is rejected, so that captured registrations
+// cannot be replayed later on.
+const MaxWorkerSignatureAge = 5 * time.Minute
+
+var ErrUnknownWorkerKey = errors.New("no key is configured for the worker")
+var ErrMissingWorkerSignature = errors.New("registration is not signed")
+var ErrInvalidWorkerSignature = errors.New("registration signature is invalid")
+var ErrStaleWorkerSignature = errors.New("registration signature has expired")
+
+type workerKeyVerifier struct {
+	keys  map[string]*rsa.PublicKey
+	clock clock.Clock
+}
+
+// NewWorkerKeyVerifier returns a verifier that only accepts registrations
+// for the named workers, signed by each worker's own private key.
+func NewWorkerKeyVerifier(keys map[string]*rsa.PublicKey, clock clock.Clock) WorkerRegistrationVerifier {
+	return &workerKeyVerifier{
+		keys:  keys,
+		clock: clock,
+	}
+}
+
+func (verifier *workerKeyVerifier) VerifyRegistration(workerName string, r *http.Request, body []byte) error {
+	key, found := verifier.keys[workerName]
+	if !found {
+		return ErrUnknownWorkerKey
+	}
+
+	timestamp := r.Header.Get(WorkerTimestampHeader)
+	encodedSignature := r.Header.Get(WorkerSignatureHeader)
+	if timestamp == "" || encodedSignature == "" {
+		return ErrMissingWorkerSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWorkerSignature
+	}
+
+	age := verifier.clock.Now().Sub(time.Unix(unix, 0))
+	if age > MaxWorkerSignatureAge || age < -MaxWorkerSignatureAge {
+		return ErrStaleWorkerSignature
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return ErrInvalidWorkerSignature
+	}
+
+	err = rsa.VerifyPKCS1v15(key, crypto.SHA256, workerRegistrationDigest(timestamp, body), signature)
+	if err != nil {
+		return ErrInvalidWorkerSignature
+	}
+
+	return nil
+}
+
+// SignWorkerRegistration sets the headers on a registration request that
+// prove it was made by the holder of the given private key.
+func SignWorkerRegistration(r *http.Request, body []byte, key *rsa.PrivateKey, now time.Time) error {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, workerRegistrationDigest(timestamp, body))
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(WorkerTimestampHeader, timestamp)
+	r.Header.Set(WorkerSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+
+	return nil
+}
+
+func workerRegistrationDigest(timestamp string, body []byte) []byte {
+	hash := sha256.New()
+	hash.Write([]byte(timestamp))
+	hash.Write([]byte("\n"))
+	hash.Write(body)
+	return hash.Sum(nil)
+}
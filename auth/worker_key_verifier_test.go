@@ -0,0 +1,112 @@
+package auth_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	"github.com/concourse/atc/auth"
+)
+
+var _ = Describe("WorkerKeyVerifier", func() {
+	var (
+		workerKey *rsa.PrivateKey
+		otherKey  *rsa.PrivateKey
+		fakeClock *fakeclock.FakeClock
+
+		verifier auth.WorkerRegistrationVerifier
+
+		body    []byte
+		request *http.Request
+
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		var err error
+		workerKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		otherKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+
+		verifier = auth.NewWorkerKeyVerifier(map[string]*rsa.PublicKey{
+			"some-worker": &workerKey.PublicKey,
+		}, fakeClock)
+
+		body = []byte(`{"name":"some-worker"}`)
+
+		request, err = http.NewRequest("POST", "http://example.com/api/v1/workers", bytes.NewBuffer(body))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the registration is signed by the worker's key", func() {
+		JustBeforeEach(func() {
+			err := auth.SignWorkerRegistration(request, body, workerKey, fakeClock.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			verifyErr = verifier.VerifyRegistration("some-worker", request, body)
+		})
+
+		It("accepts it", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+		})
+
+		Context("when the body has been tampered with", func() {
+			JustBeforeEach(func() {
+				verifyErr = verifier.VerifyRegistration("some-worker", request, []byte(`{"name":"some-worker","tags":["evil"]}`))
+			})
+
+			It("rejects it", func() {
+				Expect(verifyErr).To(Equal(auth.ErrInvalidWorkerSignature))
+			})
+		})
+
+		Context("when the signature is too old", func() {
+			JustBeforeEach(func() {
+				fakeClock.Increment(auth.MaxWorkerSignatureAge + time.Second)
+				verifyErr = verifier.VerifyRegistration("some-worker", request, body)
+			})
+
+			It("rejects it", func() {
+				Expect(verifyErr).To(Equal(auth.ErrStaleWorkerSignature))
+			})
+		})
+	})
+
+	Context("when the registration is signed by another key", func() {
+		BeforeEach(func() {
+			err := auth.SignWorkerRegistration(request, body, otherKey, fakeClock.Now())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects it", func() {
+			Expect(verifier.VerifyRegistration("some-worker", request, body)).To(Equal(auth.ErrInvalidWorkerSignature))
+		})
+	})
+
+	Context("when the registration is not signed", func() {
+		It("rejects it", func() {
+			Expect(verifier.VerifyRegistration("some-worker", request, body)).To(Equal(auth.ErrMissingWorkerSignature))
+		})
+	})
+
+	Context("when no key is configured for the worker", func() {
+		BeforeEach(func() {
+			err := auth.SignWorkerRegistration(request, body, otherKey, fakeClock.Now())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects it", func() {
+			Expect(verifier.VerifyRegistration("some-other-worker", request, body)).To(Equal(auth.ErrUnknownWorkerKey))
+		})
+	})
+})
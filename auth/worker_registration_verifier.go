@@ -0,0 +1,15 @@
+package auth
+
+import "net/http"
+
+//go:generate counterfeiter . WorkerRegistrationVerifier
+
+type WorkerRegistrationVerifier interface {
+	VerifyRegistration(workerName string, r *http.Request, body []byte) error
+}
+
+type NoopWorkerRegistrationVerifier struct{}
+
+func (NoopWorkerRegistrationVerifier) VerifyRegistration(string, *http.Request, []byte) error {
+	return nil
+}
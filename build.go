@@ -21,6 +21,7 @@ type Build struct {
 	PipelineName string `json:"pipeline_name,omitempty"`
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
+	RerunOf      int    `json:"rerun_of,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -53,6 +54,29 @@ type BuildPreparation struct {
 	PausedPipeline   BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob        BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds BuildPreparationStatus            `json:"max_running_builds"`
+	Schedule         BuildPreparationStatus            `json:"schedule"`
 	Inputs           map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied  BuildPreparationStatus            `json:"inputs_satisfied"`
 }
+
+type PendingBuildReason string
+
+const (
+	PendingBuildReasonInputsNotDetermined PendingBuildReason = "inputs_not_determined"
+	PendingBuildReasonSerialGroupHeld     PendingBuildReason = "serial_group_held"
+	PendingBuildReasonMaxInFlightReached  PendingBuildReason = "max_in_flight_reached"
+	PendingBuildReasonQueued              PendingBuildReason = "queued"
+	PendingBuildReasonNextInLine          PendingBuildReason = "next_in_line"
+)
+
+type PendingBuild struct {
+	Build            Build              `json:"build"`
+	Reason           PendingBuildReason `json:"reason"`
+	BlockingBuildIDs []int              `json:"blocking_build_ids,omitempty"`
+}
+
+type SerialGroupQueue struct {
+	SerialGroup   string         `json:"serial_group"`
+	RunningBuilds []Build        `json:"running_builds"`
+	PendingBuilds []PendingBuild `json:"pending_builds"`
+}
@@ -1,6 +1,10 @@
 package atc
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
 const DefaultPipelineName = "main"
@@ -13,6 +17,10 @@ type Config struct {
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+
+	SerialGroups SerialGroupConfigs `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
+
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
 }
 
 type GroupConfig struct {
@@ -33,18 +41,49 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, bool) {
 	return GroupConfig{}, false
 }
 
+type SerialGroupConfig struct {
+	Name        string `yaml:"name" json:"name" mapstructure:"name"`
+	MaxInFlight int    `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+}
+
+type SerialGroupConfigs []SerialGroupConfig
+
+func (groups SerialGroupConfigs) Lookup(name string) (SerialGroupConfig, bool) {
+	for _, group := range groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+
+	return SerialGroupConfig{}, false
+}
+
 type ResourceConfig struct {
 	Name string `yaml:"name" json:"name" mapstructure:"name"`
 
-	Type       string `yaml:"type" json:"type" mapstructure:"type"`
-	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
-	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	Type         string `yaml:"type" json:"type" mapstructure:"type"`
+	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
+	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	CheckTimeout string `yaml:"check_timeout,omitempty" json:"check_timeout,omitempty" mapstructure:"check_timeout"`
+
+	VersionRetention *VersionRetentionConfig `yaml:"version_retention,omitempty" json:"version_retention,omitempty" mapstructure:"version_retention"`
+}
+
+// VersionRetentionConfig bounds how many of a resource's versions are kept,
+// either by number or by age. Versions used by builds are always kept.
+type VersionRetentionConfig struct {
+	Count int    `yaml:"count,omitempty" json:"count,omitempty" mapstructure:"count"`
+	Age   string `yaml:"age,omitempty" json:"age,omitempty" mapstructure:"age"`
 }
 
 type ResourceType struct {
 	Name   string `yaml:"name" json:"name" mapstructure:"name"`
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+
+	// Version pins the resource type's image to a specific version, rather
+	// than whichever version the pipeline last checked.
+	Version Version `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
 type ResourceTypes []ResourceType
@@ -78,6 +117,10 @@ type JobConfig struct {
 	SerialGroups   []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 
+	PauseAfterFailures int `yaml:"pause_after_failures,omitempty" json:"pause_after_failures,omitempty" mapstructure:"pause_after_failures"`
+
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 }
 
@@ -132,6 +175,8 @@ type PlanConfig struct {
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+	// which versions of the resource to run the job with
+	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 
 	// name of 'output', e.g. rootfs-tarball
 	Put string `yaml:"put,omitempty" json:"put,omitempty" mapstructure:"put"`
@@ -148,6 +193,8 @@ type PlanConfig struct {
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	// JUnit XML reports in the task's outputs, e.g. test-output/junit.xml
+	Reports []string `yaml:"reports,omitempty" json:"reports,omitempty" mapstructure:"reports"`
 
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
@@ -200,6 +247,112 @@ func (config PlanConfig) Name() string {
 	return ""
 }
 
+const (
+	VersionLatest = "latest"
+	VersionEvery  = "every"
+)
+
+// A VersionConfig determines which versions of a resource a get step will
+// run with. It is either `latest` (the default), `every`, or a specific
+// version to pin to.
+type VersionConfig struct {
+	Every  bool    `mapstructure:"every"`
+	Latest bool    `mapstructure:"latest"`
+	Pinned Version `mapstructure:"pinned"`
+}
+
+var ErrInvalidVersionConfig = errors.New("version must be 'latest', 'every', or a version")
+
+func (c *VersionConfig) UnmarshalJSON(payload []byte) error {
+	var data interface{}
+	err := json.Unmarshal(payload, &data)
+	if err != nil {
+		return err
+	}
+
+	return c.fromRaw(data)
+}
+
+func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var data interface{}
+	err := unmarshal(&data)
+	if err != nil {
+		return err
+	}
+
+	return c.fromRaw(data)
+}
+
+func (c *VersionConfig) fromRaw(data interface{}) error {
+	switch actual := data.(type) {
+	case string:
+		switch actual {
+		case VersionLatest:
+			c.Latest = true
+		case VersionEvery:
+			c.Every = true
+		default:
+			return ErrInvalidVersionConfig
+		}
+
+	case map[string]interface{}:
+		version := Version{}
+		for k, v := range actual {
+			str, ok := v.(string)
+			if !ok {
+				return ErrInvalidVersionConfig
+			}
+
+			version[k] = str
+		}
+
+		c.Pinned = version
+
+	case map[interface{}]interface{}:
+		version := Version{}
+		for k, v := range actual {
+			key, ok := k.(string)
+			if !ok {
+				return ErrInvalidVersionConfig
+			}
+
+			str, ok := v.(string)
+			if !ok {
+				return ErrInvalidVersionConfig
+			}
+
+			version[key] = str
+		}
+
+		c.Pinned = version
+
+	default:
+		return ErrInvalidVersionConfig
+	}
+
+	return nil
+}
+
+func (c VersionConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.raw())
+}
+
+func (c VersionConfig) MarshalYAML() (interface{}, error) {
+	return c.raw(), nil
+}
+
+func (c VersionConfig) raw() interface{} {
+	if c.Every {
+		return VersionEvery
+	}
+
+	if c.Pinned != nil {
+		return c.Pinned
+	}
+
+	return VersionLatest
+}
+
 func (config PlanConfig) ResourceName() string {
 	resourceName := config.Resource
 	if resourceName != "" {
@@ -243,6 +396,32 @@ func (jobs JobConfigs) Lookup(name string) (JobConfig, bool) {
 	return JobConfig{}, false
 }
 
+// SerialGroupLimits returns how many builds may run at once in each of the
+// job's serial groups. Groups that are not declared in the config allow one
+// build at a time.
+func (config Config) SerialGroupLimits(job JobConfig) map[string]int {
+	limits := map[string]int{}
+
+	if len(job.SerialGroups) == 0 {
+		for _, name := range job.GetSerialGroups() {
+			limits[name] = job.MaxInFlight()
+		}
+
+		return limits
+	}
+
+	for _, name := range job.SerialGroups {
+		limits[name] = 1
+
+		group, found := config.SerialGroups.Lookup(name)
+		if found && group.MaxInFlight > 0 {
+			limits[name] = group.MaxInFlight
+		}
+	}
+
+	return limits
+}
+
 func (config Config) JobIsPublic(jobName string) (bool, error) {
 	job, found := config.Jobs.Lookup(jobName)
 	if !found {
@@ -10,6 +10,7 @@ type JobInput struct {
 	Resource string
 	Passed   []string
 	Trigger  bool
+	Version  *atc.VersionConfig
 	Params   atc.Params
 	Tags     atc.Tags
 }
@@ -71,6 +72,7 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 			Resource: resource,
 			Passed:   plan.Passed,
 			Trigger:  plan.Trigger,
+			Version:  plan.Version,
 			Params:   plan.Params,
 			Tags:     plan.Tags,
 		})
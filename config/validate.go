@@ -52,6 +52,18 @@ func ValidateConfig(c atc.Config) ([]Warning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
+	serialGroupsErr := validateSerialGroups(c)
+	if serialGroupsErr != nil {
+		errorMessages = append(errorMessages, formatErr("serial groups", serialGroupsErr))
+	}
+
+	if c.Schedule != nil {
+		scheduleErr := compositeErr(validateSchedule("schedule", *c.Schedule))
+		if scheduleErr != nil {
+			errorMessages = append(errorMessages, formatErr("schedule", scheduleErr))
+		}
+	}
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -114,11 +126,51 @@ func validateResources(c atc.Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.CheckTimeout != "" {
+			timeout, err := time.ParseDuration(resource.CheckTimeout)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+" has an invalid check_timeout: "+err.Error())
+			} else if timeout <= 0 {
+				errorMessages = append(errorMessages, identifier+" has a check_timeout that is not positive")
+			}
+		}
+
+		if resource.VersionRetention != nil {
+			errorMessages = append(errorMessages, validateVersionRetention(identifier, *resource.VersionRetention)...)
+		}
 	}
 
 	return compositeErr(errorMessages)
 }
 
+func validateVersionRetention(identifier string, retention atc.VersionRetentionConfig) []string {
+	errorMessages := []string{}
+
+	if retention.Count == 0 && retention.Age == "" {
+		return append(errorMessages, identifier+" has a version_retention with neither count nor age")
+	}
+
+	if retention.Count != 0 && retention.Age != "" {
+		return append(errorMessages, identifier+" has a version_retention with both count and age")
+	}
+
+	if retention.Count < 0 {
+		errorMessages = append(errorMessages, identifier+" has a version_retention count that is not positive")
+	}
+
+	if retention.Age != "" {
+		age, err := time.ParseDuration(retention.Age)
+		if err != nil {
+			errorMessages = append(errorMessages, identifier+" has an invalid version_retention age: "+err.Error())
+		} else if age <= 0 {
+			errorMessages = append(errorMessages, identifier+" has a version_retention age that is not positive")
+		}
+	}
+
+	return errorMessages
+}
+
 func validateResourceTypes(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -153,6 +205,83 @@ func validateResourceTypes(c atc.Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateSerialGroups(c atc.Config) error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, group := range c.SerialGroups {
+		var identifier string
+		if group.Name == "" {
+			identifier = fmt.Sprintf("serial_groups[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("serial_groups.%s", group.Name)
+		}
+
+		if other, exists := names[group.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"serial_groups[%d] and serial_groups[%d] have the same name ('%s')",
+					other, i, group.Name))
+		} else if group.Name != "" {
+			names[group.Name] = i
+		}
+
+		if group.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		}
+
+		if group.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, identifier+" has a negative max_in_flight")
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func validateSchedule(identifier string, schedule atc.ScheduleConfig) []string {
+	errorMessages := []string{}
+
+	_, err := time.LoadLocation(schedule.Location)
+	if err != nil {
+		errorMessages = append(errorMessages,
+			fmt.Sprintf("%s has an unknown location '%s'", identifier, schedule.Location))
+	}
+
+	windows := map[string][]atc.TimeWindowConfig{
+		"allow":  schedule.Allow,
+		"forbid": schedule.Forbid,
+	}
+
+	for _, kind := range []string{"allow", "forbid"} {
+		for i, window := range windows[kind] {
+			windowIdentifier := fmt.Sprintf("%s.%s[%d]", identifier, kind, i)
+
+			_, err := atc.ParseTimeOfDay(window.Start)
+			if err != nil {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has an invalid start time '%s' (expected HH:MM)", windowIdentifier, window.Start))
+			}
+
+			_, err = atc.ParseTimeOfDay(window.Stop)
+			if err != nil {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has an invalid stop time '%s' (expected HH:MM)", windowIdentifier, window.Stop))
+			}
+
+			for _, day := range window.Days {
+				_, err := atc.ParseWeekday(day)
+				if err != nil {
+					errorMessages = append(errorMessages,
+						fmt.Sprintf("%s has an unknown day '%s'", windowIdentifier, day))
+				}
+			}
+		}
+	}
+
+	return errorMessages
+}
+
 func validateJobs(c atc.Config) ([]Warning, error) {
 	errorMessages := []string{}
 	warnings := []Warning{}
@@ -179,6 +308,15 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 		if job.Name == "" {
 			errorMessages = append(errorMessages, identifier+" has no name")
 		}
+
+		if job.PauseAfterFailures < 0 {
+			errorMessages = append(errorMessages, identifier+" has a negative pause_after_failures")
+		}
+
+		if job.Schedule != nil {
+			errorMessages = append(errorMessages, validateSchedule(identifier+".schedule", *job.Schedule)...)
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -296,7 +434,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "reports"},
 			plan, identifier)...,
 		)
 
@@ -371,7 +509,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "version", "privileged", "config", "file", "reports"},
 			plan, identifier)...,
 		)
 
@@ -411,8 +549,19 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			warnings = append(warnings, newDeprecationWarning(identifier+" specifies both `file` and `config` in a task step"))
 		}
 
+		for _, report := range plan.Reports {
+			segs := strings.SplitN(report, "/", 2)
+			if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf(
+					"%s.reports refers to '%s', which is not a path within one of the task's outputs",
+					identifier,
+					report,
+				))
+			}
+		}
+
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "version"},
 			plan, identifier)...,
 		)
 
@@ -478,6 +627,10 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.Trigger {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "version":
+			if plan.Version != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "privileged":
 			if plan.Privileged {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -490,6 +643,10 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "reports":
+			if len(plan.Reports) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -167,6 +167,148 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has an invalid check timeout", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:         "bogus-resource",
+					Type:         "some-type",
+					CheckTimeout: "nope",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has an invalid check_timeout"))
+			})
+		})
+
+		Context("when a resource has a check timeout that is not positive", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:         "bogus-resource",
+					Type:         "some-type",
+					CheckTimeout: "0s",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has a check_timeout that is not positive"))
+			})
+		})
+
+		Context("when a resource has a version retention", func() {
+			Context("with a count", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{Count: 10},
+					})
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("with an age", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{Age: "720h"},
+					})
+				})
+
+				It("returns no error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("with neither count nor age", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has a version_retention with neither count nor age"))
+				})
+			})
+
+			Context("with both count and age", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{Count: 10, Age: "720h"},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has a version_retention with both count and age"))
+				})
+			})
+
+			Context("with a negative count", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{Count: -1},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has a version_retention count that is not positive"))
+				})
+			})
+
+			Context("with an invalid age", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{Age: "nope"},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has an invalid version_retention age"))
+				})
+			})
+
+			Context("with an age that is not positive", func() {
+				BeforeEach(func() {
+					config.Resources = append(config.Resources, atc.ResourceConfig{
+						Name:             "bogus-resource",
+						Type:             "some-type",
+						VersionRetention: &atc.VersionRetentionConfig{Age: "0s"},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.bogus-resource has a version_retention age that is not positive"))
+				})
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -241,6 +383,73 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid serial groups", func() {
+		Context("when a serial group has no name", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{MaxInFlight: 3},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups[0] has no name"))
+			})
+		})
+
+		Context("when a serial group has a negative max in flight", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{Name: "environments", MaxInFlight: -1},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups.environments has a negative max_in_flight"))
+			})
+		})
+
+		Context("when two serial groups have the same name", func() {
+			BeforeEach(func() {
+				config.SerialGroups = atc.SerialGroupConfigs{
+					{Name: "environments", MaxInFlight: 3},
+					{Name: "environments", MaxInFlight: 2},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups[0] and serial_groups[1] have the same name ('environments')"))
+			})
+		})
+	})
+
+	Describe("invalid schedule", func() {
+		Context("when the schedule has an unknown location and malformed windows", func() {
+			BeforeEach(func() {
+				config.Schedule = &atc.ScheduleConfig{
+					Location: "Nowhere/Special",
+					Forbid: []atc.TimeWindowConfig{
+						{Days: []string{"Funday"}, Start: "9am", Stop: "25:00"},
+					},
+				}
+			})
+
+			It("returns an error describing each problem", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid schedule:"))
+				Expect(errorMessages[0]).To(ContainSubstring("schedule has an unknown location 'Nowhere/Special'"))
+				Expect(errorMessages[0]).To(ContainSubstring("schedule.forbid[0] has an invalid start time '9am' (expected HH:MM)"))
+				Expect(errorMessages[0]).To(ContainSubstring("schedule.forbid[0] has an invalid stop time '25:00' (expected HH:MM)"))
+				Expect(errorMessages[0]).To(ContainSubstring("schedule.forbid[0] has an unknown day 'Funday'"))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job atc.JobConfig
 
@@ -263,6 +472,37 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a negative pause_after_failures", func() {
+			BeforeEach(func() {
+				job.PauseAfterFailures = -1
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has a negative pause_after_failures"))
+			})
+		})
+
+		Context("when a job has an invalid schedule", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{
+					Allow: []atc.TimeWindowConfig{
+						{Start: "22:00", Stop: "6am"},
+					},
+				}
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.schedule.allow[0] has an invalid stop time '6am' (expected HH:MM)"))
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -373,6 +613,24 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan has a report that is not within an output", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						Reports:        []string{"junit.xml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.reports refers to 'junit.xml', which is not a path within one of the task's outputs"))
+				})
+			})
+
 			Context("when a task plan has config path and config specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"encoding/json"
+
 	. "github.com/concourse/atc"
 
 	. "github.com/onsi/ginkgo"
@@ -100,4 +102,92 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("VersionConfig", func() {
+		It("unmarshals 'every'", func() {
+			var version VersionConfig
+			err := json.Unmarshal([]byte(`"every"`), &version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(VersionConfig{Every: true}))
+		})
+
+		It("unmarshals 'latest'", func() {
+			var version VersionConfig
+			err := json.Unmarshal([]byte(`"latest"`), &version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(VersionConfig{Latest: true}))
+		})
+
+		It("unmarshals a pinned version", func() {
+			var version VersionConfig
+			err := json.Unmarshal([]byte(`{"ref":"abcdef"}`), &version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(VersionConfig{Pinned: Version{"ref": "abcdef"}}))
+		})
+
+		It("fails to unmarshal anything else", func() {
+			var version VersionConfig
+			err := json.Unmarshal([]byte(`"bogus"`), &version)
+			Expect(err).To(Equal(ErrInvalidVersionConfig))
+		})
+
+		It("marshals back into the same form", func() {
+			for _, payload := range []string{`"every"`, `"latest"`, `{"ref":"abcdef"}`} {
+				var version VersionConfig
+				err := json.Unmarshal([]byte(payload), &version)
+				Expect(err).NotTo(HaveOccurred())
+
+				marshalled, err := json.Marshal(version)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(marshalled).To(MatchJSON(payload))
+			}
+		})
+	})
+
+	Describe("Config", func() {
+		Describe("SerialGroupLimits", func() {
+			var config Config
+
+			BeforeEach(func() {
+				config = Config{
+					SerialGroups: SerialGroupConfigs{
+						{Name: "environments", MaxInFlight: 3},
+						{Name: "without-max-in-flight"},
+					},
+				}
+			})
+
+			It("returns the declared max in flight of each serial group", func() {
+				jobConfig := JobConfig{
+					Name:         "some-job",
+					SerialGroups: []string{"environments", "without-max-in-flight", "undeclared"},
+				}
+
+				Expect(config.SerialGroupLimits(jobConfig)).To(Equal(map[string]int{
+					"environments":          3,
+					"without-max-in-flight": 1,
+					"undeclared":            1,
+				}))
+			})
+
+			It("returns the job's max in flight if SerialGroups are not specified", func() {
+				jobConfig := JobConfig{
+					Name:           "some-job",
+					RawMaxInFlight: 2,
+				}
+
+				Expect(config.SerialGroupLimits(jobConfig)).To(Equal(map[string]int{
+					"some-job": 2,
+				}))
+			})
+
+			It("returns no limits if the job is not serial and has no max-in-flight", func() {
+				jobConfig := JobConfig{
+					Name: "some-job",
+				}
+
+				Expect(config.SerialGroupLimits(jobConfig)).To(BeEmpty())
+			})
+		})
+	})
 })
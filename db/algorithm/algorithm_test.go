@@ -237,6 +237,93 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("steps through every version after the last one used, oldest first", Example{
+		DB: DB{
+			{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			{Resource: "resource-x", Version: "rxv4", CheckOrder: 4},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Every: true, LastUsed: 2},
+		},
+
+		Result: Result{
+			"resource-x": "rxv3",
+		},
+	}),
+
+	Entry("starts every version at the latest when no version has been used", Example{
+		DB: DB{
+			{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Every: true},
+		},
+
+		Result: Result{
+			"resource-x": "rxv2",
+		},
+	}),
+
+	Entry("stays on the last used version once every version has been used", Example{
+		DB: DB{
+			{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Every: true, LastUsed: 2},
+		},
+
+		Result: Result{
+			"resource-x": "rxv2",
+		},
+	}),
+
+	Entry("steps through every version that passed the constraints", Example{
+		DB: DB{
+			{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv4", CheckOrder: 4},
+
+			{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			{Resource: "resource-x", Version: "rxv4", CheckOrder: 4},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Passed: []string{"simple-a"}, Every: true, LastUsed: 1},
+		},
+
+		Result: Result{
+			"resource-x": "rxv3",
+		},
+	}),
+
+	Entry("uses a pinned version rather than the latest", Example{
+		DB: DB{
+			{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			{Resource: "resource-y", Version: "ryv1", CheckOrder: 1},
+			{Resource: "resource-y", Version: "ryv2", CheckOrder: 2},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x", Pinned: "rxv1"},
+			{Name: "resource-y", Resource: "resource-y"},
+		},
+
+		Result: Result{
+			"resource-x": "rxv1",
+			"resource-y": "ryv2",
+		},
+	}),
+
 	Entry("bosh memory leak regression test", Example{
 		LoadDB: "testdata/bosh-versions.json",
 
@@ -4,14 +4,32 @@ type InputCandidates map[string]InputVersionCandidates
 
 type InputVersionCandidates struct {
 	VersionCandidates
-	Passed JobSet
+	Passed      JobSet
+	OldestFirst bool
+}
+
+// OrderedVersionIDs returns the candidate versions in the order they should
+// be tried: oldest first when stepping through versions the job has yet to
+// use, and newest first otherwise.
+func (candidates InputVersionCandidates) OrderedVersionIDs() []int {
+	versionIDs := candidates.VersionIDs()
+	if !candidates.OldestFirst {
+		return versionIDs
+	}
+
+	ordered := make([]int, len(versionIDs))
+	for i, id := range versionIDs {
+		ordered[len(versionIDs)-1-i] = id
+	}
+
+	return ordered
 }
 
 func (candidates InputCandidates) Reduce(jobs JobSet) (InputMapping, bool) {
 	newCandidates := candidates.pruneToCommonBuilds(jobs)
 
 	for input, versionCandidates := range newCandidates {
-		versionIDs := versionCandidates.VersionIDs()
+		versionIDs := versionCandidates.OrderedVersionIDs()
 		if len(versionIDs) == 1 {
 			// already reduced
 			continue
@@ -6,6 +6,14 @@ type InputConfig struct {
 	Name       string
 	Passed     JobSet
 	ResourceID int
+
+	// UseEveryVersion makes the input step through each version in order,
+	// starting after the newest version the job has already used.
+	UseEveryVersion    bool
+	LastUsedCheckOrder int
+
+	// PinnedVersionID restricts the input to a single version.
+	PinnedVersionID int
 }
 
 func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
@@ -19,6 +27,21 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			inputConfig.Passed,
 		)
 
+		// a job taking every version steps through the versions newer than the
+		// one it last used, oldest first; one that has yet to use any, or has
+		// used them all, takes the latest like any other
+		oldestFirst := false
+
+		if inputConfig.PinnedVersionID != 0 {
+			candidateSet = candidateSet.ForVersion(inputConfig.PinnedVersionID)
+		} else if inputConfig.UseEveryVersion && inputConfig.LastUsedCheckOrder != 0 {
+			newer := candidateSet.NewerThan(inputConfig.LastUsedCheckOrder)
+			if len(newer) > 0 {
+				candidateSet = newer
+				oldestFirst = true
+			}
+		}
+
 		if len(candidateSet) == 0 {
 			return nil, false
 		}
@@ -26,6 +49,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputCandidates[inputConfig.Name] = InputVersionCandidates{
 			VersionCandidates: candidateSet,
 			Passed:            inputConfig.Passed,
+			OldestFirst:       oldestFirst,
 		}
 	}
 
@@ -33,6 +33,10 @@ type Input struct {
 	Name     string
 	Resource string
 	Passed   []string
+
+	Every    bool
+	LastUsed int
+	Pinned   string
 }
 
 type Result map[string]string
@@ -113,9 +117,15 @@ func (example Example) Run() {
 		}
 
 		inputConfigs[i] = algorithm.InputConfig{
-			Name:       input.Name,
-			Passed:     passed,
-			ResourceID: resourceIDs.ID(input.Resource),
+			Name:               input.Name,
+			Passed:             passed,
+			ResourceID:         resourceIDs.ID(input.Resource),
+			UseEveryVersion:    input.Every,
+			LastUsedCheckOrder: input.LastUsed,
+		}
+
+		if input.Pinned != "" {
+			inputConfigs[i].PinnedVersionID = versionIDs.ID(input.Pinned)
 		}
 	}
 
@@ -97,6 +97,17 @@ func (candidates VersionCandidates) VersionIDs() []int {
 	return versionIDs
 }
 
+func (candidates VersionCandidates) NewerThan(checkOrder int) VersionCandidates {
+	newCandidates := VersionCandidates{}
+	for candidate := range candidates {
+		if candidate.CheckOrder > checkOrder {
+			newCandidates[candidate] = struct{}{}
+		}
+	}
+
+	return newCandidates
+}
+
 func (candidates VersionCandidates) ForVersion(versionID int) VersionCandidates {
 	newCandidates := VersionCandidates{}
 	for candidate := range candidates {
@@ -20,16 +20,21 @@ type Build struct {
 	Scheduled        bool
 	InputsDetermined bool
 
+	ScheduleOverridden bool
+
 	JobID        int
 	JobName      string
 	PipelineName string
 	PipelineID   int
+	TeamID       int
 
 	Engine         string
 	EngineMetadata string
 
 	StartTime time.Time
 	EndTime   time.Time
+
+	RerunOf int
 }
 
 func (b Build) OneOff() bool {
@@ -59,6 +64,10 @@ type SavedResource struct {
 	Paused       bool
 	PipelineName string
 	Resource
+
+	CheckFailures int
+	CheckBackoff  time.Duration
+	NextCheck     time.Time
 }
 
 func (r SavedResource) FailingToCheck() bool {
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+//go:generate counterfeiter . BuildEventStore
+
+// BuildEventStore holds the events of completed builds once they have been
+// compacted out of the database. Each build's events are stored as a single
+// opaque blob.
+type BuildEventStore interface {
+	Put(buildID int, blob io.Reader) error
+	Get(buildID int) (io.ReadCloser, bool, error)
+}
+
+var ErrBuildEventStoreNotConfigured = errors.New("build events have been compacted, but no build event store is configured")
+var ErrCompactedBuildEventsNotFound = errors.New("compacted build events not found in the build event store")
+
+// compactedEvent is how each event is encoded in a compacted blob, which is a
+// gzipped stream of them, one per line.
+type compactedEvent struct {
+	Type    atc.EventType    `json:"event"`
+	Version atc.EventVersion `json:"version"`
+	Data    *json.RawMessage `json:"data"`
+}
+
+type compactedEventWriter struct {
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+}
+
+func newCompactedEventWriter(w io.Writer) *compactedEventWriter {
+	gz := gzip.NewWriter(w)
+
+	return &compactedEventWriter{
+		gzip:    gz,
+		encoder: json.NewEncoder(gz),
+	}
+}
+
+func (writer *compactedEventWriter) Write(typ atc.EventType, version atc.EventVersion, payload []byte) error {
+	data := json.RawMessage(payload)
+
+	return writer.encoder.Encode(compactedEvent{
+		Type:    typ,
+		Version: version,
+		Data:    &data,
+	})
+}
+
+func (writer *compactedEventWriter) Close() error {
+	return writer.gzip.Close()
+}
+
+type compactedEventReader struct {
+	blob    io.Closer
+	gzip    *gzip.Reader
+	decoder *json.Decoder
+}
+
+func newCompactedEventReader(blob io.ReadCloser) (*compactedEventReader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(blob))
+	if err != nil {
+		blob.Close()
+		return nil, err
+	}
+
+	return &compactedEventReader{
+		blob:    blob,
+		gzip:    gz,
+		decoder: json.NewDecoder(gz),
+	}, nil
+}
+
+// Next returns the next event in the blob, or io.EOF once there are none
+// left.
+func (reader *compactedEventReader) Next() (atc.Event, error) {
+	var compacted compactedEvent
+	err := reader.decoder.Decode(&compacted)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.ParseEvent(compacted.Version, compacted.Type, *compacted.Data)
+}
+
+func (reader *compactedEventReader) Close() error {
+	reader.gzip.Close()
+	return reader.blob.Close()
+}
@@ -20,6 +20,7 @@ type BuildPreparation struct {
 	PausedPipeline   BuildPreparationStatus
 	PausedJob        BuildPreparationStatus
 	MaxRunningBuilds BuildPreparationStatus
+	Schedule         BuildPreparationStatus
 	Inputs           map[string]BuildPreparationStatus
 	InputsSatisfied  BuildPreparationStatus
 }
@@ -30,6 +31,7 @@ func NewBuildPreparation(buildID int) BuildPreparation {
 		PausedPipeline:   BuildPreparationStatusUnknown,
 		PausedJob:        BuildPreparationStatusUnknown,
 		MaxRunningBuilds: BuildPreparationStatusUnknown,
+		Schedule:         BuildPreparationStatusUnknown,
 		Inputs:           map[string]BuildPreparationStatus{},
 		InputsSatisfied:  BuildPreparationStatusUnknown,
 	}
@@ -37,7 +39,7 @@ func NewBuildPreparation(buildID int) BuildPreparation {
 
 type buildPreparationHelper struct{}
 
-const BuildPreparationColumns string = "build_id, paused_pipeline, paused_job, max_running_builds, inputs, inputs_satisfied"
+const BuildPreparationColumns string = "build_id, paused_pipeline, paused_job, max_running_builds, inputs, inputs_satisfied, schedule"
 
 func (b buildPreparationHelper) CreateBuildPreparation(tx Tx, buildID int) error {
 	_, err := tx.Exec(`
@@ -54,7 +56,7 @@ func (b buildPreparationHelper) UpdateBuildPreparation(tx Tx, buildPrep BuildPre
 	}
 	_, err = tx.Exec(`
 	UPDATE build_preparation
-	SET paused_pipeline = $2, paused_job = $3, max_running_builds = $4, inputs = $5, inputs_satisfied = $6
+	SET paused_pipeline = $2, paused_job = $3, max_running_builds = $4, inputs = $5, inputs_satisfied = $6, schedule = $7
 	WHERE build_id = $1
 	`,
 		buildPrep.BuildID,
@@ -63,6 +65,7 @@ func (b buildPreparationHelper) UpdateBuildPreparation(tx Tx, buildPrep BuildPre
 		string(buildPrep.MaxRunningBuilds),
 		string(inputsJSON),
 		string(buildPrep.InputsSatisfied),
+		string(buildPrep.Schedule),
 	)
 	return err
 }
@@ -98,10 +101,10 @@ func (b buildPreparationHelper) constructBuildPreparations(rows *sql.Rows) ([]Bu
 	buildPreps := []BuildPreparation{}
 	for rows.Next() {
 		var buildID int
-		var pausedPipeline, pausedJob, maxRunningBuilds, inputsSatisfied string
+		var pausedPipeline, pausedJob, maxRunningBuilds, inputsSatisfied, schedule string
 		var inputsBlob []byte
 
-		err := rows.Scan(&buildID, &pausedPipeline, &pausedJob, &maxRunningBuilds, &inputsBlob, &inputsSatisfied)
+		err := rows.Scan(&buildID, &pausedPipeline, &pausedJob, &maxRunningBuilds, &inputsBlob, &inputsSatisfied, &schedule)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return []BuildPreparation{}, nil
@@ -122,6 +125,7 @@ func (b buildPreparationHelper) constructBuildPreparations(rows *sql.Rows) ([]Bu
 			MaxRunningBuilds: BuildPreparationStatus(maxRunningBuilds),
 			Inputs:           inputs,
 			InputsSatisfied:  BuildPreparationStatus(inputsSatisfied),
+			Schedule:         BuildPreparationStatus(schedule),
 		})
 	}
 
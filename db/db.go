@@ -1,8 +1,10 @@
 package db
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"time"
@@ -73,6 +75,21 @@ func HashResourceConfig(checkType string, source atc.Source) string {
 	return checkType + string(sourceJSON)
 }
 
+// ResourceConfigHash identifies resources that would be checked identically,
+// so that one check can be shared between pipelines. Resources of a custom
+// type also hash the type's own configuration, as its name alone is only
+// meaningful within a pipeline.
+func ResourceConfigHash(config atc.ResourceConfig, resourceTypes atc.ResourceTypes) string {
+	hash := sha256.New()
+	hash.Write([]byte(HashResourceConfig(config.Type, config.Source)))
+
+	if resourceType, found := resourceTypes.Lookup(config.Type); found {
+		hash.Write([]byte(HashResourceConfig(resourceType.Type, resourceType.Source)))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 type DB interface {
 	SaveTeam(team Team) (SavedTeam, error)
 	GetTeamByName(teamName string) (SavedTeam, bool, error)
@@ -86,11 +103,13 @@ type DB interface {
 	GetBuildResources(buildID int) ([]BuildInput, []BuildOutput, error)
 	GetBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
+	GetBuildsWithUncompactedEvents() ([]Build, error)
 
 	CreatePipe(pipeGUID string, url string) error
 	GetPipe(pipeGUID string) (Pipe, error)
 
 	CreateOneOffBuild() (Build, error)
+	CreateRerunBuild(original Build) (Build, error)
 	GetBuildPreparation(buildID int) (BuildPreparation, bool, error)
 	UpdateBuildPreparation(buildPreparation BuildPreparation) error
 	ResetBuildPreparationsWithPipelinePaused(pipelineID int) error
@@ -98,15 +117,19 @@ type DB interface {
 	LeaseBuildTracking(buildID int, interval time.Duration) (Lease, bool, error)
 	LeaseBuildScheduling(buildID int, interval time.Duration) (Lease, bool, error)
 	LeaseCacheInvalidation(interval time.Duration) (Lease, bool, error)
+	LeaseVersionPruning(interval time.Duration) (Lease, bool, error)
+	LeaseBuildEventCompaction(interval time.Duration) (Lease, bool, error)
 
 	StartBuild(buildID int, engineName, engineMetadata string) (bool, error)
 	FinishBuild(buildID int, status Status) error
 	ErrorBuild(buildID int, cause error) error
+	CompactBuildEvents(buildID int) error
 
 	SaveBuildInput(teamName string, buildID int, input BuildInput) (SavedVersionedResource, error)
 	SaveBuildOutput(teamName string, buildID int, vr VersionedResource, explicit bool) (SavedVersionedResource, error)
 
 	GetBuildEvents(buildID int, from uint) (EventSource, error)
+	GetBuildLifecycleEvents(buildID int) ([]atc.Event, error)
 	SaveBuildEvent(buildID int, event atc.Event) error
 
 	SaveBuildEngineMetadata(buildID int, engineMetadata string) error
@@ -117,6 +140,7 @@ type DB interface {
 	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
 	GetWorker(workerName string) (SavedWorker, bool, error)
 	SaveWorker(WorkerInfo, time.Duration) (SavedWorker, error)
+	MarkWorkerUnhealthy(workerName string, coolDown time.Duration) error
 
 	FindContainersByDescriptors(Container) ([]Container, error)
 	GetContainer(string) (Container, bool, error)
@@ -138,6 +162,9 @@ type DB interface {
 
 	SaveImageResourceVersion(buildID int, planID atc.PlanID, identifier VolumeIdentifier) error
 	GetImageVolumeIdentifiersByBuildID(buildID int) ([]VolumeIdentifier, error)
+
+	SaveBuildTestResults(buildID int, planID atc.PlanID, results atc.TestResults) error
+	GetBuildTestResults(buildID int) (atc.TestResults, bool, error)
 }
 
 //go:generate counterfeiter . Notifier
@@ -211,6 +238,10 @@ type SavedWorker struct {
 	WorkerInfo
 
 	ExpiresIn time.Duration
+
+	// UnhealthyFor is how much longer the worker is excluded from placing new
+	// containers after failing repeatedly, or 0 if it is healthy.
+	UnhealthyFor time.Duration
 }
 
 type WorkerInfo struct {
@@ -218,10 +249,15 @@ type WorkerInfo struct {
 	BaggageclaimURL string
 
 	ActiveContainers int
+	MaxContainers    int
 	ResourceTypes    []atc.WorkerResourceType
 	Platform         string
 	Tags             []string
 	Name             string
+
+	// TeamID is the team that owns the worker, or 0 if any team may use it.
+	TeamID   int
+	TeamName string
 }
 
 type SavedVolume struct {
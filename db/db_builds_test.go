@@ -218,6 +218,164 @@ var _ = Describe("Keeping track of builds", func() {
 		})
 	})
 
+	Describe("CreateRerunBuild", func() {
+		Context("of a one-off build", func() {
+			var original db.Build
+
+			BeforeEach(func() {
+				var err error
+				original, err = database.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a pending one-off build linked to the original", func() {
+				rerun, err := database.CreateRerunBuild(original)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rerun.ID).NotTo(Equal(original.ID))
+				Expect(rerun.Name).To(Equal("2"))
+				Expect(rerun.JobName).To(BeZero())
+				Expect(rerun.Status).To(Equal(db.StatusPending))
+				Expect(rerun.RerunOf).To(Equal(original.ID))
+
+				rerunGot, found, err := database.GetBuild(rerun.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(rerunGot).To(Equal(rerun))
+
+				_, found, err = database.GetBuildPreparation(rerun.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("of a job build", func() {
+			var original db.Build
+
+			BeforeEach(func() {
+				var err error
+				original, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.SaveBuildInput(original.ID, db.BuildInput{
+					Name: "some-input",
+					VersionedResource: db.VersionedResource{
+						Resource:     "some-resource",
+						Type:         "some-type",
+						Version:      db.Version{"some": "version"},
+						PipelineName: "some-pipeline",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.FinishBuild(original.ID, db.StatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates the job's next build, linked to the original", func() {
+				rerun, err := database.CreateRerunBuild(original)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rerun.Name).To(Equal("2"))
+				Expect(rerun.JobName).To(Equal("some-job"))
+				Expect(rerun.PipelineName).To(Equal("some-pipeline"))
+				Expect(rerun.Status).To(Equal(db.StatusPending))
+				Expect(rerun.InputsDetermined).To(BeTrue())
+				Expect(rerun.RerunOf).To(Equal(original.ID))
+
+				rerunGot, found, err := database.GetBuild(rerun.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(rerunGot).To(Equal(rerun))
+			})
+
+			It("gives it the original build's inputs", func() {
+				rerun, err := database.CreateRerunBuild(original)
+				Expect(err).NotTo(HaveOccurred())
+
+				inputs, _, err := database.GetBuildResources(rerun.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(HaveLen(1))
+				Expect(inputs[0].Name).To(Equal("some-input"))
+				Expect(inputs[0].Version).To(Equal(db.Version{"some": "version"}))
+			})
+
+			It("is not picked up by the scheduler", func() {
+				_, err := database.CreateRerunBuild(original)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := pipelineDB.GetNextPendingBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("test results", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = database.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is not found until a step saves its results", func() {
+			_, found, err := database.GetBuildTestResults(build.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("combines the results of each step", func() {
+			err := database.SaveBuildTestResults(build.ID, atc.PlanID("1"), atc.TestResults{
+				Passed:      2,
+				Failed:      1,
+				FailedTests: []string{"some-test"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.SaveBuildTestResults(build.ID, atc.PlanID("2"), atc.TestResults{
+				Passed:      1,
+				Failed:      1,
+				Skipped:     3,
+				FailedTests: []string{"some-other-test"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			results, found, err := database.GetBuildTestResults(build.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(results).To(Equal(atc.TestResults{
+				Passed:      3,
+				Failed:      2,
+				Skipped:     3,
+				FailedTests: []string{"some-test", "some-other-test"},
+			}))
+		})
+
+		It("replaces the results of a step that is saved again", func() {
+			err := database.SaveBuildTestResults(build.ID, atc.PlanID("1"), atc.TestResults{
+				Failed:      1,
+				FailedTests: []string{"some-test"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.SaveBuildTestResults(build.ID, atc.PlanID("1"), atc.TestResults{
+				Passed:      1,
+				FailedTests: []string{},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			results, found, err := database.GetBuildTestResults(build.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(results).To(Equal(atc.TestResults{
+				Passed:      1,
+				FailedTests: []string{},
+			}))
+		})
+	})
+
 	Describe("build preparation update", func() {
 		var (
 			oneOff db.Build
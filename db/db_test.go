@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/lib/pq"
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/eventstore"
 )
 
 var _ = Describe("SQL DB", func() {
@@ -175,4 +178,175 @@ var _ = Describe("SQL DB", func() {
 		_, err = events.Next()
 		Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
 	})
+
+	It("returns the lifecycle events saved so far without waiting for more", func() {
+		build, err := database.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = database.SaveBuildEvent(build.ID, event.StartStep{Type: event.StepTypeTask, Origin: event.Origin{ID: "1"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = database.SaveBuildEvent(build.ID, event.Log{Payload: "some output"})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = database.SaveBuildEvent(build.ID, event.FinishStep{Type: event.StepTypeTask, Succeeded: true, Origin: event.Origin{ID: "1"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(database.GetBuildLifecycleEvents(build.ID)).To(Equal([]atc.Event{
+			event.StartStep{Type: event.StepTypeTask, Origin: event.Origin{ID: "1"}},
+			event.FinishStep{Type: event.StepTypeTask, Succeeded: true, Origin: event.Origin{ID: "1"}},
+		}))
+	})
+
+	Describe("compacting build events", func() {
+		var sqlDB *db.SQLDB
+		var storeDir string
+
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			storeDir, err = ioutil.TempDir("", "build-events")
+			Expect(err).NotTo(HaveOccurred())
+
+			sqlDB = database.(*db.SQLDB)
+
+			build, err = sqlDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.StartBuild(build.ID, "engine", "metadata")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sqlDB.SaveBuildEvent(build.ID, event.Log{Payload: "some "})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sqlDB.SaveBuildEvent(build.ID, event.Log{Payload: "log"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(storeDir)
+		})
+
+		Context("without a build event store", func() {
+			It("refuses to compact", func() {
+				err := sqlDB.FinishBuild(build.ID, db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = sqlDB.CompactBuildEvents(build.ID)
+				Expect(err).To(Equal(db.ErrBuildEventStoreNotConfigured))
+			})
+		})
+
+		Context("with a build event store", func() {
+			BeforeEach(func() {
+				sqlDB.UseBuildEventStore(eventstore.NewLocal(storeDir))
+			})
+
+			It("only considers completed builds", func() {
+				builds, err := sqlDB.GetBuildsWithUncompactedEvents()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+
+				err = sqlDB.CompactBuildEvents(build.ID)
+				Expect(err).To(HaveOccurred())
+
+				err = sqlDB.FinishBuild(build.ID, db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				builds, err = sqlDB.GetBuildsWithUncompactedEvents()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(build.ID))
+			})
+
+			Context("once the build has completed and been compacted", func() {
+				var finishedBuild db.Build
+
+				BeforeEach(func() {
+					err := sqlDB.FinishBuild(build.ID, db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					var found bool
+					finishedBuild, found, err = sqlDB.GetBuild(build.ID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = sqlDB.CompactBuildEvents(build.ID)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is no longer considered for compaction", func() {
+					builds, err := sqlDB.GetBuildsWithUncompactedEvents()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(BeEmpty())
+
+					Expect(sqlDB.CompactBuildEvents(build.ID)).To(Succeed())
+				})
+
+				It("removes the events from the database", func() {
+					var count int
+					err := dbConn.QueryRow(`
+						SELECT COUNT(*) FROM build_events WHERE build_id = $1
+					`, build.ID).Scan(&count)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(count).To(BeZero())
+				})
+
+				It("streams the events from the store", func() {
+					events, err := sqlDB.GetBuildEvents(build.ID, 0)
+					Expect(err).NotTo(HaveOccurred())
+
+					defer events.Close()
+
+					Expect(events.Next()).To(Equal(event.Status{
+						Status: atc.StatusStarted,
+						Time:   finishedBuild.StartTime.Unix(),
+					}))
+
+					Expect(events.Next()).To(Equal(event.Log{Payload: "some "}))
+					Expect(events.Next()).To(Equal(event.Log{Payload: "log"}))
+
+					Expect(events.Next()).To(Equal(event.Status{
+						Status: atc.StatusSucceeded,
+						Time:   finishedBuild.EndTime.Unix(),
+					}))
+
+					_, err = events.Next()
+					Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+				})
+
+				It("streams the events from an offset", func() {
+					events, err := sqlDB.GetBuildEvents(build.ID, 2)
+					Expect(err).NotTo(HaveOccurred())
+
+					defer events.Close()
+
+					Expect(events.Next()).To(Equal(event.Log{Payload: "log"}))
+
+					Expect(events.Next()).To(Equal(event.Status{
+						Status: atc.StatusSucceeded,
+						Time:   finishedBuild.EndTime.Unix(),
+					}))
+
+					_, err = events.Next()
+					Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+				})
+			})
+		})
+	})
+
+	Describe("taking out a lease on build event compaction", func() {
+		It("can only be held by one at a time", func() {
+			lease, leased, err := database.LeaseBuildEventCompaction(1 * time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leased).To(BeTrue())
+
+			_, leased, err = database.LeaseBuildEventCompaction(1 * time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leased).To(BeFalse())
+
+			lease.Break()
+		})
+	})
 })
@@ -159,9 +159,10 @@ var _ = Describe("Keeping track of workers", func() {
 			ResourceTypes: []atc.WorkerResourceType{
 				{Type: "some-resource-b", Image: "some-image-b"},
 			},
-			Platform: "plan9",
-			Tags:     []string{"russ", "cox", "was", "here"},
-			Name:     "workerName2",
+			Platform:      "plan9",
+			Tags:          []string{"russ", "cox", "was", "here"},
+			Name:          "workerName2",
+			MaxContainers: 250,
 		}
 
 		infoC := db.WorkerInfo{
@@ -195,6 +196,7 @@ var _ = Describe("Keeping track of workers", func() {
 		Expect(savedWorker.Platform).To(Equal(infoB.Platform))
 		Expect(savedWorker.Tags).To(Equal(infoB.Tags))
 		Expect(savedWorker.Name).To(Equal(infoB.Name))
+		Expect(savedWorker.MaxContainers).To(Equal(infoB.MaxContainers))
 
 		By("expiring TTLs")
 		ttl := 1 * time.Second
@@ -210,6 +212,68 @@ var _ = Describe("Keeping track of workers", func() {
 		Consistently(workerFound, ttl/2).Should(BeTrue())
 		Eventually(workerFound, 2*ttl).Should(BeFalse())
 	})
+
+	It("can mark a worker as unhealthy for a cool-down period", func() {
+		_, err := database.SaveWorker(db.WorkerInfo{
+			GardenAddr: "1.2.3.4:7777",
+			Name:       "some-worker",
+		}, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedWorker, found, err := database.GetWorker("some-worker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(savedWorker.UnhealthyFor).To(BeZero())
+
+		err = database.MarkWorkerUnhealthy("some-worker", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedWorker, found, err = database.GetWorker("some-worker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(savedWorker.UnhealthyFor).To(BeNumerically("~", time.Minute, 2*time.Second))
+
+		By("not resetting it when the worker heartbeats")
+		_, err = database.SaveWorker(db.WorkerInfo{
+			GardenAddr: "1.2.3.4:7777",
+			Name:       "some-worker",
+		}, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedWorker, found, err = database.GetWorker("some-worker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(savedWorker.UnhealthyFor).NotTo(BeZero())
+
+		By("becoming healthy again once the cool-down has passed")
+		err = database.MarkWorkerUnhealthy("some-worker", 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedWorker, found, err = database.GetWorker("some-worker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(savedWorker.UnhealthyFor).To(BeZero())
+	})
+
+	It("can keep track of the team a worker belongs to", func() {
+		team, err := database.SaveTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		info := db.WorkerInfo{
+			GardenAddr: "1.2.3.4:7777",
+			Name:       "team-worker",
+			TeamID:     team.ID,
+		}
+
+		_, err = database.SaveWorker(info, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedWorker, found, err := database.GetWorker("team-worker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(savedWorker.TeamID).To(Equal(team.ID))
+		Expect(savedWorker.TeamName).To(Equal("some-team"))
+	})
 })
 
 func getWorkerInfos(savedWorkers []db.SavedWorker, err error) []db.WorkerInfo {
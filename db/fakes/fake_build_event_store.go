@@ -0,0 +1,100 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/db"
+)
+
+type FakeBuildEventStore struct {
+	PutStub        func(buildID int, blob io.Reader) error
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		buildID int
+		blob    io.Reader
+	}
+	putReturns struct {
+		result1 error
+	}
+	GetStub        func(buildID int) (io.ReadCloser, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		buildID int
+	}
+	getReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+}
+
+func (fake *FakeBuildEventStore) Put(buildID int, blob io.Reader) error {
+	fake.putMutex.Lock()
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		buildID int
+		blob    io.Reader
+	}{buildID, blob})
+	fake.putMutex.Unlock()
+	if fake.PutStub != nil {
+		return fake.PutStub(buildID, blob)
+	} else {
+		return fake.putReturns.result1
+	}
+}
+
+func (fake *FakeBuildEventStore) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+
+func (fake *FakeBuildEventStore) PutArgsForCall(i int) (int, io.Reader) {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return fake.putArgsForCall[i].buildID, fake.putArgsForCall[i].blob
+}
+
+func (fake *FakeBuildEventStore) PutReturns(result1 error) {
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventStore) Get(buildID int) (io.ReadCloser, bool, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(buildID)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2, fake.getReturns.result3
+	}
+}
+
+func (fake *FakeBuildEventStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeBuildEventStore) GetArgsForCall(i int) int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].buildID
+}
+
+func (fake *FakeBuildEventStore) GetReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ db.BuildEventStore = new(FakeBuildEventStore)
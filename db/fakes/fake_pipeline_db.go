@@ -327,27 +327,6 @@ type FakePipelineDB struct {
 		result2 bool
 		result3 error
 	}
-	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
-	getRunningBuildsBySerialGroupMutex       sync.RWMutex
-	getRunningBuildsBySerialGroupArgsForCall []struct {
-		jobName      string
-		serialGroups []string
-	}
-	getRunningBuildsBySerialGroupReturns struct {
-		result1 []db.Build
-		result2 error
-	}
-	GetNextPendingBuildBySerialGroupStub        func(jobName string, serialGroups []string) (db.Build, bool, error)
-	getNextPendingBuildBySerialGroupMutex       sync.RWMutex
-	getNextPendingBuildBySerialGroupArgsForCall []struct {
-		jobName      string
-		serialGroups []string
-	}
-	getNextPendingBuildBySerialGroupReturns struct {
-		result1 db.Build
-		result2 bool
-		result3 error
-	}
 	UpdateBuildToScheduledStub        func(buildID int) (bool, error)
 	updateBuildToScheduledMutex       sync.RWMutex
 	updateBuildToScheduledArgsForCall []struct {
@@ -412,6 +391,198 @@ type FakePipelineDB struct {
 		result2 atc.GroupConfigs
 		result3 error
 	}
+	GetPendingBuildsStub        func() ([]db.Build, error)
+	getPendingBuildsMutex       sync.RWMutex
+	getPendingBuildsArgsForCall []struct{}
+	getPendingBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetRunningBuildsStub        func() ([]db.Build, error)
+	getRunningBuildsMutex       sync.RWMutex
+	getRunningBuildsArgsForCall []struct{}
+	getRunningBuildsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	PrioritizePendingBuildStub        func(buildID int) (bool, error)
+	prioritizePendingBuildMutex       sync.RWMutex
+	prioritizePendingBuildArgsForCall []struct {
+		buildID int
+	}
+	prioritizePendingBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	getRunningBuildsBySerialGroupMutex       sync.RWMutex
+	getRunningBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups map[string]int
+	}
+	getRunningBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetNextPendingBuildsBySerialGroupStub        func(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	getNextPendingBuildsBySerialGroupMutex       sync.RWMutex
+	getNextPendingBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups map[string]int
+	}
+	getNextPendingBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	OverrideBuildScheduleStub        func(buildID int) error
+	overrideBuildScheduleMutex       sync.RWMutex
+	overrideBuildScheduleArgsForCall []struct {
+		buildID int
+	}
+	overrideBuildScheduleReturns struct {
+		result1 error
+	}
+	GetConsecutiveFailuresStub        func(job string) (int, error)
+	getConsecutiveFailuresMutex       sync.RWMutex
+	getConsecutiveFailuresArgsForCall []struct {
+		job string
+	}
+	getConsecutiveFailuresReturns struct {
+		result1 int
+		result2 error
+	}
+	SetResourceCheckBackoffStub        func(resource db.SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error
+	setResourceCheckBackoffMutex       sync.RWMutex
+	setResourceCheckBackoffArgsForCall []struct {
+		resource  db.SavedResource
+		failures  int
+		backoff   time.Duration
+		nextCheck time.Time
+	}
+	setResourceCheckBackoffReturns struct {
+		result1 error
+	}
+	SaveResourceCheckStub        func(resource db.SavedResource, check db.ResourceCheck) error
+	saveResourceCheckMutex       sync.RWMutex
+	saveResourceCheckArgsForCall []struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}
+	saveResourceCheckReturns struct {
+		result1 error
+	}
+	GetResourceChecksStub        func(resourceName string) ([]db.ResourceCheck, bool, error)
+	getResourceChecksMutex       sync.RWMutex
+	getResourceChecksArgsForCall []struct {
+		resourceName string
+	}
+	getResourceChecksReturns struct {
+		result1 []db.ResourceCheck
+		result2 bool
+		result3 error
+	}
+	ShareResourceVersionsStub        func(resource db.SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error
+	shareResourceVersionsMutex       sync.RWMutex
+	shareResourceVersionsArgsForCall []struct {
+		resource db.SavedResource
+		config   atc.ResourceConfig
+		from     atc.Version
+		versions []atc.Version
+	}
+	shareResourceVersionsReturns struct {
+		result1 error
+	}
+	GetResourceTypeStub        func(resourceTypeName string) (db.SavedResourceType, bool, error)
+	getResourceTypeMutex       sync.RWMutex
+	getResourceTypeArgsForCall []struct {
+		resourceTypeName string
+	}
+	getResourceTypeReturns struct {
+		result1 db.SavedResourceType
+		result2 bool
+		result3 error
+	}
+	SaveResourceTypeVersionStub        func(resourceType db.SavedResourceType, version atc.Version) error
+	saveResourceTypeVersionMutex       sync.RWMutex
+	saveResourceTypeVersionArgsForCall []struct {
+		resourceType db.SavedResourceType
+		version      atc.Version
+	}
+	saveResourceTypeVersionReturns struct {
+		result1 error
+	}
+	SetResourceTypeCheckErrorStub        func(resourceType db.SavedResourceType, err error) error
+	setResourceTypeCheckErrorMutex       sync.RWMutex
+	setResourceTypeCheckErrorArgsForCall []struct {
+		resourceType db.SavedResourceType
+		err          error
+	}
+	setResourceTypeCheckErrorReturns struct {
+		result1 error
+	}
+	PinResourceTypeVersionStub        func(resourceTypeName string, version atc.Version) error
+	pinResourceTypeVersionMutex       sync.RWMutex
+	pinResourceTypeVersionArgsForCall []struct {
+		resourceTypeName string
+		version          atc.Version
+	}
+	pinResourceTypeVersionReturns struct {
+		result1 error
+	}
+	UnpinResourceTypeVersionStub        func(resourceTypeName string) error
+	unpinResourceTypeVersionMutex       sync.RWMutex
+	unpinResourceTypeVersionArgsForCall []struct {
+		resourceTypeName string
+	}
+	unpinResourceTypeVersionReturns struct {
+		result1 error
+	}
+	ResolveResourceTypeVersionsStub        func(arg1 atc.ResourceTypes) (atc.ResourceTypes, error)
+	resolveResourceTypeVersionsMutex       sync.RWMutex
+	resolveResourceTypeVersionsArgsForCall []struct {
+		arg1 atc.ResourceTypes
+	}
+	resolveResourceTypeVersionsReturns struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
+	LeaseResourceTypeCheckingStub        func(resourceType string, length time.Duration, immediate bool) (db.Lease, bool, error)
+	leaseResourceTypeCheckingMutex       sync.RWMutex
+	leaseResourceTypeCheckingArgsForCall []struct {
+		resourceType string
+		length       time.Duration
+		immediate    bool
+	}
+	leaseResourceTypeCheckingReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	PruneResourceVersionsStub        func(resource atc.ResourceConfig, jobs atc.JobConfigs) (int, error)
+	pruneResourceVersionsMutex       sync.RWMutex
+	pruneResourceVersionsArgsForCall []struct {
+		resource atc.ResourceConfig
+		jobs     atc.JobConfigs
+	}
+	pruneResourceVersionsReturns struct {
+		result1 int
+		result2 error
+	}
+	GetPipelineTeamIDStub        func() int
+	getPipelineTeamIDMutex       sync.RWMutex
+	getPipelineTeamIDArgsForCall []struct{}
+	getPipelineTeamIDReturns     struct {
+		result1 int
+	}
+	PauseJobWithReasonStub        func(job string, reason string) error
+	pauseJobWithReasonMutex       sync.RWMutex
+	pauseJobWithReasonArgsForCall []struct {
+		job    string
+		reason string
+	}
+	pauseJobWithReasonReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakePipelineDB) GetPipelineName() string {
@@ -1527,75 +1698,6 @@ func (fake *FakePipelineDB) GetCurrentBuildReturns(result1 db.Build, result2 boo
 	}{result1, result2, result3}
 }
 
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
-	fake.getRunningBuildsBySerialGroupMutex.Lock()
-	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
-		jobName      string
-		serialGroups []string
-	}{jobName, serialGroups})
-	fake.getRunningBuildsBySerialGroupMutex.Unlock()
-	if fake.GetRunningBuildsBySerialGroupStub != nil {
-		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
-	} else {
-		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
-	}
-}
-
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupCallCount() int {
-	fake.getRunningBuildsBySerialGroupMutex.RLock()
-	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
-	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
-}
-
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, []string) {
-	fake.getRunningBuildsBySerialGroupMutex.RLock()
-	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
-	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
-}
-
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
-	fake.GetRunningBuildsBySerialGroupStub = nil
-	fake.getRunningBuildsBySerialGroupReturns = struct {
-		result1 []db.Build
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error) {
-	fake.getNextPendingBuildBySerialGroupMutex.Lock()
-	fake.getNextPendingBuildBySerialGroupArgsForCall = append(fake.getNextPendingBuildBySerialGroupArgsForCall, struct {
-		jobName      string
-		serialGroups []string
-	}{jobName, serialGroups})
-	fake.getNextPendingBuildBySerialGroupMutex.Unlock()
-	if fake.GetNextPendingBuildBySerialGroupStub != nil {
-		return fake.GetNextPendingBuildBySerialGroupStub(jobName, serialGroups)
-	} else {
-		return fake.getNextPendingBuildBySerialGroupReturns.result1, fake.getNextPendingBuildBySerialGroupReturns.result2, fake.getNextPendingBuildBySerialGroupReturns.result3
-	}
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupCallCount() int {
-	fake.getNextPendingBuildBySerialGroupMutex.RLock()
-	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
-	return len(fake.getNextPendingBuildBySerialGroupArgsForCall)
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupArgsForCall(i int) (string, []string) {
-	fake.getNextPendingBuildBySerialGroupMutex.RLock()
-	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
-	return fake.getNextPendingBuildBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildBySerialGroupArgsForCall[i].serialGroups
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupReturns(result1 db.Build, result2 bool, result3 error) {
-	fake.GetNextPendingBuildBySerialGroupStub = nil
-	fake.getNextPendingBuildBySerialGroupReturns = struct {
-		result1 db.Build
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
-}
-
 func (fake *FakePipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	fake.updateBuildToScheduledMutex.Lock()
 	fake.updateBuildToScheduledArgsForCall = append(fake.updateBuildToScheduledArgsForCall, struct {
@@ -1822,4 +1924,682 @@ func (fake *FakePipelineDB) GetDashboardReturns(result1 db.Dashboard, result2 at
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipelineDB) GetPendingBuilds() ([]db.Build, error) {
+	fake.getPendingBuildsMutex.Lock()
+	fake.getPendingBuildsArgsForCall = append(fake.getPendingBuildsArgsForCall, struct{}{})
+	fake.getPendingBuildsMutex.Unlock()
+	if fake.GetPendingBuildsStub != nil {
+		return fake.GetPendingBuildsStub()
+	} else {
+		return fake.getPendingBuildsReturns.result1, fake.getPendingBuildsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetPendingBuildsCallCount() int {
+	fake.getPendingBuildsMutex.RLock()
+	defer fake.getPendingBuildsMutex.RUnlock()
+	return len(fake.getPendingBuildsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetPendingBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetPendingBuildsStub = nil
+	fake.getPendingBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetRunningBuilds() ([]db.Build, error) {
+	fake.getRunningBuildsMutex.Lock()
+	fake.getRunningBuildsArgsForCall = append(fake.getRunningBuildsArgsForCall, struct{}{})
+	fake.getRunningBuildsMutex.Unlock()
+	if fake.GetRunningBuildsStub != nil {
+		return fake.GetRunningBuildsStub()
+	} else {
+		return fake.getRunningBuildsReturns.result1, fake.getRunningBuildsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsCallCount() int {
+	fake.getRunningBuildsMutex.RLock()
+	defer fake.getRunningBuildsMutex.RUnlock()
+	return len(fake.getRunningBuildsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsStub = nil
+	fake.getRunningBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) PrioritizePendingBuild(buildID int) (bool, error) {
+	fake.prioritizePendingBuildMutex.Lock()
+	fake.prioritizePendingBuildArgsForCall = append(fake.prioritizePendingBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.prioritizePendingBuildMutex.Unlock()
+	if fake.PrioritizePendingBuildStub != nil {
+		return fake.PrioritizePendingBuildStub(buildID)
+	} else {
+		return fake.prioritizePendingBuildReturns.result1, fake.prioritizePendingBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) PrioritizePendingBuildCallCount() int {
+	fake.prioritizePendingBuildMutex.RLock()
+	defer fake.prioritizePendingBuildMutex.RUnlock()
+	return len(fake.prioritizePendingBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) PrioritizePendingBuildArgsForCall(i int) int {
+	fake.prioritizePendingBuildMutex.RLock()
+	defer fake.prioritizePendingBuildMutex.RUnlock()
+	return fake.prioritizePendingBuildArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) PrioritizePendingBuildReturns(result1 bool, result2 error) {
+	fake.PrioritizePendingBuildStub = nil
+	fake.prioritizePendingBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error) {
+	fake.getRunningBuildsBySerialGroupMutex.Lock()
+	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups map[string]int
+	}{jobName, serialGroups})
+	fake.getRunningBuildsBySerialGroupMutex.Unlock()
+	if fake.GetRunningBuildsBySerialGroupStub != nil {
+		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupCallCount() int {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, map[string]int) {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsBySerialGroupStub = nil
+	fake.getRunningBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error) {
+	fake.getNextPendingBuildsBySerialGroupMutex.Lock()
+	fake.getNextPendingBuildsBySerialGroupArgsForCall = append(fake.getNextPendingBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups map[string]int
+	}{jobName, serialGroups})
+	fake.getNextPendingBuildsBySerialGroupMutex.Unlock()
+	if fake.GetNextPendingBuildsBySerialGroupStub != nil {
+		return fake.GetNextPendingBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getNextPendingBuildsBySerialGroupReturns.result1, fake.getNextPendingBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroupCallCount() int {
+	fake.getNextPendingBuildsBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getNextPendingBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroupArgsForCall(i int) (string, map[string]int) {
+	fake.getNextPendingBuildsBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildsBySerialGroupMutex.RUnlock()
+	return fake.getNextPendingBuildsBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetNextPendingBuildsBySerialGroupStub = nil
+	fake.getNextPendingBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) OverrideBuildSchedule(buildID int) error {
+	fake.overrideBuildScheduleMutex.Lock()
+	fake.overrideBuildScheduleArgsForCall = append(fake.overrideBuildScheduleArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.overrideBuildScheduleMutex.Unlock()
+	if fake.OverrideBuildScheduleStub != nil {
+		return fake.OverrideBuildScheduleStub(buildID)
+	} else {
+		return fake.overrideBuildScheduleReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) OverrideBuildScheduleCallCount() int {
+	fake.overrideBuildScheduleMutex.RLock()
+	defer fake.overrideBuildScheduleMutex.RUnlock()
+	return len(fake.overrideBuildScheduleArgsForCall)
+}
+
+func (fake *FakePipelineDB) OverrideBuildScheduleArgsForCall(i int) int {
+	fake.overrideBuildScheduleMutex.RLock()
+	defer fake.overrideBuildScheduleMutex.RUnlock()
+	return fake.overrideBuildScheduleArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) OverrideBuildScheduleReturns(result1 error) {
+	fake.OverrideBuildScheduleStub = nil
+	fake.overrideBuildScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetConsecutiveFailures(job string) (int, error) {
+	fake.getConsecutiveFailuresMutex.Lock()
+	fake.getConsecutiveFailuresArgsForCall = append(fake.getConsecutiveFailuresArgsForCall, struct {
+		job string
+	}{job})
+	fake.getConsecutiveFailuresMutex.Unlock()
+	if fake.GetConsecutiveFailuresStub != nil {
+		return fake.GetConsecutiveFailuresStub(job)
+	} else {
+		return fake.getConsecutiveFailuresReturns.result1, fake.getConsecutiveFailuresReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetConsecutiveFailuresCallCount() int {
+	fake.getConsecutiveFailuresMutex.RLock()
+	defer fake.getConsecutiveFailuresMutex.RUnlock()
+	return len(fake.getConsecutiveFailuresArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetConsecutiveFailuresArgsForCall(i int) string {
+	fake.getConsecutiveFailuresMutex.RLock()
+	defer fake.getConsecutiveFailuresMutex.RUnlock()
+	return fake.getConsecutiveFailuresArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) GetConsecutiveFailuresReturns(result1 int, result2 error) {
+	fake.GetConsecutiveFailuresStub = nil
+	fake.getConsecutiveFailuresReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoff(resource db.SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error {
+	fake.setResourceCheckBackoffMutex.Lock()
+	fake.setResourceCheckBackoffArgsForCall = append(fake.setResourceCheckBackoffArgsForCall, struct {
+		resource  db.SavedResource
+		failures  int
+		backoff   time.Duration
+		nextCheck time.Time
+	}{resource, failures, backoff, nextCheck})
+	fake.setResourceCheckBackoffMutex.Unlock()
+	if fake.SetResourceCheckBackoffStub != nil {
+		return fake.SetResourceCheckBackoffStub(resource, failures, backoff, nextCheck)
+	} else {
+		return fake.setResourceCheckBackoffReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffCallCount() int {
+	fake.setResourceCheckBackoffMutex.RLock()
+	defer fake.setResourceCheckBackoffMutex.RUnlock()
+	return len(fake.setResourceCheckBackoffArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffArgsForCall(i int) (db.SavedResource, int, time.Duration, time.Time) {
+	fake.setResourceCheckBackoffMutex.RLock()
+	defer fake.setResourceCheckBackoffMutex.RUnlock()
+	return fake.setResourceCheckBackoffArgsForCall[i].resource, fake.setResourceCheckBackoffArgsForCall[i].failures, fake.setResourceCheckBackoffArgsForCall[i].backoff, fake.setResourceCheckBackoffArgsForCall[i].nextCheck
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffReturns(result1 error) {
+	fake.SetResourceCheckBackoffStub = nil
+	fake.setResourceCheckBackoffReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) SaveResourceCheck(resource db.SavedResource, check db.ResourceCheck) error {
+	fake.saveResourceCheckMutex.Lock()
+	fake.saveResourceCheckArgsForCall = append(fake.saveResourceCheckArgsForCall, struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}{resource, check})
+	fake.saveResourceCheckMutex.Unlock()
+	if fake.SaveResourceCheckStub != nil {
+		return fake.SaveResourceCheckStub(resource, check)
+	} else {
+		return fake.saveResourceCheckReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveResourceCheckCallCount() int {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return len(fake.saveResourceCheckArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveResourceCheckArgsForCall(i int) (db.SavedResource, db.ResourceCheck) {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return fake.saveResourceCheckArgsForCall[i].resource, fake.saveResourceCheckArgsForCall[i].check
+}
+
+func (fake *FakePipelineDB) SaveResourceCheckReturns(result1 error) {
+	fake.SaveResourceCheckStub = nil
+	fake.saveResourceCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetResourceChecks(resourceName string) ([]db.ResourceCheck, bool, error) {
+	fake.getResourceChecksMutex.Lock()
+	fake.getResourceChecksArgsForCall = append(fake.getResourceChecksArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.getResourceChecksMutex.Unlock()
+	if fake.GetResourceChecksStub != nil {
+		return fake.GetResourceChecksStub(resourceName)
+	} else {
+		return fake.getResourceChecksReturns.result1, fake.getResourceChecksReturns.result2, fake.getResourceChecksReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetResourceChecksCallCount() int {
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
+	return len(fake.getResourceChecksArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetResourceChecksArgsForCall(i int) string {
+	fake.getResourceChecksMutex.RLock()
+	defer fake.getResourceChecksMutex.RUnlock()
+	return fake.getResourceChecksArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) GetResourceChecksReturns(result1 []db.ResourceCheck, result2 bool, result3 error) {
+	fake.GetResourceChecksStub = nil
+	fake.getResourceChecksReturns = struct {
+		result1 []db.ResourceCheck
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) ShareResourceVersions(resource db.SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error {
+	fake.shareResourceVersionsMutex.Lock()
+	fake.shareResourceVersionsArgsForCall = append(fake.shareResourceVersionsArgsForCall, struct {
+		resource db.SavedResource
+		config   atc.ResourceConfig
+		from     atc.Version
+		versions []atc.Version
+	}{resource, config, from, versions})
+	fake.shareResourceVersionsMutex.Unlock()
+	if fake.ShareResourceVersionsStub != nil {
+		return fake.ShareResourceVersionsStub(resource, config, from, versions)
+	} else {
+		return fake.shareResourceVersionsReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ShareResourceVersionsCallCount() int {
+	fake.shareResourceVersionsMutex.RLock()
+	defer fake.shareResourceVersionsMutex.RUnlock()
+	return len(fake.shareResourceVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) ShareResourceVersionsArgsForCall(i int) (db.SavedResource, atc.ResourceConfig, atc.Version, []atc.Version) {
+	fake.shareResourceVersionsMutex.RLock()
+	defer fake.shareResourceVersionsMutex.RUnlock()
+	return fake.shareResourceVersionsArgsForCall[i].resource, fake.shareResourceVersionsArgsForCall[i].config, fake.shareResourceVersionsArgsForCall[i].from, fake.shareResourceVersionsArgsForCall[i].versions
+}
+
+func (fake *FakePipelineDB) ShareResourceVersionsReturns(result1 error) {
+	fake.ShareResourceVersionsStub = nil
+	fake.shareResourceVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetResourceType(resourceTypeName string) (db.SavedResourceType, bool, error) {
+	fake.getResourceTypeMutex.Lock()
+	fake.getResourceTypeArgsForCall = append(fake.getResourceTypeArgsForCall, struct {
+		resourceTypeName string
+	}{resourceTypeName})
+	fake.getResourceTypeMutex.Unlock()
+	if fake.GetResourceTypeStub != nil {
+		return fake.GetResourceTypeStub(resourceTypeName)
+	} else {
+		return fake.getResourceTypeReturns.result1, fake.getResourceTypeReturns.result2, fake.getResourceTypeReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetResourceTypeCallCount() int {
+	fake.getResourceTypeMutex.RLock()
+	defer fake.getResourceTypeMutex.RUnlock()
+	return len(fake.getResourceTypeArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetResourceTypeArgsForCall(i int) string {
+	fake.getResourceTypeMutex.RLock()
+	defer fake.getResourceTypeMutex.RUnlock()
+	return fake.getResourceTypeArgsForCall[i].resourceTypeName
+}
+
+func (fake *FakePipelineDB) GetResourceTypeReturns(result1 db.SavedResourceType, result2 bool, result3 error) {
+	fake.GetResourceTypeStub = nil
+	fake.getResourceTypeReturns = struct {
+		result1 db.SavedResourceType
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) SaveResourceTypeVersion(resourceType db.SavedResourceType, version atc.Version) error {
+	fake.saveResourceTypeVersionMutex.Lock()
+	fake.saveResourceTypeVersionArgsForCall = append(fake.saveResourceTypeVersionArgsForCall, struct {
+		resourceType db.SavedResourceType
+		version      atc.Version
+	}{resourceType, version})
+	fake.saveResourceTypeVersionMutex.Unlock()
+	if fake.SaveResourceTypeVersionStub != nil {
+		return fake.SaveResourceTypeVersionStub(resourceType, version)
+	} else {
+		return fake.saveResourceTypeVersionReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveResourceTypeVersionCallCount() int {
+	fake.saveResourceTypeVersionMutex.RLock()
+	defer fake.saveResourceTypeVersionMutex.RUnlock()
+	return len(fake.saveResourceTypeVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveResourceTypeVersionArgsForCall(i int) (db.SavedResourceType, atc.Version) {
+	fake.saveResourceTypeVersionMutex.RLock()
+	defer fake.saveResourceTypeVersionMutex.RUnlock()
+	return fake.saveResourceTypeVersionArgsForCall[i].resourceType, fake.saveResourceTypeVersionArgsForCall[i].version
+}
+
+func (fake *FakePipelineDB) SaveResourceTypeVersionReturns(result1 error) {
+	fake.SaveResourceTypeVersionStub = nil
+	fake.saveResourceTypeVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) SetResourceTypeCheckError(resourceType db.SavedResourceType, err error) error {
+	fake.setResourceTypeCheckErrorMutex.Lock()
+	fake.setResourceTypeCheckErrorArgsForCall = append(fake.setResourceTypeCheckErrorArgsForCall, struct {
+		resourceType db.SavedResourceType
+		err          error
+	}{resourceType, err})
+	fake.setResourceTypeCheckErrorMutex.Unlock()
+	if fake.SetResourceTypeCheckErrorStub != nil {
+		return fake.SetResourceTypeCheckErrorStub(resourceType, err)
+	} else {
+		return fake.setResourceTypeCheckErrorReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetResourceTypeCheckErrorCallCount() int {
+	fake.setResourceTypeCheckErrorMutex.RLock()
+	defer fake.setResourceTypeCheckErrorMutex.RUnlock()
+	return len(fake.setResourceTypeCheckErrorArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetResourceTypeCheckErrorArgsForCall(i int) (db.SavedResourceType, error) {
+	fake.setResourceTypeCheckErrorMutex.RLock()
+	defer fake.setResourceTypeCheckErrorMutex.RUnlock()
+	return fake.setResourceTypeCheckErrorArgsForCall[i].resourceType, fake.setResourceTypeCheckErrorArgsForCall[i].err
+}
+
+func (fake *FakePipelineDB) SetResourceTypeCheckErrorReturns(result1 error) {
+	fake.SetResourceTypeCheckErrorStub = nil
+	fake.setResourceTypeCheckErrorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) PinResourceTypeVersion(resourceTypeName string, version atc.Version) error {
+	fake.pinResourceTypeVersionMutex.Lock()
+	fake.pinResourceTypeVersionArgsForCall = append(fake.pinResourceTypeVersionArgsForCall, struct {
+		resourceTypeName string
+		version          atc.Version
+	}{resourceTypeName, version})
+	fake.pinResourceTypeVersionMutex.Unlock()
+	if fake.PinResourceTypeVersionStub != nil {
+		return fake.PinResourceTypeVersionStub(resourceTypeName, version)
+	} else {
+		return fake.pinResourceTypeVersionReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinResourceTypeVersionCallCount() int {
+	fake.pinResourceTypeVersionMutex.RLock()
+	defer fake.pinResourceTypeVersionMutex.RUnlock()
+	return len(fake.pinResourceTypeVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceTypeVersionArgsForCall(i int) (string, atc.Version) {
+	fake.pinResourceTypeVersionMutex.RLock()
+	defer fake.pinResourceTypeVersionMutex.RUnlock()
+	return fake.pinResourceTypeVersionArgsForCall[i].resourceTypeName, fake.pinResourceTypeVersionArgsForCall[i].version
+}
+
+func (fake *FakePipelineDB) PinResourceTypeVersionReturns(result1 error) {
+	fake.PinResourceTypeVersionStub = nil
+	fake.pinResourceTypeVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinResourceTypeVersion(resourceTypeName string) error {
+	fake.unpinResourceTypeVersionMutex.Lock()
+	fake.unpinResourceTypeVersionArgsForCall = append(fake.unpinResourceTypeVersionArgsForCall, struct {
+		resourceTypeName string
+	}{resourceTypeName})
+	fake.unpinResourceTypeVersionMutex.Unlock()
+	if fake.UnpinResourceTypeVersionStub != nil {
+		return fake.UnpinResourceTypeVersionStub(resourceTypeName)
+	} else {
+		return fake.unpinResourceTypeVersionReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceTypeVersionCallCount() int {
+	fake.unpinResourceTypeVersionMutex.RLock()
+	defer fake.unpinResourceTypeVersionMutex.RUnlock()
+	return len(fake.unpinResourceTypeVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceTypeVersionArgsForCall(i int) string {
+	fake.unpinResourceTypeVersionMutex.RLock()
+	defer fake.unpinResourceTypeVersionMutex.RUnlock()
+	return fake.unpinResourceTypeVersionArgsForCall[i].resourceTypeName
+}
+
+func (fake *FakePipelineDB) UnpinResourceTypeVersionReturns(result1 error) {
+	fake.UnpinResourceTypeVersionStub = nil
+	fake.unpinResourceTypeVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersions(arg1 atc.ResourceTypes) (atc.ResourceTypes, error) {
+	fake.resolveResourceTypeVersionsMutex.Lock()
+	fake.resolveResourceTypeVersionsArgsForCall = append(fake.resolveResourceTypeVersionsArgsForCall, struct {
+		arg1 atc.ResourceTypes
+	}{arg1})
+	fake.resolveResourceTypeVersionsMutex.Unlock()
+	if fake.ResolveResourceTypeVersionsStub != nil {
+		return fake.ResolveResourceTypeVersionsStub(arg1)
+	} else {
+		return fake.resolveResourceTypeVersionsReturns.result1, fake.resolveResourceTypeVersionsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersionsCallCount() int {
+	fake.resolveResourceTypeVersionsMutex.RLock()
+	defer fake.resolveResourceTypeVersionsMutex.RUnlock()
+	return len(fake.resolveResourceTypeVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersionsArgsForCall(i int) atc.ResourceTypes {
+	fake.resolveResourceTypeVersionsMutex.RLock()
+	defer fake.resolveResourceTypeVersionsMutex.RUnlock()
+	return fake.resolveResourceTypeVersionsArgsForCall[i].arg1
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersionsReturns(result1 atc.ResourceTypes, result2 error) {
+	fake.ResolveResourceTypeVersionsStub = nil
+	fake.resolveResourceTypeVersionsReturns = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) LeaseResourceTypeChecking(resourceType string, length time.Duration, immediate bool) (db.Lease, bool, error) {
+	fake.leaseResourceTypeCheckingMutex.Lock()
+	fake.leaseResourceTypeCheckingArgsForCall = append(fake.leaseResourceTypeCheckingArgsForCall, struct {
+		resourceType string
+		length       time.Duration
+		immediate    bool
+	}{resourceType, length, immediate})
+	fake.leaseResourceTypeCheckingMutex.Unlock()
+	if fake.LeaseResourceTypeCheckingStub != nil {
+		return fake.LeaseResourceTypeCheckingStub(resourceType, length, immediate)
+	} else {
+		return fake.leaseResourceTypeCheckingReturns.result1, fake.leaseResourceTypeCheckingReturns.result2, fake.leaseResourceTypeCheckingReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) LeaseResourceTypeCheckingCallCount() int {
+	fake.leaseResourceTypeCheckingMutex.RLock()
+	defer fake.leaseResourceTypeCheckingMutex.RUnlock()
+	return len(fake.leaseResourceTypeCheckingArgsForCall)
+}
+
+func (fake *FakePipelineDB) LeaseResourceTypeCheckingArgsForCall(i int) (string, time.Duration, bool) {
+	fake.leaseResourceTypeCheckingMutex.RLock()
+	defer fake.leaseResourceTypeCheckingMutex.RUnlock()
+	return fake.leaseResourceTypeCheckingArgsForCall[i].resourceType, fake.leaseResourceTypeCheckingArgsForCall[i].length, fake.leaseResourceTypeCheckingArgsForCall[i].immediate
+}
+
+func (fake *FakePipelineDB) LeaseResourceTypeCheckingReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.LeaseResourceTypeCheckingStub = nil
+	fake.leaseResourceTypeCheckingReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) PruneResourceVersions(resource atc.ResourceConfig, jobs atc.JobConfigs) (int, error) {
+	fake.pruneResourceVersionsMutex.Lock()
+	fake.pruneResourceVersionsArgsForCall = append(fake.pruneResourceVersionsArgsForCall, struct {
+		resource atc.ResourceConfig
+		jobs     atc.JobConfigs
+	}{resource, jobs})
+	fake.pruneResourceVersionsMutex.Unlock()
+	if fake.PruneResourceVersionsStub != nil {
+		return fake.PruneResourceVersionsStub(resource, jobs)
+	} else {
+		return fake.pruneResourceVersionsReturns.result1, fake.pruneResourceVersionsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) PruneResourceVersionsCallCount() int {
+	fake.pruneResourceVersionsMutex.RLock()
+	defer fake.pruneResourceVersionsMutex.RUnlock()
+	return len(fake.pruneResourceVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) PruneResourceVersionsArgsForCall(i int) (atc.ResourceConfig, atc.JobConfigs) {
+	fake.pruneResourceVersionsMutex.RLock()
+	defer fake.pruneResourceVersionsMutex.RUnlock()
+	return fake.pruneResourceVersionsArgsForCall[i].resource, fake.pruneResourceVersionsArgsForCall[i].jobs
+}
+
+func (fake *FakePipelineDB) PruneResourceVersionsReturns(result1 int, result2 error) {
+	fake.PruneResourceVersionsStub = nil
+	fake.pruneResourceVersionsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetPipelineTeamID() int {
+	fake.getPipelineTeamIDMutex.Lock()
+	fake.getPipelineTeamIDArgsForCall = append(fake.getPipelineTeamIDArgsForCall, struct{}{})
+	fake.getPipelineTeamIDMutex.Unlock()
+	if fake.GetPipelineTeamIDStub != nil {
+		return fake.GetPipelineTeamIDStub()
+	} else {
+		return fake.getPipelineTeamIDReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) GetPipelineTeamIDCallCount() int {
+	fake.getPipelineTeamIDMutex.RLock()
+	defer fake.getPipelineTeamIDMutex.RUnlock()
+	return len(fake.getPipelineTeamIDArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetPipelineTeamIDReturns(result1 int) {
+	fake.GetPipelineTeamIDStub = nil
+	fake.getPipelineTeamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakePipelineDB) PauseJobWithReason(job string, reason string) error {
+	fake.pauseJobWithReasonMutex.Lock()
+	fake.pauseJobWithReasonArgsForCall = append(fake.pauseJobWithReasonArgsForCall, struct {
+		job    string
+		reason string
+	}{job, reason})
+	fake.pauseJobWithReasonMutex.Unlock()
+	if fake.PauseJobWithReasonStub != nil {
+		return fake.PauseJobWithReasonStub(job, reason)
+	} else {
+		return fake.pauseJobWithReasonReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PauseJobWithReasonCallCount() int {
+	fake.pauseJobWithReasonMutex.RLock()
+	defer fake.pauseJobWithReasonMutex.RUnlock()
+	return len(fake.pauseJobWithReasonArgsForCall)
+}
+
+func (fake *FakePipelineDB) PauseJobWithReasonArgsForCall(i int) (string, string) {
+	fake.pauseJobWithReasonMutex.RLock()
+	defer fake.pauseJobWithReasonMutex.RUnlock()
+	return fake.pauseJobWithReasonArgsForCall[i].job, fake.pauseJobWithReasonArgsForCall[i].reason
+}
+
+func (fake *FakePipelineDB) PauseJobWithReasonReturns(result1 error) {
+	fake.PauseJobWithReasonStub = nil
+	fake.pauseJobWithReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ db.PipelineDB = new(FakePipelineDB)
@@ -9,6 +9,7 @@ type Job struct {
 type SavedJob struct {
 	ID           int
 	Paused       bool
+	PausedReason string
 	PipelineName string
 	Job
 }
@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateBuildTestResults(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_test_results (
+			id serial PRIMARY KEY,
+			build_id integer REFERENCES builds (id) ON DELETE CASCADE NOT NULL,
+			plan_id text NOT NULL,
+			passed integer NOT NULL,
+			failed integer NOT NULL,
+			skipped integer NOT NULL,
+			failed_tests text NOT NULL,
+			UNIQUE (build_id, plan_id)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildEventCompaction(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds ADD COLUMN events_compacted boolean NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE TABLE build_event_compactor (
+		last_compacted timestamp NOT NULL DEFAULT 'epoch'
+	)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddQueuePriorityToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE builds
+	ADD COLUMN queue_priority integer NOT NULL DEFAULT 0
+	`)
+	return err
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddScheduleToBuildPreparation(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE build_preparation
+	ADD COLUMN schedule text NOT NULL DEFAULT 'unknown'
+	`)
+	return err
+}
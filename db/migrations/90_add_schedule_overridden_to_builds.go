@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddScheduleOverriddenToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE builds
+	ADD COLUMN schedule_overridden boolean NOT NULL DEFAULT false
+	`)
+	return err
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddPausedReasonAndFailureStreakToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE jobs
+	ADD COLUMN paused_reason text NOT NULL DEFAULT ''
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	ALTER TABLE jobs
+	ADD COLUMN failure_streak_after_build_id integer NOT NULL DEFAULT 0
+	`)
+	return err
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddCheckBackoffToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE resources
+	ADD COLUMN check_failures integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	ALTER TABLE resources
+	ADD COLUMN check_backoff bigint NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	ALTER TABLE resources
+	ADD COLUMN next_check timestamp with time zone
+	`)
+	return err
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateResourceChecks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`CREATE TABLE resource_checks (
+    id serial PRIMARY KEY,
+    resource_id integer REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    worker_name text NOT NULL DEFAULT '',
+    versions_found integer NOT NULL DEFAULT 0,
+    exit_status integer,
+    stderr text NOT NULL DEFAULT '',
+    error text NOT NULL DEFAULT ''
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resource_checks_resource_id_idx ON resource_checks (resource_id)
+	`)
+	return err
+}
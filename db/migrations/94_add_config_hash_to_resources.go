@@ -0,0 +1,18 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddConfigHashToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE resources
+	ADD COLUMN config_hash text NOT NULL DEFAULT ''
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX resources_config_hash_idx ON resources (config_hash)
+	`)
+	return err
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateResourceTypes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`CREATE TABLE resource_types (
+    id serial PRIMARY KEY,
+    pipeline_id integer REFERENCES pipelines (id) ON DELETE CASCADE NOT NULL,
+    name text NOT NULL,
+    config_hash text NOT NULL DEFAULT '',
+    version text,
+    pinned_version text,
+    last_checked timestamp NOT NULL DEFAULT 'epoch',
+    checking boolean NOT NULL DEFAULT false,
+    check_error text,
+    UNIQUE (pipeline_id, name)
+	)`)
+	return err
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateVersionPruner(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`CREATE TABLE version_pruner (
+		last_pruned timestamp NOT NULL DEFAULT 'epoch'
+	)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddMaxContainersToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers ADD COLUMN max_containers integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddUnhealthyUntilToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers ADD COLUMN unhealthy_until timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
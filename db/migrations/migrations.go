@@ -90,4 +90,19 @@ var Migrations = []migration.Migrator{
 	AddImageResourceTypeAndSourceToContainers,
 	AddUserToContainer,
 	ResetPendingBuilds,
+	AddQueuePriorityToBuilds,
+	AddScheduleToBuildPreparation,
+	AddScheduleOverriddenToBuilds,
+	AddPausedReasonAndFailureStreakToJobs,
+	AddCheckBackoffToResources,
+	CreateResourceChecks,
+	AddConfigHashToResources,
+	CreateResourceTypes,
+	CreateVersionPruner,
+	AddTeamIDToWorkers,
+	AddMaxContainersToWorkers,
+	AddUnhealthyUntilToWorkers,
+	AddRerunOfToBuilds,
+	CreateBuildTestResults,
+	AddBuildEventCompaction,
 }
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -18,6 +21,7 @@ import (
 
 type PipelineDB interface {
 	GetPipelineName() string
+	GetPipelineTeamID() int
 	ScopedName(string) string
 
 	Pause() error
@@ -37,16 +41,31 @@ type PipelineDB interface {
 	UnpauseResource(resourceName string) error
 
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
+	ShareResourceVersions(resource SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error
 	GetLatestVersionedResource(resource SavedResource) (SavedVersionedResource, bool, error)
 	GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
+	PruneResourceVersions(resource atc.ResourceConfig, jobs atc.JobConfigs) (int, error)
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
 	SetResourceCheckError(resource SavedResource, err error) error
+	SetResourceCheckBackoff(resource SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error
+	SaveResourceCheck(resource SavedResource, check ResourceCheck) error
+	GetResourceChecks(resourceName string) ([]ResourceCheck, bool, error)
 	LeaseResourceChecking(resource string, length time.Duration, immediate bool) (Lease, bool, error)
 
+	GetResourceType(resourceTypeName string) (SavedResourceType, bool, error)
+	SaveResourceTypeVersion(resourceType SavedResourceType, version atc.Version) error
+	SetResourceTypeCheckError(resourceType SavedResourceType, err error) error
+	PinResourceTypeVersion(resourceTypeName string, version atc.Version) error
+	UnpinResourceTypeVersion(resourceTypeName string) error
+	ResolveResourceTypeVersions(atc.ResourceTypes) (atc.ResourceTypes, error)
+	LeaseResourceTypeChecking(resourceType string, length time.Duration, immediate bool) (Lease, bool, error)
+
 	GetJob(job string) (SavedJob, error)
 	PauseJob(job string) error
 	UnpauseJob(job string) error
+	PauseJobWithReason(job string, reason string) error
+	GetConsecutiveFailures(job string) (int, error)
 
 	GetJobFinishedAndNextBuild(job string) (*Build, *Build, error)
 
@@ -66,10 +85,14 @@ type PipelineDB interface {
 
 	GetBuild(buildID int) (Build, bool, error)
 	GetCurrentBuild(job string) (Build, bool, error)
-	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error)
-	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (Build, bool, error)
+	GetRunningBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]Build, error)
+	GetNextPendingBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]Build, error)
+	GetPendingBuilds() ([]Build, error)
+	GetRunningBuilds() ([]Build, error)
+	PrioritizePendingBuild(buildID int) (bool, error)
 
 	UpdateBuildToScheduled(buildID int) (bool, error)
+	OverrideBuildSchedule(buildID int) error
 	SaveBuildInput(buildID int, input BuildInput) (SavedVersionedResource, error)
 	SaveBuildOutput(buildID int, vr VersionedResource, explicit bool) (SavedVersionedResource, error)
 	GetBuildsWithVersionAsInput(versionedResourceID int) ([]Build, error)
@@ -97,6 +120,10 @@ func (pdb *pipelineDB) GetPipelineName() string {
 	return pdb.Name
 }
 
+func (pdb *pipelineDB) GetPipelineTeamID() int {
+	return pdb.TeamID
+}
+
 func (pdb *pipelineDB) ScopedName(name string) string {
 	return pdb.Name + ":" + name
 }
@@ -212,11 +239,23 @@ func (pdb *pipelineDB) LeaseResourceChecking(resourceName string, interval time.
 		"resource": resourceName,
 	})
 
+	return pdb.leaseChecking(logger, "resources", resourceName, interval, immediate)
+}
+
+func (pdb *pipelineDB) LeaseResourceTypeChecking(resourceTypeName string, interval time.Duration, immediate bool) (Lease, bool, error) {
+	logger := pdb.logger.Session("lease", lager.Data{
+		"resource-type": resourceTypeName,
+	})
+
+	return pdb.leaseChecking(logger, "resource_types", resourceTypeName, interval, immediate)
+}
+
+func (pdb *pipelineDB) leaseChecking(logger lager.Logger, table string, name string, interval time.Duration, immediate bool) (Lease, bool, error) {
 	lease := &lease{
 		conn:   pdb.conn,
 		logger: logger,
 		attemptSignFunc: func(tx Tx) (sql.Result, error) {
-			params := []interface{}{resourceName, pdb.ID}
+			params := []interface{}{name, pdb.ID}
 
 			condition := ""
 			if immediate {
@@ -227,7 +266,7 @@ func (pdb *pipelineDB) LeaseResourceChecking(resourceName string, interval time.
 			}
 
 			return tx.Exec(`
-				UPDATE resources
+				UPDATE `+table+`
 				SET last_checked = now(), checking = true
 				WHERE name = $1
 					AND pipeline_id = $2
@@ -235,19 +274,19 @@ func (pdb *pipelineDB) LeaseResourceChecking(resourceName string, interval time.
 		},
 		heartbeatFunc: func(tx Tx) (sql.Result, error) {
 			return tx.Exec(`
-				UPDATE resources
+				UPDATE `+table+`
 				SET last_checked = now()
 				WHERE name = $1
 					AND pipeline_id = $2
-			`, resourceName, pdb.ID)
+			`, name, pdb.ID)
 		},
 		breakFunc: func() {
 			_, err := pdb.conn.Exec(`
-				UPDATE resources
+				UPDATE `+table+`
 				SET checking = false
 				WHERE name = $1
 				  AND pipeline_id = $2
-			`, resourceName, pdb.ID)
+			`, name, pdb.ID)
 			if err != nil {
 				logger.Error("failed-to-reset-checking-state", err)
 			}
@@ -441,14 +480,16 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, error) {
 	var checkErr sql.NullString
+	var checkBackoff int64
+	var nextCheck pq.NullTime
 	var resource SavedResource
 
 	err := tx.QueryRow(`
-			SELECT id, name, check_error, paused
+			SELECT id, name, check_error, paused, check_failures, check_backoff, next_check
 			FROM resources
 			WHERE name = $1
 				AND pipeline_id = $2
-		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused)
+		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused, &resource.CheckFailures, &checkBackoff, &nextCheck)
 	if err != nil {
 		return SavedResource{}, err
 	}
@@ -457,6 +498,12 @@ func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, error) {
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	resource.CheckBackoff = time.Duration(checkBackoff)
+
+	if nextCheck.Valid {
+		resource.NextCheck = nextCheck.Time
+	}
+
 	resource.PipelineName = pdb.Name
 
 	return resource, nil
@@ -527,6 +574,107 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 	return nil
 }
 
+// ShareResourceVersions saves the versions found by checking the given
+// resource to every other resource, in any pipeline, with an identical
+// configuration, and marks them as just checked so that they can skip their
+// own check until their interval elapses. Resources that are paused, in a
+// paused pipeline, or whose latest version differs from the one the check
+// started from are left to check for themselves.
+func (pdb *pipelineDB) ShareResourceVersions(resource SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT r.id, r.name, (
+			SELECT v.version
+			FROM versioned_resources v
+			WHERE v.resource_id = r.id
+			ORDER BY v.check_order DESC
+			LIMIT 1
+		)
+		FROM resources r
+		JOIN pipelines p ON p.id = r.pipeline_id
+		WHERE r.config_hash = (
+			SELECT config_hash FROM resources WHERE id = $1
+		)
+			AND r.config_hash != ''
+			AND r.id != $1
+			AND NOT r.paused
+			AND NOT r.checking
+			AND NOT p.paused
+		FOR UPDATE OF r
+	`, resource.ID)
+	if err != nil {
+		return err
+	}
+
+	type sharedResource struct {
+		id   int
+		name string
+	}
+
+	shared := []sharedResource{}
+
+	for rows.Next() {
+		var sr sharedResource
+		var latestVersion sql.NullString
+
+		err := rows.Scan(&sr.id, &sr.name, &latestVersion)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		var latest atc.Version
+		if latestVersion.Valid {
+			err := json.Unmarshal([]byte(latestVersion.String), &latest)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+		}
+
+		if !reflect.DeepEqual(latest, from) {
+			continue
+		}
+
+		shared = append(shared, sr)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, sr := range shared {
+		for _, version := range versions {
+			_, err := pdb.saveVersionedResourceForID(tx, sr.id, VersionedResource{
+				Resource: sr.name,
+				Type:     config.Type,
+				Version:  Version(version),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(`
+			UPDATE resources
+			SET last_checked = now(), check_error = NULL, check_failures = 0, check_backoff = 0
+			WHERE id = $1
+		`, sr.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (pdb *pipelineDB) DisableVersionedResource(versionedResourceID int) error {
 	return pdb.toggleVersionedResource(versionedResourceID, false)
 }
@@ -662,6 +810,258 @@ func (pdb *pipelineDB) SetResourceCheckError(resource SavedResource, cause error
 	return err
 }
 
+func (pdb *pipelineDB) SetResourceCheckBackoff(resource SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error {
+	_, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET check_failures = $2, check_backoff = $3, next_check = $4
+		WHERE id = $1
+	`, resource.ID, failures, int64(backoff), nextCheck)
+
+	return err
+}
+
+func (pdb *pipelineDB) SaveResourceCheck(resource SavedResource, check ResourceCheck) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var exitStatus sql.NullInt64
+	if check.ExitStatus != nil {
+		exitStatus = sql.NullInt64{Int64: int64(*check.ExitStatus), Valid: true}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO resource_checks (resource_id, start_time, end_time, worker_name, versions_found, exit_status, stderr, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, resource.ID, check.StartTime, check.EndTime, check.WorkerName, check.VersionsFound, exitStatus, check.Stderr, check.Error)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM resource_checks
+		WHERE resource_id = $1
+			AND id NOT IN (
+				SELECT id
+				FROM resource_checks
+				WHERE resource_id = $1
+				ORDER BY id DESC
+				LIMIT $2
+			)
+	`, resource.ID, ResourceCheckHistoryLimit)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) GetResourceType(name string) (SavedResourceType, bool, error) {
+	var versionJSON, pinnedVersionJSON, checkErr sql.NullString
+	var resourceType SavedResourceType
+
+	err := pdb.conn.QueryRow(`
+		SELECT id, name, config_hash, version, pinned_version, check_error
+		FROM resource_types
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, name, pdb.ID).Scan(&resourceType.ID, &resourceType.Name, &resourceType.ConfigHash, &versionJSON, &pinnedVersionJSON, &checkErr)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedResourceType{}, false, nil
+		}
+
+		return SavedResourceType{}, false, err
+	}
+
+	if versionJSON.Valid {
+		err := json.Unmarshal([]byte(versionJSON.String), &resourceType.Version)
+		if err != nil {
+			return SavedResourceType{}, false, err
+		}
+	}
+
+	if pinnedVersionJSON.Valid {
+		err := json.Unmarshal([]byte(pinnedVersionJSON.String), &resourceType.PinnedVersion)
+		if err != nil {
+			return SavedResourceType{}, false, err
+		}
+	}
+
+	if checkErr.Valid {
+		resourceType.CheckError = errors.New(checkErr.String)
+	}
+
+	return resourceType, true, nil
+}
+
+// SaveResourceTypeVersion records the latest version of a resource type,
+// unless its configuration has changed since it was checked.
+func (pdb *pipelineDB) SaveResourceTypeVersion(resourceType SavedResourceType, version atc.Version) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	_, err = pdb.conn.Exec(`
+		UPDATE resource_types
+		SET version = $3
+		WHERE id = $1
+			AND config_hash = $2
+	`, resourceType.ID, resourceType.ConfigHash, string(versionJSON))
+
+	return err
+}
+
+func (pdb *pipelineDB) SetResourceTypeCheckError(resourceType SavedResourceType, cause error) error {
+	var err error
+
+	if cause == nil {
+		_, err = pdb.conn.Exec(`
+			UPDATE resource_types
+			SET check_error = NULL
+			WHERE id = $1
+			`, resourceType.ID)
+	} else {
+		_, err = pdb.conn.Exec(`
+			UPDATE resource_types
+			SET check_error = $2
+			WHERE id = $1
+		`, resourceType.ID, cause.Error())
+	}
+
+	return err
+}
+
+func (pdb *pipelineDB) PinResourceTypeVersion(resourceTypeName string, version atc.Version) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	return pdb.updatePinnedVersion(resourceTypeName, sql.NullString{String: string(versionJSON), Valid: true})
+}
+
+func (pdb *pipelineDB) UnpinResourceTypeVersion(resourceTypeName string) error {
+	return pdb.updatePinnedVersion(resourceTypeName, sql.NullString{})
+}
+
+func (pdb *pipelineDB) updatePinnedVersion(resourceTypeName string, pinnedVersion sql.NullString) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE resource_types
+		SET pinned_version = $1
+		WHERE name = $2
+			AND pipeline_id = $3
+	`, pinnedVersion, resourceTypeName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+// ResolveResourceTypeVersions returns the given resource types with the
+// version of each one's image that should be used: the version pinned in the
+// config, then the version pinned through the API, then the latest version
+// checked. Types that have not yet been checked are left without a version,
+// and will be checked when their image is fetched.
+func (pdb *pipelineDB) ResolveResourceTypeVersions(resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+	resolved := make(atc.ResourceTypes, len(resourceTypes))
+
+	for i, resourceType := range resourceTypes {
+		resolved[i] = resourceType
+
+		if resourceType.Version != nil {
+			continue
+		}
+
+		savedResourceType, found, err := pdb.GetResourceType(resourceType.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		if savedResourceType.PinnedVersion != nil {
+			resolved[i].Version = savedResourceType.PinnedVersion
+		} else {
+			resolved[i].Version = savedResourceType.Version
+		}
+	}
+
+	return resolved, nil
+}
+
+func (pdb *pipelineDB) GetResourceChecks(resourceName string) ([]ResourceCheck, bool, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer tx.Rollback()
+
+	resource, err := pdb.getResource(tx, resourceName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, start_time, end_time, worker_name, versions_found, exit_status, stderr, error
+		FROM resource_checks
+		WHERE resource_id = $1
+		ORDER BY id DESC
+	`, resource.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	checks := []ResourceCheck{}
+
+	for rows.Next() {
+		var check ResourceCheck
+		var exitStatus sql.NullInt64
+
+		err := rows.Scan(&check.ID, &check.StartTime, &check.EndTime, &check.WorkerName, &check.VersionsFound, &exitStatus, &check.Stderr, &check.Error)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if exitStatus.Valid {
+			status := int(exitStatus.Int64)
+			check.ExitStatus = &status
+		}
+
+		checks = append(checks, check)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return checks, true, nil
+}
+
 func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) error {
 	query, err := tx.Prepare(` 
 	    WITH max_checkorder AS
@@ -697,6 +1097,10 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, vr VersionedResource) (Saved
 		return SavedVersionedResource{}, err
 	}
 
+	return pdb.saveVersionedResourceForID(tx, savedResource.ID, vr)
+}
+
+func (pdb *pipelineDB) saveVersionedResourceForID(tx Tx, resourceID int, vr VersionedResource) (SavedVersionedResource, error) {
 	versionJSON, err := json.Marshal(vr.Version)
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -722,14 +1126,14 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, vr VersionedResource) (Saved
 			AND type = $2
 			AND version = $3
 		)
-	`, savedResource.ID, vr.Type, string(versionJSON), string(metadataJSON))
+	`, resourceID, vr.Type, string(versionJSON), string(metadataJSON))
 
 	err = swallowUniqueViolation(err)
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
 
-	err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
+	err = pdb.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -745,7 +1149,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, vr VersionedResource) (Saved
 			AND type = $2
 			AND version = $3
 			RETURNING id, enabled, metadata, modified_time, check_order
-		`, savedResource.ID, vr.Type, string(versionJSON), string(metadataJSON)).Scan(&id, &enabled, &savedMetadata, &modified_time, &check_order)
+		`, resourceID, vr.Type, string(versionJSON), string(metadataJSON)).Scan(&id, &enabled, &savedMetadata, &modified_time, &check_order)
 	} else {
 		err = tx.QueryRow(`
 			SELECT id, enabled, metadata, modified_time, check_order
@@ -753,7 +1157,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx Tx, vr VersionedResource) (Saved
 			WHERE resource_id = $1
 			AND type = $2
 			AND version = $3
-		`, savedResource.ID, vr.Type, string(versionJSON)).Scan(&id, &enabled, &savedMetadata, &modified_time, &check_order)
+		`, resourceID, vr.Type, string(versionJSON)).Scan(&id, &enabled, &savedMetadata, &modified_time, &check_order)
 	}
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -828,6 +1232,10 @@ func (pdb *pipelineDB) GetJobBuild(job string, name string) (Build, bool, error)
 	return build, found, nil
 }
 
+// CreateJobBuildForCandidateInputs creates a build to determine inputs for,
+// unless one already is. Builds are only ever created one at a time this way,
+// and each one's inputs count towards the versions the job has used once
+// they're determined, so an input taking every version gets the next one.
 func (pdb *pipelineDB) CreateJobBuildForCandidateInputs(jobName string) (Build, bool, error) {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -977,6 +1385,12 @@ func (pdb *pipelineDB) createJobBuild(jobName string, tx Tx) (Build, error) {
 				FROM jobs j
 				INNER JOIN pipelines p ON j.pipeline_id = p.id
 				WHERE j.id = job_id
+			),
+			(
+				SELECT p.team_id
+				FROM jobs j
+				INNER JOIN pipelines p ON j.pipeline_id = p.id
+				WHERE j.id = job_id
 			)
 	`, name, dbJob.ID))
 	if err != nil {
@@ -1230,7 +1644,8 @@ func (pdb *pipelineDB) GetNextPendingBuild(job string) (Build, bool, error) {
 		INNER JOIN pipelines p ON j.pipeline_id = p.id
 		WHERE b.job_id = $1
 		AND b.status = 'pending'
-		ORDER BY b.id ASC
+		AND b.rerun_of IS NULL
+		ORDER BY b.queue_priority DESC, b.id ASC
 		LIMIT 1
 	`, dbJob.ID))
 }
@@ -1269,61 +1684,121 @@ func (pdb *pipelineDB) updateSerialGroupsForJob(jobName string, serialGroups []s
 	return tx.Commit()
 }
 
-func (pdb *pipelineDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (Build, bool, error) {
-	pdb.updateSerialGroupsForJob(jobName, serialGroups)
-
-	serialGroupNames := []interface{}{}
-	refs := []string{}
-	serialGroupNames = append(serialGroupNames, pdb.ID)
-	for i, serialGroup := range serialGroups {
-		serialGroupNames = append(serialGroupNames, serialGroup)
-		refs = append(refs, fmt.Sprintf("$%d", i+2))
+func sortedSerialGroups(serialGroups map[string]int) []string {
+	names := []string{}
+	for name := range serialGroups {
+		names = append(names, name)
 	}
 
-	return scanBuild(pdb.conn.QueryRow(`
-		SELECT DISTINCT `+qualifiedBuildColumns+`
-		FROM builds b
-		INNER JOIN jobs j ON b.job_id = j.id
-		INNER JOIN pipelines p ON j.pipeline_id = p.id
-		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
-				AND jsg.serial_group IN (`+strings.Join(refs, ",")+`)
-		WHERE b.status = 'pending'
-			AND b.inputs_determined = true
-			AND j.pipeline_id = $1
-		ORDER BY b.id ASC
-		LIMIT 1
-	`, serialGroupNames...))
+	sort.Strings(names)
+
+	return names
 }
 
-func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]Build, error) {
-	pdb.updateSerialGroupsForJob(jobName, serialGroups)
+// GetNextPendingBuildsBySerialGroup returns the pending builds that may take
+// one of the free slots in every one of the given serial groups. Each group
+// allows as many builds to run at once as its configured limit.
+func (pdb *pipelineDB) GetNextPendingBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]Build, error) {
+	groupNames := sortedSerialGroups(serialGroups)
 
-	serialGroupNames := []interface{}{}
-	refs := []string{}
-	serialGroupNames = append(serialGroupNames, pdb.ID)
-	for i, serialGroup := range serialGroups {
-		serialGroupNames = append(serialGroupNames, serialGroup)
-		refs = append(refs, fmt.Sprintf("$%d", i+2))
-	}
+	pdb.updateSerialGroupsForJob(jobName, groupNames)
 
-	rows, err := pdb.conn.Query(`
-		SELECT DISTINCT `+qualifiedBuildColumns+`
-		FROM builds b
-		INNER JOIN jobs j ON b.job_id = j.id
-		INNER JOIN pipelines p ON j.pipeline_id = p.id
-		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
-				AND jsg.serial_group IN (`+strings.Join(refs, ",")+`)
-		WHERE (
-				b.status = 'started'
-				OR
-				(b.scheduled = true AND b.status = 'pending')
-			)
-			AND j.pipeline_id = $1
-	`, serialGroupNames...)
+	var candidates []Build
 
-	if err != nil {
-		return nil, err
-	}
+	for _, group := range groupNames {
+		running, err := pdb.getRunningBuildsInSerialGroup(group)
+		if err != nil {
+			return nil, err
+		}
+
+		freeSlots := serialGroups[group] - len(running)
+		if freeSlots <= 0 {
+			return []Build{}, nil
+		}
+
+		pending, err := pdb.getNextPendingBuildsInSerialGroup(group, freeSlots)
+		if err != nil {
+			return nil, err
+		}
+
+		if candidates == nil {
+			candidates = pending
+			continue
+		}
+
+		inGroup := map[int]bool{}
+		for _, build := range pending {
+			inGroup[build.ID] = true
+		}
+
+		remaining := []Build{}
+		for _, build := range candidates {
+			if inGroup[build.ID] {
+				remaining = append(remaining, build)
+			}
+		}
+
+		candidates = remaining
+	}
+
+	if candidates == nil {
+		return []Build{}, nil
+	}
+
+	return candidates, nil
+}
+
+// GetRunningBuildsBySerialGroup returns the running builds that occupy every
+// slot of one of the given serial groups. An empty result means that none of
+// the groups is full.
+func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]Build, error) {
+	groupNames := sortedSerialGroups(serialGroups)
+
+	pdb.updateSerialGroupsForJob(jobName, groupNames)
+
+	seen := map[int]bool{}
+	bs := []Build{}
+
+	for _, group := range groupNames {
+		running, err := pdb.getRunningBuildsInSerialGroup(group)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(running) < serialGroups[group] {
+			continue
+		}
+
+		for _, build := range running {
+			if !seen[build.ID] {
+				seen[build.ID] = true
+				bs = append(bs, build)
+			}
+		}
+	}
+
+	return bs, nil
+}
+
+func (pdb *pipelineDB) getNextPendingBuildsInSerialGroup(serialGroup string, limit int) ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
+		WHERE b.status = 'pending'
+			AND b.scheduled = false
+			AND b.inputs_determined = true
+			AND b.rerun_of IS NULL
+			AND j.pipeline_id = $1
+			AND jsg.serial_group = $2
+		ORDER BY b.queue_priority DESC, b.id ASC
+		LIMIT $3
+	`, pdb.ID, serialGroup, limit)
+	if err != nil {
+		return nil, err
+	}
 
 	defer rows.Close()
 
@@ -1341,6 +1816,136 @@ func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroup
 	return bs, nil
 }
 
+func (pdb *pipelineDB) getRunningBuildsInSerialGroup(serialGroup string) ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
+		WHERE (
+				b.status = 'started'
+				OR
+				(b.scheduled = true AND b.status = 'pending')
+			)
+			AND j.pipeline_id = $1
+			AND jsg.serial_group = $2
+		ORDER BY b.id ASC
+	`, pdb.ID, serialGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := scanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+func (pdb *pipelineDB) GetPendingBuilds() ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		WHERE b.status = 'pending'
+			AND b.scheduled = false
+			AND j.pipeline_id = $1
+		ORDER BY b.queue_priority DESC, b.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := scanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+func (pdb *pipelineDB) GetRunningBuilds() ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		WHERE (
+				b.status = 'started'
+				OR
+				(b.scheduled = true AND b.status = 'pending')
+			)
+			AND j.pipeline_id = $1
+		ORDER BY b.id ASC
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := scanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+func (pdb *pipelineDB) PrioritizePendingBuild(buildID int) (bool, error) {
+	result, err := pdb.conn.Exec(`
+		UPDATE builds b
+		SET queue_priority = (
+			SELECT COALESCE(MAX(ob.queue_priority), 0) + 1
+			FROM builds ob
+			INNER JOIN jobs oj ON ob.job_id = oj.id
+			WHERE ob.status = 'pending'
+				AND oj.pipeline_id = $2
+		)
+		FROM jobs j
+		WHERE b.id = $1
+			AND b.job_id = j.id
+			AND j.pipeline_id = $2
+			AND b.status = 'pending'
+			AND b.scheduled = false
+	`, buildID, pdb.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
 func (pdb *pipelineDB) GetBuild(buildID int) (Build, bool, error) {
 	return scanBuild(pdb.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
@@ -1401,6 +2006,15 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
+func (pdb *pipelineDB) OverrideBuildSchedule(buildID int) error {
+	_, err := pdb.conn.Exec(`
+		UPDATE builds
+		SET schedule_overridden = true
+		WHERE id = $1
+	`, buildID)
+	return err
+}
+
 func (pdb *pipelineDB) GetCurrentBuild(job string) (Build, bool, error) {
 	rows, err := pdb.conn.Query(`
 		SELECT `+qualifiedBuildColumns+`
@@ -1599,11 +2213,38 @@ func (pdb *pipelineDB) GetLatestInputVersions(db *algorithm.VersionsDB, jobName
 			jobs[db.JobIDs[jobName]] = struct{}{}
 		}
 
-		inputConfigs = append(inputConfigs, algorithm.InputConfig{
+		inputConfig := algorithm.InputConfig{
 			Name:       input.Name,
 			ResourceID: db.ResourceIDs[input.Resource],
 			Passed:     jobs,
-		})
+		}
+
+		if input.Version != nil {
+			switch {
+			case input.Version.Pinned != nil:
+				id, found, err := pdb.getVersionedResourceID(inputConfig.ResourceID, input.Version.Pinned)
+				if err != nil {
+					return nil, false, err
+				}
+
+				if !found {
+					return nil, false, nil
+				}
+
+				inputConfig.PinnedVersionID = id
+
+			case input.Version.Every:
+				checkOrder, err := pdb.getLastUsedCheckOrder(pdb.conn, jobName, input.Name, inputConfig.ResourceID)
+				if err != nil {
+					return nil, false, err
+				}
+
+				inputConfig.UseEveryVersion = true
+				inputConfig.LastUsedCheckOrder = checkOrder
+			}
+		}
+
+		inputConfigs = append(inputConfigs, inputConfig)
 	}
 
 	resolved, ok := inputConfigs.Resolve(db)
@@ -1650,15 +2291,243 @@ func (pdb *pipelineDB) GetLatestInputVersions(db *algorithm.VersionsDB, jobName
 	return buildInputs, true, nil
 }
 
+func (pdb *pipelineDB) getVersionedResourceID(resourceID int, version atc.Version) (int, bool, error) {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var id int
+	err = pdb.conn.QueryRow(`
+		SELECT id
+		FROM versioned_resources
+		WHERE resource_id = $1
+			AND version = $2
+			AND enabled
+	`, resourceID, string(versionJSON)).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+type rowQueryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// getLastUsedCheckOrder returns the check order of the newest version of the
+// resource that any build of the job has used for the given input, or 0 if
+// none has.
+func (pdb *pipelineDB) getLastUsedCheckOrder(q rowQueryer, jobName string, inputName string, resourceID int) (int, error) {
+	var checkOrder int
+	err := q.QueryRow(`
+		SELECT COALESCE(MAX(v.check_order), 0)
+		FROM build_inputs bi, builds b, versioned_resources v, jobs j
+		WHERE bi.build_id = b.id
+			AND bi.versioned_resource_id = v.id
+			AND b.job_id = j.id
+			AND j.name = $1
+			AND j.pipeline_id = $2
+			AND bi.name = $3
+			AND v.resource_id = $4
+	`, jobName, pdb.ID, inputName, resourceID).Scan(&checkOrder)
+	if err != nil {
+		return 0, err
+	}
+
+	return checkOrder, nil
+}
+
+// PruneResourceVersions deletes versions of the resource that fall outside of
+// its version_retention. Versions that any build used as an input or output
+// are kept, as are the latest version, versions pinned by a job's get step,
+// and versions that a job taking every version has yet to use, so that
+// scheduling is unaffected by pruning.
+func (pdb *pipelineDB) PruneResourceVersions(resource atc.ResourceConfig, jobs atc.JobConfigs) (int, error) {
+	if resource.VersionRetention == nil {
+		return 0, nil
+	}
+
+	retention := *resource.VersionRetention
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	savedResource, err := pdb.getResource(tx, resource.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	var latestID, latestCheckOrder int
+	err = tx.QueryRow(`
+		SELECT id, check_order
+		FROM versioned_resources
+		WHERE resource_id = $1
+		ORDER BY check_order DESC
+		LIMIT 1
+	`, savedResource.ID).Scan(&latestID, &latestCheckOrder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	pinned := map[string]bool{}
+	floor := latestCheckOrder
+
+	for _, job := range jobs {
+		for _, input := range config.JobInputs(job) {
+			if input.Resource != resource.Name || input.Version == nil {
+				continue
+			}
+
+			switch {
+			case input.Version.Pinned != nil:
+				versionJSON, err := json.Marshal(input.Version.Pinned)
+				if err != nil {
+					return 0, err
+				}
+
+				pinned[string(versionJSON)] = true
+
+			case input.Version.Every:
+				lastUsed, err := pdb.getLastUsedCheckOrder(tx, job.Name, input.Name, savedResource.ID)
+				if err != nil {
+					return 0, err
+				}
+
+				// a job that has yet to use any version starts from the latest
+				if lastUsed > 0 && lastUsed < floor {
+					floor = lastUsed
+				}
+			}
+		}
+	}
+
+	var retentionClause string
+	params := []interface{}{savedResource.ID, floor}
+
+	if retention.Count > 0 {
+		retentionClause = `
+			AND v.id NOT IN (
+				SELECT id
+				FROM versioned_resources
+				WHERE resource_id = $1
+				ORDER BY check_order DESC
+				LIMIT $3
+			)
+		`
+		params = append(params, retention.Count)
+	} else {
+		age, err := time.ParseDuration(retention.Age)
+		if err != nil {
+			return 0, err
+		}
+
+		retentionClause = `
+			AND now() - v.modified_time > ($3 || ' SECONDS')::INTERVAL
+		`
+		params = append(params, age.Seconds())
+	}
+
+	rows, err := tx.Query(`
+		SELECT v.id, v.version
+		FROM versioned_resources v
+		WHERE v.resource_id = $1
+			AND v.check_order < $2
+			AND NOT EXISTS (
+				SELECT 1 FROM build_inputs WHERE versioned_resource_id = v.id
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM build_outputs WHERE versioned_resource_id = v.id
+			)
+	`+retentionClause, params...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	prunable := []int{}
+
+	for rows.Next() {
+		var id int
+		var version string
+		err := rows.Scan(&id, &version)
+		if err != nil {
+			return 0, err
+		}
+
+		if pinned[version] {
+			continue
+		}
+
+		prunable = append(prunable, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(prunable) == 0 {
+		return 0, nil
+	}
+
+	for _, id := range prunable {
+		_, err := tx.Exec(`
+			DELETE FROM versioned_resources
+			WHERE id = $1
+		`, id)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// deleting versions does not advance the latest modified time that the
+	// versions DB is cached by, so touch the latest version to invalidate it
+	_, err = tx.Exec(`
+		UPDATE versioned_resources
+		SET modified_time = now()
+		WHERE id = $1
+	`, latestID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(prunable), nil
+}
+
 func (pdb *pipelineDB) PauseJob(job string) error {
-	return pdb.updatePausedJob(job, true)
+	return pdb.updatePausedJob(job, true, "")
+}
+
+// PauseJobWithReason pauses the job and records why in the same update, so
+// that the job is never seen paused without its reason.
+func (pdb *pipelineDB) PauseJobWithReason(job string, reason string) error {
+	return pdb.updatePausedJob(job, true, reason)
 }
 
 func (pdb *pipelineDB) UnpauseJob(job string) error {
-	return pdb.updatePausedJob(job, false)
+	return pdb.updatePausedJob(job, false, "")
 }
 
-func (pdb *pipelineDB) updatePausedJob(job string, pause bool) error {
+func (pdb *pipelineDB) updatePausedJob(job string, pause bool, reason string) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
 		return err
@@ -1671,11 +2540,26 @@ func (pdb *pipelineDB) updatePausedJob(job string, pause bool) error {
 		return err
 	}
 
-	result, err := tx.Exec(`
-		UPDATE jobs
-		SET paused = $1
-		WHERE id = $2
-	`, pause, dbJob.ID)
+	var result sql.Result
+	if pause {
+		result, err = tx.Exec(`
+			UPDATE jobs
+			SET paused = true, paused_reason = $2
+			WHERE id = $1
+		`, dbJob.ID, reason)
+	} else {
+		// unpausing gives the job a fresh start, so that a job paused after too
+		// many failures is not immediately paused again
+		result, err = tx.Exec(`
+			UPDATE jobs
+			SET paused = false, paused_reason = '', failure_streak_after_build_id = (
+				SELECT COALESCE(MAX(b.id), 0)
+				FROM builds b
+				WHERE b.job_id = $1
+			)
+			WHERE id = $1
+		`, dbJob.ID)
+	}
 	if err != nil {
 		return err
 	}
@@ -1692,6 +2576,32 @@ func (pdb *pipelineDB) updatePausedJob(job string, pause bool) error {
 	return tx.Commit()
 }
 
+// GetConsecutiveFailures counts the failed or errored builds of the job since
+// its last successful build, or since it was last unpaused.
+func (pdb *pipelineDB) GetConsecutiveFailures(job string) (int, error) {
+	var failures int
+	err := pdb.conn.QueryRow(`
+		SELECT COUNT(*)
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		WHERE j.name = $1
+			AND j.pipeline_id = $2
+			AND b.status IN ('failed', 'errored')
+			AND b.id > j.failure_streak_after_build_id
+			AND b.id > COALESCE((
+				SELECT MAX(s.id)
+				FROM builds s
+				WHERE s.job_id = j.id
+					AND s.status = 'succeeded'
+			), 0)
+	`, job, pdb.ID).Scan(&failures)
+	if err != nil {
+		return 0, err
+	}
+
+	return failures, nil
+}
+
 func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Pagination, error) {
 	var (
 		err        error
@@ -1928,7 +2838,7 @@ func (pdb *pipelineDB) GetDashboard() (Dashboard, atc.GroupConfigs, error) {
 
 func (pdb *pipelineDB) getJobs() (map[string]SavedJob, error) {
 	rows, err := pdb.conn.Query(`
-  	SELECT id, name, paused
+  	SELECT id, name, paused, paused_reason
   	FROM jobs
   	WHERE pipeline_id = $1
   `, pdb.ID)
@@ -1943,7 +2853,7 @@ func (pdb *pipelineDB) getJobs() (map[string]SavedJob, error) {
 	for rows.Next() {
 		var savedJob SavedJob
 
-		err := rows.Scan(&savedJob.ID, &savedJob.Name, &savedJob.Paused)
+		err := rows.Scan(&savedJob.ID, &savedJob.Name, &savedJob.Paused, &savedJob.PausedReason)
 		if err != nil {
 			return nil, err
 		}
@@ -2003,11 +2913,11 @@ func (pdb *pipelineDB) getJob(tx Tx, name string) (SavedJob, error) {
 	var job SavedJob
 
 	err := tx.QueryRow(`
-  	SELECT id, name, paused
+  	SELECT id, name, paused, paused_reason
   	FROM jobs
   	WHERE name = $1
   		AND pipeline_id = $2
-  `, name, pdb.ID).Scan(&job.ID, &job.Name, &job.Paused)
+  `, name, pdb.ID).Scan(&job.ID, &job.Name, &job.Paused, &job.PausedReason)
 	if err != nil {
 		return SavedJob{}, err
 	}
@@ -2021,10 +2931,10 @@ func (pdb *pipelineDB) getJobByID(id int) (SavedJob, error) {
 	var job SavedJob
 
 	err := pdb.conn.QueryRow(`
-		SELECT id, name, paused
+		SELECT id, name, paused, paused_reason
 		FROM jobs
 		WHERE id = $1
-  `, id).Scan(&job.ID, &job.Name, &job.Paused)
+  `, id).Scan(&job.ID, &job.Name, &job.Paused, &job.PausedReason)
 	if err != nil {
 		return SavedJob{}, err
 	}
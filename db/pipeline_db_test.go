@@ -78,6 +78,16 @@ var _ = Describe("PipelineDB", func() {
 			},
 		},
 
+		ResourceTypes: atc.ResourceTypes{
+			{
+				Name: "some-resource-type",
+				Type: "docker-image",
+				Source: atc.Source{
+					"repository": "some-repository",
+				},
+			},
+		},
+
 		Jobs: atc.JobConfigs{
 			{
 				Name: "some-job",
@@ -247,7 +257,7 @@ var _ = Describe("PipelineDB", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// populate jobs_serial_groups table
-			_, err = fetchedPipelineDB.GetRunningBuildsBySerialGroup("some-job", []string{"serial-group"})
+			_, err = fetchedPipelineDB.GetRunningBuildsBySerialGroup("some-job", map[string]int{"serial-group": 1})
 			Expect(err).NotTo(HaveOccurred())
 
 			// populate build_inputs table
@@ -1437,233 +1447,918 @@ var _ = Describe("PipelineDB", func() {
 				})
 			})
 		})
-	})
-
-	Describe("Jobs", func() {
-		Describe("GetDashboard", func() {
-			It("returns a Dashboard object with a DashboardJob corresponding to each configured job", func() {
-				job, err := pipelineDB.GetJob("some-job")
-				Expect(err).NotTo(HaveOccurred())
 
-				otherJob, err := pipelineDB.GetJob("some-other-job")
+		Describe("resource check history", func() {
+			It("returns the saved checks, newest first", func() {
+				resource, err := pipelineDB.GetResource("some-resource")
 				Expect(err).NotTo(HaveOccurred())
 
-				aJob, err := pipelineDB.GetJob("a-job")
-				Expect(err).NotTo(HaveOccurred())
+				exitStatus := 1
+				failedCheck := db.ResourceCheck{
+					StartTime:  time.Unix(10, 0),
+					EndTime:    time.Unix(20, 0),
+					WorkerName: "some-worker",
+					ExitStatus: &exitStatus,
+					Stderr:     "some-stderr",
+					Error:      "some-error",
+				}
 
-				sharedJob, err := pipelineDB.GetJob("shared-job")
+				err = pipelineDB.SaveResourceCheck(resource, failedCheck)
 				Expect(err).NotTo(HaveOccurred())
 
-				randomJob, err := pipelineDB.GetJob("random-job")
-				Expect(err).NotTo(HaveOccurred())
+				succeededCheck := db.ResourceCheck{
+					StartTime:     time.Unix(30, 0),
+					EndTime:       time.Unix(40, 0),
+					WorkerName:    "some-other-worker",
+					VersionsFound: 2,
+				}
 
-				otherSerialGroupJob, err := pipelineDB.GetJob("other-serial-group-job")
+				err = pipelineDB.SaveResourceCheck(resource, succeededCheck)
 				Expect(err).NotTo(HaveOccurred())
 
-				differentSerialGroupJob, err := pipelineDB.GetJob("different-serial-group-job")
+				checks, found, err := pipelineDB.GetResourceChecks("some-resource")
 				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(HaveLen(2))
 
-				lookupConfig := func(jobName string) atc.JobConfig {
-					config, found := pipelineConfig.Jobs.Lookup(jobName)
-					Expect(found).To(BeTrue())
-
-					return config
-				}
+				Expect(checks[0].WorkerName).To(Equal("some-other-worker"))
+				Expect(checks[0].VersionsFound).To(Equal(2))
+				Expect(checks[0].ExitStatus).To(BeNil())
+				Expect(checks[0].StartTime.Unix()).To(Equal(int64(30)))
+				Expect(checks[0].EndTime.Unix()).To(Equal(int64(40)))
 
-				By("returning jobs with no builds")
-				expectedDashboard := db.Dashboard{
-					{
-						JobConfig:     lookupConfig(job.Name),
-						Job:           job,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-					{
-						JobConfig:     lookupConfig(otherJob.Name),
-						Job:           otherJob,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-					{
-						JobConfig:     lookupConfig(aJob.Name),
-						Job:           aJob,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-					{
-						JobConfig:     lookupConfig(sharedJob.Name),
-						Job:           sharedJob,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-					{
-						JobConfig:     lookupConfig(randomJob.Name),
-						Job:           randomJob,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-					{
-						JobConfig:     lookupConfig(otherSerialGroupJob.Name),
-						Job:           otherSerialGroupJob,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-					{
-						JobConfig:     lookupConfig(differentSerialGroupJob.Name),
-						Job:           differentSerialGroupJob,
-						NextBuild:     nil,
-						FinishedBuild: nil,
-					},
-				}
+				Expect(checks[1].WorkerName).To(Equal("some-worker"))
+				Expect(*checks[1].ExitStatus).To(Equal(1))
+				Expect(checks[1].Stderr).To(Equal("some-stderr"))
+				Expect(checks[1].Error).To(Equal("some-error"))
 
-				actualDashboard, groups, err := pipelineDB.GetDashboard()
+				otherChecks, found, err := otherPipelineDB.GetResourceChecks("some-resource")
 				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(otherChecks).To(BeEmpty())
+			})
 
-				Expect(groups).To(Equal(pipelineConfig.Groups))
-				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
-
-				By("returning a job's most recent pending build if there are no running builds")
-				jobBuildOld, err := pipelineDB.CreateJobBuild("some-job")
+			It("only keeps the most recent checks", func() {
+				resource, err := pipelineDB.GetResource("some-resource")
 				Expect(err).NotTo(HaveOccurred())
 
-				expectedDashboard[0].NextBuild = &jobBuildOld
+				for i := 0; i < db.ResourceCheckHistoryLimit+5; i++ {
+					err = pipelineDB.SaveResourceCheck(resource, db.ResourceCheck{
+						StartTime:     time.Unix(int64(i), 0),
+						EndTime:       time.Unix(int64(i), 0),
+						VersionsFound: i,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
 
-				actualDashboard, _, err = pipelineDB.GetDashboard()
+				checks, _, err := pipelineDB.GetResourceChecks("some-resource")
 				Expect(err).NotTo(HaveOccurred())
+				Expect(checks).To(HaveLen(db.ResourceCheckHistoryLimit))
+				Expect(checks[0].VersionsFound).To(Equal(db.ResourceCheckHistoryLimit + 4))
+			})
 
-				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+			It("is not found for a resource that does not exist", func() {
+				_, found, err := pipelineDB.GetResourceChecks("bogus-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
 
-				By("returning a job's most recent started build")
-				sqlDB.StartBuild(jobBuildOld.ID, "engine", "metadata")
+		Describe("sharing resource checks", func() {
+			var (
+				resourceConfig atc.ResourceConfig
+				resource       db.SavedResource
+				otherResource  db.SavedResource
+			)
 
+			BeforeEach(func() {
 				var found bool
-				jobBuildOld, found, err = sqlDB.GetBuild(jobBuildOld.ID)
-				Expect(err).NotTo(HaveOccurred())
+				resourceConfig, found = pipelineConfig.Resources.Lookup("some-resource")
 				Expect(found).To(BeTrue())
 
-				expectedDashboard[0].NextBuild = &jobBuildOld
+				var err error
+				resource, err = pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
 
-				actualDashboard, _, err = pipelineDB.GetDashboard()
+				otherResource, err = otherPipelineDB.GetResource("some-resource")
 				Expect(err).NotTo(HaveOccurred())
+			})
 
-				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+			It("saves the versions to identically configured resources in other pipelines", func() {
+				err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{
+					{"version": "1"},
+					{"version": "2"},
+				})
+				Expect(err).NotTo(HaveOccurred())
 
-				By("returning a job's most recent started build even if there is a newer pending build")
-				jobBuild, err := pipelineDB.CreateJobBuild("some-job")
+				savedVR, found, err := otherPipelineDB.GetLatestVersionedResource(otherResource)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedVR.Resource).To(Equal("some-resource"))
+				Expect(savedVR.Type).To(Equal("some-type"))
+				Expect(savedVR.Version).To(Equal(db.Version{"version": "2"}))
+			})
 
-				expectedDashboard[0].NextBuild = &jobBuildOld
+			It("does not save the versions to the checked resource itself", func() {
+				err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{
+					{"version": "1"},
+				})
+				Expect(err).NotTo(HaveOccurred())
 
-				actualDashboard, _, err = pipelineDB.GetDashboard()
+				_, found, err := pipelineDB.GetLatestVersionedResource(resource)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
 
-				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+			It("marks the identically configured resources as checked", func() {
+				err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{})
+				Expect(err).NotTo(HaveOccurred())
 
-				By("returning a job's most recent finished build")
-				err = sqlDB.FinishBuild(jobBuild.ID, db.StatusSucceeded)
+				lease, leased, err := otherPipelineDB.LeaseResourceChecking("some-resource", 1*time.Minute, false)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(leased).To(BeFalse())
+				Expect(lease).To(BeNil())
+			})
 
-				jobBuild, found, err = sqlDB.GetBuild(jobBuild.ID)
+			It("clears the check error of the identically configured resources", func() {
+				err := otherPipelineDB.SetResourceCheckError(otherResource, errors.New("oops"))
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
 
-				expectedDashboard[0].FinishedBuild = &jobBuild
-				expectedDashboard[0].NextBuild = &jobBuildOld
+				err = pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{})
+				Expect(err).NotTo(HaveOccurred())
 
-				actualDashboard, _, err = pipelineDB.GetDashboard()
+				returnedResource, err := otherPipelineDB.GetResource("some-resource")
 				Expect(err).NotTo(HaveOccurred())
+				Expect(returnedResource.CheckError).To(BeNil())
+			})
 
-				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+			Context("when the other pipeline is paused", func() {
+				BeforeEach(func() {
+					err := otherPipelineDB.Pause()
+					Expect(err).NotTo(HaveOccurred())
+				})
 
-				By("returning a job's most recent finished build even when there is a newer unfinished build")
-				jobBuildNew, err := pipelineDB.CreateJobBuild("some-job")
-				Expect(err).NotTo(HaveOccurred())
-				sqlDB.StartBuild(jobBuildNew.ID, "engine", "metadata")
-				jobBuildNew, found, err = sqlDB.GetBuild(jobBuildNew.ID)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
+				It("does not share with it", func() {
+					err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{
+						{"version": "1"},
+					})
+					Expect(err).NotTo(HaveOccurred())
 
-				expectedDashboard[0].FinishedBuild = &jobBuild
-				expectedDashboard[0].NextBuild = &jobBuildNew
+					_, found, err := otherPipelineDB.GetLatestVersionedResource(otherResource)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
 
-				actualDashboard, _, err = pipelineDB.GetDashboard()
-				Expect(err).NotTo(HaveOccurred())
+					lease, leased, err := otherPipelineDB.LeaseResourceChecking("some-resource", 1*time.Minute, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(leased).To(BeTrue())
 
-				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+					lease.Break()
+				})
 			})
-		})
 
-		Describe("CreateJobBuild", func() {
-			var build db.Build
+			Context("when the other resource is paused", func() {
+				BeforeEach(func() {
+					err := otherPipelineDB.PauseResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+				})
 
-			BeforeEach(func() {
-				var err error
-				build, err = pipelineDB.CreateJobBuild("some-job")
-				Expect(err).NotTo(HaveOccurred())
+				It("does not share with it", func() {
+					err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{
+						{"version": "1"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, found, err := otherPipelineDB.GetLatestVersionedResource(otherResource)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
 			})
 
-			It("sets the properties of a build for a given job", func() {
-				Expect(build.ID).NotTo(BeZero())
-				Expect(build.JobID).NotTo(BeZero())
-				Expect(build.Name).To(Equal("1"))
-				Expect(build.Status).To(Equal(db.StatusPending))
-				Expect(build.Scheduled).To(BeFalse())
+			Context("when the other resource's latest version differs from where the check started", func() {
+				BeforeEach(func() {
+					err := otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{
+						{"version": "0"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not share with it", func() {
+					err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{
+						{"version": "1"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := otherPipelineDB.GetLatestVersionedResource(otherResource)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(savedVR.Version).To(Equal(db.Version{"version": "0"}))
+				})
+
+				It("shares with it once the check starts from the same version", func() {
+					err := pipelineDB.ShareResourceVersions(resource, resourceConfig, atc.Version{"version": "0"}, []atc.Version{
+						{"version": "1"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := otherPipelineDB.GetLatestVersionedResource(otherResource)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(savedVR.Version).To(Equal(db.Version{"version": "1"}))
+				})
 			})
 
-			It("creates an entry in build_preparation", func() {
-				buildPrep, found, err := sqlDB.GetBuildPreparation(build.ID)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
+			Context("when the other resource is configured differently", func() {
+				BeforeEach(func() {
+					otherConfig, otherConfigVersion, found, err := otherPipelineDB.GetConfig()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
 
-				Expect(buildPrep.BuildID).To(Equal(build.ID))
+					otherConfig.Resources[0].Source = atc.Source{"source-config": "some-other-value"}
+
+					_, _, err = sqlDB.SaveConfig(team.Name, "other-pipeline-name", otherConfig, otherConfigVersion, db.PipelineNoChange)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not share with it", func() {
+					err := pipelineDB.ShareResourceVersions(resource, resourceConfig, nil, []atc.Version{
+						{"version": "1"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, found, err := otherPipelineDB.GetLatestVersionedResource(otherResource)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
 			})
 		})
 
-		Describe("saving builds for scheduling", func() {
-			buildMetadata := []db.MetadataField{
-				{
-					Name:  "meta1",
-					Value: "value1",
-				},
-				{
-					Name:  "meta2",
-					Value: "value2",
-				},
-			}
-
-			vr1 := db.VersionedResource{
-				PipelineName: "a-pipeline-name",
-				Resource:     "some-resource",
-				Type:         "some-type",
-				Version:      db.Version{"ver": "1"},
-				Metadata:     buildMetadata,
-			}
+		Describe("pruning resource versions", func() {
+			var (
+				resourceConfig atc.ResourceConfig
+				jobs           atc.JobConfigs
+			)
 
-			vr2 := db.VersionedResource{
-				PipelineName: "a-pipeline-name",
-				Resource:     "some-other-resource",
-				Type:         "some-type",
-				Version:      db.Version{"ver": "2"},
-			}
+			prunedVersions := func() []db.Version {
+				savedVRs, _, found, err := pipelineDB.GetResourceVersions("some-other-resource", db.Page{Limit: 100})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
 
-			input1 := db.BuildInput{
-				Name:              "some-input",
-				VersionedResource: vr1,
-			}
+				versions := []db.Version{}
+				for _, savedVR := range savedVRs {
+					versions = append(versions, savedVR.Version)
+				}
 
-			input2 := db.BuildInput{
-				Name:              "some-other-input",
-				VersionedResource: vr2,
+				return versions
 			}
 
-			inputs := []db.BuildInput{input1, input2}
-
-			It("does not create a new build if one is already running that does not have determined inputs ", func() {
-				build, created, err := pipelineDB.CreateJobBuildForCandidateInputs("some-job")
+			useVersion := func(jobName string, version string) {
+				build, err := pipelineDB.CreateJobBuild(jobName)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(created).To(BeTrue())
 
-				Expect(build.ID).NotTo(BeZero())
+				_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+					Name: "some-input-name",
+					VersionedResource: db.VersionedResource{
+						Resource:     "some-other-resource",
+						Type:         "some-type",
+						Version:      db.Version{"version": version},
+						PipelineName: pipelineDB.GetPipelineName(),
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			BeforeEach(func() {
+				var found bool
+				resourceConfig, found = pipelineConfig.Resources.Lookup("some-other-resource")
+				Expect(found).To(BeTrue())
+
+				resourceConfig.VersionRetention = &atc.VersionRetentionConfig{Count: 2}
+				jobs = atc.JobConfigs{}
+
+				err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{
+					{"version": "1"},
+					{"version": "2"},
+					{"version": "3"},
+					{"version": "4"},
+					{"version": "5"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes versions beyond the retained count", func() {
+				pruned, err := pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(Equal(3))
+
+				Expect(prunedVersions()).To(Equal([]db.Version{
+					{"version": "5"},
+					{"version": "4"},
+				}))
+			})
+
+			It("does not touch resources without a version retention", func() {
+				resourceConfig.VersionRetention = nil
+
+				pruned, err := pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(BeZero())
+				Expect(prunedVersions()).To(HaveLen(5))
+			})
+
+			It("keeps versions that a build has used as an input", func() {
+				useVersion("a-job", "2")
+
+				_, err := pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(prunedVersions()).To(Equal([]db.Version{
+					{"version": "5"},
+					{"version": "4"},
+					{"version": "2"},
+				}))
+			})
+
+			It("keeps versions that a build has produced as an output", func() {
+				build, err := pipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.SaveBuildOutput(build.ID, db.VersionedResource{
+					Resource:     "some-other-resource",
+					Type:         "some-type",
+					Version:      db.Version{"version": "1"},
+					PipelineName: pipelineDB.GetPipelineName(),
+				}, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(prunedVersions()).To(Equal([]db.Version{
+					{"version": "5"},
+					{"version": "4"},
+					{"version": "1"},
+				}))
+			})
+
+			It("keeps versions that a job pins", func() {
+				jobs = atc.JobConfigs{
+					{
+						Name: "a-job",
+						Plan: atc.PlanSequence{
+							{
+								Get:     "some-other-resource",
+								Version: &atc.VersionConfig{Pinned: atc.Version{"version": "1"}},
+							},
+						},
+					},
+				}
+
+				_, err := pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(prunedVersions()).To(Equal([]db.Version{
+					{"version": "5"},
+					{"version": "4"},
+					{"version": "1"},
+				}))
+			})
+
+			It("keeps versions that a job taking every version has yet to use", func() {
+				jobs = atc.JobConfigs{
+					{
+						Name: "a-job",
+						Plan: atc.PlanSequence{
+							{
+								Get:      "some-input-name",
+								Resource: "some-other-resource",
+								Version:  &atc.VersionConfig{Every: true},
+							},
+						},
+					},
+				}
+
+				useVersion("a-job", "2")
+
+				everyInput := []config.JobInput{
+					{
+						Name:     "some-input-name",
+						Resource: "some-other-resource",
+						Version:  &atc.VersionConfig{Every: true},
+					},
+				}
+
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				inputsBefore, found, err := pipelineDB.GetLatestInputVersions(versions, "a-job", everyInput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(prunedVersions()).To(Equal([]db.Version{
+					{"version": "5"},
+					{"version": "4"},
+					{"version": "3"},
+					{"version": "2"},
+				}))
+
+				versions, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				inputsAfter, found, err := pipelineDB.GetLatestInputVersions(versions, "a-job", everyInput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(inputsAfter).To(Equal(inputsBefore))
+			})
+
+			It("invalidates the cached versions DB", func() {
+				versions, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				prunedVersionsDB, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(prunedVersionsDB).NotTo(BeIdenticalTo(versions))
+				Expect(prunedVersionsDB.ResourceVersions).To(HaveLen(len(versions.ResourceVersions) - 3))
+			})
+
+			Context("when retaining versions by age", func() {
+				BeforeEach(func() {
+					_, err := dbConn.Exec(`
+						UPDATE versioned_resources
+						SET modified_time = now() - '2 days'::INTERVAL
+						WHERE version IN ('{"version":"1"}', '{"version":"2"}')
+					`)
+					Expect(err).NotTo(HaveOccurred())
+
+					resourceConfig.VersionRetention = &atc.VersionRetentionConfig{Age: "24h"}
+				})
+
+				It("deletes versions older than the retained age", func() {
+					pruned, err := pipelineDB.PruneResourceVersions(resourceConfig, jobs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pruned).To(Equal(2))
+
+					Expect(prunedVersions()).To(Equal([]db.Version{
+						{"version": "5"},
+						{"version": "4"},
+						{"version": "3"},
+					}))
+				})
+			})
+		})
+
+		Describe("resource types", func() {
+			It("registers the configured resource types without a version", func() {
+				resourceType, found, err := pipelineDB.GetResourceType("some-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(resourceType.Name).To(Equal("some-resource-type"))
+				Expect(resourceType.Version).To(BeNil())
+				Expect(resourceType.PinnedVersion).To(BeNil())
+				Expect(resourceType.CheckError).To(BeNil())
+			})
+
+			It("does not find resource types that are not configured", func() {
+				_, found, err := pipelineDB.GetResourceType("bogus-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("saves the latest version", func() {
+				resourceType, _, err := pipelineDB.GetResourceType("some-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.SaveResourceTypeVersion(resourceType, atc.Version{"digest": "some-digest"})
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceType, _, err = pipelineDB.GetResourceType("some-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resourceType.Version).To(Equal(atc.Version{"digest": "some-digest"}))
+			})
+
+			It("saves and clears check errors", func() {
+				resourceType, _, err := pipelineDB.GetResourceType("some-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.SetResourceTypeCheckError(resourceType, errors.New("oops"))
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceType, _, err = pipelineDB.GetResourceType("some-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resourceType.CheckError).To(Equal(errors.New("oops")))
+
+				err = pipelineDB.SetResourceTypeCheckError(resourceType, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceType, _, err = pipelineDB.GetResourceType("some-resource-type")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resourceType.CheckError).To(BeNil())
+			})
+
+			It("can be leased for checking", func() {
+				lease, leased, err := pipelineDB.LeaseResourceTypeChecking("some-resource-type", 1*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(leased).To(BeTrue())
+
+				lease.Break()
+
+				_, leased, err = pipelineDB.LeaseResourceTypeChecking("some-resource-type", 1*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(leased).To(BeFalse())
+			})
+
+			Context("when the resource type's configuration changes", func() {
+				var staleResourceType db.SavedResourceType
+
+				BeforeEach(func() {
+					var err error
+					staleResourceType, _, err = pipelineDB.GetResourceType("some-resource-type")
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDB.SaveResourceTypeVersion(staleResourceType, atc.Version{"digest": "some-digest"})
+					Expect(err).NotTo(HaveOccurred())
+
+					config, configVersion, _, err := pipelineDB.GetConfig()
+					Expect(err).NotTo(HaveOccurred())
+
+					config.ResourceTypes[0].Source = atc.Source{"repository": "some-other-repository"}
+
+					_, _, err = sqlDB.SaveConfig(team.Name, "a-pipeline-name", config, configVersion, db.PipelineNoChange)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("forgets the version checked with the old configuration", func() {
+					resourceType, _, err := pipelineDB.GetResourceType("some-resource-type")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resourceType.Version).To(BeNil())
+				})
+
+				It("ignores versions from checks that used the old configuration", func() {
+					err := pipelineDB.SaveResourceTypeVersion(staleResourceType, atc.Version{"digest": "stale-digest"})
+					Expect(err).NotTo(HaveOccurred())
+
+					resourceType, _, err := pipelineDB.GetResourceType("some-resource-type")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resourceType.Version).To(BeNil())
+				})
+			})
+
+			Describe("pinning versions", func() {
+				It("pins and unpins the version", func() {
+					err := pipelineDB.PinResourceTypeVersion("some-resource-type", atc.Version{"digest": "pinned-digest"})
+					Expect(err).NotTo(HaveOccurred())
+
+					resourceType, _, err := pipelineDB.GetResourceType("some-resource-type")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resourceType.PinnedVersion).To(Equal(atc.Version{"digest": "pinned-digest"}))
+
+					err = pipelineDB.UnpinResourceTypeVersion("some-resource-type")
+					Expect(err).NotTo(HaveOccurred())
+
+					resourceType, _, err = pipelineDB.GetResourceType("some-resource-type")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resourceType.PinnedVersion).To(BeNil())
+				})
+
+				It("fails for resource types that are not configured", func() {
+					err := pipelineDB.PinResourceTypeVersion("bogus-resource-type", atc.Version{"digest": "pinned-digest"})
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Describe("ResolveResourceTypeVersions", func() {
+				var resourceTypes atc.ResourceTypes
+
+				BeforeEach(func() {
+					resourceTypes = atc.ResourceTypes{
+						{
+							Name:   "some-resource-type",
+							Type:   "docker-image",
+							Source: atc.Source{"repository": "some-repository"},
+						},
+						{
+							Name:   "unsaved-resource-type",
+							Type:   "docker-image",
+							Source: atc.Source{"repository": "some-repository"},
+						},
+					}
+				})
+
+				It("leaves resource types that have not been checked without a version", func() {
+					resolved, err := pipelineDB.ResolveResourceTypeVersions(resourceTypes)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resolved).To(Equal(resourceTypes))
+				})
+
+				Context("when a version has been checked", func() {
+					BeforeEach(func() {
+						resourceType, _, err := pipelineDB.GetResourceType("some-resource-type")
+						Expect(err).NotTo(HaveOccurred())
+
+						err = pipelineDB.SaveResourceTypeVersion(resourceType, atc.Version{"digest": "checked-digest"})
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("uses it", func() {
+						resolved, err := pipelineDB.ResolveResourceTypeVersions(resourceTypes)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(resolved[0].Version).To(Equal(atc.Version{"digest": "checked-digest"}))
+						Expect(resolved[1].Version).To(BeNil())
+					})
+
+					It("does not modify the given resource types", func() {
+						_, err := pipelineDB.ResolveResourceTypeVersions(resourceTypes)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(resourceTypes[0].Version).To(BeNil())
+					})
+
+					Context("when a version has been pinned through the API", func() {
+						BeforeEach(func() {
+							err := pipelineDB.PinResourceTypeVersion("some-resource-type", atc.Version{"digest": "pinned-digest"})
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("uses the pinned version", func() {
+							resolved, err := pipelineDB.ResolveResourceTypeVersions(resourceTypes)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(resolved[0].Version).To(Equal(atc.Version{"digest": "pinned-digest"}))
+						})
+
+						Context("when a version is pinned in the config", func() {
+							BeforeEach(func() {
+								resourceTypes[0].Version = atc.Version{"digest": "configured-digest"}
+							})
+
+							It("uses the configured version", func() {
+								resolved, err := pipelineDB.ResolveResourceTypeVersions(resourceTypes)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(resolved[0].Version).To(Equal(atc.Version{"digest": "configured-digest"}))
+							})
+						})
+					})
+				})
+			})
+		})
+
+		Describe("recording resource check backoff", func() {
+			It("is not backing off when first created", func() {
+				resource, err := pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resource.CheckFailures).To(BeZero())
+				Expect(resource.CheckBackoff).To(BeZero())
+				Expect(resource.NextCheck).To(BeZero())
+			})
+
+			It("saves the failures, backoff, and next check time", func() {
+				resource, err := pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				nextCheck := time.Now().Add(4 * time.Minute)
+
+				err = pipelineDB.SetResourceCheckBackoff(resource, 2, 4*time.Minute, nextCheck)
+				Expect(err).NotTo(HaveOccurred())
+
+				returnedResource, err := pipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(returnedResource.CheckFailures).To(Equal(2))
+				Expect(returnedResource.CheckBackoff).To(Equal(4 * time.Minute))
+				Expect(returnedResource.NextCheck.Unix()).To(Equal(nextCheck.Unix()))
+			})
+		})
+	})
+
+	Describe("Jobs", func() {
+		Describe("GetDashboard", func() {
+			It("returns a Dashboard object with a DashboardJob corresponding to each configured job", func() {
+				job, err := pipelineDB.GetJob("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				otherJob, err := pipelineDB.GetJob("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				aJob, err := pipelineDB.GetJob("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				sharedJob, err := pipelineDB.GetJob("shared-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				randomJob, err := pipelineDB.GetJob("random-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				otherSerialGroupJob, err := pipelineDB.GetJob("other-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				differentSerialGroupJob, err := pipelineDB.GetJob("different-serial-group-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				lookupConfig := func(jobName string) atc.JobConfig {
+					config, found := pipelineConfig.Jobs.Lookup(jobName)
+					Expect(found).To(BeTrue())
+
+					return config
+				}
+
+				By("returning jobs with no builds")
+				expectedDashboard := db.Dashboard{
+					{
+						JobConfig:     lookupConfig(job.Name),
+						Job:           job,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+					{
+						JobConfig:     lookupConfig(otherJob.Name),
+						Job:           otherJob,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+					{
+						JobConfig:     lookupConfig(aJob.Name),
+						Job:           aJob,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+					{
+						JobConfig:     lookupConfig(sharedJob.Name),
+						Job:           sharedJob,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+					{
+						JobConfig:     lookupConfig(randomJob.Name),
+						Job:           randomJob,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+					{
+						JobConfig:     lookupConfig(otherSerialGroupJob.Name),
+						Job:           otherSerialGroupJob,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+					{
+						JobConfig:     lookupConfig(differentSerialGroupJob.Name),
+						Job:           differentSerialGroupJob,
+						NextBuild:     nil,
+						FinishedBuild: nil,
+					},
+				}
+
+				actualDashboard, groups, err := pipelineDB.GetDashboard()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(groups).To(Equal(pipelineConfig.Groups))
+				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+
+				By("returning a job's most recent pending build if there are no running builds")
+				jobBuildOld, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedDashboard[0].NextBuild = &jobBuildOld
+
+				actualDashboard, _, err = pipelineDB.GetDashboard()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+
+				By("returning a job's most recent started build")
+				sqlDB.StartBuild(jobBuildOld.ID, "engine", "metadata")
+
+				var found bool
+				jobBuildOld, found, err = sqlDB.GetBuild(jobBuildOld.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				expectedDashboard[0].NextBuild = &jobBuildOld
+
+				actualDashboard, _, err = pipelineDB.GetDashboard()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+
+				By("returning a job's most recent started build even if there is a newer pending build")
+				jobBuild, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedDashboard[0].NextBuild = &jobBuildOld
+
+				actualDashboard, _, err = pipelineDB.GetDashboard()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+
+				By("returning a job's most recent finished build")
+				err = sqlDB.FinishBuild(jobBuild.ID, db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobBuild, found, err = sqlDB.GetBuild(jobBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				expectedDashboard[0].FinishedBuild = &jobBuild
+				expectedDashboard[0].NextBuild = &jobBuildOld
+
+				actualDashboard, _, err = pipelineDB.GetDashboard()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+
+				By("returning a job's most recent finished build even when there is a newer unfinished build")
+				jobBuildNew, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				sqlDB.StartBuild(jobBuildNew.ID, "engine", "metadata")
+				jobBuildNew, found, err = sqlDB.GetBuild(jobBuildNew.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				expectedDashboard[0].FinishedBuild = &jobBuild
+				expectedDashboard[0].NextBuild = &jobBuildNew
+
+				actualDashboard, _, err = pipelineDB.GetDashboard()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(actualDashboard).To(ConsistOf(expectedDashboard))
+			})
+		})
+
+		Describe("CreateJobBuild", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("sets the properties of a build for a given job", func() {
+				Expect(build.ID).NotTo(BeZero())
+				Expect(build.JobID).NotTo(BeZero())
+				Expect(build.Name).To(Equal("1"))
+				Expect(build.Status).To(Equal(db.StatusPending))
+				Expect(build.Scheduled).To(BeFalse())
+			})
+
+			It("creates an entry in build_preparation", func() {
+				buildPrep, found, err := sqlDB.GetBuildPreparation(build.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(buildPrep.BuildID).To(Equal(build.ID))
+			})
+		})
+
+		Describe("saving builds for scheduling", func() {
+			buildMetadata := []db.MetadataField{
+				{
+					Name:  "meta1",
+					Value: "value1",
+				},
+				{
+					Name:  "meta2",
+					Value: "value2",
+				},
+			}
+
+			vr1 := db.VersionedResource{
+				PipelineName: "a-pipeline-name",
+				Resource:     "some-resource",
+				Type:         "some-type",
+				Version:      db.Version{"ver": "1"},
+				Metadata:     buildMetadata,
+			}
+
+			vr2 := db.VersionedResource{
+				PipelineName: "a-pipeline-name",
+				Resource:     "some-other-resource",
+				Type:         "some-type",
+				Version:      db.Version{"ver": "2"},
+			}
+
+			input1 := db.BuildInput{
+				Name:              "some-input",
+				VersionedResource: vr1,
+			}
+
+			input2 := db.BuildInput{
+				Name:              "some-other-input",
+				VersionedResource: vr2,
+			}
+
+			inputs := []db.BuildInput{input1, input2}
+
+			It("does not create a new build if one is already running that does not have determined inputs ", func() {
+				build, created, err := pipelineDB.CreateJobBuildForCandidateInputs("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				Expect(build.ID).NotTo(BeZero())
 				Expect(build.JobID).NotTo(BeZero())
 				Expect(build.Name).To(Equal("1"))
 				Expect(build.Status).To(Equal(db.StatusPending))
@@ -2147,6 +2842,75 @@ var _ = Describe("PipelineDB", func() {
 
 				Expect(unpausedJob.Paused).To(BeFalse())
 			})
+
+			It("records why the job was paused until it is unpaused", func() {
+				err := pipelineDB.PauseJobWithReason(job, "some-reason")
+				Expect(err).NotTo(HaveOccurred())
+
+				pausedJob, err := pipelineDB.GetJob(job)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pausedJob.Paused).To(BeTrue())
+				Expect(pausedJob.PausedReason).To(Equal("some-reason"))
+
+				err = pipelineDB.UnpauseJob(job)
+				Expect(err).NotTo(HaveOccurred())
+
+				unpausedJob, err := pipelineDB.GetJob(job)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpausedJob.PausedReason).To(BeEmpty())
+			})
+		})
+
+		Describe("GetConsecutiveFailures", func() {
+			finishBuild := func(status db.Status) {
+				build, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = sqlDB.FinishBuild(build.ID, status)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			It("is zero when the job has no builds", func() {
+				failures, err := pipelineDB.GetConsecutiveFailures("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failures).To(BeZero())
+			})
+
+			It("counts failed and errored builds since the last success", func() {
+				finishBuild(db.StatusFailed)
+				finishBuild(db.StatusSucceeded)
+				finishBuild(db.StatusFailed)
+				finishBuild(db.StatusErrored)
+
+				failures, err := pipelineDB.GetConsecutiveFailures("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failures).To(Equal(2))
+
+				otherFailures, err := otherPipelineDB.GetConsecutiveFailures("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(otherFailures).To(BeZero())
+			})
+
+			It("starts counting again when the job is unpaused", func() {
+				finishBuild(db.StatusFailed)
+				finishBuild(db.StatusFailed)
+
+				err := pipelineDB.PauseJob("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pipelineDB.UnpauseJob("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				failures, err := pipelineDB.GetConsecutiveFailures("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failures).To(BeZero())
+
+				finishBuild(db.StatusFailed)
+
+				failures, err = pipelineDB.GetConsecutiveFailures("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(failures).To(Equal(1))
+			})
 		})
 
 		Describe("GetJobBuild", func() {
@@ -2171,7 +2935,7 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
-		Describe("GetNextPendingBuildBySerialGroup", func() {
+		Describe("GetNextPendingBuildsBySerialGroup", func() {
 			var jobOneConfig atc.JobConfig
 			var jobOneTwoConfig atc.JobConfig
 
@@ -2215,10 +2979,10 @@ var _ = Describe("PipelineDB", func() {
 				})
 
 				It("should return the next most pending build in a group of jobs", func() {
-					build, found, err := pipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
+					builds, err := pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 1})
 					Expect(err).NotTo(HaveOccurred())
-					Expect(found).To(BeTrue())
-					Expect(build.ID).To(Equal(acutalBuild.ID))
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID).To(Equal(acutalBuild.ID))
 				})
 			})
 
@@ -2249,84 +3013,212 @@ var _ = Describe("PipelineDB", func() {
 
 				Expect(err).NotTo(HaveOccurred())
 
-				build, found, err := pipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
+				builds, err := pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(buildOne.ID))
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(buildOne.ID))
 
-				build, found, err = pipelineDB.GetNextPendingBuildBySerialGroup(jobOneTwoConfig.Name, []string{"one", "two"})
+				By("only returning builds that are next in every serial group")
+				builds, err = pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneTwoConfig.Name, map[string]int{"one": 1, "two": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(buildOne.ID))
+				Expect(builds).To(BeEmpty())
 
 				scheduled, err := pipelineDB.UpdateBuildToScheduled(buildOne.ID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
-				Expect(sqlDB.FinishBuild(buildOne.ID, db.StatusSucceeded)).To(Succeed())
 
-				build, found, err = pipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
+				By("not returning any build while the serial group is full")
+				builds, err = pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(buildTwo.ID))
+				Expect(builds).To(BeEmpty())
+
+				Expect(sqlDB.FinishBuild(buildOne.ID, db.StatusSucceeded)).To(Succeed())
 
-				build, found, err = pipelineDB.GetNextPendingBuildBySerialGroup(jobOneTwoConfig.Name, []string{"one", "two"})
+				builds, err = pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(buildTwo.ID))
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(buildTwo.ID))
 
 				scheduled, err = pipelineDB.UpdateBuildToScheduled(buildTwo.ID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
 				Expect(sqlDB.FinishBuild(buildTwo.ID, db.StatusSucceeded)).To(Succeed())
 
-				build, found, err = otherPipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
-				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(otherBuildOne.ID))
-
-				build, found, err = otherPipelineDB.GetNextPendingBuildBySerialGroup(jobOneTwoConfig.Name, []string{"one", "two"})
+				builds, err = otherPipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(otherBuildOne.ID))
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(otherBuildOne.ID))
 
 				scheduled, err = otherPipelineDB.UpdateBuildToScheduled(otherBuildOne.ID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
 				Expect(sqlDB.FinishBuild(otherBuildOne.ID, db.StatusSucceeded)).To(Succeed())
 
-				build, found, err = otherPipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
-				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(otherBuildTwo.ID))
-
-				build, found, err = otherPipelineDB.GetNextPendingBuildBySerialGroup(jobOneTwoConfig.Name, []string{"one", "two"})
+				builds, err = otherPipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(otherBuildTwo.ID))
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(otherBuildTwo.ID))
 
 				scheduled, err = otherPipelineDB.UpdateBuildToScheduled(otherBuildTwo.ID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
 				Expect(sqlDB.FinishBuild(otherBuildTwo.ID, db.StatusSucceeded)).To(Succeed())
 
-				build, found, err = otherPipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
+				builds, err = otherPipelineDB.GetNextPendingBuildsBySerialGroup(jobOneTwoConfig.Name, map[string]int{"one": 1, "two": 1})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(otherBuildThree.ID))
-				build, found, err = otherPipelineDB.GetNextPendingBuildBySerialGroup(jobOneTwoConfig.Name, []string{"one", "two"})
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(otherBuildThree.ID))
+
+				builds, err = pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneTwoConfig.Name, map[string]int{"one": 1, "two": 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(buildThree.ID))
+			})
+
+			Context("when the serial group allows more than one build in flight", func() {
+				var firstBuild, secondBuild, thirdBuild db.Build
+
+				BeforeEach(func() {
+					var err error
+					firstBuild, err = pipelineDB.CreateJobBuild(jobOneConfig.Name)
+					Expect(err).NotTo(HaveOccurred())
+
+					secondBuild, err = pipelineDB.CreateJobBuild(jobOneConfig.Name)
+					Expect(err).NotTo(HaveOccurred())
+
+					thirdBuild, err = pipelineDB.CreateJobBuild(jobOneConfig.Name)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = dbConn.Query(`
+						UPDATE builds
+						SET inputs_determined = true
+						WHERE id in ($1, $2, $3)
+					`, firstBuild.ID, secondBuild.ID, thirdBuild.ID)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns as many builds as there are free slots", func() {
+					builds, err := pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 2})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(HaveLen(2))
+					Expect(builds[0].ID).To(Equal(firstBuild.ID))
+					Expect(builds[1].ID).To(Equal(secondBuild.ID))
+
+					scheduled, err := pipelineDB.UpdateBuildToScheduled(firstBuild.ID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					builds, err = pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 2})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID).To(Equal(secondBuild.ID))
+
+					scheduled, err = pipelineDB.UpdateBuildToScheduled(secondBuild.ID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					builds, err = pipelineDB.GetNextPendingBuildsBySerialGroup(jobOneConfig.Name, map[string]int{"one": 2})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("PrioritizePendingBuild", func() {
+			var firstBuild, secondBuild, thirdBuild db.Build
+
+			BeforeEach(func() {
+				//TODO: Delete this query after #114257887
+				_, err := dbConn.Query(`
+				INSERT INTO jobs_serial_groups (serial_group, job_id) VALUES
+				('one', (select j.id from jobs j, pipelines p where j.name = 'some-job' and j.pipeline_id = p.id and p.name = $1)),
+				('one', (select j.id from jobs j, pipelines p where j.name = 'some-other-job' and j.pipeline_id = p.id and p.name = $1))
+			`, pipelineDB.GetPipelineName())
 				Expect(err).NotTo(HaveOccurred())
-				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(otherBuildThree.ID))
 
-				build, found, err = pipelineDB.GetNextPendingBuildBySerialGroup(jobOneConfig.Name, []string{"one"})
+				firstBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				secondBuild, err = pipelineDB.CreateJobBuild("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				thirdBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = dbConn.Query(`
+					UPDATE builds
+					SET inputs_determined = true
+					WHERE id in ($1, $2, $3)
+				`, firstBuild.ID, secondBuild.ID, thirdBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("moves the build to the front of the queue", func() {
+				prioritized, err := pipelineDB.PrioritizePendingBuild(thirdBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(prioritized).To(BeTrue())
+
+				builds, err := pipelineDB.GetNextPendingBuildsBySerialGroup("some-job", map[string]int{"one": 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(thirdBuild.ID))
+
+				build, found, err := pipelineDB.GetNextPendingBuild("some-job")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(buildThree.ID))
+				Expect(build.ID).To(Equal(thirdBuild.ID))
+
+				pendingBuilds, err := pipelineDB.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+
+				ids := []int{}
+				for _, build := range pendingBuilds {
+					ids = append(ids, build.ID)
+				}
+				Expect(ids).To(Equal([]int{thirdBuild.ID, firstBuild.ID, secondBuild.ID}))
+			})
+
+			It("puts the most recently prioritized build first", func() {
+				_, err := pipelineDB.PrioritizePendingBuild(thirdBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pipelineDB.PrioritizePendingBuild(secondBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				builds, err := pipelineDB.GetNextPendingBuildsBySerialGroup("some-job", map[string]int{"one": 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(secondBuild.ID))
+			})
+
+			It("does not prioritize builds that are no longer pending", func() {
+				Expect(sqlDB.FinishBuild(firstBuild.ID, db.StatusAborted)).To(Succeed())
+
+				prioritized, err := pipelineDB.PrioritizePendingBuild(firstBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(prioritized).To(BeFalse())
+			})
+
+			It("does not prioritize builds from other pipelines", func() {
+				prioritized, err := otherPipelineDB.PrioritizePendingBuild(thirdBuild.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(prioritized).To(BeFalse())
+			})
+		})
+
+		Describe("OverrideBuildSchedule", func() {
+			It("marks the build as overriding the schedule", func() {
+				build, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(build.ScheduleOverridden).To(BeFalse())
+
+				err = pipelineDB.OverrideBuildSchedule(build.ID)
+				Expect(err).NotTo(HaveOccurred())
 
-				build, found, err = pipelineDB.GetNextPendingBuildBySerialGroup(jobOneTwoConfig.Name, []string{"one", "two"})
+				build, found, err := pipelineDB.GetNextPendingBuild("some-job")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
-				Expect(build.ID).To(Equal(buildThree.ID))
+				Expect(build.ScheduleOverridden).To(BeTrue())
 			})
 		})
 
@@ -2377,7 +3269,7 @@ var _ = Describe("PipelineDB", func() {
 				})
 
 				It("returns a list of running or schedule builds for said job", func() {
-					builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", []string{"serial-group"})
+					builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", map[string]int{"serial-group": 1})
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(len(builds)).To(Equal(2))
@@ -2387,6 +3279,14 @@ var _ = Describe("PipelineDB", func() {
 					}
 					Expect(ids).To(ConsistOf([]int{startedBuild.ID, scheduledBuild.ID}))
 				})
+
+				Context("when the serial group still has free slots", func() {
+					It("returns no builds", func() {
+						builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", map[string]int{"serial-group": 3})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(builds).To(BeEmpty())
+					})
+				})
 			})
 
 			Describe("multiple jobs with same serial group", func() {
@@ -2413,7 +3313,7 @@ var _ = Describe("PipelineDB", func() {
 				})
 
 				It("returns a list of builds in the same serial group", func() {
-					builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", []string{"serial-group"})
+					builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", map[string]int{"serial-group": 1})
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(len(builds)).To(Equal(1))
@@ -2646,6 +3546,92 @@ var _ = Describe("PipelineDB", func() {
 				Expect(buildInputs).To(Equal([]db.BuildInput{}))
 			})
 
+			Context("when an input steps through every version", func() {
+				BeforeEach(func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "1"}, {"version": "2"}, {"version": "3"}})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				everyInput := []config.JobInput{
+					{
+						Name:     "some-input-name",
+						Resource: "some-resource",
+						Version:  &atc.VersionConfig{Every: true},
+					},
+				}
+
+				It("starts with the latest version", func() {
+					buildInputs, found, err := loadAndGetLatestInputVersions("a-job", everyInput)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs).To(HaveLen(1))
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "3"}))
+				})
+
+				It("uses the version after the newest one the job has used", func() {
+					build, err := pipelineDB.CreateJobBuild("a-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+						Name: "some-input-name",
+						VersionedResource: db.VersionedResource{
+							Resource:     "some-resource",
+							Type:         "some-type",
+							Version:      db.Version{"version": "1"},
+							PipelineName: pipelineDB.GetPipelineName(),
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					buildInputs, found, err := loadAndGetLatestInputVersions("a-job", everyInput)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs).To(HaveLen(1))
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "2"}))
+				})
+			})
+
+			Context("when an input is pinned to a version", func() {
+				BeforeEach(func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": "1"}, {"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("uses the pinned version", func() {
+					buildInputs, found, err := loadAndGetLatestInputVersions("a-job", []config.JobInput{
+						{
+							Name:     "some-input-name",
+							Resource: "some-resource",
+							Version:  &atc.VersionConfig{Pinned: atc.Version{"version": "1"}},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(buildInputs).To(HaveLen(1))
+					Expect(buildInputs[0].VersionedResource.Version).To(Equal(db.Version{"version": "1"}))
+				})
+
+				It("finds no inputs when the pinned version does not exist", func() {
+					_, found, err := loadAndGetLatestInputVersions("a-job", []config.JobInput{
+						{
+							Name:     "some-input-name",
+							Resource: "some-resource",
+							Version:  &atc.VersionConfig{Pinned: atc.Version{"version": "bogus"}},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+
 			It("ensures that when scanning for previous inputs versions it only considers those from the same job", func() {
 				resource, err := pipelineDB.GetResource("some-resource")
 				Expect(err).NotTo(HaveOccurred())
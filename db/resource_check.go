@@ -0,0 +1,19 @@
+package db
+
+import "time"
+
+// ResourceCheckHistoryLimit is how many checks are kept for each resource.
+const ResourceCheckHistoryLimit = 100
+
+type ResourceCheck struct {
+	ID            int
+	StartTime     time.Time
+	EndTime       time.Time
+	WorkerName    string
+	VersionsFound int
+
+	// ExitStatus is nil if the check script did not run to completion.
+	ExitStatus *int
+	Stderr     string
+	Error      string
+}
@@ -0,0 +1,17 @@
+package db
+
+import "github.com/concourse/atc"
+
+type SavedResourceType struct {
+	ID         int
+	Name       string
+	ConfigHash string
+
+	// Version is the latest version found by checking the resource type.
+	Version atc.Version
+
+	// PinnedVersion, if set through the API, is used instead of Version.
+	PinnedVersion atc.Version
+
+	CheckError error
+}
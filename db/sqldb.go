@@ -14,6 +14,8 @@ type SQLDB struct {
 	bus  *notificationsBus
 
 	buildPrepHelper buildPreparationHelper
+
+	eventStore BuildEventStore
 }
 
 func NewSQL(
@@ -29,6 +31,12 @@ func NewSQL(
 	}
 }
 
+// UseBuildEventStore configures where the events of completed builds are
+// compacted to. Without one, events are kept in the database indefinitely.
+func (db *SQLDB) UseBuildEventStore(store BuildEventStore) {
+	db.eventStore = store
+}
+
 type nonOneRowAffectedError struct {
 	RowsAffected int64
 }
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+
+	"github.com/concourse/atc"
+)
+
+func (db *SQLDB) GetBuildsWithUncompactedEvents() ([]Build, error) {
+	rows, err := db.conn.Query(`
+		SELECT ` + qualifiedBuildColumns + `
+		FROM builds b
+		LEFT OUTER JOIN jobs j ON b.job_id = j.id
+		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
+		WHERE b.completed
+		AND NOT b.events_compacted
+		ORDER BY b.id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := scanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, nil
+}
+
+// CompactBuildEvents moves the events of a completed build out of the
+// database and into the configured build event store. Streaming the build's
+// events continues to work afterwards, reading them back from the store.
+//
+// The events are streamed to the store as they are read, and are read and
+// deleted within one transaction, so only the events that made it into the
+// blob are removed.
+func (db *SQLDB) CompactBuildEvents(buildID int) error {
+	if db.eventStore == nil {
+		return ErrBuildEventStoreNotConfigured
+	}
+
+	build, found, err := db.GetBuild(buildID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("build %d not found", buildID)
+	}
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var completed, compacted bool
+	err = tx.QueryRow(`
+		SELECT completed, events_compacted
+		FROM builds
+		WHERE id = $1
+		FOR UPDATE
+	`, buildID).Scan(&completed, &compacted)
+	if err != nil {
+		return err
+	}
+
+	if !completed {
+		return fmt.Errorf("cannot compact events of build %d as it has not completed", buildID)
+	}
+
+	if compacted {
+		return nil
+	}
+
+	table := "build_events"
+	if build.PipelineID != 0 {
+		table = fmt.Sprintf("pipeline_build_events_%d", build.PipelineID)
+	}
+
+	rows, err := tx.Query(`
+		SELECT event_id, type, version, payload
+		FROM `+table+`
+		WHERE build_id = $1
+		ORDER BY event_id ASC
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	blob, blobWriter := io.Pipe()
+
+	var maxEventID int
+	compactErr := make(chan error, 1)
+
+	go func() {
+		var err error
+		maxEventID, err = writeCompactedEvents(blobWriter, rows)
+		blobWriter.CloseWithError(err)
+		compactErr <- err
+	}()
+
+	err = db.eventStore.Put(buildID, blob)
+
+	// unblock the writer if the store stopped reading early
+	blob.Close()
+
+	writeErr := <-compactErr
+
+	if err != nil {
+		return err
+	}
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET events_compacted = true
+		WHERE id = $1
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM `+table+`
+		WHERE build_id = $1
+		AND event_id <= $2
+	`, buildID, maxEventID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// writeCompactedEvents writes each row's event to w as a compacted blob,
+// returning the ID of the last event written.
+func writeCompactedEvents(w io.Writer, rows *sql.Rows) (int, error) {
+	defer rows.Close()
+
+	writer := newCompactedEventWriter(w)
+
+	maxEventID := 0
+
+	for rows.Next() {
+		var id int
+		var t, v, p string
+		err := rows.Scan(&id, &t, &v, &p)
+		if err != nil {
+			return 0, err
+		}
+
+		err = writer.Write(atc.EventType(t), atc.EventVersion(v), []byte(p))
+		if err != nil {
+			return 0, err
+		}
+
+		maxEventID = id
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return maxEventID, writer.Close()
+}
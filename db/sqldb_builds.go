@@ -11,8 +11,8 @@ import (
 	"github.com/lib/pq"
 )
 
-const buildColumns = "id, name, job_id, status, scheduled, inputs_determined, engine, engine_metadata, start_time, end_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.inputs_determined, b.engine, b.engine_metadata, b.start_time, b.end_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name"
+const buildColumns = "id, name, job_id, status, scheduled, inputs_determined, schedule_overridden, engine, engine_metadata, start_time, end_time, rerun_of"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.inputs_determined, b.schedule_overridden, b.engine, b.engine_metadata, b.start_time, b.end_time, b.rerun_of, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, p.team_id as team_id"
 
 func (db *SQLDB) GetBuilds(page Page) ([]Build, Pagination, error) {
 	query := `
@@ -356,7 +356,7 @@ func (db *SQLDB) CreateOneOffBuild() (Build, error) {
 	build, _, err := scanBuild(tx.QueryRow(`
 		INSERT INTO builds (name, status)
 		VALUES (nextval('one_off_name'), 'pending')
-		RETURNING ` + buildColumns + `, null, null, null
+		RETURNING ` + buildColumns + `, null, null, null, null
 	`))
 	if err != nil {
 		return Build{}, err
@@ -382,6 +382,85 @@ func (db *SQLDB) CreateOneOffBuild() (Build, error) {
 	return build, nil
 }
 
+// CreateRerunBuild creates a pending build that re-runs the given build. Job
+// builds get the next build number of their job and the original build's
+// inputs. The scheduler never picks re-runs up, so they must be started
+// by whoever created them.
+func (db *SQLDB) CreateRerunBuild(original Build) (Build, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return Build{}, err
+	}
+
+	defer tx.Rollback()
+
+	var build Build
+	if original.OneOff() {
+		build, _, err = scanBuild(tx.QueryRow(`
+			INSERT INTO builds (name, status, rerun_of)
+			VALUES (nextval('one_off_name'), 'pending', $1)
+			RETURNING `+buildColumns+`, null, null, null, null
+		`, original.ID))
+		if err != nil {
+			return Build{}, err
+		}
+	} else {
+		var name string
+		err = tx.QueryRow(`
+			UPDATE jobs
+			SET build_number_seq = build_number_seq + 1
+			WHERE id = $1
+			RETURNING build_number_seq
+		`, original.JobID).Scan(&name)
+		if err != nil {
+			return Build{}, err
+		}
+
+		build, _, err = scanBuild(tx.QueryRow(`
+			INSERT INTO builds (name, job_id, status, inputs_determined, rerun_of)
+			VALUES ($1, $2, 'pending', true, $3)
+			RETURNING `+buildColumns+`, null, null, null, null
+		`, name, original.JobID, original.ID))
+		if err != nil {
+			return Build{}, err
+		}
+
+		build.JobName = original.JobName
+		build.PipelineID = original.PipelineID
+		build.PipelineName = original.PipelineName
+		build.TeamID = original.TeamID
+
+		_, err = tx.Exec(`
+			INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+			SELECT $1, versioned_resource_id, name
+			FROM build_inputs
+			WHERE build_id = $2
+		`, build.ID, original.ID)
+		if err != nil {
+			return Build{}, err
+		}
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		CREATE SEQUENCE %s MINVALUE 0
+	`, buildEventSeq(build.ID)))
+	if err != nil {
+		return Build{}, err
+	}
+
+	err = db.buildPrepHelper.CreateBuildPreparation(tx, build.ID)
+	if err != nil {
+		return Build{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Build{}, err
+	}
+
+	return build, nil
+}
+
 func (db *SQLDB) GetBuildPreparation(passedBuildID int) (BuildPreparation, bool, error) {
 	return db.buildPrepHelper.GetBuildPreparation(db.conn, passedBuildID)
 }
@@ -409,7 +488,8 @@ func (db *SQLDB) ResetBuildPreparationsWithPipelinePaused(pipelineID int) error
 			    paused_job='unknown',
 					max_running_builds='unknown',
 					inputs='{}',
-					inputs_satisfied='unknown'
+					inputs_satisfied='unknown',
+					schedule='unknown'
 			FROM build_preparation bp, builds b, jobs j
 			WHERE bp.build_id = b.id AND b.job_id = j.id
 				AND j.pipeline_id = $1 AND b.status = 'pending' AND b.scheduled = false
@@ -577,11 +657,60 @@ func (db *SQLDB) GetBuildEvents(buildID int, from uint) (EventSource, error) {
 		buildID,
 		table,
 		db.conn,
+		db.eventStore,
 		notifier,
 		from,
 	), nil
 }
 
+// GetBuildLifecycleEvents returns the events a build has saved so far, other
+// than its logs, without waiting for any more. It only reads the database, so
+// it is for following builds that are still running; their events have not
+// been compacted.
+func (db *SQLDB) GetBuildLifecycleEvents(buildID int) ([]atc.Event, error) {
+	build, _, err := db.GetBuild(buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	table := "build_events"
+	if build.PipelineID != 0 {
+		table = fmt.Sprintf("pipeline_build_events_%d", build.PipelineID)
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT type, version, payload
+		FROM `+table+`
+		WHERE build_id = $1
+		AND type NOT IN ($2, $3)
+		ORDER BY event_id ASC
+	`, buildID, string(event.EventTypeLog), string(event.EventTypeLogTruncated))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := []atc.Event{}
+
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		ev, err := event.ParseEvent(atc.EventVersion(v), atc.EventType(t), []byte(p))
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, ev)
+	}
+
+	return events, rows.Err()
+}
+
 func (db *SQLDB) AbortBuild(buildID int) error {
 	_, err := db.conn.Exec(`
    UPDATE builds
@@ -669,15 +798,17 @@ func (db *SQLDB) saveBuildEvent(tx Tx, buildID int, event atc.Event) error {
 func scanBuild(row scannable) (Build, bool, error) {
 	var id int
 	var name string
-	var jobID, pipelineID sql.NullInt64
+	var jobID, pipelineID, teamID sql.NullInt64
 	var status string
 	var scheduled bool
 	var inputsDetermined bool
+	var scheduleOverridden bool
 	var engine, engineMetadata, jobName, pipelineName sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
+	var rerunOf sql.NullInt64
 
-	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &inputsDetermined, &engine, &engineMetadata, &startTime, &endTime, &jobName, &pipelineID, &pipelineName)
+	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &inputsDetermined, &scheduleOverridden, &engine, &engineMetadata, &startTime, &endTime, &rerunOf, &jobName, &pipelineID, &pipelineName, &teamID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return Build{}, false, nil
@@ -693,11 +824,15 @@ func scanBuild(row scannable) (Build, bool, error) {
 		Scheduled:        scheduled,
 		InputsDetermined: inputsDetermined,
 
+		ScheduleOverridden: scheduleOverridden,
+
 		Engine:         engine.String,
 		EngineMetadata: engineMetadata.String,
 
 		StartTime: startTime.Time,
 		EndTime:   endTime.Time,
+
+		RerunOf: int(rerunOf.Int64),
 	}
 
 	if jobID.Valid {
@@ -705,6 +840,7 @@ func scanBuild(row scannable) (Build, bool, error) {
 		build.JobName = jobName.String
 		build.PipelineName = pipelineName.String
 		build.PipelineID = int(pipelineID.Int64)
+		build.TeamID = int(teamID.Int64)
 	}
 
 	return build, true, nil
@@ -11,7 +11,7 @@ import (
 	"github.com/concourse/atc"
 )
 
-const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user"
+const containerColumns = "worker_name, resource_id, check_type, check_source, build_id, plan_id, stage, handle, b.name as build_name, r.name as resource_name, p.id as pipeline_id, p.name as pipeline_name, p.team_id as team_id, j.name as job_name, step_name, type, working_directory, env_variables, attempts, process_user"
 const containerJoins = `
 		LEFT JOIN pipelines p
 		  ON p.id = c.pipeline_id
@@ -491,6 +491,7 @@ func scanContainer(row scannable) (Container, error) {
 		resourceName     sql.NullString
 		pipelineID       sql.NullInt64
 		pipelineName     sql.NullString
+		teamID           sql.NullInt64
 		jobName          sql.NullString
 		infoType         string
 		envVariablesBlob []byte
@@ -511,6 +512,7 @@ func scanContainer(row scannable) (Container, error) {
 		&resourceName,
 		&pipelineID,
 		&pipelineName,
+		&teamID,
 		&jobName,
 		&container.StepName,
 		&infoType,
@@ -551,6 +553,10 @@ func scanContainer(row scannable) (Container, error) {
 		container.PipelineName = pipelineName.String
 	}
 
+	if teamID.Valid {
+		container.TeamID = int(teamID.Int64)
+	}
+
 	if jobName.Valid {
 		container.JobName = jobName.String
 	}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"io"
 	"sync"
 
 	"github.com/concourse/atc"
@@ -11,6 +12,7 @@ func newSQLDBBuildEventSource(
 	buildID int,
 	table string,
 	conn Conn,
+	store BuildEventStore,
 	notifier Notifier,
 	from uint,
 ) *sqldbBuildEventSource {
@@ -20,7 +22,8 @@ func newSQLDBBuildEventSource(
 		buildID: buildID,
 		table:   table,
 
-		conn: conn,
+		conn:  conn,
+		store: store,
 
 		notifier: notifier,
 
@@ -40,6 +43,7 @@ type sqldbBuildEventSource struct {
 	table   string
 
 	conn     Conn
+	store    BuildEventStore
 	notifier Notifier
 
 	events chan atc.Event
@@ -142,22 +146,31 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 			continue
 		}
 
-		var completed bool
+		var completed, compacted bool
 		var lastEventID uint
 		err = source.conn.QueryRow(`
-			SELECT builds.completed, coalesce(max(`+source.table+`.event_id), 0)
+			SELECT builds.completed, builds.events_compacted, coalesce(max(`+source.table+`.event_id), 0)
 			FROM builds
 			LEFT JOIN `+source.table+`
 			ON `+source.table+`.build_id = builds.id
 			WHERE builds.id = $1
 			GROUP BY builds.id
-		`, source.buildID).Scan(&completed, &lastEventID)
+		`, source.buildID).Scan(&completed, &compacted, &lastEventID)
 		if err != nil {
 			source.err = err
 			close(source.events)
 			return
 		}
 
+		if compacted {
+			// the remaining events have been moved out of the database; this
+			// may have happened partway through streaming, so pick up from
+			// wherever the cursor got to
+			source.err = source.collectCompactedEvents(cursor)
+			close(source.events)
+			return
+		}
+
 		if completed && cursor > lastEventID {
 			source.err = ErrEndOfBuildEventStream
 			close(source.events)
@@ -173,3 +186,48 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+func (source *sqldbBuildEventSource) collectCompactedEvents(cursor uint) error {
+	if source.store == nil {
+		return ErrBuildEventStoreNotConfigured
+	}
+
+	blob, found, err := source.store.Get(source.buildID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrCompactedBuildEventsNotFound
+	}
+
+	reader, err := newCompactedEventReader(blob)
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	var skipped uint
+	for {
+		ev, err := reader.Next()
+		if err == io.EOF {
+			return ErrEndOfBuildEventStream
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if skipped < cursor {
+			skipped++
+			continue
+		}
+
+		select {
+		case source.events <- ev:
+		case <-source.stop:
+			return ErrBuildEventStreamClosed
+		}
+	}
+}
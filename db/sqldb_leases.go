@@ -122,3 +122,87 @@ func (db *SQLDB) LeaseCacheInvalidation(interval time.Duration) (Lease, bool, er
 
 	return lease, true, nil
 }
+
+func (db *SQLDB) LeaseVersionPruning(interval time.Duration) (Lease, bool, error) {
+	lease := &lease{
+		conn: db.conn,
+		logger: db.logger.Session("lease", lager.Data{
+			"lease": "version-pruning",
+		}),
+		attemptSignFunc: func(tx Tx) (sql.Result, error) {
+			_, err := tx.Exec(`
+				INSERT INTO version_pruner (last_pruned)
+				SELECT 'epoch'
+				WHERE NOT EXISTS (SELECT * FROM version_pruner)`)
+			if err != nil {
+				return nil, err
+			}
+			return tx.Exec(`
+				UPDATE version_pruner
+				SET last_pruned = now()
+				WHERE now() - last_pruned > ($1 || ' SECONDS')::INTERVAL
+			`, interval.Seconds())
+		},
+		heartbeatFunc: func(tx Tx) (sql.Result, error) {
+			return tx.Exec(`
+				UPDATE version_pruner
+				SET last_pruned = now()
+			`)
+		},
+	}
+
+	renewed, err := lease.AttemptSign(interval)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !renewed {
+		return nil, renewed, nil
+	}
+
+	lease.KeepSigned(interval)
+
+	return lease, true, nil
+}
+
+func (db *SQLDB) LeaseBuildEventCompaction(interval time.Duration) (Lease, bool, error) {
+	lease := &lease{
+		conn: db.conn,
+		logger: db.logger.Session("lease", lager.Data{
+			"lease": "build-event-compaction",
+		}),
+		attemptSignFunc: func(tx Tx) (sql.Result, error) {
+			_, err := tx.Exec(`
+				INSERT INTO build_event_compactor (last_compacted)
+				SELECT 'epoch'
+				WHERE NOT EXISTS (SELECT * FROM build_event_compactor)`)
+			if err != nil {
+				return nil, err
+			}
+			return tx.Exec(`
+				UPDATE build_event_compactor
+				SET last_compacted = now()
+				WHERE now() - last_compacted > ($1 || ' SECONDS')::INTERVAL
+			`, interval.Seconds())
+		},
+		heartbeatFunc: func(tx Tx) (sql.Result, error) {
+			return tx.Exec(`
+				UPDATE build_event_compactor
+				SET last_compacted = now()
+			`)
+		},
+	}
+
+	renewed, err := lease.AttemptSign(interval)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !renewed {
+		return nil, renewed, nil
+	}
+
+	lease.KeepSigned(interval)
+
+	return lease, true, nil
+}
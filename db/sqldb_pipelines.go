@@ -275,7 +275,14 @@ func (db *SQLDB) SaveConfig(
 	}
 
 	for _, resource := range config.Resources {
-		err = db.registerResource(tx, resource.Name, savedPipeline.ID)
+		err = db.registerResource(tx, resource.Name, ResourceConfigHash(resource, config.ResourceTypes), savedPipeline.ID)
+		if err != nil {
+			return SavedPipeline{}, false, err
+		}
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		err = db.registerResourceType(tx, resourceType.Name, resourceTypeConfigHash(resourceType, config.ResourceTypes), savedPipeline.ID)
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -303,16 +310,61 @@ func (db *SQLDB) registerJob(tx Tx, name string, pipelineID int) error {
 	return swallowUniqueViolation(err)
 }
 
-func (db *SQLDB) registerResource(tx Tx, name string, pipelineID int) error {
+func (db *SQLDB) registerResource(tx Tx, name string, configHash string, pipelineID int) error {
 	_, err := tx.Exec(`
-		INSERT INTO resources (name, pipeline_id)
-		SELECT $1, $2
+		INSERT INTO resources (name, pipeline_id, config_hash)
+		SELECT $1, $2, $3
 		WHERE NOT EXISTS (
 			SELECT 1 FROM resources WHERE name = $1 AND pipeline_id = $2
 		)
-	`, name, pipelineID)
+	`, name, pipelineID, configHash)
 
-	return swallowUniqueViolation(err)
+	err = swallowUniqueViolation(err)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE resources
+		SET config_hash = $3
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, name, pipelineID, configHash)
+
+	return err
+}
+
+func (db *SQLDB) registerResourceType(tx Tx, name string, configHash string, pipelineID int) error {
+	_, err := tx.Exec(`
+		INSERT INTO resource_types (name, pipeline_id, config_hash)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (
+			SELECT 1 FROM resource_types WHERE name = $1 AND pipeline_id = $2
+		)
+	`, name, pipelineID, configHash)
+
+	err = swallowUniqueViolation(err)
+	if err != nil {
+		return err
+	}
+
+	// versions checked with a different configuration no longer apply
+	_, err = tx.Exec(`
+		UPDATE resource_types
+		SET config_hash = $3, version = NULL, last_checked = 'epoch'
+		WHERE name = $1
+			AND pipeline_id = $2
+			AND config_hash != $3
+	`, name, pipelineID, configHash)
+
+	return err
+}
+
+func resourceTypeConfigHash(resourceType atc.ResourceType, resourceTypes atc.ResourceTypes) string {
+	return ResourceConfigHash(atc.ResourceConfig{
+		Type:   resourceType.Type,
+		Source: resourceType.Source,
+	}, resourceTypes.Without(resourceType.Name))
 }
 
 func scanPipeline(rows scannable) (SavedPipeline, error) {
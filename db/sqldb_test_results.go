@@ -0,0 +1,82 @@
+package db
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+func (db *SQLDB) SaveBuildTestResults(buildID int, planID atc.PlanID, results atc.TestResults) error {
+	failedTests, err := json.Marshal(results.FailedTests)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.conn.Exec(`
+		UPDATE build_test_results
+		SET passed = $3, failed = $4, skipped = $5, failed_tests = $6
+		WHERE build_id = $1 AND plan_id = $2
+	`, buildID, string(planID), results.Passed, results.Failed, results.Skipped, failedTests)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err := db.conn.Exec(`
+			INSERT INTO build_test_results (build_id, plan_id, passed, failed, skipped, failed_tests)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, buildID, string(planID), results.Passed, results.Failed, results.Skipped, failedTests)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBuildTestResults combines the results of the test reports of all of the
+// build's steps. It returns false if none of them had any reports.
+func (db *SQLDB) GetBuildTestResults(buildID int) (atc.TestResults, bool, error) {
+	rows, err := db.conn.Query(`
+		SELECT passed, failed, skipped, failed_tests
+		FROM build_test_results
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, buildID)
+	if err != nil {
+		return atc.TestResults{}, false, err
+	}
+
+	defer rows.Close()
+
+	results := atc.TestResults{
+		FailedTests: []string{},
+	}
+
+	found := false
+
+	for rows.Next() {
+		var stepResults atc.TestResults
+		var failedTests []byte
+
+		err := rows.Scan(&stepResults.Passed, &stepResults.Failed, &stepResults.Skipped, &failedTests)
+		if err != nil {
+			return atc.TestResults{}, false, err
+		}
+
+		err = json.Unmarshal(failedTests, &stepResults.FailedTests)
+		if err != nil {
+			return atc.TestResults{}, false, err
+		}
+
+		results = results.Add(stepResults)
+		found = true
+	}
+
+	return results, found, nil
+}
@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, active_containers, resource_types, platform, tags, name"
+var workerColumns = "EXTRACT(epoch FROM expires - NOW()), EXTRACT(epoch FROM unhealthy_until - NOW()), addr, baggageclaim_url, active_containers, max_containers, resource_types, platform, tags, name, team_id, (SELECT t.name FROM teams t WHERE t.id = team_id)"
 
 func (db *SQLDB) Workers() ([]SavedWorker, error) {
 	// reap expired workers
@@ -82,21 +82,26 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 		return SavedWorker{}, err
 	}
 
+	var teamID sql.NullInt64
+	if info.TeamID != 0 {
+		teamID = sql.NullInt64{Int64: int64(info.TeamID), Valid: true}
+	}
+
 	if ttl == 0 {
 		row := db.conn.QueryRow(`
 			UPDATE workers
-			SET addr = $1, expires = NULL, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, name = $7
+			SET addr = $1, expires = NULL, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, name = $7, team_id = $8, max_containers = $9
 			WHERE name = $7 OR addr = $1
 			RETURNING  `+workerColumns,
-			info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name)
+			info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name, teamID, info.MaxContainers)
 
 		savedWorker, err = scanWorker(row)
 		if err == sql.ErrNoRows {
 			row = db.conn.QueryRow(`
-				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, name)
-				VALUES ($1, NULL, $2, $3, $4, $5, $6, $7)
+				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, name, team_id, max_containers)
+				VALUES ($1, NULL, $2, $3, $4, $5, $6, $7, $8, $9)
 				RETURNING `+workerColumns,
-				info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name)
+				info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name, teamID, info.MaxContainers)
 			savedWorker, err = scanWorker(row)
 		}
 		if err != nil {
@@ -107,18 +112,18 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 
 		row := db.conn.QueryRow(`
 			UPDATE workers
-			SET addr = $1, expires = NOW() + $2::INTERVAL, active_containers = $3, resource_types = $4, platform = $5, tags = $6, baggageclaim_url = $7, name = $8
+			SET addr = $1, expires = NOW() + $2::INTERVAL, active_containers = $3, resource_types = $4, platform = $5, tags = $6, baggageclaim_url = $7, name = $8, team_id = $9, max_containers = $10
 			WHERE name = $8 OR addr = $1
 			RETURNING `+workerColumns,
-			info.GardenAddr, interval, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name)
+			info.GardenAddr, interval, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name, teamID, info.MaxContainers)
 
 		savedWorker, err = scanWorker(row)
 		if err == sql.ErrNoRows {
 			row := db.conn.QueryRow(`
-				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, name)
-				VALUES ($1, NOW() + $2::INTERVAL, $3, $4, $5, $6, $7, $8)
+				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, name, team_id, max_containers)
+				VALUES ($1, NOW() + $2::INTERVAL, $3, $4, $5, $6, $7, $8, $9, $10)
 				RETURNING `+workerColumns,
-				info.GardenAddr, interval, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name)
+				info.GardenAddr, interval, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.Name, teamID, info.MaxContainers)
 			savedWorker, err = scanWorker(row)
 		}
 		if err != nil {
@@ -129,22 +134,46 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 	return savedWorker, nil
 }
 
+func (db *SQLDB) MarkWorkerUnhealthy(name string, coolDown time.Duration) error {
+	interval := fmt.Sprintf("%d second", int(coolDown.Seconds()))
+
+	_, err := db.conn.Exec(`
+		UPDATE workers
+		SET unhealthy_until = NOW() + $2::INTERVAL
+		WHERE name = $1
+	`, name, interval)
+
+	return err
+}
+
 func scanWorker(row scannable) (SavedWorker, error) {
 	info := SavedWorker{}
 
 	var ttlSeconds *float64
+	var unhealthySeconds *float64
 	var resourceTypes []byte
 	var tags []byte
+	var teamID sql.NullInt64
+	var teamName sql.NullString
 
-	err := row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name)
+	err := row.Scan(&ttlSeconds, &unhealthySeconds, &info.GardenAddr, &info.BaggageclaimURL, &info.ActiveContainers, &info.MaxContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &teamID, &teamName)
 	if err != nil {
 		return SavedWorker{}, err
 	}
 
+	if teamID.Valid {
+		info.TeamID = int(teamID.Int64)
+		info.TeamName = teamName.String
+	}
+
 	if ttlSeconds != nil {
 		info.ExpiresIn = time.Duration(*ttlSeconds) * time.Second
 	}
 
+	if unhealthySeconds != nil && *unhealthySeconds > 0 {
+		info.UnhealthyFor = time.Duration(*unhealthySeconds) * time.Second
+	}
+
 	err = json.Unmarshal(resourceTypes, &info.ResourceTypes)
 	if err != nil {
 		return SavedWorker{}, err
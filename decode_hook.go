@@ -29,6 +29,35 @@ var SanitizeDecodeHook = func(
 	return data, nil
 }
 
+// VersionConfigDecodeHook allows a get step's version to be configured as
+// either a string ('latest' or 'every') or as a version to pin to.
+var VersionConfigDecodeHook = func(
+	dataType reflect.Type,
+	valType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if valType != reflect.TypeOf(VersionConfig{}) {
+		return data, nil
+	}
+
+	switch dataType.Kind() {
+	case reflect.String:
+		switch data.(string) {
+		case VersionLatest:
+			return map[string]interface{}{"latest": true}, nil
+		case VersionEvery:
+			return map[string]interface{}{"every": true}, nil
+		default:
+			return nil, ErrInvalidVersionConfig
+		}
+
+	case reflect.Map:
+		return map[string]interface{}{"pinned": data}, nil
+	}
+
+	return data, nil
+}
+
 func sanitize(root interface{}) (interface{}, error) {
 	switch rootVal := root.(type) {
 	case map[interface{}]interface{}:
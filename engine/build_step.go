@@ -4,6 +4,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
@@ -105,17 +106,46 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		"task",
 	)
 
-	return build.factory.Task(
-		logger,
-		exec.SourceName(plan.Task.Name),
-		workerID,
-		workerMetadata,
-		build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
-		exec.Privileged(plan.Task.Privileged),
-		plan.Task.Tags,
-		configSource,
-		plan.Task.ResourceTypes,
-	)
+	var step exec.StepFactory
+	if build.reuses(plan.ID) {
+		step = build.factory.ReusedTask(
+			logger,
+			exec.SourceName(plan.Task.Name),
+			worker.Identifier{
+				BuildID: build.metadata.RerunOf,
+				PlanID:  plan.ID,
+			},
+			workerID,
+			workerMetadata,
+			build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
+			exec.Privileged(plan.Task.Privileged),
+			plan.Task.Tags,
+			configSource,
+			plan.Task.ResourceTypes,
+		)
+	} else {
+		step = build.factory.Task(
+			logger,
+			exec.SourceName(plan.Task.Name),
+			workerID,
+			workerMetadata,
+			build.delegate.ExecutionDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
+			exec.Privileged(plan.Task.Privileged),
+			plan.Task.Tags,
+			configSource,
+			plan.Task.ResourceTypes,
+		)
+	}
+
+	if len(plan.Task.Reports) > 0 {
+		// failing tests usually fail the task, so parse its reports regardless
+		step = exec.Ensure(step, exec.Report(
+			plan.Task.Reports,
+			build.delegate.ReportDelegate(logger, *plan.Task, event.OriginID(plan.ID)),
+		))
+	}
+
+	return step
 }
 
 func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
@@ -165,6 +195,13 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		"put",
 	)
 
+	if build.reuses(plan.ID) {
+		return exec.RecordedPut(
+			build.delegate.OutputDelegate(logger, *plan.Put, event.OriginID(plan.ID)),
+			build.metadata.RecordedVersions[plan.ID],
+		)
+	}
+
 	return build.factory.Put(
 		logger,
 		build.stepMetadata,
@@ -197,6 +234,28 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		"get",
 	)
 
+	if build.reuses(plan.ID) {
+		// fetch the version that the original build's put produced, rather
+		// than whatever the put reports now
+		return build.factory.Get(
+			logger,
+			build.stepMetadata,
+			exec.SourceName(getPlan.Name),
+			workerID,
+			workerMetadata,
+			build.delegate.InputDelegate(logger, getPlan, event.OriginID(plan.ID)),
+			atc.ResourceConfig{
+				Name:   getPlan.Resource,
+				Type:   getPlan.Type,
+				Source: getPlan.Source,
+			},
+			getPlan.Tags,
+			getPlan.Params,
+			build.metadata.RecordedVersions[plan.ID].Version,
+			getPlan.ResourceTypes,
+		)
+	}
+
 	return build.factory.DependentGet(
 		logger,
 		build.stepMetadata,
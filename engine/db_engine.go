@@ -64,6 +64,27 @@ func (engine *dbEngine) CreateBuild(logger lager.Logger, build db.Build, plan at
 		return nil, err
 	}
 
+	return engine.startBuild(logger, build, buildEngine, createdBuild)
+}
+
+// RerunBuild re-runs the original build with the engine that ran it, since
+// only that engine understands its metadata.
+func (engine *dbEngine) RerunBuild(logger lager.Logger, build db.Build, original db.Build, from atc.PlanID) (Build, error) {
+	buildEngine, found := engine.engines.Lookup(original.Engine)
+	if !found {
+		logger.Error("unknown-engine", nil, lager.Data{"engine": original.Engine})
+		return nil, UnknownEngineError{original.Engine}
+	}
+
+	createdBuild, err := buildEngine.RerunBuild(logger, build, original, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.startBuild(logger, build, buildEngine, createdBuild)
+}
+
+func (engine *dbEngine) startBuild(logger lager.Logger, build db.Build, buildEngine Engine, createdBuild Build) (Build, error) {
 	started, err := engine.db.StartBuild(build.ID, buildEngine.Name(), createdBuild.Metadata())
 	if err != nil {
 		return nil, err
@@ -139,6 +139,98 @@ var _ = Describe("DBEngine", func() {
 		})
 	})
 
+	Describe("RerunBuild", func() {
+		var (
+			build    db.Build
+			original db.Build
+
+			rerunBuild Build
+			rerunErr   error
+		)
+
+		BeforeEach(func() {
+			build = db.Build{
+				ID:      129,
+				Name:    "some-rerun",
+				RerunOf: 128,
+			}
+
+			original = db.Build{
+				ID:             128,
+				Name:           "some-build",
+				Engine:         "fake-engine-b",
+				EngineMetadata: "some-original-metadata",
+			}
+
+			fakeBuildDB.StartBuildReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			rerunBuild, rerunErr = dbEngine.RerunBuild(logger, build, original, "some-plan-id")
+		})
+
+		Context("when the original build's engine re-runs it", func() {
+			var fakeBuild *fakes.FakeBuild
+
+			BeforeEach(func() {
+				fakeBuild = new(fakes.FakeBuild)
+				fakeBuild.MetadataReturns("some-metadata")
+
+				fakeEngineB.RerunBuildReturns(fakeBuild, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+				Expect(rerunBuild).NotTo(BeNil())
+			})
+
+			It("re-runs the build with the original build's engine", func() {
+				Expect(fakeEngineA.RerunBuildCallCount()).To(BeZero())
+				Expect(fakeEngineB.RerunBuildCallCount()).To(Equal(1))
+
+				_, rerunModel, rerunOriginal, from := fakeEngineB.RerunBuildArgsForCall(0)
+				Expect(rerunModel).To(Equal(build))
+				Expect(rerunOriginal).To(Equal(original))
+				Expect(from).To(Equal(atc.PlanID("some-plan-id")))
+			})
+
+			It("starts the build in the database", func() {
+				Expect(fakeBuildDB.StartBuildCallCount()).To(Equal(1))
+
+				buildID, engine, metadata := fakeBuildDB.StartBuildArgsForCall(0)
+				Expect(buildID).To(Equal(129))
+				Expect(engine).To(Equal("fake-engine-b"))
+				Expect(metadata).To(Equal("some-metadata"))
+			})
+		})
+
+		Context("when the engine fails to re-run it", func() {
+			disaster := errors.New("failed")
+
+			BeforeEach(func() {
+				fakeEngineB.RerunBuildReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(rerunErr).To(Equal(disaster))
+			})
+
+			It("does not start the build", func() {
+				Expect(fakeBuildDB.StartBuildCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the original build's engine is unknown", func() {
+			BeforeEach(func() {
+				original.Engine = "bogus"
+			})
+
+			It("returns an UnknownEngineError", func() {
+				Expect(rerunErr).To(Equal(UnknownEngineError{"bogus"}))
+			})
+		})
+	})
+
 	Describe("LookupBuild", func() {
 		var (
 			build db.Build
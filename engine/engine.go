@@ -13,12 +13,17 @@ type Engine interface {
 
 	CreateBuild(lager.Logger, db.Build, atc.Plan) (Build, error)
 	LookupBuild(lager.Logger, db.Build) (Build, error)
+
+	// RerunBuild creates a build that runs the plan of the original build
+	// again, reusing the results of the steps that ran before the given step.
+	RerunBuild(logger lager.Logger, build db.Build, original db.Build, from atc.PlanID) (Build, error)
 }
 
 //go:generate counterfeiter . EngineDB
 
 type EngineDB interface {
 	SaveBuildEvent(buildID int, event atc.Event) error
+	GetBuildEvents(buildID int, from uint) (db.EventSource, error)
 
 	FinishBuild(buildID int, status db.Status) error
 
@@ -28,6 +33,8 @@ type EngineDB interface {
 	SaveBuildOutput(teamName string, buildID int, vr db.VersionedResource, explicit bool) (db.SavedVersionedResource, error)
 
 	SaveImageResourceVersion(buildID int, planID atc.PlanID, identifier db.VolumeIdentifier) error
+
+	SaveBuildTestResults(buildID int, planID atc.PlanID, results atc.TestResults) error
 }
 
 //go:generate counterfeiter . Build
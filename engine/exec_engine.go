@@ -9,6 +9,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 	"github.com/pivotal-golang/lager"
@@ -17,6 +18,15 @@ import (
 
 type execMetadata struct {
 	Plan atc.Plan
+
+	// RerunOf is the build whose task results are reused for the steps in
+	// ReusedSteps.
+	RerunOf     int          `json:",omitempty"`
+	ReusedSteps []atc.PlanID `json:",omitempty"`
+
+	// RecordedVersions are the versions that the puts and dependent gets in
+	// ReusedSteps produced or fetched in the original build.
+	RecordedVersions map[atc.PlanID]exec.VersionInfo `json:",omitempty"`
 }
 
 const execEngineName = "exec.v2"
@@ -44,6 +54,7 @@ func (engine *execEngine) Name() string {
 func (engine *execEngine) CreateBuild(logger lager.Logger, model db.Build, plan atc.Plan) (Build, error) {
 	return &execBuild{
 		buildID:      model.ID,
+		teamID:       model.TeamID,
 		stepMetadata: buildMetadata(model, engine.externalURL),
 
 		db:       engine.db,
@@ -57,6 +68,51 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, model db.Build, plan
 	}, nil
 }
 
+// RerunBuild runs the original build's plan again. Tasks that ran before the
+// given step recover their results from the original build's containers, if
+// they have not yet expired. Puts before it are not run again; they report
+// the versions they produced in the original build, and dependent gets fetch
+// the versions they fetched back then. Gets run again, but their versions are
+// pinned in the plan, so they are normally found in the cache. Everything
+// else, from the given step on, is executed as usual.
+func (engine *execEngine) RerunBuild(logger lager.Logger, model db.Build, original db.Build, from atc.PlanID) (Build, error) {
+	var originalMetadata execMetadata
+	err := json.Unmarshal([]byte(original.EngineMetadata), &originalMetadata)
+	if err != nil {
+		logger.Error("invalid-metadata", err)
+		return nil, err
+	}
+
+	reusedSteps, found := stepsBefore(originalMetadata.Plan, from)
+	if !found {
+		return nil, UnknownPlanIDError{from}
+	}
+
+	versions, err := recordedVersions(engine.db, original.ID, versionedSteps(originalMetadata.Plan, reusedSteps))
+	if err != nil {
+		logger.Error("failed-to-get-recorded-versions", err)
+		return nil, err
+	}
+
+	return &execBuild{
+		buildID:      model.ID,
+		teamID:       model.TeamID,
+		stepMetadata: buildMetadata(model, engine.externalURL),
+
+		db:       engine.db,
+		factory:  engine.factory,
+		delegate: engine.delegateFactory.Delegate(model.ID),
+		metadata: execMetadata{
+			Plan:             originalMetadata.Plan,
+			RerunOf:          original.ID,
+			ReusedSteps:      reusedSteps,
+			RecordedVersions: versions,
+		},
+
+		signals: make(chan os.Signal, 1),
+	}, nil
+}
+
 func (engine *execEngine) LookupBuild(logger lager.Logger, model db.Build) (Build, error) {
 	var metadata execMetadata
 	err := json.Unmarshal([]byte(model.EngineMetadata), &metadata)
@@ -67,6 +123,7 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, model db.Build) (Buil
 
 	return &execBuild{
 		buildID:      model.ID,
+		teamID:       model.TeamID,
 		stepMetadata: buildMetadata(model, engine.externalURL),
 
 		db:       engine.db,
@@ -90,6 +147,7 @@ func buildMetadata(model db.Build, externalURL string) StepMetadata {
 
 type execBuild struct {
 	buildID      int
+	teamID       int
 	stepMetadata StepMetadata
 
 	db EngineDB
@@ -161,56 +219,62 @@ func (build *execBuild) Resume(logger lager.Logger) {
 
 func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	if plan.Aggregate != nil {
-		return build.buildAggregateStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeAggregate, build.buildAggregateStep(logger, plan))
 	}
 
 	if plan.Do != nil {
-		return build.buildDoStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeDo, build.buildDoStep(logger, plan))
 	}
 
 	if plan.Timeout != nil {
-		return build.buildTimeoutStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeTimeout, build.buildTimeoutStep(logger, plan))
 	}
 
 	if plan.Try != nil {
-		return build.buildTryStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeTry, build.buildTryStep(logger, plan))
 	}
 
 	if plan.OnSuccess != nil {
-		return build.buildOnSuccessStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeOnSuccess, build.buildOnSuccessStep(logger, plan))
 	}
 
 	if plan.OnFailure != nil {
-		return build.buildOnFailureStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeOnFailure, build.buildOnFailureStep(logger, plan))
 	}
 
 	if plan.Ensure != nil {
-		return build.buildEnsureStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeEnsure, build.buildEnsureStep(logger, plan))
 	}
 
 	if plan.Task != nil {
-		return build.buildTaskStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeTask, build.buildTaskStep(logger, plan))
 	}
 
 	if plan.Get != nil {
-		return build.buildGetStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeGet, build.buildGetStep(logger, plan))
 	}
 
 	if plan.Put != nil {
-		return build.buildPutStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypePut, build.buildPutStep(logger, plan))
 	}
 
 	if plan.DependentGet != nil {
-		return build.buildDependentGetStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeGet, build.buildDependentGetStep(logger, plan))
 	}
 
 	if plan.Retry != nil {
-		return build.buildRetryStep(logger, plan)
+		return build.lifecycle(logger, plan, event.StepTypeRetry, build.buildRetryStep(logger, plan))
 	}
 
 	return exec.Identity{}
 }
 
+// lifecycle wraps the step so that it emits start and finish events, giving
+// every node of the plan a consistent timing regardless of its type.
+func (build *execBuild) lifecycle(logger lager.Logger, plan atc.Plan, stepType event.StepType, step exec.StepFactory) exec.StepFactory {
+	return exec.Lifecycle(step, build.delegate.LifecycleDelegate(logger, stepType, plan.Attempts, event.OriginID(plan.ID)))
+}
+
 func (build *execBuild) stepIdentifier(
 	logger lager.Logger,
 	stepName string,
@@ -232,6 +296,7 @@ func (build *execBuild) stepIdentifier(
 			StepName:     stepName,
 			Type:         stepType,
 			PipelineName: pipelineName,
+			TeamID:       build.teamID,
 			Attempts:     attempts,
 		}
 }
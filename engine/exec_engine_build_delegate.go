@@ -24,6 +24,8 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	ReportDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.ReportDelegate
+	LifecycleDelegate(lager.Logger, event.StepType, []int, event.OriginID) exec.LifecycleDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -35,15 +37,16 @@ type BuildDelegateFactory interface {
 }
 
 type buildDelegateFactory struct {
-	db EngineDB
+	db        EngineDB
+	logLimits LogLimits
 }
 
-func NewBuildDelegateFactory(db EngineDB) BuildDelegateFactory {
-	return buildDelegateFactory{db}
+func NewBuildDelegateFactory(db EngineDB, logLimits LogLimits) BuildDelegateFactory {
+	return buildDelegateFactory{db, logLimits}
 }
 
 func (factory buildDelegateFactory) Delegate(buildID int) BuildDelegate {
-	return newBuildDelegate(factory.db, buildID)
+	return newBuildDelegate(factory.db, buildID, factory.logLimits)
 }
 
 type delegate struct {
@@ -53,16 +56,20 @@ type delegate struct {
 
 	implicitOutputs map[string]implicitOutput
 
+	logs *logLimiter
+
 	lock sync.Mutex
 }
 
-func newBuildDelegate(db EngineDB, buildID int) BuildDelegate {
+func newBuildDelegate(db EngineDB, buildID int, logLimits LogLimits) BuildDelegate {
 	return &delegate{
 		db: db,
 
 		buildID: buildID,
 
 		implicitOutputs: make(map[string]implicitOutput),
+
+		logs: newLogLimiter(logLimits),
 	}
 }
 
@@ -96,6 +103,26 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) ReportDelegate(logger lager.Logger, plan atc.TaskPlan, id event.OriginID) exec.ReportDelegate {
+	return &reportDelegate{
+		logger: logger,
+
+		id:       id,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) LifecycleDelegate(logger lager.Logger, stepType event.StepType, attempts []int, id event.OriginID) exec.LifecycleDelegate {
+	return &lifecycleDelegate{
+		logger: logger,
+
+		stepType: stepType,
+		attempts: attempts,
+		id:       id,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -136,6 +163,7 @@ func (delegate *delegate) unregisterImplicitOutput(resource string) {
 
 func (delegate *delegate) saveInitializeTask(logger lager.Logger, taskConfig atc.TaskConfig, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.InitializeTask{
+		Time:       time.Now().Unix(),
 		TaskConfig: event.ShadowTaskConfig(taskConfig),
 		Origin:     origin,
 	})
@@ -146,6 +174,7 @@ func (delegate *delegate) saveInitializeTask(logger lager.Logger, taskConfig atc
 
 func (delegate *delegate) saveInitializeGet(logger lager.Logger, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.InitializeGet{
+		Time:   time.Now().Unix(),
 		Origin: origin,
 	})
 	if err != nil {
@@ -153,8 +182,20 @@ func (delegate *delegate) saveInitializeGet(logger lager.Logger, origin event.Or
 	}
 }
 
+func (delegate *delegate) saveStartGet(logger lager.Logger, workerName string, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, event.StartGet{
+		Time:   time.Now().Unix(),
+		Worker: workerName,
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-event", err)
+	}
+}
+
 func (delegate *delegate) saveInitializePut(logger lager.Logger, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.InitializePut{
+		Time:   time.Now().Unix(),
 		Origin: origin,
 	})
 	if err != nil {
@@ -162,9 +203,21 @@ func (delegate *delegate) saveInitializePut(logger lager.Logger, origin event.Or
 	}
 }
 
-func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
+func (delegate *delegate) saveStartPut(logger lager.Logger, workerName string, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, event.StartPut{
+		Time:   time.Now().Unix(),
+		Worker: workerName,
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-event", err)
+	}
+}
+
+func (delegate *delegate) saveStart(logger lager.Logger, workerName string, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.StartTask{
 		Time:   time.Now().Unix(),
+		Worker: workerName,
 		Origin: origin,
 	})
 	if err != nil {
@@ -183,6 +236,31 @@ func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveStartStep(logger lager.Logger, stepType event.StepType, attempts []int, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, event.StartStep{
+		Time:     time.Now().Unix(),
+		Type:     stepType,
+		Attempts: attempts,
+		Origin:   origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-step-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishStep(logger lager.Logger, stepType event.StepType, attempts []int, succeeded exec.Success, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, event.FinishStep{
+		Time:      time.Now().Unix(),
+		Type:      stepType,
+		Attempts:  attempts,
+		Succeeded: bool(succeeded),
+		Origin:    origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-step-event", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.db.FinishBuild(delegate.buildID, db.Status(status))
 	if err != nil {
@@ -218,6 +296,7 @@ func (delegate *delegate) saveInput(logger lager.Logger, status exec.ExitStatus,
 	}
 
 	ev := event.FinishGet{
+		Time:   time.Now().Unix(),
 		Origin: origin,
 		Plan: event.GetPlan{
 			Name:     plan.Name,
@@ -246,6 +325,7 @@ func (delegate *delegate) saveOutput(logger lager.Logger, status exec.ExitStatus
 	}
 
 	ev := event.FinishPut{
+		Time:   time.Now().Unix(),
 		Origin: origin,
 		Plan: event.PutPlan{
 			Name:     plan.Name,
@@ -302,6 +382,7 @@ func (delegate *delegate) eventWriter(origin event.Origin) io.Writer {
 	return &dbEventWriter{
 		db:      delegate.db,
 		buildID: delegate.buildID,
+		logs:    delegate.logs,
 		origin:  origin,
 	}
 }
@@ -318,6 +399,12 @@ func (input *inputDelegate) Initializing() {
 	input.delegate.saveInitializeGet(input.logger, event.Origin{ID: input.id})
 }
 
+func (input *inputDelegate) Started(workerName string) {
+	input.delegate.saveStartGet(input.logger, workerName, event.Origin{ID: input.id})
+
+	input.logger.Info("started", lager.Data{"worker": workerName})
+}
+
 func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
 	input.delegate.saveInput(input.logger, status, input.plan, info, event.Origin{
 		ID: input.id,
@@ -370,6 +457,12 @@ func (output *outputDelegate) Initializing() {
 	output.delegate.saveInitializePut(output.logger, event.Origin{ID: output.id})
 }
 
+func (output *outputDelegate) Started(workerName string) {
+	output.delegate.saveStartPut(output.logger, workerName, event.Origin{ID: output.id})
+
+	output.logger.Info("started", lager.Data{"worker": workerName})
+}
+
 func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
 	output.delegate.unregisterImplicitOutput(output.plan.Resource)
 	output.delegate.saveOutput(output.logger, status, output.plan, info, event.Origin{
@@ -424,12 +517,12 @@ func (execution *executionDelegate) Initializing(config atc.TaskConfig) {
 	execution.logger.Info("initializing")
 }
 
-func (execution *executionDelegate) Started() {
-	execution.delegate.saveStart(execution.logger, event.Origin{
+func (execution *executionDelegate) Started(workerName string) {
+	execution.delegate.saveStart(execution.logger, workerName, event.Origin{
 		ID: execution.id,
 	})
 
-	execution.logger.Info("started")
+	execution.logger.Info("started", lager.Data{"worker": workerName})
 }
 
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
@@ -465,9 +558,65 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type reportDelegate struct {
+	logger lager.Logger
+
+	id event.OriginID
+
+	delegate *delegate
+}
+
+func (report *reportDelegate) Reported(results atc.TestResults) {
+	err := report.delegate.db.SaveBuildTestResults(report.delegate.buildID, atc.PlanID(report.id), results)
+	if err != nil {
+		report.logger.Error("failed-to-save-test-results", err)
+		return
+	}
+
+	report.logger.Info("reported", lager.Data{
+		"passed":  results.Passed,
+		"failed":  results.Failed,
+		"skipped": results.Skipped,
+	})
+}
+
+func (report *reportDelegate) Stderr() io.Writer {
+	return report.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStderr,
+		ID:     report.id,
+	})
+}
+
+type lifecycleDelegate struct {
+	logger lager.Logger
+
+	stepType event.StepType
+	attempts []int
+	id       event.OriginID
+
+	delegate *delegate
+}
+
+func (lifecycle *lifecycleDelegate) Started() {
+	lifecycle.delegate.saveStartStep(lifecycle.logger, lifecycle.stepType, lifecycle.attempts, event.Origin{
+		ID: lifecycle.id,
+	})
+}
+
+func (lifecycle *lifecycleDelegate) ForcedFailure() bool {
+	return lifecycle.delegate.logs.limits.FailStepOnOverflow && lifecycle.delegate.logs.truncated(lifecycle.id)
+}
+
+func (lifecycle *lifecycleDelegate) Finished(succeeded exec.Success) {
+	lifecycle.delegate.saveFinishStep(lifecycle.logger, lifecycle.stepType, lifecycle.attempts, succeeded, event.Origin{
+		ID: lifecycle.id,
+	})
+}
+
 type dbEventWriter struct {
 	buildID int
 	db      EngineDB
+	logs    *logLimiter
 
 	origin event.Origin
 
@@ -485,10 +634,24 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 
 	writer.dangling = nil
 
-	writer.db.SaveBuildEvent(writer.buildID, event.Log{
-		Payload: string(text),
-		Origin:  writer.origin,
-	})
+	admitted, scope, limit := writer.logs.admit(writer.origin.ID, text)
+
+	if len(admitted) > 0 {
+		writer.db.SaveBuildEvent(writer.buildID, event.Log{
+			Time:    time.Now().Unix(),
+			Payload: string(admitted),
+			Origin:  writer.origin,
+		})
+	}
+
+	if scope != "" {
+		writer.db.SaveBuildEvent(writer.buildID, event.LogTruncated{
+			Time:   time.Now().Unix(),
+			Origin: writer.origin,
+			Scope:  scope,
+			Limit:  limit,
+		})
+	}
 
 	return len(data), nil
 }
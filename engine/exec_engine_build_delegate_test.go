@@ -15,6 +15,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("BuildDelegate", func() {
@@ -33,7 +34,7 @@ var _ = Describe("BuildDelegate", func() {
 
 	BeforeEach(func() {
 		fakeDB = new(fakes.FakeEngineDB)
-		factory = NewBuildDelegateFactory(fakeDB)
+		factory = NewBuildDelegateFactory(fakeDB, LogLimits{})
 
 		buildID = 42
 		delegate = factory.Delegate(buildID)
@@ -74,7 +75,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.InitializeGet{}))
+				Expect(savedEvent.(event.InitializeGet).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.InitializeGet{
+					Time: savedEvent.(event.InitializeGet).Time,
 					Origin: event.Origin{
 						ID: originID,
 					},
@@ -82,6 +86,25 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Started", func() {
+			JustBeforeEach(func() {
+				inputDelegate.Started("some-worker")
+			})
+
+			It("saves a start event with the worker", func() {
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.StartGet{}))
+				Expect(savedEvent.(event.StartGet).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.StartGet).Worker).To(Equal("some-worker"))
+				Expect(savedEvent.(event.StartGet).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
 		Describe("Completed", func() {
 			var versionInfo *exec.VersionInfo
 
@@ -106,7 +129,10 @@ var _ = Describe("BuildDelegate", func() {
 
 					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 					Expect(buildID).To(Equal(42))
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishGet{}))
+					Expect(savedEvent.(event.FinishGet).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 					Expect(savedEvent).To(Equal(event.FinishGet{
+						Time: savedEvent.(event.FinishGet).Time,
 						Origin: event.Origin{
 							ID: originID,
 						},
@@ -135,7 +161,10 @@ var _ = Describe("BuildDelegate", func() {
 
 					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 					Expect(buildID).To(Equal(42))
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishGet{}))
+					Expect(savedEvent.(event.FinishGet).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 					Expect(savedEvent).To(Equal(event.FinishGet{
+						Time: savedEvent.(event.FinishGet).Time,
 						Origin: event.Origin{
 							ID: originID,
 						},
@@ -172,7 +201,10 @@ var _ = Describe("BuildDelegate", func() {
 
 					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 					Expect(buildID).To(Equal(42))
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishGet{}))
+					Expect(savedEvent.(event.FinishGet).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 					Expect(savedEvent).To(Equal(event.FinishGet{
+						Time: savedEvent.(event.FinishGet).Time,
 						Origin: event.Origin{
 							ID: originID,
 						},
@@ -276,7 +308,10 @@ var _ = Describe("BuildDelegate", func() {
 
 						buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 						Expect(buildID).To(Equal(42))
+						Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishGet{}))
+						Expect(savedEvent.(event.FinishGet).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 						Expect(savedEvent).To(Equal(event.FinishGet{
+							Time: savedEvent.(event.FinishGet).Time,
 							Origin: event.Origin{
 								ID: originID,
 							},
@@ -488,7 +523,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
 					Origin: event.Origin{
 						Source: event.OriginSourceStdout,
 						ID:     originID,
@@ -514,7 +552,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
 					Origin: event.Origin{
 						Source: event.OriginSourceStderr,
 						ID:     originID,
@@ -562,7 +603,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.InitializeTask{}))
+				Expect(savedEvent.(event.InitializeTask).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.InitializeTask{
+					Time: savedEvent.(event.InitializeTask).Time,
 					TaskConfig: event.TaskConfig{
 						Run: event.TaskRunConfig{
 							Path: "ls",
@@ -578,7 +622,7 @@ var _ = Describe("BuildDelegate", func() {
 
 		Describe("Started", func() {
 			JustBeforeEach(func() {
-				executionDelegate.Started()
+				executionDelegate.Started("some-worker")
 			})
 
 			It("saves a start event", func() {
@@ -588,6 +632,7 @@ var _ = Describe("BuildDelegate", func() {
 				Expect(buildID).To(Equal(42))
 				Expect(savedEvent).To(BeAssignableToTypeOf(event.StartTask{}))
 				Expect(savedEvent.(event.StartTask).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.StartTask).Worker).To(Equal("some-worker"))
 				Expect(savedEvent.(event.StartTask).Origin).To(Equal(event.Origin{
 					ID: originID,
 				}))
@@ -759,7 +804,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
 					Origin: event.Origin{
 						Source: event.OriginSourceStdout,
 						ID:     originID,
@@ -785,7 +833,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
 					Origin: event.Origin{
 						Source: event.OriginSourceStderr,
 						ID:     originID,
@@ -797,6 +848,129 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 
+	Describe("ReportDelegate", func() {
+		var reportDelegate exec.ReportDelegate
+
+		BeforeEach(func() {
+			reportDelegate = delegate.ReportDelegate(logger, atc.TaskPlan{
+				Name:    "some-task",
+				Reports: []string{"some-output/junit.xml"},
+			}, originID)
+		})
+
+		Describe("Reported", func() {
+			var results atc.TestResults
+
+			BeforeEach(func() {
+				results = atc.TestResults{
+					Passed:      2,
+					Failed:      1,
+					FailedTests: []string{"some-test"},
+				}
+			})
+
+			JustBeforeEach(func() {
+				reportDelegate.Reported(results)
+			})
+
+			It("saves the results for the step", func() {
+				Expect(fakeDB.SaveBuildTestResultsCallCount()).To(Equal(1))
+
+				savedBuildID, savedPlanID, savedResults := fakeDB.SaveBuildTestResultsArgsForCall(0)
+				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedPlanID).To(Equal(atc.PlanID(originID)))
+				Expect(savedResults).To(Equal(results))
+			})
+
+			Context("when saving the results fails", func() {
+				BeforeEach(func() {
+					fakeDB.SaveBuildTestResultsReturns(errors.New("nope"))
+				})
+
+				It("logs the failure", func() {
+					Expect(logger).To(gbytes.Say("failed-to-save-test-results"))
+				})
+			})
+		})
+
+		Describe("Stderr", func() {
+			It("saves log events with the correct origin", func() {
+				_, err := reportDelegate.Stderr().Write([]byte("some stderr"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
+					Origin: event.Origin{
+						Source: event.OriginSourceStderr,
+						ID:     originID,
+					},
+					Payload: "some stderr",
+				}))
+			})
+		})
+	})
+
+	Describe("LifecycleDelegate", func() {
+		var lifecycleDelegate exec.LifecycleDelegate
+
+		BeforeEach(func() {
+			lifecycleDelegate = delegate.LifecycleDelegate(logger, event.StepTypeTimeout, []int{2, 1}, originID)
+		})
+
+		Describe("Started", func() {
+			JustBeforeEach(func() {
+				lifecycleDelegate.Started()
+			})
+
+			It("saves a start-step event", func() {
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.StartStep{}))
+				Expect(savedEvent.(event.StartStep).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent).To(Equal(event.StartStep{
+					Time:     savedEvent.(event.StartStep).Time,
+					Type:     event.StepTypeTimeout,
+					Attempts: []int{2, 1},
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				lifecycleDelegate.Finished(exec.Success(true))
+			})
+
+			It("saves a finish-step event", func() {
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishStep{}))
+				Expect(savedEvent.(event.FinishStep).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent).To(Equal(event.FinishStep{
+					Time:      savedEvent.(event.FinishStep).Time,
+					Type:      event.StepTypeTimeout,
+					Attempts:  []int{2, 1},
+					Succeeded: true,
+					Origin: event.Origin{
+						ID: originID,
+					},
+				}))
+			})
+		})
+	})
+
 	Describe("OutputDelegate", func() {
 		var (
 			putPlan atc.PutPlan
@@ -827,7 +1001,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.InitializePut{}))
+				Expect(savedEvent.(event.InitializePut).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.InitializePut{
+					Time: savedEvent.(event.InitializePut).Time,
 					Origin: event.Origin{
 						ID: originID,
 					},
@@ -835,6 +1012,25 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Started", func() {
+			JustBeforeEach(func() {
+				outputDelegate.Started("some-worker")
+			})
+
+			It("saves a start event with the worker", func() {
+				Expect(fakeDB.SaveBuildEventCallCount()).To(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.StartPut{}))
+				Expect(savedEvent.(event.StartPut).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.StartPut).Worker).To(Equal("some-worker"))
+				Expect(savedEvent.(event.StartPut).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
 		Describe("Completed", func() {
 			var versionInfo *exec.VersionInfo
 
@@ -859,7 +1055,10 @@ var _ = Describe("BuildDelegate", func() {
 
 					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 					Expect(buildID).To(Equal(42))
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishPut{}))
+					Expect(savedEvent.(event.FinishPut).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 					Expect(savedEvent).To(Equal(event.FinishPut{
+						Time: savedEvent.(event.FinishPut).Time,
 						Origin: event.Origin{
 							ID: originID,
 						},
@@ -903,7 +1102,10 @@ var _ = Describe("BuildDelegate", func() {
 
 					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 					Expect(buildID).To(Equal(42))
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishPut{}))
+					Expect(savedEvent.(event.FinishPut).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 					Expect(savedEvent).To(Equal(event.FinishPut{
+						Time: savedEvent.(event.FinishPut).Time,
 						Origin: event.Origin{
 							ID: originID,
 						},
@@ -947,7 +1149,10 @@ var _ = Describe("BuildDelegate", func() {
 
 					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 					Expect(buildID).To(Equal(42))
+					Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishPut{}))
+					Expect(savedEvent.(event.FinishPut).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 					Expect(savedEvent).To(Equal(event.FinishPut{
+						Time: savedEvent.(event.FinishPut).Time,
 						Origin: event.Origin{
 							ID: originID,
 						},
@@ -1084,7 +1289,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
 					Origin: event.Origin{
 						Source: event.OriginSourceStdout,
 						ID:     originID,
@@ -1110,7 +1318,10 @@ var _ = Describe("BuildDelegate", func() {
 
 				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
 				Expect(savedBuildID).To(Equal(buildID))
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Log{}))
+				Expect(savedEvent.(event.Log).Time).To(BeNumerically("~", time.Now().Unix(), 1))
 				Expect(savedEvent).To(Equal(event.Log{
+					Time: savedEvent.(event.Log).Time,
 					Origin: event.Origin{
 						Source: event.OriginSourceStderr,
 						ID:     originID,
@@ -1174,4 +1385,158 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("log limits", func() {
+		var (
+			logLimits LogLimits
+
+			taskStdout io.Writer
+			taskStderr io.Writer
+			getStdout  io.Writer
+
+			savedEvents func() []atc.Event
+		)
+
+		BeforeEach(func() {
+			logLimits = LogLimits{}
+
+			savedEvents = func() []atc.Event {
+				events := []atc.Event{}
+				for i := 0; i < fakeDB.SaveBuildEventCallCount(); i++ {
+					_, savedEvent := fakeDB.SaveBuildEventArgsForCall(i)
+					events = append(events, savedEvent)
+				}
+
+				return events
+			}
+		})
+
+		JustBeforeEach(func() {
+			delegate = NewBuildDelegateFactory(fakeDB, logLimits).Delegate(buildID)
+
+			executionDelegate := delegate.ExecutionDelegate(logger, atc.TaskPlan{}, "some-task-id")
+			taskStdout = executionDelegate.Stdout()
+			taskStderr = executionDelegate.Stderr()
+
+			getStdout = delegate.InputDelegate(logger, atc.GetPlan{}, "some-get-id").Stdout()
+		})
+
+		payloads := func(events []atc.Event) []string {
+			logged := []string{}
+			for _, e := range events {
+				if log, ok := e.(event.Log); ok {
+					logged = append(logged, log.Payload)
+				}
+			}
+
+			return logged
+		}
+
+		truncations := func(events []atc.Event) []event.LogTruncated {
+			truncated := []event.LogTruncated{}
+			for _, e := range events {
+				if t, ok := e.(event.LogTruncated); ok {
+					truncated = append(truncated, t)
+				}
+			}
+
+			return truncated
+		}
+
+		Context("with a step limit", func() {
+			BeforeEach(func() {
+				logLimits.MaxStepBytes = 8
+			})
+
+			JustBeforeEach(func() {
+				taskStdout.Write([]byte("12345"))
+				taskStderr.Write([]byte("67890"))
+				taskStdout.Write([]byte("more"))
+			})
+
+			It("saves output up to the limit, across both stdout and stderr", func() {
+				Expect(payloads(savedEvents())).To(Equal([]string{"12345", "678"}))
+			})
+
+			It("marks the step's log as truncated once", func() {
+				truncated := truncations(savedEvents())
+				Expect(truncated).To(HaveLen(1))
+				Expect(truncated[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(truncated[0]).To(Equal(event.LogTruncated{
+					Time: truncated[0].Time,
+					Origin: event.Origin{
+						Source: event.OriginSourceStderr,
+						ID:     "some-task-id",
+					},
+					Scope: event.LogLimitScopeStep,
+					Limit: 8,
+				}))
+			})
+
+			It("does not limit the other steps", func() {
+				getStdout.Write([]byte("123456789"))
+				Expect(payloads(savedEvents())).To(ContainElement("123456789"))
+			})
+
+			It("does not fail the step", func() {
+				Expect(delegate.LifecycleDelegate(logger, event.StepTypeTask, nil, "some-task-id").ForcedFailure()).To(BeFalse())
+			})
+
+			Context("when steps are failed when their log overflows", func() {
+				BeforeEach(func() {
+					logLimits.FailStepOnOverflow = true
+				})
+
+				It("fails the step", func() {
+					Expect(delegate.LifecycleDelegate(logger, event.StepTypeTask, nil, "some-task-id").ForcedFailure()).To(BeTrue())
+				})
+
+				It("does not fail the other steps", func() {
+					Expect(delegate.LifecycleDelegate(logger, event.StepTypeGet, nil, "some-get-id").ForcedFailure()).To(BeFalse())
+				})
+			})
+		})
+
+		Context("with a build limit", func() {
+			BeforeEach(func() {
+				logLimits.MaxBuildBytes = 8
+			})
+
+			JustBeforeEach(func() {
+				taskStdout.Write([]byte("12345"))
+				getStdout.Write([]byte("67890"))
+				taskStdout.Write([]byte("more"))
+			})
+
+			It("saves output up to the limit, across all steps", func() {
+				Expect(payloads(savedEvents())).To(Equal([]string{"12345", "678"}))
+			})
+
+			It("marks each step's log as truncated once it is cut short", func() {
+				truncated := truncations(savedEvents())
+				Expect(truncated).To(HaveLen(2))
+
+				Expect(truncated[0].Origin.ID).To(Equal(event.OriginID("some-get-id")))
+				Expect(truncated[0].Scope).To(Equal(event.LogLimitScopeBuild))
+				Expect(truncated[0].Limit).To(Equal(int64(8)))
+
+				Expect(truncated[1].Origin.ID).To(Equal(event.OriginID("some-task-id")))
+				Expect(truncated[1].Scope).To(Equal(event.LogLimitScopeBuild))
+			})
+		})
+
+		Context("when the limit falls within a multi-byte character", func() {
+			BeforeEach(func() {
+				logLimits.MaxStepBytes = 4
+			})
+
+			JustBeforeEach(func() {
+				taskStdout.Write([]byte("123☃"))
+			})
+
+			It("does not split the character", func() {
+				Expect(payloads(savedEvents())).To(Equal([]string{"123"}))
+			})
+		})
+	})
 })
@@ -39,6 +39,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, "http://example.com")
 
 		fakeDelegate = new(fakes.FakeBuildDelegate)
+		fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
 		fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 		buildModel = db.Build{
@@ -46,6 +47,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			Name:         "42",
 			JobName:      "some-job",
 			PipelineName: "some-pipeline",
+			TeamID:       17,
 		}
 
 		expectedMetadata = engine.StepMetadata{
@@ -111,6 +113,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 			BeforeEach(func() {
 				fakeDelegate = new(fakes.FakeBuildDelegate)
+				fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
 				fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 				fakeInputDelegate = new(execfakes.FakeGetDelegate)
@@ -187,6 +190,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 						PipelineName: "some-pipeline",
 						StepName:     "some-input",
 						Type:         db.ContainerTypeGet,
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -207,6 +211,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 						PipelineName: "some-pipeline",
 						StepName:     "some-completion-task",
 						Type:         db.ContainerTypeTask,
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -228,6 +233,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 						PipelineName: "some-pipeline",
 						StepName:     "some-failure-task",
 						Type:         db.ContainerTypeTask,
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -249,6 +255,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 						PipelineName: "some-pipeline",
 						StepName:     "some-success-task",
 						Type:         db.ContainerTypeTask,
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -270,6 +277,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 						PipelineName: "some-pipeline",
 						StepName:     "some-next-task",
 						Type:         db.ContainerTypeTask,
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
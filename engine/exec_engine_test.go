@@ -1,10 +1,12 @@
 package engine_test
 
 import (
+	"errors"
 	"os"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	dbfakes "github.com/concourse/atc/db/fakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/fakes"
 	"github.com/concourse/atc/event"
@@ -13,6 +15,7 @@ import (
 	"github.com/concourse/atc/worker"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 )
@@ -78,6 +81,7 @@ var _ = Describe("ExecEngine", func() {
 				Name:         "21",
 				JobName:      "some-job",
 				PipelineName: "some-pipeline",
+				TeamID:       17,
 			}
 
 			expectedMetadata = engine.StepMetadata{
@@ -89,6 +93,7 @@ var _ = Describe("ExecEngine", func() {
 			}
 
 			fakeDelegate = new(fakes.FakeBuildDelegate)
+			fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
 			fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 			fakeInputDelegate = new(execfakes.FakeGetDelegate)
@@ -197,6 +202,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -218,6 +224,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put-2",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -248,6 +255,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -274,6 +282,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get-2",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -360,6 +369,28 @@ var _ = Describe("ExecEngine", func() {
 				Expect(*retryPlan.Retry).To(HaveLen(3))
 			})
 
+			It("tracks the lifecycle of every step, along with its attempts", func() {
+				type lifecycle struct {
+					stepType event.StepType
+					attempts []int
+					originID event.OriginID
+				}
+
+				lifecycles := []lifecycle{}
+				for i := 0; i < fakeDelegate.LifecycleDelegateCallCount(); i++ {
+					_, stepType, attempts, originID := fakeDelegate.LifecycleDelegateArgsForCall(i)
+					lifecycles = append(lifecycles, lifecycle{stepType, attempts, originID})
+				}
+
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeRetry, nil, event.OriginID(retryPlan.ID)}))
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeGet, []int{1}, event.OriginID(getPlan.ID)}))
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeTimeout, []int{2}, event.OriginID(timeoutPlan.ID)}))
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeDo, []int{2}, event.OriginID(doPlan.ID)}))
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeAggregate, []int{2}, event.OriginID(aggregatePlan.ID)}))
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeTask, []int{2, 1}, event.OriginID(taskPlan.ID)}))
+				Expect(lifecycles).To(ContainElement(lifecycle{event.StepTypeTask, []int{2, 2}, event.OriginID(taskPlan.ID)}))
+			})
+
 			It("constructss the first get correctly", func() {
 				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, params, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
@@ -370,6 +401,7 @@ var _ = Describe("ExecEngine", func() {
 					StepName:     "some-get",
 					PipelineName: "some-pipeline",
 					Attempts:     []int{1},
+					TeamID:       17,
 				}))
 				Expect(workerID).To(Equal(worker.Identifier{
 					BuildID: 42,
@@ -396,6 +428,7 @@ var _ = Describe("ExecEngine", func() {
 					StepName:     "some-get",
 					PipelineName: "some-pipeline",
 					Attempts:     []int{3},
+					TeamID:       17,
 				}))
 				Expect(workerID).To(Equal(worker.Identifier{
 					BuildID: 42,
@@ -426,6 +459,7 @@ var _ = Describe("ExecEngine", func() {
 					StepName:     "some-task",
 					PipelineName: "some-pipeline",
 					Attempts:     []int{2, 1},
+					TeamID:       17,
 				}))
 				Expect(workerID).To(Equal(worker.Identifier{
 					BuildID: 42,
@@ -446,6 +480,7 @@ var _ = Describe("ExecEngine", func() {
 					StepName:     "some-task",
 					PipelineName: "some-pipeline",
 					Attempts:     []int{2, 2},
+					TeamID:       17,
 				}))
 				Expect(workerID).To(Equal(worker.Identifier{
 					BuildID: 42,
@@ -548,6 +583,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-input",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(sourceName).To(Equal(exec.SourceName("some-input")))
 					Expect(workerID).To(Equal(worker.Identifier{
@@ -628,6 +664,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeTask,
 						StepName:     "some-task",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -659,6 +696,42 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that contains tasks with reports", func() {
+				var fakeReportDelegate *execfakes.FakeReportDelegate
+
+				BeforeEach(func() {
+					fakeReportDelegate = new(execfakes.FakeReportDelegate)
+					fakeReportDelegate.StderrReturns(gbytes.NewBuffer())
+					fakeDelegate.ReportDelegateReturns(fakeReportDelegate)
+
+					taskStep.ResultStub = successResult(false)
+
+					plan = planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						ConfigPath: "some-input/build.yml",
+						Reports:    []string{"some-output/junit.xml"},
+						Pipeline:   "some-pipeline",
+					})
+				})
+
+				It("parses the reports once the task has run, even if it failed", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, buildModel, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+
+					Expect(taskStep.RunCallCount()).To(Equal(1))
+
+					Expect(fakeDelegate.ReportDelegateCallCount()).To(Equal(1))
+					_, taskPlan, originID := fakeDelegate.ReportDelegateArgsForCall(0)
+					Expect(taskPlan.Reports).To(Equal([]string{"some-output/junit.xml"}))
+					Expect(originID).To(Equal(event.OriginID(plan.ID)))
+
+					Expect(fakeReportDelegate.ReportedCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					plan             atc.Plan
@@ -708,6 +781,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypePut,
 						StepName:     "some-put",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -742,6 +816,7 @@ var _ = Describe("ExecEngine", func() {
 						Type:         db.ContainerTypeGet,
 						StepName:     "some-get",
 						PipelineName: "some-pipeline",
+						TeamID:       17,
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 42,
@@ -774,6 +849,314 @@ var _ = Describe("ExecEngine", func() {
 		})
 	})
 
+	Describe("RerunBuild", func() {
+		var (
+			logger *lagertest.TestLogger
+
+			firstTaskPlan  atc.Plan
+			secondTaskPlan atc.Plan
+			thirdTaskPlan  atc.Plan
+
+			original db.Build
+			rerun    db.Build
+
+			from atc.PlanID
+
+			build    engine.Build
+			rerunErr error
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("test")
+
+			planFactory := atc.NewPlanFactory(123)
+
+			taskConfig := &atc.TaskConfig{
+				Run: atc.TaskRunConfig{Path: "some-path"},
+			}
+
+			firstTaskPlan = planFactory.NewPlan(atc.TaskPlan{Name: "first-task", Config: taskConfig})
+			secondTaskPlan = planFactory.NewPlan(atc.TaskPlan{Name: "second-task", Config: taskConfig})
+			thirdTaskPlan = planFactory.NewPlan(atc.TaskPlan{Name: "third-task", Config: taskConfig})
+
+			plan := planFactory.NewPlan(atc.DoPlan{
+				firstTaskPlan,
+				secondTaskPlan,
+				thirdTaskPlan,
+			})
+
+			originalBuild, err := execEngine.CreateBuild(logger, db.Build{ID: 42}, plan)
+			Expect(err).NotTo(HaveOccurred())
+
+			original = db.Build{
+				ID:             42,
+				Engine:         "exec.v2",
+				EngineMetadata: originalBuild.Metadata(),
+			}
+
+			rerun = db.Build{
+				ID:      43,
+				RerunOf: 42,
+			}
+
+			fakeDelegate := new(fakes.FakeBuildDelegate)
+			fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
+			fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+			taskStep := new(execfakes.FakeStep)
+			taskStep.ResultStub = successResult(true)
+
+			taskStepFactory := new(execfakes.FakeStepFactory)
+			taskStepFactory.UsingReturns(taskStep)
+
+			fakeFactory.TaskReturns(taskStepFactory)
+			fakeFactory.ReusedTaskReturns(taskStepFactory)
+		})
+
+		JustBeforeEach(func() {
+			build, rerunErr = execEngine.RerunBuild(logger, rerun, original, from)
+		})
+
+		Context("from a step in the plan", func() {
+			BeforeEach(func() {
+				from = secondTaskPlan.ID
+			})
+
+			It("succeeds", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+			})
+
+			It("reuses the results of the steps before it from the original build", func() {
+				build.Resume(logger)
+
+				Expect(fakeFactory.ReusedTaskCallCount()).To(Equal(1))
+
+				_, sourceName, reuseID, workerID, _, _, _, _, _, _ := fakeFactory.ReusedTaskArgsForCall(0)
+				Expect(sourceName).To(Equal(exec.SourceName("first-task")))
+				Expect(reuseID).To(Equal(worker.Identifier{
+					BuildID: 42,
+					PlanID:  firstTaskPlan.ID,
+				}))
+				Expect(workerID).To(Equal(worker.Identifier{
+					BuildID: 43,
+					PlanID:  firstTaskPlan.ID,
+				}))
+			})
+
+			It("runs the step and the ones after it again", func() {
+				build.Resume(logger)
+
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+
+				_, sourceName, workerID, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				Expect(sourceName).To(Equal(exec.SourceName("second-task")))
+				Expect(workerID).To(Equal(worker.Identifier{
+					BuildID: 43,
+					PlanID:  secondTaskPlan.ID,
+				}))
+
+				_, sourceName, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				Expect(sourceName).To(Equal(exec.SourceName("third-task")))
+			})
+
+			It("keeps reusing them when the build is looked up again", func() {
+				lookedUp, err := execEngine.LookupBuild(logger, db.Build{
+					ID:             43,
+					EngineMetadata: build.Metadata(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				lookedUp.Resume(logger)
+
+				Expect(fakeFactory.ReusedTaskCallCount()).To(Equal(1))
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("from a step after a put", func() {
+			var (
+				putPlan          atc.Plan
+				dependentGetPlan atc.Plan
+
+				fakeDelegate       *fakes.FakeBuildDelegate
+				fakeOutputDelegate *execfakes.FakePutDelegate
+				fakeEventSource    *dbfakes.FakeEventSource
+
+				getStepFactory *execfakes.FakeStepFactory
+			)
+
+			BeforeEach(func() {
+				planFactory := atc.NewPlanFactory(456)
+
+				putPlan = planFactory.NewPlan(atc.PutPlan{
+					Name:     "some-put",
+					Resource: "some-output-resource",
+					Type:     "some-type",
+					Source:   atc.Source{"some": "source"},
+					Params:   atc.Params{"some": "params"},
+				})
+
+				dependentGetPlan = planFactory.NewPlan(atc.DependentGetPlan{
+					Name:     "some-put",
+					Resource: "some-output-resource",
+					Type:     "some-type",
+					Source:   atc.Source{"some": "source"},
+				})
+
+				plan := planFactory.NewPlan(atc.DoPlan{
+					firstTaskPlan,
+					planFactory.NewPlan(atc.OnSuccessPlan{
+						Step: putPlan,
+						Next: dependentGetPlan,
+					}),
+					secondTaskPlan,
+				})
+
+				originalBuild, err := execEngine.CreateBuild(logger, db.Build{ID: 42}, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				original.EngineMetadata = originalBuild.Metadata()
+
+				from = secondTaskPlan.ID
+
+				fakeOutputDelegate = new(execfakes.FakePutDelegate)
+
+				fakeDelegate = new(fakes.FakeBuildDelegate)
+				fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
+				fakeDelegate.OutputDelegateReturns(fakeOutputDelegate)
+				fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+				getStep := new(execfakes.FakeStep)
+				getStep.ResultStub = successResult(true)
+
+				getStepFactory = new(execfakes.FakeStepFactory)
+				getStepFactory.UsingReturns(getStep)
+				fakeFactory.GetReturns(getStepFactory)
+
+				recordedEvents := []atc.Event{
+					event.FinishPut{
+						Origin:          event.Origin{ID: event.OriginID(putPlan.ID)},
+						CreatedVersion:  atc.Version{"version": "original-put"},
+						CreatedMetadata: []atc.MetadataField{{Name: "some", Value: "metadata"}},
+					},
+					event.FinishGet{
+						Origin:         event.Origin{ID: event.OriginID(dependentGetPlan.ID)},
+						FetchedVersion: atc.Version{"version": "original-put"},
+					},
+				}
+
+				fakeEventSource = new(dbfakes.FakeEventSource)
+				fakeEventSource.NextStub = func() (atc.Event, error) {
+					if len(recordedEvents) == 0 {
+						return nil, db.ErrEndOfBuildEventStream
+					}
+
+					ev := recordedEvents[0]
+					recordedEvents = recordedEvents[1:]
+					return ev, nil
+				}
+
+				fakeDB.GetBuildEventsReturns(fakeEventSource, nil)
+			})
+
+			It("reads the versions recorded by the original build", func() {
+				Expect(rerunErr).NotTo(HaveOccurred())
+
+				buildID, from := fakeDB.GetBuildEventsArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(from).To(BeZero())
+
+				Expect(fakeEventSource.CloseCallCount()).To(Equal(1))
+			})
+
+			It("does not run the put again", func() {
+				build.Resume(logger)
+
+				Expect(fakeFactory.PutCallCount()).To(BeZero())
+			})
+
+			It("reports the version the put produced in the original build", func() {
+				build.Resume(logger)
+
+				Expect(fakeOutputDelegate.CompletedCallCount()).To(Equal(1))
+
+				exitStatus, versionInfo := fakeOutputDelegate.CompletedArgsForCall(0)
+				Expect(exitStatus).To(Equal(exec.ExitStatus(0)))
+				Expect(versionInfo).To(Equal(&exec.VersionInfo{
+					Version:  atc.Version{"version": "original-put"},
+					Metadata: []atc.MetadataField{{Name: "some", Value: "metadata"}},
+				}))
+			})
+
+			It("fetches the version the dependent get fetched in the original build", func() {
+				build.Resume(logger)
+
+				Expect(fakeFactory.DependentGetCallCount()).To(BeZero())
+				Expect(fakeFactory.GetCallCount()).To(Equal(1))
+
+				_, _, sourceName, _, _, _, _, _, _, version, _ := fakeFactory.GetArgsForCall(0)
+				Expect(sourceName).To(Equal(exec.SourceName("some-put")))
+				Expect(version).To(Equal(atc.Version{"version": "original-put"}))
+			})
+
+			It("keeps using the recorded versions when the build is looked up again", func() {
+				lookedUp, err := execEngine.LookupBuild(logger, db.Build{
+					ID:             43,
+					EngineMetadata: build.Metadata(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				lookedUp.Resume(logger)
+
+				Expect(fakeFactory.PutCallCount()).To(BeZero())
+
+				_, _, _, _, _, _, _, _, _, version, _ := fakeFactory.GetArgsForCall(0)
+				Expect(version).To(Equal(atc.Version{"version": "original-put"}))
+			})
+
+			Context("when the original build did not record the put's version", func() {
+				BeforeEach(func() {
+					fakeEventSource.NextReturns(nil, db.ErrEndOfBuildEventStream)
+				})
+
+				It("returns an error", func() {
+					Expect(rerunErr).To(Equal(engine.UnrecordedVersionError{PlanID: putPlan.ID}))
+				})
+			})
+
+			Context("when the original build's events cannot be read", func() {
+				BeforeEach(func() {
+					fakeDB.GetBuildEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns the error", func() {
+					Expect(rerunErr).To(MatchError("nope"))
+				})
+			})
+		})
+
+		Context("from a step that is not in the plan", func() {
+			BeforeEach(func() {
+				from = "bogus-plan-id"
+			})
+
+			It("returns an error", func() {
+				Expect(rerunErr).To(Equal(engine.UnknownPlanIDError{PlanID: "bogus-plan-id"}))
+			})
+		})
+
+		Context("when the original build's metadata is malformed", func() {
+			BeforeEach(func() {
+				from = secondTaskPlan.ID
+				original.EngineMetadata = "bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(rerunErr).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("PublicPlan", func() {
 		var build engine.Build
 		var logger lager.Logger
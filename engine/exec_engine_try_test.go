@@ -40,6 +40,7 @@ var _ = Describe("Exec Engine with Try", func() {
 		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, "http://example.com")
 
 		fakeDelegate = new(fakes.FakeBuildDelegate)
+		fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
 		fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 		buildModel = db.Build{
@@ -93,6 +94,7 @@ var _ = Describe("Exec Engine with Try", func() {
 			BeforeEach(func() {
 				planFactory = atc.NewPlanFactory(123)
 				fakeDelegate = new(fakes.FakeBuildDelegate)
+				fakeDelegate.LifecycleDelegateReturns(new(execfakes.FakeLifecycleDelegate))
 				fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 				fakeInputDelegate = new(execfakes.FakeGetDelegate)
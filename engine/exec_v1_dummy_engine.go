@@ -24,6 +24,10 @@ func (execV1DummyEngine) CreateBuild(logger lager.Logger, model db.Build, plan a
 	return nil, errors.New("dummy engine does not support new builds")
 }
 
+func (execV1DummyEngine) RerunBuild(logger lager.Logger, model db.Build, original db.Build, from atc.PlanID) (Build, error) {
+	return nil, errors.New("dummy engine does not support re-running builds")
+}
+
 func (execV1DummyEngine) LookupBuild(logger lager.Logger, model db.Build) (Build, error) {
 	return execV1DummyBuild{}, nil
 }
@@ -50,6 +50,27 @@ type FakeBuildDelegate struct {
 		arg3 exec.Success
 		arg4 bool
 	}
+	ReportDelegateStub        func(lager.Logger, atc.TaskPlan, event.OriginID) exec.ReportDelegate
+	reportDelegateMutex       sync.RWMutex
+	reportDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.TaskPlan
+		arg3 event.OriginID
+	}
+	reportDelegateReturns struct {
+		result1 exec.ReportDelegate
+	}
+	LifecycleDelegateStub        func(lager.Logger, event.StepType, []int, event.OriginID) exec.LifecycleDelegate
+	lifecycleDelegateMutex       sync.RWMutex
+	lifecycleDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 event.StepType
+		arg3 []int
+		arg4 event.OriginID
+	}
+	lifecycleDelegateReturns struct {
+		result1 exec.LifecycleDelegate
+	}
 }
 
 func (fake *FakeBuildDelegate) InputDelegate(arg1 lager.Logger, arg2 atc.GetPlan, arg3 event.OriginID) exec.GetDelegate {
@@ -180,4 +201,73 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, ex
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3, fake.finishArgsForCall[i].arg4
 }
 
+func (fake *FakeBuildDelegate) ReportDelegate(arg1 lager.Logger, arg2 atc.TaskPlan, arg3 event.OriginID) exec.ReportDelegate {
+	fake.reportDelegateMutex.Lock()
+	fake.reportDelegateArgsForCall = append(fake.reportDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.TaskPlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.reportDelegateMutex.Unlock()
+	if fake.ReportDelegateStub != nil {
+		return fake.ReportDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.reportDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) ReportDelegateCallCount() int {
+	fake.reportDelegateMutex.RLock()
+	defer fake.reportDelegateMutex.RUnlock()
+	return len(fake.reportDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) ReportDelegateArgsForCall(i int) (lager.Logger, atc.TaskPlan, event.OriginID) {
+	fake.reportDelegateMutex.RLock()
+	defer fake.reportDelegateMutex.RUnlock()
+	return fake.reportDelegateArgsForCall[i].arg1, fake.reportDelegateArgsForCall[i].arg2, fake.reportDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) ReportDelegateReturns(result1 exec.ReportDelegate) {
+	fake.ReportDelegateStub = nil
+	fake.reportDelegateReturns = struct {
+		result1 exec.ReportDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) LifecycleDelegate(arg1 lager.Logger, arg2 event.StepType, arg3 []int, arg4 event.OriginID) exec.LifecycleDelegate {
+	fake.lifecycleDelegateMutex.Lock()
+	fake.lifecycleDelegateArgsForCall = append(fake.lifecycleDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 event.StepType
+		arg3 []int
+		arg4 event.OriginID
+	}{arg1, arg2, arg3, arg4})
+	fake.lifecycleDelegateMutex.Unlock()
+	if fake.LifecycleDelegateStub != nil {
+		return fake.LifecycleDelegateStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.lifecycleDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) LifecycleDelegateCallCount() int {
+	fake.lifecycleDelegateMutex.RLock()
+	defer fake.lifecycleDelegateMutex.RUnlock()
+	return len(fake.lifecycleDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) LifecycleDelegateArgsForCall(i int) (lager.Logger, event.StepType, []int, event.OriginID) {
+	fake.lifecycleDelegateMutex.RLock()
+	defer fake.lifecycleDelegateMutex.RUnlock()
+	return fake.lifecycleDelegateArgsForCall[i].arg1, fake.lifecycleDelegateArgsForCall[i].arg2, fake.lifecycleDelegateArgsForCall[i].arg3, fake.lifecycleDelegateArgsForCall[i].arg4
+}
+
+func (fake *FakeBuildDelegate) LifecycleDelegateReturns(result1 exec.LifecycleDelegate) {
+	fake.LifecycleDelegateStub = nil
+	fake.lifecycleDelegateReturns = struct {
+		result1 exec.LifecycleDelegate
+	}{result1}
+}
+
 var _ engine.BuildDelegate = new(FakeBuildDelegate)
@@ -38,6 +38,18 @@ type FakeEngine struct {
 		result1 engine.Build
 		result2 error
 	}
+	RerunBuildStub        func(logger lager.Logger, build db.Build, original db.Build, from atc.PlanID) (engine.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		logger   lager.Logger
+		build    db.Build
+		original db.Build
+		from     atc.PlanID
+	}
+	rerunBuildReturns struct {
+		result1 engine.Build
+		result2 error
+	}
 }
 
 func (fake *FakeEngine) Name() string {
@@ -133,4 +145,40 @@ func (fake *FakeEngine) LookupBuildReturns(result1 engine.Build, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeEngine) RerunBuild(logger lager.Logger, build db.Build, original db.Build, from atc.PlanID) (engine.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		logger   lager.Logger
+		build    db.Build
+		original db.Build
+		from     atc.PlanID
+	}{logger, build, original, from})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(logger, build, original, from)
+	} else {
+		return fake.rerunBuildReturns.result1, fake.rerunBuildReturns.result2
+	}
+}
+
+func (fake *FakeEngine) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeEngine) RerunBuildArgsForCall(i int) (lager.Logger, db.Build, db.Build, atc.PlanID) {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return fake.rerunBuildArgsForCall[i].logger, fake.rerunBuildArgsForCall[i].build, fake.rerunBuildArgsForCall[i].original, fake.rerunBuildArgsForCall[i].from
+}
+
+func (fake *FakeEngine) RerunBuildReturns(result1 engine.Build, result2 error) {
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 engine.Build
+		result2 error
+	}{result1, result2}
+}
+
 var _ engine.Engine = new(FakeEngine)
@@ -70,6 +70,26 @@ type FakeEngineDB struct {
 	saveImageResourceVersionReturns struct {
 		result1 error
 	}
+	SaveBuildTestResultsStub        func(buildID int, planID atc.PlanID, results atc.TestResults) error
+	saveBuildTestResultsMutex       sync.RWMutex
+	saveBuildTestResultsArgsForCall []struct {
+		buildID int
+		planID  atc.PlanID
+		results atc.TestResults
+	}
+	saveBuildTestResultsReturns struct {
+		result1 error
+	}
+	GetBuildEventsStub        func(buildID int, from uint) (db.EventSource, error)
+	getBuildEventsMutex       sync.RWMutex
+	getBuildEventsArgsForCall []struct {
+		buildID int
+		from    uint
+	}
+	getBuildEventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
 }
 
 func (fake *FakeEngineDB) SaveBuildEvent(buildID int, event atc.Event) error {
@@ -276,4 +296,72 @@ func (fake *FakeEngineDB) SaveImageResourceVersionReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeEngineDB) SaveBuildTestResults(buildID int, planID atc.PlanID, results atc.TestResults) error {
+	fake.saveBuildTestResultsMutex.Lock()
+	fake.saveBuildTestResultsArgsForCall = append(fake.saveBuildTestResultsArgsForCall, struct {
+		buildID int
+		planID  atc.PlanID
+		results atc.TestResults
+	}{buildID, planID, results})
+	fake.saveBuildTestResultsMutex.Unlock()
+	if fake.SaveBuildTestResultsStub != nil {
+		return fake.SaveBuildTestResultsStub(buildID, planID, results)
+	} else {
+		return fake.saveBuildTestResultsReturns.result1
+	}
+}
+
+func (fake *FakeEngineDB) SaveBuildTestResultsCallCount() int {
+	fake.saveBuildTestResultsMutex.RLock()
+	defer fake.saveBuildTestResultsMutex.RUnlock()
+	return len(fake.saveBuildTestResultsArgsForCall)
+}
+
+func (fake *FakeEngineDB) SaveBuildTestResultsArgsForCall(i int) (int, atc.PlanID, atc.TestResults) {
+	fake.saveBuildTestResultsMutex.RLock()
+	defer fake.saveBuildTestResultsMutex.RUnlock()
+	return fake.saveBuildTestResultsArgsForCall[i].buildID, fake.saveBuildTestResultsArgsForCall[i].planID, fake.saveBuildTestResultsArgsForCall[i].results
+}
+
+func (fake *FakeEngineDB) SaveBuildTestResultsReturns(result1 error) {
+	fake.SaveBuildTestResultsStub = nil
+	fake.saveBuildTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEngineDB) GetBuildEvents(buildID int, from uint) (db.EventSource, error) {
+	fake.getBuildEventsMutex.Lock()
+	fake.getBuildEventsArgsForCall = append(fake.getBuildEventsArgsForCall, struct {
+		buildID int
+		from    uint
+	}{buildID, from})
+	fake.getBuildEventsMutex.Unlock()
+	if fake.GetBuildEventsStub != nil {
+		return fake.GetBuildEventsStub(buildID, from)
+	} else {
+		return fake.getBuildEventsReturns.result1, fake.getBuildEventsReturns.result2
+	}
+}
+
+func (fake *FakeEngineDB) GetBuildEventsCallCount() int {
+	fake.getBuildEventsMutex.RLock()
+	defer fake.getBuildEventsMutex.RUnlock()
+	return len(fake.getBuildEventsArgsForCall)
+}
+
+func (fake *FakeEngineDB) GetBuildEventsArgsForCall(i int) (int, uint) {
+	fake.getBuildEventsMutex.RLock()
+	defer fake.getBuildEventsMutex.RUnlock()
+	return fake.getBuildEventsArgsForCall[i].buildID, fake.getBuildEventsArgsForCall[i].from
+}
+
+func (fake *FakeEngineDB) GetBuildEventsReturns(result1 db.EventSource, result2 error) {
+	fake.GetBuildEventsStub = nil
+	fake.getBuildEventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
 var _ engine.EngineDB = new(FakeEngineDB)
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/concourse/atc/event"
+)
+
+// LogLimits bounds how much output is saved as build events. A limit of 0
+// means there is no limit.
+type LogLimits struct {
+	// MaxStepBytes is the number of bytes each step may log, counting both
+	// stdout and stderr.
+	MaxStepBytes int64
+
+	// MaxBuildBytes is the number of bytes all of a build's steps may log
+	// between them.
+	MaxBuildBytes int64
+
+	// FailStepOnOverflow causes any step whose log was truncated to fail,
+	// regardless of its own result.
+	FailStepOnOverflow bool
+}
+
+type stepLog struct {
+	bytes     int64
+	truncated bool
+}
+
+type logLimiter struct {
+	limits LogLimits
+
+	buildBytes int64
+	steps      map[event.OriginID]*stepLog
+
+	lock sync.Mutex
+}
+
+func newLogLimiter(limits LogLimits) *logLimiter {
+	return &logLimiter{
+		limits: limits,
+		steps:  make(map[event.OriginID]*stepLog),
+	}
+}
+
+// admit returns how much of the given output may be saved for the step. The
+// first time a step's output is cut short, the scope and value of the limit
+// that was reached are returned so that it can be marked in the log.
+func (limiter *logLimiter) admit(id event.OriginID, text []byte) ([]byte, event.LogLimitScope, int64) {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	step, found := limiter.steps[id]
+	if !found {
+		step = &stepLog{}
+		limiter.steps[id] = step
+	}
+
+	if step.truncated {
+		return nil, "", 0
+	}
+
+	allowed := int64(len(text))
+	var scope event.LogLimitScope
+	var limit int64
+
+	if limiter.limits.MaxStepBytes > 0 && step.bytes+allowed > limiter.limits.MaxStepBytes {
+		allowed = limiter.limits.MaxStepBytes - step.bytes
+		scope = event.LogLimitScopeStep
+		limit = limiter.limits.MaxStepBytes
+	}
+
+	if limiter.limits.MaxBuildBytes > 0 && limiter.buildBytes+allowed > limiter.limits.MaxBuildBytes {
+		allowed = limiter.limits.MaxBuildBytes - limiter.buildBytes
+		scope = event.LogLimitScopeBuild
+		limit = limiter.limits.MaxBuildBytes
+	}
+
+	if scope == "" {
+		step.bytes += allowed
+		limiter.buildBytes += allowed
+		return text, "", 0
+	}
+
+	// don't split a multi-byte character
+	for allowed > 0 && !utf8.RuneStart(text[allowed]) {
+		allowed--
+	}
+
+	step.bytes += allowed
+	step.truncated = true
+	limiter.buildBytes += allowed
+
+	return text[:allowed], scope, limit
+}
+
+func (limiter *logLimiter) truncated(id event.OriginID) bool {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	step, found := limiter.steps[id]
+	return found && step.truncated
+}
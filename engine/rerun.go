@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+// UnknownPlanIDError is returned when re-running a build from a step that is
+// not in its plan.
+type UnknownPlanIDError struct {
+	PlanID atc.PlanID
+}
+
+func (err UnknownPlanIDError) Error() string {
+	return fmt.Sprintf("build plan has no step with id '%s'", err.PlanID)
+}
+
+// UnrecordedVersionError is returned when re-running a build from a step
+// after a put or dependent get whose version the original build did not
+// record, e.g. because the put failed.
+type UnrecordedVersionError struct {
+	PlanID atc.PlanID
+}
+
+func (err UnrecordedVersionError) Error() string {
+	return fmt.Sprintf("original build has no version recorded for step with id '%s'", err.PlanID)
+}
+
+func (build *execBuild) reuses(planID atc.PlanID) bool {
+	for _, id := range build.metadata.ReusedSteps {
+		if id == planID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stepsBefore returns the IDs of the steps that run before the step with the
+// given ID, in the order they are run, and whether the step was found at all.
+// Steps in an aggregate are considered to run in the order they are listed.
+func stepsBefore(plan atc.Plan, id atc.PlanID) ([]atc.PlanID, bool) {
+	ids := []atc.PlanID{}
+	found := collectStepsBefore(plan, id, &ids)
+	return ids, found
+}
+
+func collectStepsBefore(plan atc.Plan, id atc.PlanID, ids *[]atc.PlanID) bool {
+	if plan.ID == id {
+		return true
+	}
+
+	for _, innerPlan := range innerPlans(plan) {
+		if collectStepsBefore(innerPlan, id, ids) {
+			return true
+		}
+	}
+
+	*ids = append(*ids, plan.ID)
+
+	return false
+}
+
+// versionedSteps returns the IDs of the puts and dependent gets among the
+// given steps. Rather than running these again, a re-run uses the versions the
+// original build recorded for them.
+func versionedSteps(plan atc.Plan, ids []atc.PlanID) []atc.PlanID {
+	versioned := []atc.PlanID{}
+	collectVersionedSteps(plan, ids, &versioned)
+	return versioned
+}
+
+func collectVersionedSteps(plan atc.Plan, ids []atc.PlanID, versioned *[]atc.PlanID) {
+	if plan.Put != nil || plan.DependentGet != nil {
+		for _, id := range ids {
+			if id == plan.ID {
+				*versioned = append(*versioned, plan.ID)
+				break
+			}
+		}
+	}
+
+	for _, innerPlan := range innerPlans(plan) {
+		collectVersionedSteps(innerPlan, ids, versioned)
+	}
+}
+
+// recordedVersions reads the versions that the given puts and dependent gets
+// produced or fetched in a finished build from its events.
+func recordedVersions(engineDB EngineDB, buildID int, ids []atc.PlanID) (map[atc.PlanID]exec.VersionInfo, error) {
+	versions := map[atc.PlanID]exec.VersionInfo{}
+
+	if len(ids) == 0 {
+		return versions, nil
+	}
+
+	events, err := engineDB.GetBuildEvents(buildID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	defer events.Close()
+
+	for {
+		ev, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch e := ev.(type) {
+		case event.FinishPut:
+			if e.ExitStatus == 0 {
+				versions[atc.PlanID(e.Origin.ID)] = exec.VersionInfo{
+					Version:  e.CreatedVersion,
+					Metadata: e.CreatedMetadata,
+				}
+			}
+		case event.FinishGet:
+			if e.ExitStatus == 0 {
+				versions[atc.PlanID(e.Origin.ID)] = exec.VersionInfo{
+					Version:  e.FetchedVersion,
+					Metadata: e.FetchedMetadata,
+				}
+			}
+		}
+	}
+
+	recorded := map[atc.PlanID]exec.VersionInfo{}
+	for _, id := range ids {
+		info, found := versions[id]
+		if !found {
+			return nil, UnrecordedVersionError{id}
+		}
+
+		recorded[id] = info
+	}
+
+	return recorded, nil
+}
+
+func innerPlans(plan atc.Plan) []atc.Plan {
+	switch {
+	case plan.Aggregate != nil:
+		return *plan.Aggregate
+	case plan.Do != nil:
+		return *plan.Do
+	case plan.Retry != nil:
+		return *plan.Retry
+	case plan.Timeout != nil:
+		return []atc.Plan{plan.Timeout.Step}
+	case plan.Try != nil:
+		return []atc.Plan{plan.Try.Step}
+	case plan.OnSuccess != nil:
+		return []atc.Plan{plan.OnSuccess.Step, plan.OnSuccess.Next}
+	case plan.OnFailure != nil:
+		return []atc.Plan{plan.OnFailure.Step, plan.OnFailure.Next}
+	case plan.Ensure != nil:
+		return []atc.Plan{plan.Ensure.Step, plan.Ensure.Next}
+	default:
+		return nil
+	}
+}
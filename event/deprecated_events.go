@@ -432,3 +432,63 @@ type FinishPutV30 struct {
 
 func (FinishPutV30) EventType() atc.EventType  { return "finish-put" }
 func (FinishPutV30) Version() atc.EventVersion { return "3.0" }
+
+type InitializeTaskV40 struct {
+	TaskConfig TaskConfig `json:"config"`
+	Origin     Origin     `json:"origin"`
+}
+
+func (InitializeTaskV40) EventType() atc.EventType  { return "initialize-task" }
+func (InitializeTaskV40) Version() atc.EventVersion { return "4.0" }
+
+type StartTaskV40 struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (StartTaskV40) EventType() atc.EventType  { return "start-task" }
+func (StartTaskV40) Version() atc.EventVersion { return "4.0" }
+
+type InitializeGetV10 struct {
+	Origin Origin `json:"origin"`
+}
+
+func (InitializeGetV10) EventType() atc.EventType  { return "initialize-get" }
+func (InitializeGetV10) Version() atc.EventVersion { return "1.0" }
+
+type FinishGetV40 struct {
+	Origin          Origin              `json:"origin"`
+	Plan            GetPlan             `json:"plan"`
+	ExitStatus      int                 `json:"exit_status"`
+	FetchedVersion  atc.Version         `json:"version"`
+	FetchedMetadata []atc.MetadataField `json:"metadata,omitempty"`
+}
+
+func (FinishGetV40) EventType() atc.EventType  { return "finish-get" }
+func (FinishGetV40) Version() atc.EventVersion { return "4.0" }
+
+type InitializePutV10 struct {
+	Origin Origin `json:"origin"`
+}
+
+func (InitializePutV10) EventType() atc.EventType  { return "initialize-put" }
+func (InitializePutV10) Version() atc.EventVersion { return "1.0" }
+
+type FinishPutV40 struct {
+	Origin          Origin              `json:"origin"`
+	Plan            PutPlan             `json:"plan"`
+	CreatedVersion  atc.Version         `json:"version"`
+	CreatedMetadata []atc.MetadataField `json:"metadata,omitempty"`
+	ExitStatus      int                 `json:"exit_status"`
+}
+
+func (FinishPutV40) EventType() atc.EventType  { return "finish-put" }
+func (FinishPutV40) Version() atc.EventVersion { return "4.0" }
+
+type LogV50 struct {
+	Origin  Origin `json:"origin"`
+	Payload string `json:"payload"`
+}
+
+func (LogV50) EventType() atc.EventType  { return "log" }
+func (LogV50) Version() atc.EventVersion { return "5.0" }
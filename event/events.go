@@ -20,12 +20,13 @@ func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
 func (FinishTask) Version() atc.EventVersion { return "4.0" }
 
 type InitializeTask struct {
+	Time       int64      `json:"time"`
 	TaskConfig TaskConfig `json:"config"`
 	Origin     Origin     `json:"origin"`
 }
 
 func (InitializeTask) EventType() atc.EventType  { return EventTypeInitializeTask }
-func (InitializeTask) Version() atc.EventVersion { return "4.0" }
+func (InitializeTask) Version() atc.EventVersion { return "5.0" }
 
 // shadow the real atc.TaskConfig
 type TaskConfig struct {
@@ -69,11 +70,12 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 
 type StartTask struct {
 	Time   int64  `json:"time"`
+	Worker string `json:"worker"`
 	Origin Origin `json:"origin"`
 }
 
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
-func (StartTask) Version() atc.EventVersion { return "4.0" }
+func (StartTask) Version() atc.EventVersion { return "5.0" }
 
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
@@ -84,12 +86,30 @@ func (Status) EventType() atc.EventType  { return EventTypeStatus }
 func (Status) Version() atc.EventVersion { return "1.0" }
 
 type Log struct {
+	Time    int64  `json:"time"`
 	Origin  Origin `json:"origin"`
 	Payload string `json:"payload"`
 }
 
 func (Log) EventType() atc.EventType  { return EventTypeLog }
-func (Log) Version() atc.EventVersion { return "5.0" }
+func (Log) Version() atc.EventVersion { return "6.0" }
+
+type LogLimitScope string
+
+const (
+	LogLimitScopeStep  LogLimitScope = "step"
+	LogLimitScopeBuild LogLimitScope = "build"
+)
+
+type LogTruncated struct {
+	Time   int64         `json:"time"`
+	Origin Origin        `json:"origin"`
+	Scope  LogLimitScope `json:"scope"`
+	Limit  int64         `json:"limit"`
+}
+
+func (LogTruncated) EventType() atc.EventType  { return EventTypeLogTruncated }
+func (LogTruncated) Version() atc.EventVersion { return "1.0" }
 
 type Origin struct {
 	ID     OriginID     `json:"id,omitempty"`
@@ -106,6 +126,7 @@ const (
 )
 
 type FinishGet struct {
+	Time            int64               `json:"time"`
 	Origin          Origin              `json:"origin"`
 	Plan            GetPlan             `json:"plan"`
 	ExitStatus      int                 `json:"exit_status"`
@@ -114,7 +135,7 @@ type FinishGet struct {
 }
 
 func (FinishGet) EventType() atc.EventType  { return EventTypeFinishGet }
-func (FinishGet) Version() atc.EventVersion { return "4.0" }
+func (FinishGet) Version() atc.EventVersion { return "5.0" }
 
 type GetPlan struct {
 	Name     string      `json:"name"`
@@ -124,6 +145,7 @@ type GetPlan struct {
 }
 
 type FinishPut struct {
+	Time            int64               `json:"time"`
 	Origin          Origin              `json:"origin"`
 	Plan            PutPlan             `json:"plan"`
 	CreatedVersion  atc.Version         `json:"version"`
@@ -132,7 +154,7 @@ type FinishPut struct {
 }
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
-func (FinishPut) Version() atc.EventVersion { return "4.0" }
+func (FinishPut) Version() atc.EventVersion { return "5.0" }
 
 type PutPlan struct {
 	Name     string `json:"name"`
@@ -141,15 +163,79 @@ type PutPlan struct {
 }
 
 type InitializeGet struct {
+	Time   int64  `json:"time"`
 	Origin Origin `json:"origin"`
 }
 
 func (InitializeGet) EventType() atc.EventType  { return EventTypeInitializeGet }
-func (InitializeGet) Version() atc.EventVersion { return "1.0" }
+func (InitializeGet) Version() atc.EventVersion { return "2.0" }
+
+type StartGet struct {
+	Time   int64  `json:"time"`
+	Worker string `json:"worker"`
+	Origin Origin `json:"origin"`
+}
+
+func (StartGet) EventType() atc.EventType  { return EventTypeStartGet }
+func (StartGet) Version() atc.EventVersion { return "1.0" }
 
 type InitializePut struct {
+	Time   int64  `json:"time"`
 	Origin Origin `json:"origin"`
 }
 
 func (InitializePut) EventType() atc.EventType  { return EventTypeInitializePut }
-func (InitializePut) Version() atc.EventVersion { return "1.0" }
+func (InitializePut) Version() atc.EventVersion { return "2.0" }
+
+type StartPut struct {
+	Time   int64  `json:"time"`
+	Worker string `json:"worker"`
+	Origin Origin `json:"origin"`
+}
+
+func (StartPut) EventType() atc.EventType  { return EventTypeStartPut }
+func (StartPut) Version() atc.EventVersion { return "1.0" }
+
+// StepType is the kind of plan node that a StartStep or FinishStep event
+// refers to.
+type StepType string
+
+const (
+	StepTypeAggregate StepType = "aggregate"
+	StepTypeDo        StepType = "do"
+	StepTypeOnSuccess StepType = "on_success"
+	StepTypeOnFailure StepType = "on_failure"
+	StepTypeEnsure    StepType = "ensure"
+	StepTypeTimeout   StepType = "timeout"
+	StepTypeTry       StepType = "try"
+	StepTypeRetry     StepType = "retry"
+	StepTypeTask      StepType = "task"
+	StepTypeGet       StepType = "get"
+	StepTypePut       StepType = "put"
+)
+
+// StartStep is emitted when any step of the build's plan begins running.
+// Attempts holds the attempt number of each retry the step is nested in,
+// outermost first.
+type StartStep struct {
+	Time     int64    `json:"time"`
+	Type     StepType `json:"type"`
+	Attempts []int    `json:"attempts,omitempty"`
+	Origin   Origin   `json:"origin"`
+}
+
+func (StartStep) EventType() atc.EventType  { return EventTypeStartStep }
+func (StartStep) Version() atc.EventVersion { return "1.0" }
+
+// FinishStep is emitted when any step of the build's plan is done running,
+// whether it succeeded, failed, or errored.
+type FinishStep struct {
+	Time      int64    `json:"time"`
+	Type      StepType `json:"type"`
+	Attempts  []int    `json:"attempts,omitempty"`
+	Succeeded bool     `json:"succeeded"`
+	Origin    Origin   `json:"origin"`
+}
+
+func (FinishStep) EventType() atc.EventType  { return EventTypeFinishStep }
+func (FinishStep) Version() atc.EventVersion { return "1.0" }
@@ -37,11 +37,16 @@ func init() {
 	registerEvent(StartTask{})
 	registerEvent(FinishTask{})
 	registerEvent(InitializeGet{})
+	registerEvent(StartGet{})
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
+	registerEvent(StartPut{})
 	registerEvent(FinishPut{})
+	registerEvent(StartStep{})
+	registerEvent(FinishStep{})
 	registerEvent(Status{})
 	registerEvent(Log{})
+	registerEvent(LogTruncated{})
 	registerEvent(Error{})
 
 	// deprecated:
@@ -61,19 +66,26 @@ func init() {
 	registerEvent(InitializeTaskV10{})
 	registerEvent(InitializeTaskV20{})
 	registerEvent(InitializeTaskV30{})
+	registerEvent(InitializeTaskV40{})
 	registerEvent(StartTaskV10{})
 	registerEvent(StartTaskV20{})
 	registerEvent(StartTaskV30{})
+	registerEvent(StartTaskV40{})
 	registerEvent(LogV10{})
 	registerEvent(LogV20{})
 	registerEvent(LogV30{})
 	registerEvent(LogV40{})
+	registerEvent(LogV50{})
 	registerEvent(FinishGetV10{})
 	registerEvent(FinishGetV20{})
 	registerEvent(FinishGetV30{})
+	registerEvent(FinishGetV40{})
+	registerEvent(InitializeGetV10{})
 	registerEvent(FinishPutV10{})
 	registerEvent(FinishPutV20{})
 	registerEvent(FinishPutV30{})
+	registerEvent(FinishPutV40{})
+	registerEvent(InitializePutV10{})
 }
 
 type Message struct {
@@ -23,15 +23,30 @@ const (
 	// get step initializing
 	EventTypeInitializeGet atc.EventType = "initialize-get"
 
+	// get step running on a worker
+	EventTypeStartGet atc.EventType = "start-get"
+
 	// finished getting something
 	EventTypeFinishGet atc.EventType = "finish-get"
 
 	// put step initializing
 	EventTypeInitializePut atc.EventType = "initialize-put"
 
+	// put step running on a worker
+	EventTypeStartPut atc.EventType = "start-put"
+
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// any step of the plan started
+	EventTypeStartStep atc.EventType = "start-step"
+
+	// any step of the plan finished
+	EventTypeFinishStep atc.EventType = "finish-step"
+
+	// a step's log reached its size limit, and the rest was discarded
+	EventTypeLogTruncated atc.EventType = "log-truncated"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
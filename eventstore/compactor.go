@@ -0,0 +1,83 @@
+package eventstore
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+//go:generate counterfeiter . CompactorDB
+
+type CompactorDB interface {
+	LeaseBuildEventCompaction(interval time.Duration) (db.Lease, bool, error)
+	GetBuildsWithUncompactedEvents() ([]db.Build, error)
+	CompactBuildEvents(buildID int) error
+}
+
+// NewCompactor returns a runner which periodically moves the events of
+// completed builds out of the database and into the build event store.
+func NewCompactor(
+	logger lager.Logger,
+	db CompactorDB,
+	clock clock.Clock,
+	interval time.Duration,
+) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+
+		close(ready)
+
+		ticker := clock.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C():
+				leaseLogger := logger.Session("lease-compact-build-events")
+				leaseLogger.Info("tick")
+
+				lease, leased, err := db.LeaseBuildEventCompaction(interval)
+
+				if err != nil {
+					leaseLogger.Error("failed-to-get-lease", err)
+					break
+				}
+
+				if !leased {
+					leaseLogger.Debug("did-not-get-lease")
+					break
+				}
+
+				compact(leaseLogger, db)
+
+				lease.Break()
+			case <-signals:
+				return nil
+			}
+		}
+	})
+}
+
+func compact(logger lager.Logger, db CompactorDB) {
+	builds, err := db.GetBuildsWithUncompactedEvents()
+	if err != nil {
+		logger.Error("failed-to-get-builds", err)
+		return
+	}
+
+	for _, build := range builds {
+		err := db.CompactBuildEvents(build.ID)
+		if err != nil {
+			logger.Error("failed-to-compact-build-events", err, lager.Data{
+				"build": build.ID,
+			})
+		}
+	}
+
+	logger.Info("compacted", lager.Data{
+		"builds": len(builds),
+	})
+}
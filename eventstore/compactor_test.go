@@ -0,0 +1,143 @@
+package eventstore_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	"github.com/concourse/atc/db"
+	dbfakes "github.com/concourse/atc/db/fakes"
+	. "github.com/concourse/atc/eventstore"
+	"github.com/concourse/atc/eventstore/fakes"
+)
+
+var _ = Describe("Compactor", func() {
+	var (
+		fakeDB    *fakes.FakeCompactorDB
+		fakeClock *fakeclock.FakeClock
+		fakeLease *dbfakes.FakeLease
+
+		interval time.Duration
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(fakes.FakeCompactorDB)
+		fakeLease = new(dbfakes.FakeLease)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		interval = 100 * time.Millisecond
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(NewCompactor(
+			lagertest.NewTestLogger("test"),
+			fakeDB,
+			fakeClock,
+			interval,
+		))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Expect(<-process.Wait()).ToNot(HaveOccurred())
+	})
+
+	Context("when the interval elapses", func() {
+		JustBeforeEach(func() {
+			fakeClock.WaitForWatcherAndIncrement(interval)
+		})
+
+		It("calls to get a lease for build event compaction", func() {
+			Eventually(fakeDB.LeaseBuildEventCompactionCallCount).Should(Equal(1))
+			Expect(fakeDB.LeaseBuildEventCompactionArgsForCall(0)).To(Equal(interval))
+		})
+
+		Context("when getting a lease succeeds", func() {
+			BeforeEach(func() {
+				fakeDB.LeaseBuildEventCompactionReturns(fakeLease, true, nil)
+				fakeDB.GetBuildsWithUncompactedEventsReturns([]db.Build{
+					{ID: 1},
+					{ID: 2},
+				}, nil)
+			})
+
+			It("compacts the events of each build", func() {
+				Eventually(fakeDB.CompactBuildEventsCallCount).Should(Equal(2))
+				Expect(fakeDB.CompactBuildEventsArgsForCall(0)).To(Equal(1))
+				Expect(fakeDB.CompactBuildEventsArgsForCall(1)).To(Equal(2))
+			})
+
+			It("breaks the lease", func() {
+				Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+			})
+
+			Context("when compacting a build fails", func() {
+				BeforeEach(func() {
+					fakeDB.CompactBuildEventsStub = func(buildID int) error {
+						if buildID == 1 {
+							return errors.New("disaster")
+						}
+
+						return nil
+					}
+				})
+
+				It("carries on with the remaining builds", func() {
+					Eventually(fakeDB.CompactBuildEventsCallCount).Should(Equal(2))
+				})
+
+				It("breaks the lease", func() {
+					Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+				})
+			})
+
+			Context("when getting the builds fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetBuildsWithUncompactedEventsReturns(nil, errors.New("disaster"))
+				})
+
+				It("does not exit and does not compact anything", func() {
+					Consistently(fakeDB.CompactBuildEventsCallCount).Should(Equal(0))
+					Consistently(process.Wait()).ShouldNot(Receive())
+				})
+
+				It("breaks the lease", func() {
+					Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+				})
+			})
+		})
+
+		Context("when getting a lease fails", func() {
+			Context("because of an error", func() {
+				BeforeEach(func() {
+					fakeDB.LeaseBuildEventCompactionReturns(nil, true, errors.New("disaster"))
+				})
+
+				It("does not exit and does not compact anything", func() {
+					Consistently(fakeDB.GetBuildsWithUncompactedEventsCallCount).Should(Equal(0))
+					Consistently(process.Wait()).ShouldNot(Receive())
+				})
+			})
+
+			Context("because we got leased of false", func() {
+				BeforeEach(func() {
+					fakeDB.LeaseBuildEventCompactionReturns(nil, false, nil)
+				})
+
+				It("does not exit and does not compact anything", func() {
+					Consistently(fakeDB.GetBuildsWithUncompactedEventsCallCount).Should(Equal(0))
+					Consistently(process.Wait()).ShouldNot(Receive())
+				})
+			})
+		})
+	})
+})
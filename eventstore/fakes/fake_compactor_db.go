@@ -0,0 +1,131 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/eventstore"
+)
+
+type FakeCompactorDB struct {
+	LeaseBuildEventCompactionStub        func(interval time.Duration) (db.Lease, bool, error)
+	leaseBuildEventCompactionMutex       sync.RWMutex
+	leaseBuildEventCompactionArgsForCall []struct {
+		interval time.Duration
+	}
+	leaseBuildEventCompactionReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	GetBuildsWithUncompactedEventsStub        func() ([]db.Build, error)
+	getBuildsWithUncompactedEventsMutex       sync.RWMutex
+	getBuildsWithUncompactedEventsArgsForCall []struct{}
+	getBuildsWithUncompactedEventsReturns     struct {
+		result1 []db.Build
+		result2 error
+	}
+	CompactBuildEventsStub        func(buildID int) error
+	compactBuildEventsMutex       sync.RWMutex
+	compactBuildEventsArgsForCall []struct {
+		buildID int
+	}
+	compactBuildEventsReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeCompactorDB) LeaseBuildEventCompaction(interval time.Duration) (db.Lease, bool, error) {
+	fake.leaseBuildEventCompactionMutex.Lock()
+	fake.leaseBuildEventCompactionArgsForCall = append(fake.leaseBuildEventCompactionArgsForCall, struct {
+		interval time.Duration
+	}{interval})
+	fake.leaseBuildEventCompactionMutex.Unlock()
+	if fake.LeaseBuildEventCompactionStub != nil {
+		return fake.LeaseBuildEventCompactionStub(interval)
+	} else {
+		return fake.leaseBuildEventCompactionReturns.result1, fake.leaseBuildEventCompactionReturns.result2, fake.leaseBuildEventCompactionReturns.result3
+	}
+}
+
+func (fake *FakeCompactorDB) LeaseBuildEventCompactionCallCount() int {
+	fake.leaseBuildEventCompactionMutex.RLock()
+	defer fake.leaseBuildEventCompactionMutex.RUnlock()
+	return len(fake.leaseBuildEventCompactionArgsForCall)
+}
+
+func (fake *FakeCompactorDB) LeaseBuildEventCompactionArgsForCall(i int) time.Duration {
+	fake.leaseBuildEventCompactionMutex.RLock()
+	defer fake.leaseBuildEventCompactionMutex.RUnlock()
+	return fake.leaseBuildEventCompactionArgsForCall[i].interval
+}
+
+func (fake *FakeCompactorDB) LeaseBuildEventCompactionReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.LeaseBuildEventCompactionStub = nil
+	fake.leaseBuildEventCompactionReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompactorDB) GetBuildsWithUncompactedEvents() ([]db.Build, error) {
+	fake.getBuildsWithUncompactedEventsMutex.Lock()
+	fake.getBuildsWithUncompactedEventsArgsForCall = append(fake.getBuildsWithUncompactedEventsArgsForCall, struct{}{})
+	fake.getBuildsWithUncompactedEventsMutex.Unlock()
+	if fake.GetBuildsWithUncompactedEventsStub != nil {
+		return fake.GetBuildsWithUncompactedEventsStub()
+	} else {
+		return fake.getBuildsWithUncompactedEventsReturns.result1, fake.getBuildsWithUncompactedEventsReturns.result2
+	}
+}
+
+func (fake *FakeCompactorDB) GetBuildsWithUncompactedEventsCallCount() int {
+	fake.getBuildsWithUncompactedEventsMutex.RLock()
+	defer fake.getBuildsWithUncompactedEventsMutex.RUnlock()
+	return len(fake.getBuildsWithUncompactedEventsArgsForCall)
+}
+
+func (fake *FakeCompactorDB) GetBuildsWithUncompactedEventsReturns(result1 []db.Build, result2 error) {
+	fake.GetBuildsWithUncompactedEventsStub = nil
+	fake.getBuildsWithUncompactedEventsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCompactorDB) CompactBuildEvents(buildID int) error {
+	fake.compactBuildEventsMutex.Lock()
+	fake.compactBuildEventsArgsForCall = append(fake.compactBuildEventsArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.compactBuildEventsMutex.Unlock()
+	if fake.CompactBuildEventsStub != nil {
+		return fake.CompactBuildEventsStub(buildID)
+	} else {
+		return fake.compactBuildEventsReturns.result1
+	}
+}
+
+func (fake *FakeCompactorDB) CompactBuildEventsCallCount() int {
+	fake.compactBuildEventsMutex.RLock()
+	defer fake.compactBuildEventsMutex.RUnlock()
+	return len(fake.compactBuildEventsArgsForCall)
+}
+
+func (fake *FakeCompactorDB) CompactBuildEventsArgsForCall(i int) int {
+	fake.compactBuildEventsMutex.RLock()
+	defer fake.compactBuildEventsMutex.RUnlock()
+	return fake.compactBuildEventsArgsForCall[i].buildID
+}
+
+func (fake *FakeCompactorDB) CompactBuildEventsReturns(result1 error) {
+	fake.CompactBuildEventsStub = nil
+	fake.compactBuildEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ eventstore.CompactorDB = new(FakeCompactorDB)
@@ -0,0 +1,66 @@
+package eventstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+)
+
+type localStore struct {
+	dir string
+}
+
+// NewLocal returns a build event store which keeps each build's compacted
+// events as a file in the given directory.
+func NewLocal(dir string) db.BuildEventStore {
+	return &localStore{
+		dir: dir,
+	}
+}
+
+func (store *localStore) Put(buildID int, blob io.Reader) error {
+	tmp, err := ioutil.TempFile(store.dir, ".incoming-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, blob)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), store.path(buildID))
+}
+
+func (store *localStore) Get(buildID int) (io.ReadCloser, bool, error) {
+	file, err := os.Open(store.path(buildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (store *localStore) path(buildID int) string {
+	return filepath.Join(store.dir, blobName(buildID))
+}
+
+func blobName(buildID int) string {
+	return strconv.Itoa(buildID) + ".json.gz"
+}
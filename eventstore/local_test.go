@@ -0,0 +1,77 @@
+package eventstore_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/eventstore"
+)
+
+var _ = Describe("Local", func() {
+	var (
+		dir   string
+		store db.BuildEventStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "event-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = NewLocal(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("stores blobs by build ID", func() {
+		err := store.Put(42, bytes.NewBufferString("some-events"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(filepath.Join(dir, "42.json.gz")).To(BeARegularFile())
+
+		blob, found, err := store.Get(42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		defer blob.Close()
+
+		contents, err := ioutil.ReadAll(blob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-events"))
+	})
+
+	It("replaces existing blobs", func() {
+		err := store.Put(42, bytes.NewBufferString("old-events"))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = store.Put(42, bytes.NewBufferString("new-events"))
+		Expect(err).NotTo(HaveOccurred())
+
+		blob, _, err := store.Get(42)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer blob.Close()
+
+		contents, err := ioutil.ReadAll(blob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("new-events"))
+
+		entries, err := ioutil.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("does not find blobs that were never stored", func() {
+		_, found, err := store.Get(42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
@@ -0,0 +1,380 @@
+package eventstore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/clock"
+)
+
+// s3PartSize is how much of a blob is uploaded per request. Every part of a
+// multipart upload but the last must be at least 5MB.
+const s3PartSize = 5 * 1024 * 1024
+
+type S3Config struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Prefix          string
+}
+
+type s3Store struct {
+	config S3Config
+	client *http.Client
+	clock  clock.Clock
+}
+
+// NewS3 returns a build event store backed by a bucket in S3, or any service
+// exposing an S3-compatible API. Objects are addressed path-style, so the
+// endpoint should not include the bucket name.
+func NewS3(config S3Config, client *http.Client, clock clock.Clock) db.BuildEventStore {
+	return &s3Store{
+		config: config,
+		client: client,
+		clock:  clock,
+	}
+}
+
+type UnexpectedResponseError struct {
+	Method     string
+	Key        string
+	StatusCode int
+	Body       string
+}
+
+func (err UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("unexpected response for %s %s: %d %s", err.Method, err.Key, err.StatusCode, err.Body)
+}
+
+// Put uploads the blob as it is read, holding at most one part of it in
+// memory. Blobs that fit in a single part are uploaded with one request;
+// larger ones are uploaded in parts.
+func (store *s3Store) Put(buildID int, blob io.Reader) error {
+	part := make([]byte, s3PartSize)
+
+	n, err := io.ReadFull(blob, part)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return store.putObject(buildID, part[:n])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return store.putMultipart(buildID, part, blob)
+}
+
+func (store *s3Store) putObject(buildID int, payload []byte) error {
+	response, err := store.do("PUT", buildID, nil, payload)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return store.unexpectedResponse("PUT", buildID, response)
+	}
+
+	return nil
+}
+
+// putMultipart uploads first and then the rest of the blob as the parts of a
+// multipart upload, aborting the upload if any of it fails.
+func (store *s3Store) putMultipart(buildID int, first []byte, rest io.Reader) error {
+	uploadID, err := store.createMultipartUpload(buildID)
+	if err != nil {
+		return err
+	}
+
+	parts, err := store.uploadParts(buildID, uploadID, first, rest)
+	if err == nil {
+		err = store.completeMultipartUpload(buildID, uploadID, parts)
+	}
+
+	if err != nil {
+		store.abortMultipartUpload(buildID, uploadID)
+		return err
+	}
+
+	return nil
+}
+
+func (store *s3Store) uploadParts(buildID int, uploadID string, part []byte, rest io.Reader) ([]s3CompletedPart, error) {
+	parts := []s3CompletedPart{}
+
+	for {
+		partNumber := len(parts) + 1
+
+		response, err := store.do("PUT", buildID, url.Values{
+			"partNumber": {strconv.Itoa(partNumber)},
+			"uploadId":   {uploadID},
+		}, part)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return nil, store.unexpectedResponse("PUT", buildID, response)
+		}
+
+		parts = append(parts, s3CompletedPart{
+			PartNumber: partNumber,
+			ETag:       response.Header.Get("ETag"),
+		})
+
+		n, err := io.ReadFull(rest, part[:cap(part)])
+		if err == io.EOF {
+			return parts, nil
+		}
+
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+
+		// the last part may be smaller than the rest
+		part = part[:n]
+	}
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+type s3CompletedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+func (store *s3Store) createMultipartUpload(buildID int) (string, error) {
+	response, err := store.do("POST", buildID, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", store.unexpectedResponse("POST", buildID, response)
+	}
+
+	var result s3InitiateMultipartUploadResult
+	err = xml.NewDecoder(response.Body).Decode(&result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.UploadID, nil
+}
+
+func (store *s3Store) completeMultipartUpload(buildID int, uploadID string, parts []s3CompletedPart) error {
+	payload, err := xml.Marshal(s3CompleteMultipartUpload{Parts: parts})
+	if err != nil {
+		return err
+	}
+
+	response, err := store.do("POST", buildID, url.Values{"uploadId": {uploadID}}, payload)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return store.unexpectedResponse("POST", buildID, response)
+	}
+
+	// completing can fail after the response has started, in which case the
+	// error is in the body instead
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Contains(body, []byte("<Error>")) {
+		return UnexpectedResponseError{
+			Method:     "POST",
+			Key:        store.key(buildID),
+			StatusCode: response.StatusCode,
+			Body:       string(body),
+		}
+	}
+
+	return nil
+}
+
+// abortMultipartUpload discards the parts uploaded so far. It is best effort;
+// a bucket lifecycle rule can clean up after any uploads it fails to abort.
+func (store *s3Store) abortMultipartUpload(buildID int, uploadID string) {
+	response, err := store.do("DELETE", buildID, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return
+	}
+
+	response.Body.Close()
+}
+
+func (store *s3Store) Get(buildID int) (io.ReadCloser, bool, error) {
+	response, err := store.do("GET", buildID, nil, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, true, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, false, nil
+	default:
+		defer response.Body.Close()
+		return nil, false, store.unexpectedResponse("GET", buildID, response)
+	}
+}
+
+func (store *s3Store) key(buildID int) string {
+	return store.config.Prefix + blobName(buildID)
+}
+
+func (store *s3Store) do(method string, buildID int, query url.Values, payload []byte) (*http.Response, error) {
+	endpoint := strings.TrimRight(store.config.Endpoint, "/")
+
+	objectURL, err := url.Parse(endpoint + "/" + store.config.Bucket + "/" + store.key(buildID))
+	if err != nil {
+		return nil, err
+	}
+
+	objectURL.RawQuery = canonicalQuery(query)
+
+	request, err := http.NewRequest(method, objectURL.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	request.ContentLength = int64(len(payload))
+
+	if store.config.AccessKeyID != "" {
+		store.sign(request, payload)
+	}
+
+	return store.client.Do(request)
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request,
+// covering the host, date, and payload hash. The request's query must already
+// be in canonical form.
+func (store *s3Store) sign(request *http.Request, payload []byte) {
+	now := store.clock.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	payloadHash := hexSHA256(payload)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		"host:" + request.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, store.config.Region, "s3", "aws4_request"}, "/")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+store.config.SecretAccessKey), date)
+	key = hmacSHA256(key, store.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		store.config.AccessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+func (store *s3Store) unexpectedResponse(method string, buildID int, response *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(response.Body, 1024))
+
+	return UnexpectedResponseError{
+		Method:     method,
+		Key:        store.key(buildID),
+		StatusCode: response.StatusCode,
+		Body:       string(body),
+	}
+}
+
+// canonicalQuery encodes the query as Signature Version 4 expects: sorted by
+// key, with every value present and spaces escaped as %20.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func awsEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,213 @@
+package eventstore_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/eventstore"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible object store.
+type fakeS3 struct {
+	lock     sync.Mutex
+	objects  map[string][]byte
+	parts    map[string][]byte
+	requests []*http.Request
+}
+
+func (s3 *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s3.lock.Lock()
+	defer s3.lock.Unlock()
+
+	s3.requests = append(s3.requests, r)
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+
+	switch r.Method {
+	case "PUT":
+		if partNumber := query.Get("partNumber"); partNumber != "" {
+			s3.parts[partNumber] = body
+			w.Header().Set("ETag", `"etag-`+partNumber+`"`)
+			return
+		}
+
+		s3.objects[r.URL.Path] = body
+	case "POST":
+		if _, found := query["uploads"]; found {
+			w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>some-upload-id</UploadId></InitiateMultipartUploadResult>`))
+			return
+		}
+
+		var upload struct {
+			Parts []struct {
+				PartNumber string
+				ETag       string
+			} `xml:"Part"`
+		}
+
+		err := xml.Unmarshal(body, &upload)
+		if err != nil || query.Get("uploadId") != "some-upload-id" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		object := []byte{}
+		for _, part := range upload.Parts {
+			if part.ETag != `"etag-`+part.PartNumber+`"` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			object = append(object, s3.parts[part.PartNumber]...)
+		}
+
+		s3.objects[r.URL.Path] = object
+	case "GET":
+		body, found := s3.objects[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(body)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s3 *fakeS3) lastRequest() *http.Request {
+	s3.lock.Lock()
+	defer s3.lock.Unlock()
+
+	return s3.requests[len(s3.requests)-1]
+}
+
+var _ = Describe("S3", func() {
+	var (
+		s3     *fakeS3
+		server *httptest.Server
+
+		config S3Config
+		store  db.BuildEventStore
+	)
+
+	BeforeEach(func() {
+		s3 = &fakeS3{objects: map[string][]byte{}, parts: map[string][]byte{}}
+		server = httptest.NewServer(s3)
+
+		config = S3Config{
+			Endpoint:        server.URL,
+			Bucket:          "some-bucket",
+			Region:          "us-east-1",
+			AccessKeyID:     "some-access-key-id",
+			SecretAccessKey: "some-secret-access-key",
+			Prefix:          "build-events/",
+		}
+	})
+
+	JustBeforeEach(func() {
+		store = NewS3(config, http.DefaultClient, fakeclock.NewFakeClock(time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("stores blobs by build ID under the prefix", func() {
+		err := store.Put(42, bytes.NewBufferString("some-events"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(s3.objects).To(HaveKeyWithValue("/some-bucket/build-events/42.json.gz", []byte("some-events")))
+
+		blob, found, err := store.Get(42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		defer blob.Close()
+
+		contents, err := ioutil.ReadAll(blob)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-events"))
+	})
+
+	It("uploads blobs too large for one request in parts", func() {
+		blob := bytes.Repeat([]byte("some-events"), 1024*1024)
+
+		err := store.Put(42, bytes.NewReader(blob))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(s3.parts).To(HaveLen(3))
+		Expect(s3.parts["1"]).To(HaveLen(5 * 1024 * 1024))
+		Expect(s3.objects["/some-bucket/build-events/42.json.gz"]).To(Equal(blob))
+	})
+
+	It("signs requests", func() {
+		err := store.Put(42, bytes.NewBufferString("some-events"))
+		Expect(err).NotTo(HaveOccurred())
+
+		request := s3.lastRequest()
+		Expect(request.Header.Get("X-Amz-Date")).To(Equal("20160504T030201Z"))
+		Expect(request.Header.Get("X-Amz-Content-Sha256")).To(Equal("2a1197d84bc7558275305180bf64aaa51b16bae8117de9ce837d24f00ada1995"))
+		Expect(request.Header.Get("Authorization")).To(HavePrefix(
+			"AWS4-HMAC-SHA256 Credential=some-access-key-id/20160504/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=",
+		))
+	})
+
+	Context("without credentials", func() {
+		BeforeEach(func() {
+			config.AccessKeyID = ""
+			config.SecretAccessKey = ""
+		})
+
+		It("does not sign requests", func() {
+			err := store.Put(42, bytes.NewBufferString("some-events"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(s3.lastRequest().Header.Get("Authorization")).To(BeEmpty())
+		})
+	})
+
+	It("does not find blobs that were never stored", func() {
+		_, found, err := store.Get(42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when the store responds with an error", func() {
+		BeforeEach(func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte("access denied"))
+			})
+		})
+
+		It("returns an error", func() {
+			err := store.Put(42, bytes.NewBufferString("some-events"))
+			Expect(err).To(Equal(UnexpectedResponseError{
+				Method:     "PUT",
+				Key:        "build-events/42.json.gz",
+				StatusCode: http.StatusForbidden,
+				Body:       "access denied",
+			}))
+
+			_, _, err = store.Get(42)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -30,6 +30,19 @@ type ArtifactSource interface {
 	VolumeOn(worker.Worker) (baggageclaim.Volume, bool, error)
 }
 
+//go:generate counterfeiter . P2PArtifactSource
+
+// P2PArtifactSource is implemented by artifact sources whose data lives in a
+// volume on a worker. Rather than relaying the data through the ATC with
+// `StreamTo`, another worker can pull the volume found with `VolumeOn`
+// directly from this one.
+type P2PArtifactSource interface {
+	ArtifactSource
+
+	// WorkerName returns the name of the worker that holds the data.
+	WorkerName() string
+}
+
 //go:generate counterfeiter . ArtifactDestination
 
 // ArtifactDestination is the inverse of ArtifactSource. This interface allows
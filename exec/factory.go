@@ -68,6 +68,22 @@ type Factory interface {
 		TaskConfigSource,
 		atc.ResourceTypes,
 	) StepFactory
+
+	// ReusedTask constructs a TaskStep factory that recovers the task's result
+	// and outputs from the container identified by the first identifier, left
+	// behind by a previous build, and only runs the task if it is gone.
+	ReusedTask(
+		lager.Logger,
+		SourceName,
+		worker.Identifier,
+		worker.Identifier,
+		worker.Metadata,
+		TaskDelegate,
+		Privileged,
+		atc.Tags,
+		TaskConfigSource,
+		atc.ResourceTypes,
+	) StepFactory
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -82,7 +98,7 @@ type StepMetadata interface {
 // behavior.
 type TaskDelegate interface {
 	Initializing(atc.TaskConfig)
-	Started()
+	Started(workerName string)
 
 	Finished(ExitStatus)
 	Failed(error)
@@ -97,6 +113,7 @@ type TaskDelegate interface {
 // behavior.
 type ResourceDelegate interface {
 	Initializing()
+	Started(workerName string)
 
 	Completed(ExitStatus, *VersionInfo)
 	Failed(error)
@@ -123,6 +140,30 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . LifecycleDelegate
+
+// LifecycleDelegate is used to record when a step of any kind starts and
+// finishes running.
+//
+// ForcedFailure reports whether the step must be considered to have failed
+// regardless of its own result, e.g. because it exceeded a limit enforced by
+// the delegate.
+type LifecycleDelegate interface {
+	Started()
+	ForcedFailure() bool
+	Finished(Success)
+}
+
+//go:generate counterfeiter . ReportDelegate
+
+// ReportDelegate is used to record the results of the test reports parsed by
+// a ReportStep.
+type ReportDelegate interface {
+	Reported(atc.TestResults)
+
+	Stderr() io.Writer
+}
+
 // Privileged is used to indicate whether the given step should run with
 // special privileges (i.e. as an administrator user).
 type Privileged bool
@@ -78,6 +78,23 @@ type FakeFactory struct {
 	taskReturns struct {
 		result1 exec.StepFactory
 	}
+	ReusedTaskStub        func(lager.Logger, exec.SourceName, worker.Identifier, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, atc.ResourceTypes) exec.StepFactory
+	reusedTaskMutex       sync.RWMutex
+	reusedTaskArgsForCall []struct {
+		arg1  lager.Logger
+		arg2  exec.SourceName
+		arg3  worker.Identifier
+		arg4  worker.Identifier
+		arg5  worker.Metadata
+		arg6  exec.TaskDelegate
+		arg7  exec.Privileged
+		arg8  atc.Tags
+		arg9  exec.TaskConfigSource
+		arg10 atc.ResourceTypes
+	}
+	reusedTaskReturns struct {
+		result1 exec.StepFactory
+	}
 }
 
 func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exec.SourceName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 atc.Params, arg10 atc.Version, arg11 atc.ResourceTypes) exec.StepFactory {
@@ -243,4 +260,45 @@ func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
+func (fake *FakeFactory) ReusedTask(arg1 lager.Logger, arg2 exec.SourceName, arg3 worker.Identifier, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.TaskDelegate, arg7 exec.Privileged, arg8 atc.Tags, arg9 exec.TaskConfigSource, arg10 atc.ResourceTypes) exec.StepFactory {
+	fake.reusedTaskMutex.Lock()
+	fake.reusedTaskArgsForCall = append(fake.reusedTaskArgsForCall, struct {
+		arg1  lager.Logger
+		arg2  exec.SourceName
+		arg3  worker.Identifier
+		arg4  worker.Identifier
+		arg5  worker.Metadata
+		arg6  exec.TaskDelegate
+		arg7  exec.Privileged
+		arg8  atc.Tags
+		arg9  exec.TaskConfigSource
+		arg10 atc.ResourceTypes
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
+	fake.reusedTaskMutex.Unlock()
+	if fake.ReusedTaskStub != nil {
+		return fake.ReusedTaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+	} else {
+		return fake.reusedTaskReturns.result1
+	}
+}
+
+func (fake *FakeFactory) ReusedTaskCallCount() int {
+	fake.reusedTaskMutex.RLock()
+	defer fake.reusedTaskMutex.RUnlock()
+	return len(fake.reusedTaskArgsForCall)
+}
+
+func (fake *FakeFactory) ReusedTaskArgsForCall(i int) (lager.Logger, exec.SourceName, worker.Identifier, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, atc.ResourceTypes) {
+	fake.reusedTaskMutex.RLock()
+	defer fake.reusedTaskMutex.RUnlock()
+	return fake.reusedTaskArgsForCall[i].arg1, fake.reusedTaskArgsForCall[i].arg2, fake.reusedTaskArgsForCall[i].arg3, fake.reusedTaskArgsForCall[i].arg4, fake.reusedTaskArgsForCall[i].arg5, fake.reusedTaskArgsForCall[i].arg6, fake.reusedTaskArgsForCall[i].arg7, fake.reusedTaskArgsForCall[i].arg8, fake.reusedTaskArgsForCall[i].arg9, fake.reusedTaskArgsForCall[i].arg10
+}
+
+func (fake *FakeFactory) ReusedTaskReturns(result1 exec.StepFactory) {
+	fake.ReusedTaskStub = nil
+	fake.reusedTaskReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
 var _ exec.Factory = new(FakeFactory)
@@ -44,6 +44,11 @@ type FakeGetDelegate struct {
 	stderrReturns     struct {
 		result1 io.Writer
 	}
+	StartedStub        func(workerName string)
+	startedMutex       sync.RWMutex
+	startedArgsForCall []struct {
+		workerName string
+	}
 }
 
 func (fake *FakeGetDelegate) Initializing() {
@@ -188,4 +193,27 @@ func (fake *FakeGetDelegate) StderrReturns(result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeGetDelegate) Started(workerName string) {
+	fake.startedMutex.Lock()
+	fake.startedArgsForCall = append(fake.startedArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.startedMutex.Unlock()
+	if fake.StartedStub != nil {
+		fake.StartedStub(workerName)
+	}
+}
+
+func (fake *FakeGetDelegate) StartedCallCount() int {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return len(fake.startedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) StartedArgsForCall(i int) string {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return fake.startedArgsForCall[i].workerName
+}
+
 var _ exec.GetDelegate = new(FakeGetDelegate)
@@ -0,0 +1,89 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeLifecycleDelegate struct {
+	StartedStub         func()
+	startedMutex        sync.RWMutex
+	startedArgsForCall  []struct{}
+	FinishedStub        func(exec.Success)
+	finishedMutex       sync.RWMutex
+	finishedArgsForCall []struct {
+		arg1 exec.Success
+	}
+	ForcedFailureStub        func() bool
+	forcedFailureMutex       sync.RWMutex
+	forcedFailureArgsForCall []struct{}
+	forcedFailureReturns     struct {
+		result1 bool
+	}
+}
+
+func (fake *FakeLifecycleDelegate) Started() {
+	fake.startedMutex.Lock()
+	fake.startedArgsForCall = append(fake.startedArgsForCall, struct{}{})
+	fake.startedMutex.Unlock()
+	if fake.StartedStub != nil {
+		fake.StartedStub()
+	}
+}
+
+func (fake *FakeLifecycleDelegate) StartedCallCount() int {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return len(fake.startedArgsForCall)
+}
+
+func (fake *FakeLifecycleDelegate) Finished(arg1 exec.Success) {
+	fake.finishedMutex.Lock()
+	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
+		arg1 exec.Success
+	}{arg1})
+	fake.finishedMutex.Unlock()
+	if fake.FinishedStub != nil {
+		fake.FinishedStub(arg1)
+	}
+}
+
+func (fake *FakeLifecycleDelegate) FinishedCallCount() int {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return len(fake.finishedArgsForCall)
+}
+
+func (fake *FakeLifecycleDelegate) FinishedArgsForCall(i int) exec.Success {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return fake.finishedArgsForCall[i].arg1
+}
+
+func (fake *FakeLifecycleDelegate) ForcedFailure() bool {
+	fake.forcedFailureMutex.Lock()
+	fake.forcedFailureArgsForCall = append(fake.forcedFailureArgsForCall, struct{}{})
+	fake.forcedFailureMutex.Unlock()
+	if fake.ForcedFailureStub != nil {
+		return fake.ForcedFailureStub()
+	} else {
+		return fake.forcedFailureReturns.result1
+	}
+}
+
+func (fake *FakeLifecycleDelegate) ForcedFailureCallCount() int {
+	fake.forcedFailureMutex.RLock()
+	defer fake.forcedFailureMutex.RUnlock()
+	return len(fake.forcedFailureArgsForCall)
+}
+
+func (fake *FakeLifecycleDelegate) ForcedFailureReturns(result1 bool) {
+	fake.ForcedFailureStub = nil
+	fake.forcedFailureReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+var _ exec.LifecycleDelegate = new(FakeLifecycleDelegate)
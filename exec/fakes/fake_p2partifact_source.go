@@ -0,0 +1,172 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+)
+
+type FakeP2PArtifactSource struct {
+	StreamToStub        func(exec.ArtifactDestination) error
+	streamToMutex       sync.RWMutex
+	streamToArgsForCall []struct {
+		arg1 exec.ArtifactDestination
+	}
+	streamToReturns struct {
+		result1 error
+	}
+	StreamFileStub        func(path string) (io.ReadCloser, error)
+	streamFileMutex       sync.RWMutex
+	streamFileArgsForCall []struct {
+		path string
+	}
+	streamFileReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	VolumeOnStub        func(worker.Worker) (baggageclaim.Volume, bool, error)
+	volumeOnMutex       sync.RWMutex
+	volumeOnArgsForCall []struct {
+		arg1 worker.Worker
+	}
+	volumeOnReturns struct {
+		result1 baggageclaim.Volume
+		result2 bool
+		result3 error
+	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns     struct {
+		result1 string
+	}
+}
+
+func (fake *FakeP2PArtifactSource) StreamTo(arg1 exec.ArtifactDestination) error {
+	fake.streamToMutex.Lock()
+	fake.streamToArgsForCall = append(fake.streamToArgsForCall, struct {
+		arg1 exec.ArtifactDestination
+	}{arg1})
+	fake.streamToMutex.Unlock()
+	if fake.StreamToStub != nil {
+		return fake.StreamToStub(arg1)
+	} else {
+		return fake.streamToReturns.result1
+	}
+}
+
+func (fake *FakeP2PArtifactSource) StreamToCallCount() int {
+	fake.streamToMutex.RLock()
+	defer fake.streamToMutex.RUnlock()
+	return len(fake.streamToArgsForCall)
+}
+
+func (fake *FakeP2PArtifactSource) StreamToArgsForCall(i int) exec.ArtifactDestination {
+	fake.streamToMutex.RLock()
+	defer fake.streamToMutex.RUnlock()
+	return fake.streamToArgsForCall[i].arg1
+}
+
+func (fake *FakeP2PArtifactSource) StreamToReturns(result1 error) {
+	fake.StreamToStub = nil
+	fake.streamToReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeP2PArtifactSource) StreamFile(path string) (io.ReadCloser, error) {
+	fake.streamFileMutex.Lock()
+	fake.streamFileArgsForCall = append(fake.streamFileArgsForCall, struct {
+		path string
+	}{path})
+	fake.streamFileMutex.Unlock()
+	if fake.StreamFileStub != nil {
+		return fake.StreamFileStub(path)
+	} else {
+		return fake.streamFileReturns.result1, fake.streamFileReturns.result2
+	}
+}
+
+func (fake *FakeP2PArtifactSource) StreamFileCallCount() int {
+	fake.streamFileMutex.RLock()
+	defer fake.streamFileMutex.RUnlock()
+	return len(fake.streamFileArgsForCall)
+}
+
+func (fake *FakeP2PArtifactSource) StreamFileArgsForCall(i int) string {
+	fake.streamFileMutex.RLock()
+	defer fake.streamFileMutex.RUnlock()
+	return fake.streamFileArgsForCall[i].path
+}
+
+func (fake *FakeP2PArtifactSource) StreamFileReturns(result1 io.ReadCloser, result2 error) {
+	fake.StreamFileStub = nil
+	fake.streamFileReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeP2PArtifactSource) VolumeOn(arg1 worker.Worker) (baggageclaim.Volume, bool, error) {
+	fake.volumeOnMutex.Lock()
+	fake.volumeOnArgsForCall = append(fake.volumeOnArgsForCall, struct {
+		arg1 worker.Worker
+	}{arg1})
+	fake.volumeOnMutex.Unlock()
+	if fake.VolumeOnStub != nil {
+		return fake.VolumeOnStub(arg1)
+	} else {
+		return fake.volumeOnReturns.result1, fake.volumeOnReturns.result2, fake.volumeOnReturns.result3
+	}
+}
+
+func (fake *FakeP2PArtifactSource) VolumeOnCallCount() int {
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return len(fake.volumeOnArgsForCall)
+}
+
+func (fake *FakeP2PArtifactSource) VolumeOnArgsForCall(i int) worker.Worker {
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return fake.volumeOnArgsForCall[i].arg1
+}
+
+func (fake *FakeP2PArtifactSource) VolumeOnReturns(result1 baggageclaim.Volume, result2 bool, result3 error) {
+	fake.VolumeOnStub = nil
+	fake.volumeOnReturns = struct {
+		result1 baggageclaim.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeP2PArtifactSource) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	} else {
+		return fake.workerNameReturns.result1
+	}
+}
+
+func (fake *FakeP2PArtifactSource) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeP2PArtifactSource) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+var _ exec.P2PArtifactSource = new(FakeP2PArtifactSource)
@@ -44,6 +44,11 @@ type FakePutDelegate struct {
 	stderrReturns     struct {
 		result1 io.Writer
 	}
+	StartedStub        func(workerName string)
+	startedMutex       sync.RWMutex
+	startedArgsForCall []struct {
+		workerName string
+	}
 }
 
 func (fake *FakePutDelegate) Initializing() {
@@ -188,4 +193,27 @@ func (fake *FakePutDelegate) StderrReturns(result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakePutDelegate) Started(workerName string) {
+	fake.startedMutex.Lock()
+	fake.startedArgsForCall = append(fake.startedArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.startedMutex.Unlock()
+	if fake.StartedStub != nil {
+		fake.StartedStub(workerName)
+	}
+}
+
+func (fake *FakePutDelegate) StartedCallCount() int {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return len(fake.startedArgsForCall)
+}
+
+func (fake *FakePutDelegate) StartedArgsForCall(i int) string {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return fake.startedArgsForCall[i].workerName
+}
+
 var _ exec.PutDelegate = new(FakePutDelegate)
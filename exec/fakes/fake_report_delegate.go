@@ -0,0 +1,73 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/exec"
+)
+
+type FakeReportDelegate struct {
+	ReportedStub        func(atc.TestResults)
+	reportedMutex       sync.RWMutex
+	reportedArgsForCall []struct {
+		arg1 atc.TestResults
+	}
+	StderrStub        func() io.Writer
+	stderrMutex       sync.RWMutex
+	stderrArgsForCall []struct{}
+	stderrReturns     struct {
+		result1 io.Writer
+	}
+}
+
+func (fake *FakeReportDelegate) Reported(arg1 atc.TestResults) {
+	fake.reportedMutex.Lock()
+	fake.reportedArgsForCall = append(fake.reportedArgsForCall, struct {
+		arg1 atc.TestResults
+	}{arg1})
+	fake.reportedMutex.Unlock()
+	if fake.ReportedStub != nil {
+		fake.ReportedStub(arg1)
+	}
+}
+
+func (fake *FakeReportDelegate) ReportedCallCount() int {
+	fake.reportedMutex.RLock()
+	defer fake.reportedMutex.RUnlock()
+	return len(fake.reportedArgsForCall)
+}
+
+func (fake *FakeReportDelegate) ReportedArgsForCall(i int) atc.TestResults {
+	fake.reportedMutex.RLock()
+	defer fake.reportedMutex.RUnlock()
+	return fake.reportedArgsForCall[i].arg1
+}
+
+func (fake *FakeReportDelegate) Stderr() io.Writer {
+	fake.stderrMutex.Lock()
+	fake.stderrArgsForCall = append(fake.stderrArgsForCall, struct{}{})
+	fake.stderrMutex.Unlock()
+	if fake.StderrStub != nil {
+		return fake.StderrStub()
+	} else {
+		return fake.stderrReturns.result1
+	}
+}
+
+func (fake *FakeReportDelegate) StderrCallCount() int {
+	fake.stderrMutex.RLock()
+	defer fake.stderrMutex.RUnlock()
+	return len(fake.stderrArgsForCall)
+}
+
+func (fake *FakeReportDelegate) StderrReturns(result1 io.Writer) {
+	fake.StderrStub = nil
+	fake.stderrReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+var _ exec.ReportDelegate = new(FakeReportDelegate)
@@ -16,9 +16,6 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 atc.TaskConfig
 	}
-	StartedStub         func()
-	startedMutex        sync.RWMutex
-	startedArgsForCall  []struct{}
 	FinishedStub        func(exec.ExitStatus)
 	finishedMutex       sync.RWMutex
 	finishedArgsForCall []struct {
@@ -49,6 +46,11 @@ type FakeTaskDelegate struct {
 	stderrReturns     struct {
 		result1 io.Writer
 	}
+	StartedStub        func(workerName string)
+	startedMutex       sync.RWMutex
+	startedArgsForCall []struct {
+		workerName string
+	}
 }
 
 func (fake *FakeTaskDelegate) Initializing(arg1 atc.TaskConfig) {
@@ -74,21 +76,6 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) atc.TaskConfig {
 	return fake.initializingArgsForCall[i].arg1
 }
 
-func (fake *FakeTaskDelegate) Started() {
-	fake.startedMutex.Lock()
-	fake.startedArgsForCall = append(fake.startedArgsForCall, struct{}{})
-	fake.startedMutex.Unlock()
-	if fake.StartedStub != nil {
-		fake.StartedStub()
-	}
-}
-
-func (fake *FakeTaskDelegate) StartedCallCount() int {
-	fake.startedMutex.RLock()
-	defer fake.startedMutex.RUnlock()
-	return len(fake.startedArgsForCall)
-}
-
 func (fake *FakeTaskDelegate) Finished(arg1 exec.ExitStatus) {
 	fake.finishedMutex.Lock()
 	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
@@ -215,4 +202,27 @@ func (fake *FakeTaskDelegate) StderrReturns(result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) Started(workerName string) {
+	fake.startedMutex.Lock()
+	fake.startedArgsForCall = append(fake.startedArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.startedMutex.Unlock()
+	if fake.StartedStub != nil {
+		fake.StartedStub(workerName)
+	}
+}
+
+func (fake *FakeTaskDelegate) StartedCallCount() int {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return len(fake.startedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) StartedArgsForCall(i int) string {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return fake.startedArgsForCall[i].workerName
+}
+
 var _ exec.TaskDelegate = new(FakeTaskDelegate)
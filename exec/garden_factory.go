@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 
 	"github.com/concourse/atc"
@@ -16,6 +17,7 @@ type gardenFactory struct {
 	workerClient   worker.Client
 	tracker        resource.Tracker
 	trackerFactory TrackerFactory
+	p2pStreaming   bool
 }
 
 //go:generate counterfeiter . TrackerFactory
@@ -27,10 +29,12 @@ type TrackerFactory interface {
 func NewGardenFactory(
 	workerClient worker.Client,
 	tracker resource.Tracker,
+	p2pStreaming bool,
 ) Factory {
 	return &gardenFactory{
 		workerClient: workerClient,
 		tracker:      tracker,
+		p2pStreaming: p2pStreaming,
 	}
 }
 
@@ -157,9 +161,44 @@ func (factory *gardenFactory) Task(
 		workingDirectory,
 		factory.trackerFactory,
 		resourceTypes,
+		clock.NewClock(),
+		factory.p2pStreaming,
 	)
 }
 
+func (factory *gardenFactory) ReusedTask(
+	logger lager.Logger,
+	sourceName SourceName,
+	reuseID worker.Identifier,
+	id worker.Identifier,
+	workerMetadata worker.Metadata,
+	delegate TaskDelegate,
+	privileged Privileged,
+	tags atc.Tags,
+	configSource TaskConfigSource,
+	resourceTypes atc.ResourceTypes,
+) StepFactory {
+	workingDirectory := factory.taskWorkingDirectory(sourceName)
+	workerMetadata.WorkingDirectory = workingDirectory
+	step := newTaskStep(
+		logger,
+		id,
+		workerMetadata,
+		tags,
+		delegate,
+		privileged,
+		configSource,
+		factory.workerClient,
+		workingDirectory,
+		factory.trackerFactory,
+		resourceTypes,
+		clock.NewClock(),
+		factory.p2pStreaming,
+	)
+	step.reuseContainerID = &reuseID
+	return step
+}
+
 func (factory *gardenFactory) taskWorkingDirectory(sourceName SourceName) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
@@ -111,6 +111,7 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	trackedResource, cache, err := step.tracker.InitWithCache(
 		step.logger,
+		signals,
 		step.stepMetadata,
 		runSession,
 		resource.ResourceType(step.resourceConfig.Type),
@@ -119,6 +120,10 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.resourceTypes,
 		step.delegate,
 	)
+	if err == resource.ErrAborted {
+		return ErrInterrupted
+	}
+
 	if err != nil {
 		step.logger.Error("failed-to-initialize-resource", err)
 		return err
@@ -126,6 +131,8 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	step.resource = trackedResource
 
+	step.delegate.Started(step.resource.WorkerName())
+
 	step.versionedSource = step.resource.Get(
 		resource.IOConfig{
 			Stdout: step.delegate.Stdout(),
@@ -245,6 +252,11 @@ func (step *GetStep) StreamTo(destination ArtifactDestination) error {
 	return destination.StreamIn(".", out)
 }
 
+// WorkerName returns the name of the worker that fetched the resource.
+func (step *GetStep) WorkerName() string {
+	return step.resource.WorkerName()
+}
+
 // StreamFile streams a single file out of the resource.
 func (step *GetStep) StreamFile(path string) (io.ReadCloser, error) {
 	out, err := step.versionedSource.StreamOut(path)
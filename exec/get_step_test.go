@@ -54,7 +54,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeTrackerFactory = new(fakes.FakeTrackerFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, false)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -136,6 +136,7 @@ var _ = Describe("GardenFactory", func() {
 
 			BeforeEach(func() {
 				fakeResource = new(rfakes.FakeResource)
+				fakeResource.WorkerNameReturns("some-worker")
 				fakeCache = new(rfakes.FakeCache)
 				fakeTracker.InitWithCacheReturns(fakeResource, fakeCache, nil)
 
@@ -148,7 +149,7 @@ var _ = Describe("GardenFactory", func() {
 
 			It("created a cached resource", func() {
 				Expect(fakeTracker.InitWithCacheCallCount()).To(Equal(1))
-				_, sm, sid, typ, tags, cacheID, actualResourceTypes, delegate := fakeTracker.InitWithCacheArgsForCall(0)
+				_, _, sm, sid, typ, tags, cacheID, actualResourceTypes, delegate := fakeTracker.InitWithCacheArgsForCall(0)
 				Expect(sm).To(Equal(stepMetadata))
 				Expect(sid).To(Equal(resource.Session{
 					ID: worker.Identifier{
@@ -187,7 +188,7 @@ var _ = Describe("GardenFactory", func() {
 				BeforeEach(func() {
 					callCountDuringInit = make(chan int, 1)
 
-					fakeTracker.InitWithCacheStub = func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, resource.CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, resource.Cache, error) {
+					fakeTracker.InitWithCacheStub = func(lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, resource.CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, resource.Cache, error) {
 						callCountDuringInit <- getDelegate.InitializingCallCount()
 						return fakeResource, fakeCache, nil
 					}
@@ -198,6 +199,11 @@ var _ = Describe("GardenFactory", func() {
 				})
 			})
 
+			It("calls the Started method on the delegate with the resource's worker", func() {
+				Expect(getDelegate.StartedCallCount()).To(Equal(1))
+				Expect(getDelegate.StartedArgsForCall(0)).To(Equal("some-worker"))
+			})
+
 			It("gets the resource with the correct source, params, and version", func() {
 				Expect(fakeResource.GetCallCount()).To(Equal(1))
 
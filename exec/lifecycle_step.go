@@ -0,0 +1,63 @@
+package exec
+
+import "os"
+
+// LifecycleStep wraps another step, and tells its delegate when the nested
+// step starts and finishes running.
+type LifecycleStep struct {
+	step     StepFactory
+	delegate LifecycleDelegate
+
+	runStep Step
+}
+
+// Lifecycle constructs a LifecycleStep factory.
+func Lifecycle(step StepFactory, delegate LifecycleDelegate) LifecycleStep {
+	return LifecycleStep{
+		step:     step,
+		delegate: delegate,
+	}
+}
+
+// Using constructs a *LifecycleStep.
+func (ls LifecycleStep) Using(prev Step, repo *SourceRepository) Step {
+	ls.runStep = ls.step.Using(prev, repo)
+	return &ls
+}
+
+// Run notifies the delegate that the step has started, runs the nested step,
+// and then notifies the delegate of whether it succeeded. A nested step that
+// errors or is interrupted is considered to have not succeeded.
+func (ls *LifecycleStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ls.delegate.Started()
+
+	err := ls.runStep.Run(signals, ready)
+
+	var succeeded Success
+	if err == nil {
+		ls.Result(&succeeded)
+	}
+
+	ls.delegate.Finished(succeeded)
+
+	return err
+}
+
+// Release releases the nested step.
+func (ls *LifecycleStep) Release() {
+	ls.runStep.Release()
+}
+
+// Result delegates to the nested step, except that Success is false if the
+// delegate forces the step to fail.
+func (ls *LifecycleStep) Result(x interface{}) bool {
+	if !ls.runStep.Result(x) {
+		return false
+	}
+
+	if succeeded, ok := x.(*Success); ok && ls.delegate.ForcedFailure() {
+		*succeeded = false
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lifecycle Step", func() {
+	var (
+		fakeStepFactory *fakes.FakeStepFactory
+		runStep         *fakes.FakeStep
+
+		delegate *fakes.FakeLifecycleDelegate
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		fakeStepFactory = new(fakes.FakeStepFactory)
+		runStep = new(fakes.FakeStep)
+		fakeStepFactory.UsingReturns(runStep)
+
+		delegate = new(fakes.FakeLifecycleDelegate)
+
+		step = Lifecycle(fakeStepFactory, delegate).Using(nil, nil)
+	})
+
+	Describe("Run", func() {
+		It("notifies the delegate before running the nested step", func() {
+			runStep.RunStub = func(<-chan os.Signal, chan<- struct{}) error {
+				defer GinkgoRecover()
+				Expect(delegate.StartedCallCount()).To(Equal(1))
+				Expect(delegate.FinishedCallCount()).To(BeZero())
+				return nil
+			}
+
+			err := step.Run(nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(runStep.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when the nested step succeeds", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(true)
+			})
+
+			It("notifies the delegate that it succeeded", func() {
+				err := step.Run(nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(delegate.FinishedCallCount()).To(Equal(1))
+				Expect(delegate.FinishedArgsForCall(0)).To(Equal(Success(true)))
+			})
+		})
+
+		Context("when the nested step fails", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(false)
+			})
+
+			It("notifies the delegate that it did not succeed", func() {
+				err := step.Run(nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(delegate.FinishedCallCount()).To(Equal(1))
+				Expect(delegate.FinishedArgsForCall(0)).To(Equal(Success(false)))
+			})
+		})
+
+		Context("when the delegate forces the step to fail", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(true)
+				delegate.ForcedFailureReturns(true)
+			})
+
+			It("notifies the delegate that it did not succeed", func() {
+				err := step.Run(nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(delegate.FinishedCallCount()).To(Equal(1))
+				Expect(delegate.FinishedArgsForCall(0)).To(Equal(Success(false)))
+			})
+		})
+
+		Context("when the nested step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				runStep.RunReturns(disaster)
+				runStep.ResultStub = successResult(true)
+			})
+
+			It("returns the error and notifies the delegate that it did not succeed", func() {
+				err := step.Run(nil, nil)
+				Expect(err).To(Equal(disaster))
+
+				Expect(delegate.FinishedCallCount()).To(Equal(1))
+				Expect(delegate.FinishedArgsForCall(0)).To(Equal(Success(false)))
+			})
+		})
+	})
+
+	Describe("Release", func() {
+		It("releases the nested step", func() {
+			step.Release()
+			Expect(runStep.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Result", func() {
+		It("delegates to the nested step", func() {
+			runStep.ResultStub = successResult(false)
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(false)))
+		})
+
+		Context("when the delegate forces the step to fail", func() {
+			BeforeEach(func() {
+				delegate.ForcedFailureReturns(true)
+			})
+
+			It("indicates that the step did not succeed", func() {
+				runStep.ResultStub = successResult(true)
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+
+			It("does not affect other results", func() {
+				runStep.ResultStub = func(x interface{}) bool {
+					switch v := x.(type) {
+					case *ExitStatus:
+						*v = ExitStatus(0)
+						return true
+					default:
+						return false
+					}
+				}
+
+				var status ExitStatus
+				Expect(step.Result(&status)).To(BeTrue())
+				Expect(status).To(Equal(ExitStatus(0)))
+
+				var success Success
+				Expect(step.Result(&success)).To(BeFalse())
+			})
+		})
+	})
+})
@@ -90,6 +90,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	trackedResource, missingNames, err := step.tracker.InitWithSources(
 		step.logger,
+		signals,
 		step.stepMetadata,
 		runSession,
 		resource.ResourceType(step.resourceConfig.Type),
@@ -99,6 +100,10 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.delegate,
 	)
 
+	if err == resource.ErrAborted {
+		return ErrInterrupted
+	}
+
 	if err != nil {
 		return err
 	}
@@ -110,6 +115,8 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	step.resource = trackedResource
 
+	step.delegate.Started(step.resource.WorkerName())
+
 	scopedRepo, err := step.repository.ScopedTo(missingSourceNames...)
 	if err != nil {
 		return err
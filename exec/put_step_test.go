@@ -50,7 +50,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeTrackerFactory = new(fakes.FakeTrackerFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, false)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -133,6 +133,7 @@ var _ = Describe("GardenFactory", func() {
 
 			BeforeEach(func() {
 				fakeResource = new(rfakes.FakeResource)
+				fakeResource.WorkerNameReturns("some-worker")
 				fakeTracker.InitWithSourcesReturns(fakeResource, []string{"some-source", "some-other-source"}, nil)
 
 				fakeVersionedSource = new(rfakes.FakeVersionedSource)
@@ -145,7 +146,7 @@ var _ = Describe("GardenFactory", func() {
 			It("initializes the resource with the correct type, session, and sources", func() {
 				Expect(fakeTracker.InitWithSourcesCallCount()).To(Equal(1))
 
-				_, sm, sid, typ, tags, sources, actualResourceTypes, delegate := fakeTracker.InitWithSourcesArgsForCall(0)
+				_, _, sm, sid, typ, tags, sources, actualResourceTypes, delegate := fakeTracker.InitWithSourcesArgsForCall(0)
 				Expect(sm).To(Equal(stepMetadata))
 				Expect(sid).To(Equal(resource.Session{
 					ID: worker.Identifier{
@@ -261,7 +262,7 @@ var _ = Describe("GardenFactory", func() {
 				BeforeEach(func() {
 					callCountDuringInit = make(chan int, 1)
 
-					fakeTracker.InitWithSourcesStub = func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
+					fakeTracker.InitWithSourcesStub = func(lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
 						callCountDuringInit <- putDelegate.InitializingCallCount()
 						return fakeResource, []string{"some-source", "some-other-source"}, nil
 					}
@@ -272,6 +273,11 @@ var _ = Describe("GardenFactory", func() {
 				})
 			})
 
+			It("calls the Started method on the delegate with the resource's worker", func() {
+				Expect(putDelegate.StartedCallCount()).To(Equal(1))
+				Expect(putDelegate.StartedArgsForCall(0)).To(Equal("some-worker"))
+			})
+
 			Describe("releasing", func() {
 				It("releases the resource with a ttl of 5 minutes", func() {
 					<-process.Wait()
@@ -0,0 +1,70 @@
+package exec_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/fakes"
+)
+
+var _ = Describe("Recorded Put Step", func() {
+	var (
+		delegate *fakes.FakePutDelegate
+
+		info VersionInfo
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		delegate = new(fakes.FakePutDelegate)
+
+		info = VersionInfo{
+			Version:  atc.Version{"some": "version"},
+			Metadata: []atc.MetadataField{{Name: "some", Value: "metadata"}},
+		}
+
+		step = RecordedPut(delegate, info).Using(new(fakes.FakeStep), NewSourceRepository())
+	})
+
+	JustBeforeEach(func() {
+		process := ifrit.Invoke(step)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("reports the recorded version to the delegate", func() {
+		Expect(delegate.InitializingCallCount()).To(Equal(1))
+		Expect(delegate.CompletedCallCount()).To(Equal(1))
+
+		exitStatus, versionInfo := delegate.CompletedArgsForCall(0)
+		Expect(exitStatus).To(Equal(ExitStatus(0)))
+		Expect(versionInfo).To(Equal(&info))
+	})
+
+	It("does not start anything", func() {
+		Expect(delegate.StartedCallCount()).To(BeZero())
+	})
+
+	Describe("Result", func() {
+		It("is successful", func() {
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeTrue())
+		})
+
+		It("returns the recorded version", func() {
+			var versionInfo VersionInfo
+			Expect(step.Result(&versionInfo)).To(BeTrue())
+			Expect(versionInfo).To(Equal(info))
+		})
+
+		It("ignores other types", func() {
+			var exitStatus ExitStatus
+			Expect(step.Result(&exitStatus)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package exec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// ReportStep parses JUnit XML reports out of the artifacts produced by the
+// previous step, and records a summary of them with its delegate.
+type ReportStep struct {
+	paths    []string
+	delegate ReportDelegate
+
+	prev Step
+	repo *SourceRepository
+}
+
+// Report constructs a ReportStep factory. Each path must start with the name
+// of the artifact source that contains the report.
+func Report(paths []string, delegate ReportDelegate) ReportStep {
+	return ReportStep{
+		paths:    paths,
+		delegate: delegate,
+	}
+}
+
+// Using finishes construction of the ReportStep and returns a *ReportStep.
+func (step ReportStep) Using(prev Step, repo *SourceRepository) Step {
+	step.prev = prev
+	step.repo = repo
+	return &step
+}
+
+// Run parses each report and passes the combined results to the delegate.
+// Reports that cannot be found or parsed are skipped with a warning in the
+// build's log, so that they do not mask the result of the previous step.
+func (step *ReportStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	results := atc.TestResults{
+		FailedTests: []string{},
+	}
+
+	for _, path := range step.paths {
+		reportResults, err := step.parseReport(path)
+		if err != nil {
+			fmt.Fprintf(step.delegate.Stderr(), "failed to parse test report %s: %s\n", path, err)
+			continue
+		}
+
+		results = results.Add(reportResults)
+	}
+
+	step.delegate.Reported(results)
+
+	return nil
+}
+
+// Result is delegated to the previous step, as parsing its reports does not
+// change its outcome.
+func (step *ReportStep) Result(x interface{}) bool {
+	return step.prev.Result(x)
+}
+
+// Release does nothing. The previous step is released by whoever ran it.
+func (*ReportStep) Release() {}
+
+func (step *ReportStep) parseReport(path string) (atc.TestResults, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return atc.TestResults{}, UnspecifiedArtifactSourceError{path}
+	}
+
+	source, found := step.repo.SourceFor(SourceName(segs[0]))
+	if !found {
+		return atc.TestResults{}, UnknownArtifactSourceError{SourceName(segs[0])}
+	}
+
+	stream, err := source.StreamFile(segs[1])
+	if err != nil {
+		return atc.TestResults{}, err
+	}
+
+	defer stream.Close()
+
+	return ParseJUnitReport(stream)
+}
+
+type junitReport struct {
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitSuite struct {
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string    `xml:"name,attr"`
+	ClassName string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+// ParseJUnitReport summarizes a JUnit XML report. The root element may either
+// be a single <testsuite> or a <testsuites> element containing them.
+func ParseJUnitReport(reader io.Reader) (atc.TestResults, error) {
+	var report junitReport
+	err := xml.NewDecoder(reader).Decode(&report)
+	if err != nil {
+		return atc.TestResults{}, err
+	}
+
+	results := atc.TestResults{
+		FailedTests: []string{},
+	}
+
+	addJUnitCases(&results, report.Cases)
+	addJUnitSuites(&results, report.Suites)
+
+	return results, nil
+}
+
+func addJUnitSuites(results *atc.TestResults, suites []junitSuite) {
+	for _, suite := range suites {
+		addJUnitCases(results, suite.Cases)
+		addJUnitSuites(results, suite.Suites)
+	}
+}
+
+func addJUnitCases(results *atc.TestResults, cases []junitCase) {
+	for _, testCase := range cases {
+		switch {
+		case testCase.Failure != nil, testCase.Error != nil:
+			results.Failed++
+
+			name := testCase.Name
+			if testCase.ClassName != "" {
+				name = testCase.ClassName + "." + name
+			}
+
+			results.FailedTests = append(results.FailedTests, name)
+
+		case testCase.Skipped != nil:
+			results.Skipped++
+
+		default:
+			results.Passed++
+		}
+	}
+}
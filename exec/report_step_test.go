@@ -0,0 +1,194 @@
+package exec_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/fakes"
+)
+
+var _ = Describe("Report Step", func() {
+	var (
+		delegate *fakes.FakeReportDelegate
+		stderr   *gbytes.Buffer
+
+		previousStep *fakes.FakeStep
+		repo         *SourceRepository
+
+		artifactSource *fakes.FakeArtifactSource
+
+		paths []string
+
+		step Step
+	)
+
+	const report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="some-suite">
+		<testcase classname="some.Class" name="testPasses"></testcase>
+		<testcase classname="some.Class" name="testFails">
+			<failure message="expected true">stack trace</failure>
+		</testcase>
+		<testcase classname="some.Class" name="testIsSkipped">
+			<skipped/>
+		</testcase>
+	</testsuite>
+</testsuites>`
+
+	BeforeEach(func() {
+		delegate = new(fakes.FakeReportDelegate)
+		stderr = gbytes.NewBuffer()
+		delegate.StderrReturns(stderr)
+
+		previousStep = new(fakes.FakeStep)
+		repo = NewSourceRepository()
+
+		artifactSource = new(fakes.FakeArtifactSource)
+		repo.RegisterSource("some-output", artifactSource)
+
+		paths = []string{"some-output/junit.xml"}
+	})
+
+	JustBeforeEach(func() {
+		step = Report(paths, delegate).Using(previousStep, repo)
+	})
+
+	Context("when the report can be read", func() {
+		BeforeEach(func() {
+			artifactSource.StreamFileReturns(ioutil.NopCloser(strings.NewReader(report)), nil)
+		})
+
+		It("streams the report from the artifact source", func() {
+			process := ifrit.Invoke(step)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(artifactSource.StreamFileCallCount()).To(Equal(1))
+			Expect(artifactSource.StreamFileArgsForCall(0)).To(Equal("junit.xml"))
+		})
+
+		It("reports the summarized results", func() {
+			process := ifrit.Invoke(step)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(delegate.ReportedCallCount()).To(Equal(1))
+			Expect(delegate.ReportedArgsForCall(0)).To(Equal(atc.TestResults{
+				Passed:      1,
+				Failed:      1,
+				Skipped:     1,
+				FailedTests: []string{"some.Class.testFails"},
+			}))
+		})
+
+		Context("when there are multiple reports", func() {
+			BeforeEach(func() {
+				paths = []string{"some-output/a.xml", "some-output/b.xml"}
+
+				artifactSource.StreamFileStub = func(string) (io.ReadCloser, error) {
+					return ioutil.NopCloser(strings.NewReader(report)), nil
+				}
+			})
+
+			It("combines their results", func() {
+				process := ifrit.Invoke(step)
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(delegate.ReportedCallCount()).To(Equal(1))
+				Expect(delegate.ReportedArgsForCall(0)).To(Equal(atc.TestResults{
+					Passed:      2,
+					Failed:      2,
+					Skipped:     2,
+					FailedTests: []string{"some.Class.testFails", "some.Class.testFails"},
+				}))
+			})
+		})
+	})
+
+	Context("when the report's artifact source is not found", func() {
+		BeforeEach(func() {
+			paths = []string{"bogus-output/junit.xml"}
+		})
+
+		It("warns on stderr and reports empty results", func() {
+			process := ifrit.Invoke(step)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderr).To(gbytes.Say("failed to parse test report bogus-output/junit.xml"))
+
+			Expect(delegate.ReportedCallCount()).To(Equal(1))
+			Expect(delegate.ReportedArgsForCall(0)).To(Equal(atc.TestResults{
+				FailedTests: []string{},
+			}))
+		})
+	})
+
+	Context("when streaming the report fails", func() {
+		BeforeEach(func() {
+			artifactSource.StreamFileReturns(nil, errors.New("nope"))
+		})
+
+		It("warns on stderr and does not fail", func() {
+			process := ifrit.Invoke(step)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderr).To(gbytes.Say("failed to parse test report some-output/junit.xml: nope"))
+			Expect(delegate.ReportedCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Result", func() {
+		It("is delegated to the previous step", func() {
+			previousStep.ResultStub = successResult(false)
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeFalse())
+		})
+	})
+
+	Describe("ParseJUnitReport", func() {
+		It("parses a report with a single test suite as its root", func() {
+			results, err := ParseJUnitReport(bytes.NewBufferString(`
+<testsuite name="root">
+	<testcase name="passes"/>
+	<testcase name="errors"><error/></testcase>
+</testsuite>`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(results).To(Equal(atc.TestResults{
+				Passed:      1,
+				Failed:      1,
+				FailedTests: []string{"errors"},
+			}))
+		})
+
+		It("includes nested test suites", func() {
+			results, err := ParseJUnitReport(bytes.NewBufferString(`
+<testsuites>
+	<testsuite name="outer">
+		<testsuite name="inner">
+			<testcase name="passes"/>
+		</testsuite>
+	</testsuite>
+</testsuites>`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(results.Passed).To(Equal(1))
+		})
+
+		It("returns an error for malformed reports", func() {
+			_, err := ParseJUnitReport(bytes.NewBufferString(`<testsuite>`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/baggageclaim"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -47,6 +48,10 @@ type TaskStep struct {
 	artifactsRoot  string
 	trackerFactory TrackerFactory
 	resourceTypes  atc.ResourceTypes
+	clock          clock.Clock
+	p2pStreaming   bool
+
+	reuseContainerID *worker.Identifier
 
 	repo *SourceRepository
 
@@ -68,6 +73,8 @@ func newTaskStep(
 	artifactsRoot string,
 	trackerFactory TrackerFactory,
 	resourceTypes atc.ResourceTypes,
+	clock clock.Clock,
+	p2pStreaming bool,
 ) TaskStep {
 	return TaskStep{
 		logger:         logger,
@@ -81,6 +88,8 @@ func newTaskStep(
 		artifactsRoot:  artifactsRoot,
 		trackerFactory: trackerFactory,
 		resourceTypes:  resourceTypes,
+		clock:          clock,
+		p2pStreaming:   p2pStreaming,
 	}
 }
 
@@ -111,6 +120,9 @@ func (step TaskStep) Using(prev Step, repo *SourceRepository) Step {
 // are registered with the SourceRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// If the step reuses a previous build's container and it is still around, the
+// task's exit status and outputs are recovered from it instead.
 func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	var err error
 	var found bool
@@ -132,6 +144,10 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	step.metadata.EnvironmentVariables = step.envForParams(config.Params)
 
+	if step.reuseContainerID != nil && step.reuseResult(config) {
+		return nil
+	}
+
 	runContainerID := step.containerID
 	runContainerID.Stage = db.ContainerStageRun
 
@@ -179,13 +195,23 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		workerSpec := worker.WorkerSpec{
 			Platform: config.Platform,
 			Tags:     step.tags,
+			TeamID:   step.metadata.TeamID,
 		}
 
 		if config.ImageResource != nil {
 			workerSpec.ResourceType = config.ImageResource.Type
 		}
 
-		compatibleWorkers, err := step.workerPool.AllSatisfying(workerSpec, step.resourceTypes)
+		var compatibleWorkers []worker.Worker
+		err = worker.WaitForCapacity(step.logger, step.clock, step.delegate.Stderr(), signals, func() error {
+			var err error
+			compatibleWorkers, err = step.workerPool.AllSatisfying(workerSpec, step.resourceTypes)
+			return err
+		})
+		if err == worker.ErrInterruptedWaitingForCapacity {
+			return ErrInterrupted
+		}
+
 		if err != nil {
 			return err
 		}
@@ -219,9 +245,16 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			})
 		}
 
+		if step.p2pStreaming {
+			var p2pMounts []worker.VolumeMount
+			p2pMounts, inputsToStream = step.streamInputsP2P(chosenWorker, inputsToStream)
+			inputMounts = append(inputMounts, p2pMounts...)
+		}
+
 		containerSpec := worker.TaskContainerSpec{
 			Platform:             config.Platform,
 			Tags:                 step.tags,
+			TeamID:               step.metadata.TeamID,
 			Privileged:           bool(step.privileged),
 			Inputs:               inputMounts,
 			Outputs:              outputMounts,
@@ -270,7 +303,7 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
-		step.delegate.Started()
+		step.delegate.Started(step.container.WorkerName())
 
 		step.process, err = step.container.Run(garden.ProcessSpec{
 			Path: config.Run.Path,
@@ -331,6 +364,49 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	}
 }
 
+// reuseResult recovers the exit status and outputs of the task from the
+// container it ran in during a previous build. It returns false if the
+// container is gone or the task never finished in it.
+func (step *TaskStep) reuseResult(config atc.TaskConfig) bool {
+	reuseContainerID := *step.reuseContainerID
+	reuseContainerID.Stage = db.ContainerStageRun
+
+	container, found, err := step.workerPool.FindContainerForIdentifier(
+		step.logger.Session("find-reused-container"),
+		reuseContainerID,
+	)
+	if err != nil || !found {
+		return false
+	}
+
+	exitStatusProp, err := container.Property(taskExitStatusPropertyName)
+	if err != nil {
+		container.Release(nil)
+		return false
+	}
+
+	var exitStatus int
+	_, err = fmt.Sscanf(exitStatusProp, "%d", &exitStatus)
+	if err != nil {
+		container.Release(nil)
+		return false
+	}
+
+	step.logger.Info("reusing-result", lager.Data{
+		"build-id": reuseContainerID.BuildID,
+		"status":   exitStatus,
+	})
+
+	step.container = container
+	step.exitStatus = exitStatus
+
+	step.delegate.Initializing(config)
+	step.registerSource(config)
+	step.delegate.Finished(ExitStatus(exitStatus))
+
+	return true
+}
+
 func (step *TaskStep) registerSource(config atc.TaskConfig) {
 	volumeMounts := step.container.VolumeMounts()
 
@@ -528,6 +604,84 @@ func (step *TaskStep) streamInputs(inputPairs []inputPair) error {
 	return nil
 }
 
+// streamInputsP2P has the chosen worker pull the given inputs directly from
+// the workers they live on, into new volumes to mount in the container. Any
+// inputs that cannot be transferred this way, e.g. because the workers cannot
+// reach each other, are returned so that they can be streamed through the ATC
+// instead.
+func (step *TaskStep) streamInputsP2P(chosenWorker worker.Worker, inputPairs []inputPair) ([]worker.VolumeMount, []inputPair) {
+	destinationClient, found := chosenWorker.VolumeManager()
+	if !found {
+		return nil, inputPairs
+	}
+
+	var mounts []worker.VolumeMount
+	var remainingPairs []inputPair
+
+	for _, pair := range inputPairs {
+		logger := step.logger.Session("stream-p2p", lager.Data{"input": pair.input.Name})
+
+		volume, err := step.streamInputP2P(logger, pair.source, destinationClient)
+		if err != nil {
+			logger.Info("falling-back-to-streaming-through-atc", lager.Data{"error": err.Error()})
+			remainingPairs = append(remainingPairs, pair)
+			continue
+		}
+
+		mounts = append(mounts, worker.VolumeMount{
+			Volume:    volume,
+			MountPath: step.inputDestination(pair.input),
+		})
+	}
+
+	return mounts, remainingPairs
+}
+
+func (step *TaskStep) streamInputP2P(logger lager.Logger, source ArtifactSource, destinationClient baggageclaim.Client) (baggageclaim.Volume, error) {
+	p2pSource, ok := source.(P2PArtifactSource)
+	if !ok {
+		return nil, worker.ErrP2PStreamingUnsupported
+	}
+
+	sourceWorker, err := step.workerPool.GetWorker(p2pSource.WorkerName())
+	if err != nil {
+		return nil, err
+	}
+
+	sourceClient, found := sourceWorker.VolumeManager()
+	if !found {
+		return nil, worker.ErrP2PStreamingUnsupported
+	}
+
+	sourceVolume, found, err := p2pSource.VolumeOn(sourceWorker)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, worker.ErrP2PStreamingUnsupported
+	}
+
+	defer sourceVolume.Release(nil)
+
+	volume, err := destinationClient.CreateVolume(logger, baggageclaim.VolumeSpec{
+		Properties: baggageclaim.VolumeProperties{},
+		TTL:        5 * time.Minute,
+		Privileged: bool(step.privileged),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = worker.StreamVolumeP2P(logger, sourceClient, sourceVolume, ".", destinationClient, volume)
+	if err != nil {
+		volume.Release(nil)
+		return nil, err
+	}
+
+	return volume, nil
+}
+
 func (step *TaskStep) setupOutputs(outputs []atc.TaskOutputConfig) error {
 	for _, output := range outputs {
 		source := newContainerSource(step.artifactsRoot, step.container, output, step.logger, "")
@@ -598,7 +752,7 @@ func (dest *containerDestination) StreamIn(dst string, src io.Reader) error {
 }
 
 type containerSource struct {
-	container     garden.Container
+	container     worker.Container
 	outputConfig  atc.TaskOutputConfig
 	artifactsRoot string
 	volumeHandle  string
@@ -607,7 +761,7 @@ type containerSource struct {
 
 func newContainerSource(
 	artifactsRoot string,
-	container garden.Container,
+	container worker.Container,
 	outputConfig atc.TaskOutputConfig,
 	logger lager.Logger,
 	volumeHandle string,
@@ -654,6 +808,11 @@ func (src *containerSource) StreamFile(filename string) (io.ReadCloser, error) {
 	}, nil
 }
 
+// WorkerName returns the name of the worker running the task.
+func (src *containerSource) WorkerName() string {
+	return src.container.WorkerName()
+}
+
 func (src *containerSource) VolumeOn(w worker.Worker) (baggageclaim.Volume, bool, error) {
 	if baggageclaimClient, found := w.VolumeManager(); len(src.volumeHandle) > 0 && found {
 		volume, found, err := baggageclaimClient.LookupVolume(src.logger, src.volumeHandle)
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
 	"time"
 
@@ -24,6 +26,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
 	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 	"github.com/tedsuo/ifrit"
@@ -47,6 +50,7 @@ var _ = Describe("GardenFactory", func() {
 		}
 		workerMetadata = worker.Metadata{
 			PipelineName: "some-pipeline",
+			TeamID:       123,
 			Type:         db.ContainerTypeTask,
 			StepName:     "some-step",
 		}
@@ -56,7 +60,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeWorkerClient = new(wfakes.FakeClient)
 		fakeTracker = new(rfakes.FakeTracker)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, false)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -152,6 +156,31 @@ var _ = Describe("GardenFactory", func() {
 					})
 				})
 
+				Context("when every satisfying worker is at capacity", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.AllSatisfyingReturns(nil, worker.NoAvailableWorkersError{
+							Spec: worker.WorkerSpec{Platform: "some-platform"},
+						})
+					})
+
+					It("waits for capacity, explaining why in the build log", func() {
+						Eventually(stderrBuf).Should(gbytes.Say("all workers satisfying platform 'some-platform' are at capacity"))
+						Consistently(process.Wait()).ShouldNot(Receive())
+
+						process.Signal(os.Interrupt)
+						Eventually(process.Wait()).Should(Receive())
+					})
+
+					Context("when interrupted while waiting", func() {
+						It("exits with ErrInterrupted", func() {
+							Eventually(stderrBuf).Should(gbytes.Say("at capacity"))
+
+							process.Signal(os.Interrupt)
+							Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+						})
+					})
+				})
+
 				Context("when a single worker can be located", func() {
 					var fakeWorker *wfakes.FakeWorker
 					var fakeBaggageclaimClient *bfakes.FakeClient
@@ -174,6 +203,7 @@ var _ = Describe("GardenFactory", func() {
 						BeforeEach(func() {
 							fakeContainer = new(wfakes.FakeContainer)
 							fakeContainer.HandleReturns("some-handle")
+							fakeContainer.WorkerNameReturns("some-worker")
 							fakeWorker.CreateContainerReturns(fakeContainer, nil)
 
 							fakeProcess = new(gfakes.FakeProcess)
@@ -201,6 +231,7 @@ var _ = Describe("GardenFactory", func() {
 							Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(Equal(1))
 							spec, actualResourceTypes := fakeWorkerClient.AllSatisfyingArgsForCall(0)
 							Expect(spec.Platform).To(Equal("some-platform"))
+							Expect(spec.TeamID).To(Equal(123))
 							Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{
 								{
 									Name:   "custom-resource",
@@ -234,6 +265,7 @@ var _ = Describe("GardenFactory", func() {
 							}))
 							Expect(createdMetadata).To(Equal(worker.Metadata{
 								PipelineName:         "some-pipeline",
+								TeamID:               123,
 								Type:                 db.ContainerTypeTask,
 								StepName:             "some-step",
 								WorkingDirectory:     "/tmp/build/a1f5c0c1",
@@ -244,6 +276,7 @@ var _ = Describe("GardenFactory", func() {
 
 							taskSpec := spec.(worker.TaskContainerSpec)
 							Expect(taskSpec.Platform).To(Equal("some-platform"))
+							Expect(taskSpec.TeamID).To(Equal(123))
 							Expect(taskSpec.Image).To(Equal("some-image"))
 							Expect(taskSpec.ImageResourcePointer).To(Equal(&atc.TaskImageConfig{
 								Type:   "docker",
@@ -300,8 +333,9 @@ var _ = Describe("GardenFactory", func() {
 							Expect(value).To(Equal("process-id"))
 						})
 
-						It("invokes the delegate's Started callback", func() {
+						It("invokes the delegate's Started callback with the container's worker", func() {
 							Expect(taskDelegate.StartedCallCount()).To(Equal(1))
+							Expect(taskDelegate.StartedArgsForCall(0)).To(Equal("some-worker"))
 						})
 
 						Context("when privileged", func() {
@@ -319,6 +353,7 @@ var _ = Describe("GardenFactory", func() {
 								}))
 								Expect(createdMetadata).To(Equal(worker.Metadata{
 									PipelineName:         "some-pipeline",
+									TeamID:               123,
 									Type:                 db.ContainerTypeTask,
 									StepName:             "some-step",
 									WorkingDirectory:     "/tmp/build/a1f5c0c1",
@@ -477,6 +512,132 @@ var _ = Describe("GardenFactory", func() {
 								})
 							})
 
+							Context("when p2p streaming is enabled", func() {
+								var (
+									p2pInputSource    *fakes.FakeP2PArtifactSource
+									sourceWorker      *wfakes.FakeWorker
+									sourceVolume      *bfakes.FakeVolume
+									destinationVolume *bfakes.FakeVolume
+									destinationServer *ghttp.Server
+								)
+
+								BeforeEach(func() {
+									factory = NewGardenFactory(fakeWorkerClient, fakeTracker, true)
+
+									destinationServer = ghttp.NewServer()
+
+									fakeWorker.VolumeManagerReturns(addressableBaggageclaimClient{
+										FakeClient: fakeBaggageclaimClient,
+										url:        destinationServer.URL(),
+									}, true)
+
+									destinationVolume = new(bfakes.FakeVolume)
+									destinationVolume.HandleReturns("destination-volume")
+									fakeBaggageclaimClient.CreateVolumeReturns(destinationVolume, nil)
+
+									sourceWorker = new(wfakes.FakeWorker)
+									sourceWorker.VolumeManagerReturns(addressableBaggageclaimClient{
+										FakeClient: new(bfakes.FakeClient),
+										url:        "http://source-worker:7788",
+									}, true)
+									fakeWorkerClient.GetWorkerReturns(sourceWorker, nil)
+
+									sourceVolume = new(bfakes.FakeVolume)
+									sourceVolume.HandleReturns("source-volume")
+
+									p2pInputSource = new(fakes.FakeP2PArtifactSource)
+									p2pInputSource.WorkerNameReturns("source-worker")
+									p2pInputSource.VolumeOnStub = func(w worker.Worker) (baggageclaim.Volume, bool, error) {
+										if w == sourceWorker {
+											return sourceVolume, true, nil
+										}
+
+										return nil, false, nil
+									}
+
+									repo.RegisterSource("some-input", p2pInputSource)
+									repo.RegisterSource("some-other-input", otherInputSource)
+								})
+
+								AfterEach(func() {
+									destinationServer.Close()
+								})
+
+								Context("when the destination worker can pull from the source worker", func() {
+									BeforeEach(func() {
+										destinationServer.AppendHandlers(
+											ghttp.CombineHandlers(
+												ghttp.VerifyRequest("PUT", "/volumes/destination-volume/stream-p2p-in", url.Values{
+													"path":   {"."},
+													"source": {"http://source-worker:7788/volumes/source-volume/stream-out?path=."},
+												}.Encode()),
+												ghttp.RespondWith(http.StatusNoContent, nil),
+											),
+										)
+									})
+
+									It("has the chosen worker pull the input into a volume mounted at its destination", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(destinationServer.ReceivedRequests()).To(HaveLen(1))
+										Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("source-worker"))
+
+										_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+										taskSpec := spec.(worker.TaskContainerSpec)
+										Expect(taskSpec.Inputs).To(Equal([]worker.VolumeMount{
+											{
+												Volume:    destinationVolume,
+												MountPath: "/tmp/build/a1f5c0c1/some-input-configured-path",
+											},
+										}))
+
+										Expect(p2pInputSource.StreamToCallCount()).To(BeZero())
+									})
+
+									It("stops heartbeating the source volume", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+										Expect(sourceVolume.ReleaseCallCount()).To(Equal(1))
+									})
+
+									It("streams the inputs that do not live on a worker through the ATC", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+										Expect(otherInputSource.StreamToCallCount()).To(Equal(1))
+									})
+								})
+
+								Context("when the destination worker cannot pull from the source worker", func() {
+									BeforeEach(func() {
+										destinationServer.AppendHandlers(
+											ghttp.RespondWith(http.StatusInternalServerError, "connection refused"),
+										)
+									})
+
+									It("releases the volume and streams the input through the ATC instead", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(destinationVolume.ReleaseCallCount()).To(Equal(1))
+										Expect(p2pInputSource.StreamToCallCount()).To(Equal(1))
+
+										_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+										taskSpec := spec.(worker.TaskContainerSpec)
+										Expect(taskSpec.Inputs).To(BeEmpty())
+									})
+								})
+
+								Context("when the destination worker does not support pulling volumes", func() {
+									BeforeEach(func() {
+										destinationServer.AppendHandlers(
+											ghttp.RespondWith(http.StatusNotFound, nil),
+										)
+									})
+
+									It("streams the input through the ATC instead", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+										Expect(p2pInputSource.StreamToCallCount()).To(Equal(1))
+									})
+								})
+							})
+
 							Context("when any of the inputs are missing", func() {
 								BeforeEach(func() {
 									repo.RegisterSource("some-input", inputSource)
@@ -1590,4 +1751,159 @@ var _ = Describe("GardenFactory", func() {
 			})
 		})
 	})
+
+	Describe("ReusedTask", func() {
+		var (
+			taskDelegate *fakes.FakeTaskDelegate
+			configSource *fakes.FakeTaskConfigSource
+
+			reuseIdentifier worker.Identifier
+
+			repo *SourceRepository
+
+			step    Step
+			process ifrit.Process
+		)
+
+		BeforeEach(func() {
+			taskDelegate = new(fakes.FakeTaskDelegate)
+			taskDelegate.StdoutReturns(stdoutBuf)
+			taskDelegate.StderrReturns(stderrBuf)
+
+			configSource = new(fakes.FakeTaskConfigSource)
+			configSource.FetchConfigReturns(atc.TaskConfig{
+				Run: atc.TaskRunConfig{Path: "ls"},
+				Outputs: []atc.TaskOutputConfig{
+					{Name: "some-output"},
+				},
+			}, nil)
+
+			reuseIdentifier = worker.Identifier{
+				BuildID: 1233,
+				PlanID:  atc.PlanID("some-plan-id"),
+			}
+
+			repo = NewSourceRepository()
+		})
+
+		JustBeforeEach(func() {
+			step = factory.ReusedTask(
+				lagertest.NewTestLogger("test"),
+				sourceName,
+				reuseIdentifier,
+				identifier,
+				workerMetadata,
+				taskDelegate,
+				false,
+				nil,
+				configSource,
+				nil,
+			).Using(new(fakes.FakeStep), repo)
+
+			process = ifrit.Invoke(step)
+		})
+
+		exitedContainer := func(status string) *wfakes.FakeContainer {
+			container := new(wfakes.FakeContainer)
+			container.PropertyStub = func(name string) (string, error) {
+				if name == "concourse:exit-status" {
+					return status, nil
+				}
+
+				return "", errors.New("unstubbed property: " + name)
+			}
+
+			return container
+		}
+
+		Context("when the previous build's container is still around", func() {
+			var reusedContainer *wfakes.FakeContainer
+
+			BeforeEach(func() {
+				reusedContainer = exitedContainer("0")
+
+				fakeWorkerClient.FindContainerForIdentifierStub = func(_ lager.Logger, id worker.Identifier) (worker.Container, bool, error) {
+					if id.BuildID == reuseIdentifier.BuildID {
+						return reusedContainer, true, nil
+					}
+
+					return nil, false, nil
+				}
+			})
+
+			It("looks up the previous build's run container", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				_, id := fakeWorkerClient.FindContainerForIdentifierArgsForCall(0)
+
+				expectedID := reuseIdentifier
+				expectedID.Stage = db.ContainerStageRun
+				Expect(id).To(Equal(expectedID))
+			})
+
+			It("does not run the task again", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeWorkerClient.FindContainerForIdentifierCallCount()).To(Equal(1))
+				Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(BeZero())
+			})
+
+			It("reports the previous exit status", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var status ExitStatus
+				Expect(step.Result(&status)).To(BeTrue())
+				Expect(status).To(Equal(ExitStatus(0)))
+
+				Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+				Expect(taskDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+			})
+
+			It("registers the previous outputs as sources", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				_, found := repo.SourceFor("some-output")
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the previous build's container is gone", func() {
+			var ownContainer *wfakes.FakeContainer
+
+			BeforeEach(func() {
+				ownContainer = exitedContainer("1")
+
+				fakeWorkerClient.FindContainerForIdentifierStub = func(_ lager.Logger, id worker.Identifier) (worker.Container, bool, error) {
+					if id.BuildID == identifier.BuildID {
+						return ownContainer, true, nil
+					}
+
+					return nil, false, nil
+				}
+			})
+
+			It("falls back to the step's own container", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeWorkerClient.FindContainerForIdentifierCallCount()).To(Equal(2))
+
+				_, id := fakeWorkerClient.FindContainerForIdentifierArgsForCall(1)
+				Expect(id.BuildID).To(Equal(identifier.BuildID))
+
+				var status ExitStatus
+				Expect(step.Result(&status)).To(BeTrue())
+				Expect(status).To(Equal(ExitStatus(1)))
+			})
+		})
+	})
 })
+
+type addressableBaggageclaimClient struct {
+	*bfakes.FakeClient
+
+	url string
+}
+
+func (client addressableBaggageclaimClient) URL() string {
+	return client.url
+}
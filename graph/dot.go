@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the graph in Graphviz's DOT language, laid out left to
+// right.
+func (graph Graph) WriteDOT(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, "digraph %s {\n", dotQuote(graph.Name))
+	fmt.Fprintf(buf, "  rankdir=LR;\n")
+	fmt.Fprintf(buf, "  node [fontname=\"Helvetica\", style=filled, color=%s];\n", dotQuote(strokeColor))
+	fmt.Fprintf(buf, "  edge [fontname=\"Helvetica\", color=%s];\n", dotQuote(strokeColor))
+
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(
+			buf,
+			"  %s [label=%s, shape=%s, fillcolor=%s];\n",
+			dotQuote(node.ID),
+			dotQuote(node.Label),
+			node.Shape,
+			dotQuote(node.fillColor()),
+		)
+	}
+
+	for _, edge := range graph.Edges {
+		var attrs []string
+
+		if edge.Label != "" {
+			attrs = append(attrs, "label="+dotQuote(edge.Label))
+		}
+
+		if edge.Dashed {
+			attrs = append(attrs, "style=dashed")
+		}
+
+		fmt.Fprintf(buf, "  %s -> %s", dotQuote(edge.From), dotQuote(edge.To))
+
+		if len(attrs) > 0 {
+			fmt.Fprintf(buf, " [%s]", strings.Join(attrs, ", "))
+		}
+
+		fmt.Fprintf(buf, ";\n")
+	}
+
+	fmt.Fprintf(buf, "}\n")
+
+	return buf.Flush()
+}
+
+var dotEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+)
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+)
+
+type Format string
+
+const (
+	FormatDOT Format = "dot"
+	FormatSVG Format = "svg"
+)
+
+type UnknownFormatError struct {
+	Format string
+}
+
+func (err UnknownFormatError) Error() string {
+	return fmt.Sprintf("unknown graph format '%s'; must be '%s' or '%s'", err.Format, FormatDOT, FormatSVG)
+}
+
+// ParseFormat parses a format given by an API request, defaulting to DOT.
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case "", FormatDOT:
+		return FormatDOT, nil
+	case FormatSVG:
+		return FormatSVG, nil
+	default:
+		return "", UnknownFormatError{Format: format}
+	}
+}
+
+func (format Format) ContentType() string {
+	switch format {
+	case FormatSVG:
+		return "image/svg+xml"
+	default:
+		return "text/vnd.graphviz; charset=utf-8"
+	}
+}
+
+func (graph Graph) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatSVG:
+		return graph.WriteSVG(w)
+	default:
+		return graph.WriteDOT(w)
+	}
+}
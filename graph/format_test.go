@@ -0,0 +1,127 @@
+package graph_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+
+	"github.com/concourse/atc/graph"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Formats", func() {
+	var someGraph graph.Graph
+
+	BeforeEach(func() {
+		someGraph = graph.Graph{
+			Name: `some "graph"`,
+			Nodes: []graph.Node{
+				{ID: "a", Label: "some-resource", Shape: graph.ShapeEllipse},
+				{ID: "b", Label: "<job>", Shape: graph.ShapeBox, Status: graph.StatusSucceeded},
+				{ID: "c", Label: "other-job", Shape: graph.ShapeBox, Status: graph.StatusFailed},
+			},
+			Edges: []graph.Edge{
+				{From: "a", To: "b"},
+				{From: "b", To: "c", Label: "some-resource", Dashed: true},
+				{From: "c", To: "b"},
+			},
+		}
+	})
+
+	Describe("ParseFormat", func() {
+		It("defaults to DOT", func() {
+			Expect(graph.ParseFormat("")).To(Equal(graph.FormatDOT))
+		})
+
+		It("parses known formats", func() {
+			Expect(graph.ParseFormat("dot")).To(Equal(graph.FormatDOT))
+			Expect(graph.ParseFormat("svg")).To(Equal(graph.FormatSVG))
+		})
+
+		It("rejects unknown formats", func() {
+			_, err := graph.ParseFormat("png")
+			Expect(err).To(Equal(graph.UnknownFormatError{Format: "png"}))
+		})
+	})
+
+	Describe("WriteDOT", func() {
+		It("writes the graph, quoting and coloring nodes by status", func() {
+			buf := new(bytes.Buffer)
+			Expect(someGraph.WriteDOT(buf)).To(Succeed())
+
+			Expect(buf.String()).To(Equal(`digraph "some \"graph\"" {
+  rankdir=LR;
+  node [fontname="Helvetica", style=filled, color="#34495E"];
+  edge [fontname="Helvetica", color="#34495E"];
+  "a" [label="some-resource", shape=ellipse, fillcolor="#ECF0F1"];
+  "b" [label="<job>", shape=box, fillcolor="#2ECC71"];
+  "c" [label="other-job", shape=box, fillcolor="#E74C3C"];
+  "a" -> "b";
+  "b" -> "c" [label="some-resource", style=dashed];
+  "c" -> "b";
+}
+`))
+		})
+	})
+
+	Describe("WriteSVG", func() {
+		var buf *bytes.Buffer
+
+		BeforeEach(func() {
+			buf = new(bytes.Buffer)
+		})
+
+		JustBeforeEach(func() {
+			Expect(someGraph.WriteSVG(buf)).To(Succeed())
+		})
+
+		It("writes well-formed XML", func() {
+			decoder := xml.NewDecoder(buf)
+
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("escapes labels", func() {
+			Expect(buf.String()).To(ContainSubstring("&lt;job&gt;</text>"))
+			Expect(buf.String()).To(ContainSubstring("<title>some &#34;graph&#34;</title>"))
+		})
+
+		It("colors nodes by status", func() {
+			Expect(buf.String()).To(ContainSubstring(`fill="#2ECC71"`))
+			Expect(buf.String()).To(ContainSubstring(`fill="#E74C3C"`))
+		})
+
+		It("places each node in the column after the nodes leading to it", func() {
+			Expect(buf.String()).To(ContainSubstring(`<ellipse cx="94.8" cy="35.0" rx="74.8" ry="15"`))
+			Expect(buf.String()).To(ContainSubstring(`<rect x="249.5" y="20.0" width="60.0" height="30"`))
+			Expect(buf.String()).To(ContainSubstring(`<rect x="389.5" y="20.0" width="87.0" height="30"`))
+		})
+
+		It("ignores edges that close a cycle when laying out", func() {
+			Expect(buf.String()).To(ContainSubstring(`width="496" height="70"`))
+			Expect(buf.String()).To(ContainSubstring(`<path d="M476.5,35.0 C363.0,35.0 363.0,35.0 249.5,35.0"`))
+		})
+
+		It("dashes dashed edges", func() {
+			Expect(buf.String()).To(ContainSubstring(`stroke-dasharray="5,5"`))
+		})
+
+		Context("with an empty graph", func() {
+			BeforeEach(func() {
+				someGraph = graph.Graph{Name: "empty"}
+			})
+
+			It("writes an empty document", func() {
+				Expect(buf.String()).To(ContainSubstring(`width="40" height="40"`))
+			})
+		})
+	})
+})
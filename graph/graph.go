@@ -0,0 +1,88 @@
+// Package graph renders pipelines and build plans as directed graphs, either
+// as Graphviz DOT or as a standalone SVG document.
+package graph
+
+type Graph struct {
+	Name  string
+	Nodes []Node
+	Edges []Edge
+}
+
+type Shape string
+
+const (
+	ShapeBox     Shape = "box"
+	ShapeEllipse Shape = "ellipse"
+)
+
+// Status is the state of the step a node represents. Nodes without a status
+// are drawn uncolored.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusStarted   Status = "started"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusErrored   Status = "errored"
+)
+
+type Node struct {
+	ID     string
+	Label  string
+	Shape  Shape
+	Status Status
+}
+
+type Edge struct {
+	From  string
+	To    string
+	Label string
+
+	// Dashed edges are drawn for relationships that don't cause anything to
+	// happen on their own, e.g. a non-triggering input.
+	Dashed bool
+}
+
+// these match the legend colors in the web UI
+var statusColors = map[Status]string{
+	StatusPending:   "#BDC3C7",
+	StatusStarted:   "#F1C40F",
+	StatusSucceeded: "#2ECC71",
+	StatusFailed:    "#E74C3C",
+	StatusErrored:   "#E67E22",
+}
+
+const (
+	defaultFillColor = "#ECF0F1"
+	strokeColor      = "#34495E"
+)
+
+func (node Node) fillColor() string {
+	color, found := statusColors[node.Status]
+	if !found {
+		return defaultFillColor
+	}
+
+	return color
+}
+
+func (graph *Graph) addNode(node Node) {
+	for _, existing := range graph.Nodes {
+		if existing.ID == node.ID {
+			return
+		}
+	}
+
+	graph.Nodes = append(graph.Nodes, node)
+}
+
+func (graph *Graph) addEdge(edge Edge) {
+	for _, existing := range graph.Edges {
+		if existing == edge {
+			return
+		}
+	}
+
+	graph.Edges = append(graph.Edges, edge)
+}
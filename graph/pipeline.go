@@ -0,0 +1,69 @@
+package graph
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+)
+
+// Pipeline graphs a pipeline's jobs and resources. Inputs constrained by
+// `passed` are drawn as coming from the jobs they must have passed through,
+// rather than from the resource itself.
+func Pipeline(name string, pipelineConfig atc.Config) Graph {
+	graph := Graph{Name: name}
+
+	for _, resource := range pipelineConfig.Resources {
+		graph.addNode(Node{
+			ID:    resourceNodeID(resource.Name),
+			Label: resource.Name,
+			Shape: ShapeEllipse,
+		})
+	}
+
+	for _, job := range pipelineConfig.Jobs {
+		graph.addNode(Node{
+			ID:    jobNodeID(job.Name),
+			Label: job.Name,
+			Shape: ShapeBox,
+		})
+	}
+
+	for _, job := range pipelineConfig.Jobs {
+		for _, input := range config.JobInputs(job) {
+			if len(input.Passed) == 0 {
+				graph.addEdge(Edge{
+					From:   resourceNodeID(input.Resource),
+					To:     jobNodeID(job.Name),
+					Dashed: !input.Trigger,
+				})
+
+				continue
+			}
+
+			for _, passed := range input.Passed {
+				graph.addEdge(Edge{
+					From:   jobNodeID(passed),
+					To:     jobNodeID(job.Name),
+					Label:  input.Resource,
+					Dashed: !input.Trigger,
+				})
+			}
+		}
+
+		for _, output := range config.JobOutputs(job) {
+			graph.addEdge(Edge{
+				From: jobNodeID(job.Name),
+				To:   resourceNodeID(output.Resource),
+			})
+		}
+	}
+
+	return graph
+}
+
+func jobNodeID(name string) string {
+	return "job:" + name
+}
+
+func resourceNodeID(name string) string {
+	return "resource:" + name
+}
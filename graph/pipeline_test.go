@@ -0,0 +1,103 @@
+package graph_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/graph"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pipeline", func() {
+	var config atc.Config
+
+	var pipelineGraph graph.Graph
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-repo", Type: "git"},
+				{Name: "some-image", Type: "docker-image"},
+				{Name: "some-release", Type: "s3"},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "unit",
+					Plan: atc.PlanSequence{
+						{
+							Aggregate: &atc.PlanSequence{
+								{Get: "some-repo", Trigger: true},
+								{Get: "image", Resource: "some-image"},
+							},
+						},
+						{Task: "test"},
+					},
+				},
+				{
+					Name: "integration",
+					Plan: atc.PlanSequence{
+						{Get: "some-repo", Passed: []string{"unit"}, Trigger: true},
+					},
+				},
+				{
+					Name: "ship",
+					Plan: atc.PlanSequence{
+						{Get: "some-repo", Passed: []string{"unit", "integration"}},
+						{
+							Put: "some-release",
+							Success: &atc.PlanConfig{
+								Put: "some-image",
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		pipelineGraph = graph.Pipeline("some-pipeline", config)
+	})
+
+	It("is named after the pipeline", func() {
+		Expect(pipelineGraph.Name).To(Equal("some-pipeline"))
+	})
+
+	It("has a node for every resource and job", func() {
+		Expect(pipelineGraph.Nodes).To(Equal([]graph.Node{
+			{ID: "resource:some-repo", Label: "some-repo", Shape: graph.ShapeEllipse},
+			{ID: "resource:some-image", Label: "some-image", Shape: graph.ShapeEllipse},
+			{ID: "resource:some-release", Label: "some-release", Shape: graph.ShapeEllipse},
+			{ID: "job:unit", Label: "unit", Shape: graph.ShapeBox},
+			{ID: "job:integration", Label: "integration", Shape: graph.ShapeBox},
+			{ID: "job:ship", Label: "ship", Shape: graph.ShapeBox},
+		}))
+	})
+
+	It("connects inputs from their resources, or from the jobs they must have passed", func() {
+		Expect(pipelineGraph.Edges).To(Equal([]graph.Edge{
+			{From: "resource:some-repo", To: "job:unit"},
+			{From: "resource:some-image", To: "job:unit", Dashed: true},
+			{From: "job:unit", To: "job:integration", Label: "some-repo"},
+			{From: "job:unit", To: "job:ship", Label: "some-repo", Dashed: true},
+			{From: "job:integration", To: "job:ship", Label: "some-repo", Dashed: true},
+			{From: "job:ship", To: "resource:some-image"},
+			{From: "job:ship", To: "resource:some-release"},
+		}))
+	})
+
+	Context("when a job gets the same resource more than once", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan = append(config.Jobs[0].Plan, atc.PlanConfig{
+				Get:      "some-repo-again",
+				Resource: "some-repo",
+				Trigger:  true,
+			})
+		})
+
+		It("only connects them once", func() {
+			Expect(pipelineGraph.Edges[0]).To(Equal(graph.Edge{From: "resource:some-repo", To: "job:unit"}))
+			Expect(pipelineGraph.Edges[1]).To(Equal(graph.Edge{From: "resource:some-image", To: "job:unit", Dashed: true}))
+			Expect(pipelineGraph.Edges[2]).To(Equal(graph.Edge{From: "job:unit", To: "job:integration", Label: "some-repo"}))
+		})
+	})
+})
@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+var ErrMissingPlan = errors.New("build plan is empty")
+
+// publicPlan mirrors the JSON produced by atc.Plan's Public method.
+type publicPlan struct {
+	ID atc.PlanID `json:"id"`
+
+	Aggregate    *[]publicPlan   `json:"aggregate"`
+	Do           *[]publicPlan   `json:"do"`
+	Get          *publicResource `json:"get"`
+	Put          *publicResource `json:"put"`
+	Task         *publicTask     `json:"task"`
+	Ensure       *publicHook     `json:"ensure"`
+	OnSuccess    *publicHook     `json:"on_success"`
+	OnFailure    *publicHook     `json:"on_failure"`
+	Try          *publicStep     `json:"try"`
+	DependentGet *publicResource `json:"dependent_get"`
+	Timeout      *publicTimeout  `json:"timeout"`
+	Retry        *[]publicPlan   `json:"retry"`
+}
+
+type publicResource struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+}
+
+type publicTask struct {
+	Name string `json:"name"`
+}
+
+type publicStep struct {
+	Step publicPlan `json:"step"`
+}
+
+type publicHook struct {
+	Step publicPlan `json:"step"`
+
+	Ensure    *publicPlan `json:"ensure"`
+	OnSuccess *publicPlan `json:"on_success"`
+	OnFailure *publicPlan `json:"on_failure"`
+}
+
+type publicTimeout struct {
+	Step     publicPlan `json:"step"`
+	Duration string     `json:"duration"`
+}
+
+// BuildPlan graphs a build's plan as a tree, with each step colored by its
+// status. Steps missing from statuses are drawn as pending.
+func BuildPlan(name string, plan atc.PublicBuildPlan, statuses StepStatuses) (Graph, error) {
+	if plan.Plan == nil {
+		return Graph{}, ErrMissingPlan
+	}
+
+	var root publicPlan
+	err := json.Unmarshal(*plan.Plan, &root)
+	if err != nil {
+		return Graph{}, err
+	}
+
+	graph := Graph{Name: name}
+
+	addPlan(&graph, root, statuses)
+
+	return graph, nil
+}
+
+func addPlan(graph *Graph, plan publicPlan, statuses StepStatuses) {
+	id := planNodeID(plan.ID)
+
+	// reserve this node's place so that nodes are listed in the order they're
+	// reached walking the plan, ahead of their descendants
+	index := len(graph.Nodes)
+	graph.Nodes = append(graph.Nodes, Node{})
+
+	status, found := statuses[plan.ID]
+	if !found {
+		status = StatusPending
+	}
+
+	node := Node{
+		ID:     id,
+		Shape:  ShapeEllipse,
+		Status: status,
+	}
+
+	child := func(plan publicPlan, label string) {
+		graph.Edges = append(graph.Edges, Edge{
+			From:  id,
+			To:    planNodeID(plan.ID),
+			Label: label,
+		})
+
+		addPlan(graph, plan, statuses)
+	}
+
+	switch {
+	case plan.Aggregate != nil:
+		node.Label = "aggregate"
+
+		for _, p := range *plan.Aggregate {
+			child(p, "")
+		}
+
+	case plan.Do != nil:
+		node.Label = "do"
+
+		for _, p := range *plan.Do {
+			child(p, "")
+		}
+
+	case plan.Retry != nil:
+		node.Label = "retry"
+
+		for i, p := range *plan.Retry {
+			child(p, fmt.Sprintf("attempt %d", i+1))
+		}
+
+	case plan.Get != nil:
+		node.Label = "get: " + plan.Get.displayName()
+		node.Shape = ShapeBox
+
+	case plan.DependentGet != nil:
+		node.Label = "get: " + plan.DependentGet.displayName()
+		node.Shape = ShapeBox
+
+	case plan.Put != nil:
+		node.Label = "put: " + plan.Put.displayName()
+		node.Shape = ShapeBox
+
+	case plan.Task != nil:
+		node.Label = "task: " + plan.Task.Name
+		node.Shape = ShapeBox
+
+	case plan.Ensure != nil:
+		node.Label = "ensure"
+		child(plan.Ensure.Step, "")
+
+		if plan.Ensure.Ensure != nil {
+			child(*plan.Ensure.Ensure, "ensure")
+		}
+
+	case plan.OnSuccess != nil:
+		node.Label = "on_success"
+		child(plan.OnSuccess.Step, "")
+
+		if plan.OnSuccess.OnSuccess != nil {
+			child(*plan.OnSuccess.OnSuccess, "on success")
+		}
+
+	case plan.OnFailure != nil:
+		node.Label = "on_failure"
+		child(plan.OnFailure.Step, "")
+
+		if plan.OnFailure.OnFailure != nil {
+			child(*plan.OnFailure.OnFailure, "on failure")
+		}
+
+	case plan.Try != nil:
+		node.Label = "try"
+		child(plan.Try.Step, "")
+
+	case plan.Timeout != nil:
+		node.Label = "timeout " + plan.Timeout.Duration
+		child(plan.Timeout.Step, "")
+
+	default:
+		node.Label = string(plan.ID)
+	}
+
+	graph.Nodes[index] = node
+}
+
+func (resource publicResource) displayName() string {
+	if resource.Name != "" {
+		return resource.Name
+	}
+
+	return resource.Resource
+}
+
+func planNodeID(id atc.PlanID) string {
+	return "step:" + string(id)
+}
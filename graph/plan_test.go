@@ -0,0 +1,129 @@
+package graph_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/graph"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildPlan", func() {
+	var plan atc.Plan
+	var statuses graph.StepStatuses
+
+	var planGraph graph.Graph
+	var graphErr error
+
+	BeforeEach(func() {
+		plan = atc.Plan{
+			ID: "1",
+			Do: &atc.DoPlan{
+				{
+					ID: "2",
+					Aggregate: &atc.AggregatePlan{
+						{ID: "3", Get: &atc.GetPlan{Name: "some-input", Resource: "some-resource"}},
+						{ID: "4", Get: &atc.GetPlan{Resource: "other-resource"}},
+					},
+				},
+				{
+					ID: "5",
+					OnSuccess: &atc.OnSuccessPlan{
+						Step: atc.Plan{
+							ID: "6",
+							Timeout: &atc.TimeoutPlan{
+								Duration: "1h",
+								Step: atc.Plan{
+									ID:   "7",
+									Task: &atc.TaskPlan{Name: "some-task"},
+								},
+							},
+						},
+						Next: atc.Plan{
+							ID:  "8",
+							Put: &atc.PutPlan{Name: "some-output", Resource: "some-resource"},
+						},
+					},
+				},
+			},
+		}
+
+		statuses = graph.StepStatuses{
+			"1": graph.StatusStarted,
+			"3": graph.StatusSucceeded,
+			"4": graph.StatusFailed,
+			"7": graph.StatusErrored,
+		}
+	})
+
+	JustBeforeEach(func() {
+		planGraph, graphErr = graph.BuildPlan("some-build", atc.PublicBuildPlan{
+			Schema: "exec.v2",
+			Plan:   plan.Public(),
+		}, statuses)
+	})
+
+	It("has a node for every step, in the order they appear in the plan", func() {
+		Expect(graphErr).NotTo(HaveOccurred())
+
+		Expect(planGraph.Name).To(Equal("some-build"))
+		Expect(planGraph.Nodes).To(Equal([]graph.Node{
+			{ID: "step:1", Label: "do", Shape: graph.ShapeEllipse, Status: graph.StatusStarted},
+			{ID: "step:2", Label: "aggregate", Shape: graph.ShapeEllipse, Status: graph.StatusPending},
+			{ID: "step:3", Label: "get: some-input", Shape: graph.ShapeBox, Status: graph.StatusSucceeded},
+			{ID: "step:4", Label: "get: other-resource", Shape: graph.ShapeBox, Status: graph.StatusFailed},
+			{ID: "step:5", Label: "on_success", Shape: graph.ShapeEllipse, Status: graph.StatusPending},
+			{ID: "step:6", Label: "timeout 1h", Shape: graph.ShapeEllipse, Status: graph.StatusPending},
+			{ID: "step:7", Label: "task: some-task", Shape: graph.ShapeBox, Status: graph.StatusErrored},
+			{ID: "step:8", Label: "put: some-output", Shape: graph.ShapeBox, Status: graph.StatusPending},
+		}))
+	})
+
+	It("connects each step to the steps nested in it, labeling hooks", func() {
+		Expect(planGraph.Edges).To(Equal([]graph.Edge{
+			{From: "step:1", To: "step:2"},
+			{From: "step:2", To: "step:3"},
+			{From: "step:2", To: "step:4"},
+			{From: "step:1", To: "step:5"},
+			{From: "step:5", To: "step:6"},
+			{From: "step:6", To: "step:7"},
+			{From: "step:5", To: "step:8", Label: "on success"},
+		}))
+	})
+
+	Context("with a retried step", func() {
+		BeforeEach(func() {
+			plan = atc.Plan{
+				ID: "1",
+				Retry: &atc.RetryPlan{
+					{ID: "2", Task: &atc.TaskPlan{Name: "flaky"}},
+					{ID: "3", Task: &atc.TaskPlan{Name: "flaky"}},
+				},
+			}
+		})
+
+		It("labels each attempt", func() {
+			Expect(planGraph.Edges).To(Equal([]graph.Edge{
+				{From: "step:1", To: "step:2", Label: "attempt 1"},
+				{From: "step:1", To: "step:3", Label: "attempt 2"},
+			}))
+		})
+	})
+
+	Context("when the plan is missing", func() {
+		It("returns an error", func() {
+			_, err := graph.BuildPlan("some-build", atc.PublicBuildPlan{}, statuses)
+			Expect(err).To(Equal(graph.ErrMissingPlan))
+		})
+	})
+
+	Context("when the plan is malformed", func() {
+		It("returns an error", func() {
+			var malformed json.RawMessage = []byte(`"some-plan"`)
+
+			_, err := graph.BuildPlan("some-build", atc.PublicBuildPlan{Plan: &malformed}, statuses)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+// StepStatuses tracks the status of each step of a build's plan, keyed by
+// plan ID.
+type StepStatuses map[atc.PlanID]Status
+
+// Observe updates the statuses with a build event. Builds that ran before
+// every step emitted start and finish events are tracked by their task, get,
+// and put events instead.
+func (statuses StepStatuses) Observe(ev atc.Event) {
+	switch e := ev.(type) {
+	case event.StartStep:
+		statuses[planID(e.Origin)] = StatusStarted
+
+	case event.FinishStep:
+		if e.Succeeded {
+			statuses[planID(e.Origin)] = StatusSucceeded
+		} else if statuses[planID(e.Origin)] != StatusErrored {
+			statuses[planID(e.Origin)] = StatusFailed
+		}
+
+	case event.Error:
+		if e.Origin.ID != "" {
+			statuses[planID(e.Origin)] = StatusErrored
+		}
+
+	case event.InitializeTask:
+		statuses.start(e.Origin)
+	case event.StartTask:
+		statuses.start(e.Origin)
+	case event.InitializeGet:
+		statuses.start(e.Origin)
+	case event.StartGet:
+		statuses.start(e.Origin)
+	case event.InitializePut:
+		statuses.start(e.Origin)
+	case event.StartPut:
+		statuses.start(e.Origin)
+
+	case event.FinishTask:
+		statuses.finish(e.Origin, e.ExitStatus)
+	case event.FinishGet:
+		statuses.finish(e.Origin, e.ExitStatus)
+	case event.FinishPut:
+		statuses.finish(e.Origin, e.ExitStatus)
+	}
+}
+
+func (statuses StepStatuses) start(origin event.Origin) {
+	if _, found := statuses[planID(origin)]; !found {
+		statuses[planID(origin)] = StatusStarted
+	}
+}
+
+func (statuses StepStatuses) finish(origin event.Origin, exitStatus int) {
+	if statuses[planID(origin)] != StatusStarted {
+		return
+	}
+
+	if exitStatus == 0 {
+		statuses[planID(origin)] = StatusSucceeded
+	} else {
+		statuses[planID(origin)] = StatusFailed
+	}
+}
+
+func planID(origin event.Origin) atc.PlanID {
+	return atc.PlanID(origin.ID)
+}
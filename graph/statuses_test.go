@@ -0,0 +1,85 @@
+package graph_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/graph"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StepStatuses", func() {
+	var statuses graph.StepStatuses
+
+	BeforeEach(func() {
+		statuses = graph.StepStatuses{}
+	})
+
+	observe := func(events ...atc.Event) {
+		for _, ev := range events {
+			statuses.Observe(ev)
+		}
+	}
+
+	origin := func(id string) event.Origin {
+		return event.Origin{ID: event.OriginID(id)}
+	}
+
+	It("tracks steps through their start and finish events", func() {
+		observe(
+			event.StartStep{Type: event.StepTypeDo, Origin: origin("1")},
+			event.StartStep{Type: event.StepTypeGet, Origin: origin("2")},
+			event.FinishStep{Type: event.StepTypeGet, Succeeded: true, Origin: origin("2")},
+			event.StartStep{Type: event.StepTypeTask, Origin: origin("3")},
+		)
+
+		Expect(statuses).To(Equal(graph.StepStatuses{
+			"1": graph.StatusStarted,
+			"2": graph.StatusSucceeded,
+			"3": graph.StatusStarted,
+		}))
+
+		observe(
+			event.FinishStep{Type: event.StepTypeTask, Succeeded: false, Origin: origin("3")},
+			event.FinishStep{Type: event.StepTypeDo, Succeeded: false, Origin: origin("1")},
+		)
+
+		Expect(statuses["1"]).To(Equal(graph.StatusFailed))
+		Expect(statuses["3"]).To(Equal(graph.StatusFailed))
+	})
+
+	It("marks steps that error as errored, even once they finish", func() {
+		observe(
+			event.StartStep{Type: event.StepTypeGet, Origin: origin("1")},
+			event.Error{Message: "oh no", Origin: origin("1")},
+			event.FinishStep{Type: event.StepTypeGet, Succeeded: false, Origin: origin("1")},
+		)
+
+		Expect(statuses["1"]).To(Equal(graph.StatusErrored))
+	})
+
+	It("ignores errors that are not from a step", func() {
+		observe(event.Error{Message: "oh no"})
+
+		Expect(statuses).To(BeEmpty())
+	})
+
+	Context("with a build from before step events were emitted", func() {
+		It("tracks steps through their task, get, and put events", func() {
+			observe(
+				event.InitializeGet{Origin: origin("1")},
+				event.FinishGet{ExitStatus: 0, Origin: origin("1")},
+				event.InitializeTask{Origin: origin("2")},
+				event.StartTask{Origin: origin("2")},
+				event.FinishTask{ExitStatus: 1, Origin: origin("2")},
+				event.InitializePut{Origin: origin("3")},
+			)
+
+			Expect(statuses).To(Equal(graph.StepStatuses{
+				"1": graph.StatusSucceeded,
+				"2": graph.StatusFailed,
+				"3": graph.StatusStarted,
+			}))
+		})
+	})
+})
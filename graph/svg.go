@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	svgMargin      = 20
+	svgNodeHeight  = 30
+	svgNodePadding = 12
+	svgMinWidth    = 60
+	svgCharWidth   = 7
+	svgColumnGap   = 80
+	svgRowGap      = 20
+	svgFontSize    = 12
+	svgLabelSize   = 10
+)
+
+type svgBox struct {
+	x, y, width float64
+}
+
+func (box svgBox) centerY() float64 {
+	return box.y + svgNodeHeight/2
+}
+
+// WriteSVG lays the graph out left to right, placing each node one column
+// after the furthest node with an edge to it, and writes it as an SVG
+// document. It is a much simpler layout than Graphviz's, but needs nothing
+// installed to render.
+func (graph Graph) WriteSVG(w io.Writer) error {
+	boxes, width, height := graph.layout()
+
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintf(buf, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(
+		buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		width, height, width, height,
+	)
+	fmt.Fprintf(buf, "<title>%s</title>\n", html.EscapeString(graph.Name))
+	fmt.Fprintf(buf, `<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker></defs>`+"\n", strokeColor)
+
+	for _, edge := range graph.Edges {
+		from, found := boxes[edge.From]
+		if !found {
+			continue
+		}
+
+		to, found := boxes[edge.To]
+		if !found {
+			continue
+		}
+
+		x1, y1 := from.x+from.width, from.centerY()
+		x2, y2 := to.x, to.centerY()
+		mx := (x1 + x2) / 2
+
+		dash := ""
+		if edge.Dashed {
+			dash = ` stroke-dasharray="5,5"`
+		}
+
+		fmt.Fprintf(
+			buf,
+			`<path d="M%.1f,%.1f C%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="none" stroke="%s"%s marker-end="url(#arrow)"/>`+"\n",
+			x1, y1, mx, y1, mx, y2, x2, y2, strokeColor, dash,
+		)
+
+		if edge.Label != "" {
+			fmt.Fprintf(
+				buf,
+				`<text x="%.1f" y="%.1f" font-size="%d" text-anchor="middle" fill="%s">%s</text>`+"\n",
+				mx, (y1+y2)/2-4, svgLabelSize, strokeColor, html.EscapeString(edge.Label),
+			)
+		}
+	}
+
+	for _, node := range graph.Nodes {
+		box := boxes[node.ID]
+
+		switch node.Shape {
+		case ShapeEllipse:
+			fmt.Fprintf(
+				buf,
+				`<ellipse cx="%.1f" cy="%.1f" rx="%.1f" ry="%d" fill="%s" stroke="%s"/>`+"\n",
+				box.x+box.width/2, box.centerY(), box.width/2, svgNodeHeight/2, node.fillColor(), strokeColor,
+			)
+		default:
+			fmt.Fprintf(
+				buf,
+				`<rect x="%.1f" y="%.1f" width="%.1f" height="%d" rx="3" fill="%s" stroke="%s"/>`+"\n",
+				box.x, box.y, box.width, svgNodeHeight, node.fillColor(), strokeColor,
+			)
+		}
+
+		fmt.Fprintf(
+			buf,
+			`<text x="%.1f" y="%.1f" font-size="%d" text-anchor="middle" dominant-baseline="central" fill="#1A252F">%s</text>`+"\n",
+			box.x+box.width/2, box.centerY(), svgFontSize, html.EscapeString(node.Label),
+		)
+	}
+
+	fmt.Fprintf(buf, "</svg>\n")
+
+	return buf.Flush()
+}
+
+func (graph Graph) layout() (map[string]svgBox, float64, float64) {
+	ranks := graph.ranks()
+
+	var columns [][]Node
+	for _, node := range graph.Nodes {
+		rank := ranks[node.ID]
+
+		for len(columns) <= rank {
+			columns = append(columns, nil)
+		}
+
+		columns[rank] = append(columns[rank], node)
+	}
+
+	tallest := 0
+	for _, column := range columns {
+		if len(column) > tallest {
+			tallest = len(column)
+		}
+	}
+
+	columnHeight := func(rows int) float64 {
+		if rows == 0 {
+			return 0
+		}
+
+		return float64(rows*svgNodeHeight + (rows-1)*svgRowGap)
+	}
+
+	height := columnHeight(tallest)
+
+	boxes := map[string]svgBox{}
+
+	x := float64(svgMargin)
+	for _, column := range columns {
+		columnWidth := float64(svgMinWidth)
+		for _, node := range column {
+			if width := nodeWidth(node); width > columnWidth {
+				columnWidth = width
+			}
+		}
+
+		// center each column against the tallest one
+		y := svgMargin + (height-columnHeight(len(column)))/2
+
+		for _, node := range column {
+			width := nodeWidth(node)
+
+			boxes[node.ID] = svgBox{
+				x:     x + (columnWidth-width)/2,
+				y:     y,
+				width: width,
+			}
+
+			y += svgNodeHeight + svgRowGap
+		}
+
+		x += columnWidth + svgColumnGap
+	}
+
+	width := x - svgColumnGap + svgMargin
+	if len(columns) == 0 {
+		width = 2 * svgMargin
+	}
+
+	return boxes, width, height + 2*svgMargin
+}
+
+// ranks assigns each node the length of the longest path leading to it.
+// Edges that would close a cycle are ignored, so a cyclic graph still renders,
+// just with some edges pointing backwards.
+func (graph Graph) ranks() map[string]int {
+	successors := map[string][]string{}
+	for _, edge := range graph.Edges {
+		successors[edge.From] = append(successors[edge.From], edge.To)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := map[string]int{}
+	forward := map[string][]string{}
+
+	var postOrder []string
+
+	var visit func(id string)
+	visit = func(id string) {
+		states[id] = visiting
+
+		for _, next := range successors[id] {
+			switch states[next] {
+			case visiting:
+				// back edge; following it would loop forever
+				continue
+			case unvisited:
+				visit(next)
+			}
+
+			forward[id] = append(forward[id], next)
+		}
+
+		states[id] = visited
+		postOrder = append(postOrder, id)
+	}
+
+	for _, node := range graph.Nodes {
+		if states[node.ID] == unvisited {
+			visit(node.ID)
+		}
+	}
+
+	ranks := map[string]int{}
+	for _, node := range graph.Nodes {
+		ranks[node.ID] = 0
+	}
+
+	// reverse post-order visits every node before anything it leads to
+	for i := len(postOrder) - 1; i >= 0; i-- {
+		id := postOrder[i]
+
+		for _, next := range forward[id] {
+			if ranks[next] <= ranks[id] {
+				ranks[next] = ranks[id] + 1
+			}
+		}
+	}
+
+	return ranks
+}
+
+func nodeWidth(node Node) float64 {
+	width := float64(utf8.RuneCountInString(node.Label)*svgCharWidth + 2*svgNodePadding)
+	if node.Shape == ShapeEllipse {
+		// leave room for the label within the ellipse's curve
+		width *= 1.3
+	}
+
+	if width < svgMinWidth {
+		return svgMinWidth
+	}
+
+	return width
+}
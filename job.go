@@ -4,6 +4,7 @@ type Job struct {
 	Name          string `json:"name"`
 	URL           string `json:"url"`
 	Paused        bool   `json:"paused,omitempty"`
+	PausedReason  string `json:"paused_reason,omitempty"`
 	NextBuild     *Build `json:"next_build"`
 	FinishedBuild *Build `json:"finished_build"`
 
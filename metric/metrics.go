@@ -139,6 +139,28 @@ func (event WorkerContainers) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerUnhealthy struct {
+	WorkerName string
+	CoolDown   time.Duration
+}
+
+func (event WorkerUnhealthy) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("worker-unhealthy", lager.Data{
+			"worker":    event.WorkerName,
+			"cool-down": event.CoolDown.String(),
+		}),
+		goryman.Event{
+			Service: "worker unhealthy",
+			Metric:  1,
+			State:   "warning",
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
@@ -205,6 +227,31 @@ func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
 
+type JobPausedAfterFailures struct {
+	PipelineName        string
+	JobName             string
+	ConsecutiveFailures int
+}
+
+func (event JobPausedAfterFailures) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("job-paused-after-failures", lager.Data{
+			"pipeline": event.PipelineName,
+			"job":      event.JobName,
+			"failures": event.ConsecutiveFailures,
+		}),
+		goryman.Event{
+			Service: "job paused after failures",
+			Metric:  event.ConsecutiveFailures,
+			State:   "warning",
+			Attributes: map[string]string{
+				"pipeline": event.PipelineName,
+				"job":      event.JobName,
+			},
+		},
+	)
+}
+
 type HTTPReponseTime struct {
 	Route    string
 	Path     string
@@ -21,28 +21,50 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
-	db       db.DB
+	tracker       resource.Tracker
+	interval      time.Duration
+	maxBackoff    time.Duration
+	checkTimeout  time.Duration
+	initialJitter time.Duration
+	checkLimiter  *radar.CheckLimiter
+	engine        engine.Engine
+	db            db.DB
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
+	maxBackoff time.Duration,
+	checkTimeout time.Duration,
+	initialJitter time.Duration,
+	maxChecksInFlight int,
 	engine engine.Engine,
 	db db.DB,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
-		db:       db,
+		tracker:       tracker,
+		interval:      interval,
+		maxBackoff:    maxBackoff,
+		checkTimeout:  checkTimeout,
+		initialJitter: initialJitter,
+		checkLimiter:  radar.NewCheckLimiter(maxChecksInFlight),
+		engine:        engine,
+		db:            db,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildRadar(pipelineDB db.PipelineDB, externalURL string) *radar.Radar {
-	return radar.NewRadar(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewRadar(
+		rsf.tracker,
+		rsf.interval,
+		rsf.maxBackoff,
+		rsf.checkTimeout,
+		rsf.initialJitter,
+		rsf.checkLimiter,
+		pipelineDB,
+		clock.NewClock(),
+		externalURL,
+	)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
@@ -56,5 +78,6 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		),
 		Engine:  rsf.engine,
 		Scanner: radar,
+		Clock:   clock.NewClock(),
 	}
 }
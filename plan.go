@@ -106,6 +106,8 @@ type TaskPlan struct {
 
 	Params Params `json:"params,omitempty"`
 
+	Reports []string `json:"reports,omitempty"`
+
 	Pipeline      string        `json:"pipeline"`
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 }
@@ -0,0 +1,43 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/pruner"
+)
+
+type FakePruner struct {
+	PruneStub        func() error
+	pruneMutex       sync.RWMutex
+	pruneArgsForCall []struct{}
+	pruneReturns     struct {
+		result1 error
+	}
+}
+
+func (fake *FakePruner) Prune() error {
+	fake.pruneMutex.Lock()
+	fake.pruneArgsForCall = append(fake.pruneArgsForCall, struct{}{})
+	fake.pruneMutex.Unlock()
+	if fake.PruneStub != nil {
+		return fake.PruneStub()
+	} else {
+		return fake.pruneReturns.result1
+	}
+}
+
+func (fake *FakePruner) PruneCallCount() int {
+	fake.pruneMutex.RLock()
+	defer fake.pruneMutex.RUnlock()
+	return len(fake.pruneArgsForCall)
+}
+
+func (fake *FakePruner) PruneReturns(result1 error) {
+	fake.PruneStub = nil
+	fake.pruneReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ pruner.Pruner = new(FakePruner)
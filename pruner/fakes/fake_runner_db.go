@@ -0,0 +1,59 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/pruner"
+)
+
+type FakeRunnerDB struct {
+	LeaseVersionPruningStub        func(interval time.Duration) (db.Lease, bool, error)
+	leaseVersionPruningMutex       sync.RWMutex
+	leaseVersionPruningArgsForCall []struct {
+		interval time.Duration
+	}
+	leaseVersionPruningReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+}
+
+func (fake *FakeRunnerDB) LeaseVersionPruning(interval time.Duration) (db.Lease, bool, error) {
+	fake.leaseVersionPruningMutex.Lock()
+	fake.leaseVersionPruningArgsForCall = append(fake.leaseVersionPruningArgsForCall, struct {
+		interval time.Duration
+	}{interval})
+	fake.leaseVersionPruningMutex.Unlock()
+	if fake.LeaseVersionPruningStub != nil {
+		return fake.LeaseVersionPruningStub(interval)
+	} else {
+		return fake.leaseVersionPruningReturns.result1, fake.leaseVersionPruningReturns.result2, fake.leaseVersionPruningReturns.result3
+	}
+}
+
+func (fake *FakeRunnerDB) LeaseVersionPruningCallCount() int {
+	fake.leaseVersionPruningMutex.RLock()
+	defer fake.leaseVersionPruningMutex.RUnlock()
+	return len(fake.leaseVersionPruningArgsForCall)
+}
+
+func (fake *FakeRunnerDB) LeaseVersionPruningArgsForCall(i int) time.Duration {
+	fake.leaseVersionPruningMutex.RLock()
+	defer fake.leaseVersionPruningMutex.RUnlock()
+	return fake.leaseVersionPruningArgsForCall[i].interval
+}
+
+func (fake *FakeRunnerDB) LeaseVersionPruningReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.LeaseVersionPruningStub = nil
+	fake.leaseVersionPruningReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ pruner.RunnerDB = new(FakeRunnerDB)
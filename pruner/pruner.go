@@ -0,0 +1,76 @@
+package pruner
+
+import (
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . PrunerDB
+
+type PrunerDB interface {
+	GetAllPipelines() ([]db.SavedPipeline, error)
+}
+
+//go:generate counterfeiter . Pruner
+
+type Pruner interface {
+	Prune() error
+}
+
+type pruner struct {
+	logger            lager.Logger
+	db                PrunerDB
+	pipelineDBFactory db.PipelineDBFactory
+}
+
+func NewPruner(
+	logger lager.Logger,
+	db PrunerDB,
+	pipelineDBFactory db.PipelineDBFactory,
+) Pruner {
+	return &pruner{
+		logger:            logger,
+		db:                db,
+		pipelineDBFactory: pipelineDBFactory,
+	}
+}
+
+// Prune removes old versions from every resource that configures a
+// version_retention. A failure to prune one resource is logged and does not
+// stop the others from being pruned.
+func (p *pruner) Prune() error {
+	p.logger.Info("prune")
+
+	pipelines, err := p.db.GetAllPipelines()
+	if err != nil {
+		p.logger.Error("could-not-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		pipelineDB := p.pipelineDBFactory.Build(pipeline)
+
+		for _, resource := range pipeline.Config.Resources {
+			if resource.VersionRetention == nil {
+				continue
+			}
+
+			logger := p.logger.WithData(lager.Data{
+				"pipeline": pipeline.Name,
+				"resource": resource.Name,
+			})
+
+			pruned, err := pipelineDB.PruneResourceVersions(resource, pipeline.Config.Jobs)
+			if err != nil {
+				logger.Error("failed-to-prune-versions", err)
+				continue
+			}
+
+			if pruned > 0 {
+				logger.Info("pruned-versions", lager.Data{"count": pruned})
+			}
+		}
+	}
+
+	return nil
+}
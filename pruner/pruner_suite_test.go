@@ -0,0 +1,13 @@
+package pruner_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPruner(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pruner Suite")
+}
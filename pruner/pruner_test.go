@@ -0,0 +1,118 @@
+package pruner_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	dbfakes "github.com/concourse/atc/db/fakes"
+	. "github.com/concourse/atc/pruner"
+	"github.com/concourse/atc/pruner/fakes"
+)
+
+var _ = Describe("Pruner", func() {
+	var (
+		fakeDB                *fakes.FakePrunerDB
+		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
+		fakePipelineDB        *dbfakes.FakePipelineDB
+
+		pruner Pruner
+
+		jobs atc.JobConfigs
+
+		pruneErr error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(fakes.FakePrunerDB)
+		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
+		fakePipelineDB = new(dbfakes.FakePipelineDB)
+		fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+
+		jobs = atc.JobConfigs{
+			{
+				Name: "some-job",
+				Plan: atc.PlanSequence{{Get: "some-resource"}},
+			},
+		}
+
+		fakeDB.GetAllPipelinesReturns([]db.SavedPipeline{
+			{
+				ID: 1,
+				Pipeline: db.Pipeline{
+					Name: "some-pipeline",
+					Config: atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:             "some-resource",
+								Type:             "some-type",
+								VersionRetention: &atc.VersionRetentionConfig{Count: 10},
+							},
+							{
+								Name: "some-unretained-resource",
+								Type: "some-type",
+							},
+							{
+								Name:             "some-other-resource",
+								Type:             "some-type",
+								VersionRetention: &atc.VersionRetentionConfig{Age: "24h"},
+							},
+						},
+						Jobs: jobs,
+					},
+				},
+			},
+		}, nil)
+
+		pruner = NewPruner(lagertest.NewTestLogger("test"), fakeDB, fakePipelineDBFactory)
+	})
+
+	JustBeforeEach(func() {
+		pruneErr = pruner.Prune()
+	})
+
+	It("prunes each resource that configures a version retention", func() {
+		Expect(pruneErr).NotTo(HaveOccurred())
+
+		Expect(fakePipelineDBFactory.BuildCallCount()).To(Equal(1))
+		Expect(fakePipelineDBFactory.BuildArgsForCall(0).Name).To(Equal("some-pipeline"))
+
+		Expect(fakePipelineDB.PruneResourceVersionsCallCount()).To(Equal(2))
+
+		resource, actualJobs := fakePipelineDB.PruneResourceVersionsArgsForCall(0)
+		Expect(resource.Name).To(Equal("some-resource"))
+		Expect(actualJobs).To(Equal(jobs))
+
+		resource, actualJobs = fakePipelineDB.PruneResourceVersionsArgsForCall(1)
+		Expect(resource.Name).To(Equal("some-other-resource"))
+		Expect(actualJobs).To(Equal(jobs))
+	})
+
+	Context("when pruning a resource fails", func() {
+		BeforeEach(func() {
+			fakePipelineDB.PruneResourceVersionsReturns(0, errors.New("disaster"))
+		})
+
+		It("continues pruning the other resources", func() {
+			Expect(pruneErr).NotTo(HaveOccurred())
+			Expect(fakePipelineDB.PruneResourceVersionsCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when getting the pipelines fails", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fakeDB.GetAllPipelinesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(pruneErr).To(Equal(disaster))
+			Expect(fakePipelineDB.PruneResourceVersionsCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package pruner
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+//go:generate counterfeiter . RunnerDB
+
+type RunnerDB interface {
+	LeaseVersionPruning(interval time.Duration) (db.Lease, bool, error)
+}
+
+func NewRunner(
+	logger lager.Logger,
+	pruner Pruner,
+	db RunnerDB,
+	clock clock.Clock,
+	interval time.Duration,
+) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+
+		close(ready)
+
+		ticker := clock.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C():
+				leaseLogger := logger.Session("lease-prune-versions")
+				leaseLogger.Info("tick")
+
+				lease, leased, err := db.LeaseVersionPruning(interval)
+
+				if err != nil {
+					leaseLogger.Error("failed-to-get-lease", err)
+					break
+				}
+
+				if !leased {
+					leaseLogger.Debug("did-not-get-lease")
+					break
+				}
+
+				leaseLogger.Info("pruning-versions")
+				err = pruner.Prune()
+				if err != nil {
+					leaseLogger.Error("failed-to-prune-versions", err)
+				}
+
+				lease.Break()
+			case <-signals:
+				return nil
+			}
+		}
+	})
+}
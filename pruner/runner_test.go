@@ -0,0 +1,119 @@
+package pruner_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	dbfakes "github.com/concourse/atc/db/fakes"
+	. "github.com/concourse/atc/pruner"
+	"github.com/concourse/atc/pruner/fakes"
+)
+
+var _ = Describe("Runner", func() {
+	var (
+		fakeDB     *fakes.FakeRunnerDB
+		fakePruner *fakes.FakePruner
+		fakeClock  *fakeclock.FakeClock
+		fakeLease  *dbfakes.FakeLease
+
+		interval time.Duration
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(fakes.FakeRunnerDB)
+		fakePruner = new(fakes.FakePruner)
+		fakeLease = new(dbfakes.FakeLease)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		interval = 100 * time.Millisecond
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(NewRunner(
+			lagertest.NewTestLogger("test"),
+			fakePruner,
+			fakeDB,
+			fakeClock,
+			interval,
+		))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Expect(<-process.Wait()).ToNot(HaveOccurred())
+	})
+
+	Context("when the interval elapses", func() {
+		JustBeforeEach(func() {
+			fakeClock.WaitForWatcherAndIncrement(interval)
+		})
+
+		It("calls to get a lease for version pruning", func() {
+			Eventually(fakeDB.LeaseVersionPruningCallCount).Should(Equal(1))
+			actualInterval := fakeDB.LeaseVersionPruningArgsForCall(0)
+			Expect(actualInterval).To(Equal(interval))
+		})
+
+		Context("when getting a lease succeeds", func() {
+			BeforeEach(func() {
+				fakeDB.LeaseVersionPruningReturns(fakeLease, true, nil)
+			})
+
+			It("prunes versions", func() {
+				Eventually(fakePruner.PruneCallCount).Should(Equal(1))
+			})
+
+			It("breaks the lease", func() {
+				Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+			})
+
+			Context("when pruning fails", func() {
+				BeforeEach(func() {
+					fakePruner.PruneReturns(errors.New("disaster"))
+				})
+
+				It("does not exit the process", func() {
+					Consistently(process.Wait()).ShouldNot(Receive())
+				})
+
+				It("breaks the lease", func() {
+					Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+				})
+			})
+		})
+
+		Context("when getting a lease fails", func() {
+			Context("because of an error", func() {
+				BeforeEach(func() {
+					fakeDB.LeaseVersionPruningReturns(nil, true, errors.New("disaster"))
+				})
+
+				It("does not exit and does not prune versions", func() {
+					Consistently(fakePruner.PruneCallCount).Should(Equal(0))
+					Consistently(process.Wait()).ShouldNot(Receive())
+				})
+			})
+
+			Context("because we got leased of false", func() {
+				BeforeEach(func() {
+					fakeDB.LeaseVersionPruningReturns(nil, false, nil)
+				})
+
+				It("does not exit and does not prune versions", func() {
+					Consistently(fakePruner.PruneCallCount).Should(Equal(0))
+					Consistently(process.Wait()).ShouldNot(Receive())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package radar
+
+import "os"
+
+// CheckLimiter bounds the number of checks that may be in flight at once
+// across every radar sharing it, so that a burst of checks (e.g. when the ATC
+// starts up) does not overwhelm the workers.
+type CheckLimiter struct {
+	slots chan struct{}
+}
+
+// NewCheckLimiter returns a limiter allowing up to maxInFlight concurrent
+// checks. A maximum of zero places no limit on checks.
+func NewCheckLimiter(maxInFlight int) *CheckLimiter {
+	limiter := &CheckLimiter{}
+
+	if maxInFlight > 0 {
+		limiter.slots = make(chan struct{}, maxInFlight)
+	}
+
+	return limiter
+}
+
+// Acquire blocks until a check may run, returning true, or until a signal is
+// received, returning false. Each call that returns true must be paired with
+// a call to Release once the check is done.
+func (limiter *CheckLimiter) Acquire(signals <-chan os.Signal) bool {
+	if limiter.slots == nil {
+		return true
+	}
+
+	select {
+	case limiter.slots <- struct{}{}:
+		return true
+	case <-signals:
+		return false
+	}
+}
+
+func (limiter *CheckLimiter) Release() {
+	if limiter.slots == nil {
+		return
+	}
+
+	<-limiter.slots
+}
+
+// InFlight returns the number of checks currently holding a slot.
+func (limiter *CheckLimiter) InFlight() int {
+	return len(limiter.slots)
+}
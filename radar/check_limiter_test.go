@@ -0,0 +1,74 @@
+package radar_test
+
+import (
+	"os"
+
+	. "github.com/concourse/atc/radar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckLimiter", func() {
+	var limiter *CheckLimiter
+
+	Context("with a maximum", func() {
+		BeforeEach(func() {
+			limiter = NewCheckLimiter(2)
+		})
+
+		It("allows up to the maximum number of checks at once", func() {
+			Expect(limiter.Acquire(nil)).To(BeTrue())
+			Expect(limiter.Acquire(nil)).To(BeTrue())
+			Expect(limiter.InFlight()).To(Equal(2))
+
+			acquired := make(chan struct{})
+			go func() {
+				limiter.Acquire(nil)
+				close(acquired)
+			}()
+
+			Consistently(acquired).ShouldNot(BeClosed())
+
+			limiter.Release()
+
+			Eventually(acquired).Should(BeClosed())
+			Expect(limiter.InFlight()).To(Equal(2))
+		})
+
+		It("gives up waiting when signalled", func() {
+			limiter.Acquire(nil)
+			limiter.Acquire(nil)
+
+			signals := make(chan os.Signal, 1)
+
+			acquired := make(chan bool)
+			go func() {
+				acquired <- limiter.Acquire(signals)
+			}()
+
+			Consistently(acquired).ShouldNot(Receive())
+
+			signals <- os.Interrupt
+
+			Eventually(acquired).Should(Receive(BeFalse()))
+			Expect(limiter.InFlight()).To(Equal(2))
+		})
+	})
+
+	Context("with a maximum of zero", func() {
+		BeforeEach(func() {
+			limiter = NewCheckLimiter(0)
+		})
+
+		It("never blocks", func() {
+			for i := 0; i < 100; i++ {
+				Expect(limiter.Acquire(nil)).To(BeTrue())
+			}
+
+			for i := 0; i < 100; i++ {
+				limiter.Release()
+			}
+		})
+	})
+})
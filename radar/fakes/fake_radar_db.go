@@ -106,6 +106,92 @@ type FakeRadarDB struct {
 		result2 bool
 		result3 error
 	}
+	SetResourceCheckBackoffStub        func(resource db.SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error
+	setResourceCheckBackoffMutex       sync.RWMutex
+	setResourceCheckBackoffArgsForCall []struct {
+		resource  db.SavedResource
+		failures  int
+		backoff   time.Duration
+		nextCheck time.Time
+	}
+	setResourceCheckBackoffReturns struct {
+		result1 error
+	}
+	SaveResourceCheckStub        func(resource db.SavedResource, check db.ResourceCheck) error
+	saveResourceCheckMutex       sync.RWMutex
+	saveResourceCheckArgsForCall []struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}
+	saveResourceCheckReturns struct {
+		result1 error
+	}
+	ShareResourceVersionsStub        func(resource db.SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error
+	shareResourceVersionsMutex       sync.RWMutex
+	shareResourceVersionsArgsForCall []struct {
+		resource db.SavedResource
+		config   atc.ResourceConfig
+		from     atc.Version
+		versions []atc.Version
+	}
+	shareResourceVersionsReturns struct {
+		result1 error
+	}
+	GetResourceTypeStub        func(resourceTypeName string) (db.SavedResourceType, bool, error)
+	getResourceTypeMutex       sync.RWMutex
+	getResourceTypeArgsForCall []struct {
+		resourceTypeName string
+	}
+	getResourceTypeReturns struct {
+		result1 db.SavedResourceType
+		result2 bool
+		result3 error
+	}
+	SaveResourceTypeVersionStub        func(resourceType db.SavedResourceType, version atc.Version) error
+	saveResourceTypeVersionMutex       sync.RWMutex
+	saveResourceTypeVersionArgsForCall []struct {
+		resourceType db.SavedResourceType
+		version      atc.Version
+	}
+	saveResourceTypeVersionReturns struct {
+		result1 error
+	}
+	SetResourceTypeCheckErrorStub        func(resourceType db.SavedResourceType, err error) error
+	setResourceTypeCheckErrorMutex       sync.RWMutex
+	setResourceTypeCheckErrorArgsForCall []struct {
+		resourceType db.SavedResourceType
+		err          error
+	}
+	setResourceTypeCheckErrorReturns struct {
+		result1 error
+	}
+	ResolveResourceTypeVersionsStub        func(arg1 atc.ResourceTypes) (atc.ResourceTypes, error)
+	resolveResourceTypeVersionsMutex       sync.RWMutex
+	resolveResourceTypeVersionsArgsForCall []struct {
+		arg1 atc.ResourceTypes
+	}
+	resolveResourceTypeVersionsReturns struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
+	LeaseResourceTypeCheckingStub        func(resourceType string, interval time.Duration, immediate bool) (db.Lease, bool, error)
+	leaseResourceTypeCheckingMutex       sync.RWMutex
+	leaseResourceTypeCheckingArgsForCall []struct {
+		resourceType string
+		interval     time.Duration
+		immediate    bool
+	}
+	leaseResourceTypeCheckingReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	GetPipelineTeamIDStub        func() int
+	getPipelineTeamIDMutex       sync.RWMutex
+	getPipelineTeamIDArgsForCall []struct{}
+	getPipelineTeamIDReturns     struct {
+		result1 int
+	}
 }
 
 func (fake *FakeRadarDB) GetPipelineName() string {
@@ -449,4 +535,300 @@ func (fake *FakeRadarDB) LeaseResourceCheckingReturns(result1 db.Lease, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRadarDB) SetResourceCheckBackoff(resource db.SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error {
+	fake.setResourceCheckBackoffMutex.Lock()
+	fake.setResourceCheckBackoffArgsForCall = append(fake.setResourceCheckBackoffArgsForCall, struct {
+		resource  db.SavedResource
+		failures  int
+		backoff   time.Duration
+		nextCheck time.Time
+	}{resource, failures, backoff, nextCheck})
+	fake.setResourceCheckBackoffMutex.Unlock()
+	if fake.SetResourceCheckBackoffStub != nil {
+		return fake.SetResourceCheckBackoffStub(resource, failures, backoff, nextCheck)
+	} else {
+		return fake.setResourceCheckBackoffReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SetResourceCheckBackoffCallCount() int {
+	fake.setResourceCheckBackoffMutex.RLock()
+	defer fake.setResourceCheckBackoffMutex.RUnlock()
+	return len(fake.setResourceCheckBackoffArgsForCall)
+}
+
+func (fake *FakeRadarDB) SetResourceCheckBackoffArgsForCall(i int) (db.SavedResource, int, time.Duration, time.Time) {
+	fake.setResourceCheckBackoffMutex.RLock()
+	defer fake.setResourceCheckBackoffMutex.RUnlock()
+	return fake.setResourceCheckBackoffArgsForCall[i].resource, fake.setResourceCheckBackoffArgsForCall[i].failures, fake.setResourceCheckBackoffArgsForCall[i].backoff, fake.setResourceCheckBackoffArgsForCall[i].nextCheck
+}
+
+func (fake *FakeRadarDB) SetResourceCheckBackoffReturns(result1 error) {
+	fake.SetResourceCheckBackoffStub = nil
+	fake.setResourceCheckBackoffReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) SaveResourceCheck(resource db.SavedResource, check db.ResourceCheck) error {
+	fake.saveResourceCheckMutex.Lock()
+	fake.saveResourceCheckArgsForCall = append(fake.saveResourceCheckArgsForCall, struct {
+		resource db.SavedResource
+		check    db.ResourceCheck
+	}{resource, check})
+	fake.saveResourceCheckMutex.Unlock()
+	if fake.SaveResourceCheckStub != nil {
+		return fake.SaveResourceCheckStub(resource, check)
+	} else {
+		return fake.saveResourceCheckReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SaveResourceCheckCallCount() int {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return len(fake.saveResourceCheckArgsForCall)
+}
+
+func (fake *FakeRadarDB) SaveResourceCheckArgsForCall(i int) (db.SavedResource, db.ResourceCheck) {
+	fake.saveResourceCheckMutex.RLock()
+	defer fake.saveResourceCheckMutex.RUnlock()
+	return fake.saveResourceCheckArgsForCall[i].resource, fake.saveResourceCheckArgsForCall[i].check
+}
+
+func (fake *FakeRadarDB) SaveResourceCheckReturns(result1 error) {
+	fake.SaveResourceCheckStub = nil
+	fake.saveResourceCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) ShareResourceVersions(resource db.SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error {
+	fake.shareResourceVersionsMutex.Lock()
+	fake.shareResourceVersionsArgsForCall = append(fake.shareResourceVersionsArgsForCall, struct {
+		resource db.SavedResource
+		config   atc.ResourceConfig
+		from     atc.Version
+		versions []atc.Version
+	}{resource, config, from, versions})
+	fake.shareResourceVersionsMutex.Unlock()
+	if fake.ShareResourceVersionsStub != nil {
+		return fake.ShareResourceVersionsStub(resource, config, from, versions)
+	} else {
+		return fake.shareResourceVersionsReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) ShareResourceVersionsCallCount() int {
+	fake.shareResourceVersionsMutex.RLock()
+	defer fake.shareResourceVersionsMutex.RUnlock()
+	return len(fake.shareResourceVersionsArgsForCall)
+}
+
+func (fake *FakeRadarDB) ShareResourceVersionsArgsForCall(i int) (db.SavedResource, atc.ResourceConfig, atc.Version, []atc.Version) {
+	fake.shareResourceVersionsMutex.RLock()
+	defer fake.shareResourceVersionsMutex.RUnlock()
+	return fake.shareResourceVersionsArgsForCall[i].resource, fake.shareResourceVersionsArgsForCall[i].config, fake.shareResourceVersionsArgsForCall[i].from, fake.shareResourceVersionsArgsForCall[i].versions
+}
+
+func (fake *FakeRadarDB) ShareResourceVersionsReturns(result1 error) {
+	fake.ShareResourceVersionsStub = nil
+	fake.shareResourceVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) GetResourceType(resourceTypeName string) (db.SavedResourceType, bool, error) {
+	fake.getResourceTypeMutex.Lock()
+	fake.getResourceTypeArgsForCall = append(fake.getResourceTypeArgsForCall, struct {
+		resourceTypeName string
+	}{resourceTypeName})
+	fake.getResourceTypeMutex.Unlock()
+	if fake.GetResourceTypeStub != nil {
+		return fake.GetResourceTypeStub(resourceTypeName)
+	} else {
+		return fake.getResourceTypeReturns.result1, fake.getResourceTypeReturns.result2, fake.getResourceTypeReturns.result3
+	}
+}
+
+func (fake *FakeRadarDB) GetResourceTypeCallCount() int {
+	fake.getResourceTypeMutex.RLock()
+	defer fake.getResourceTypeMutex.RUnlock()
+	return len(fake.getResourceTypeArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetResourceTypeArgsForCall(i int) string {
+	fake.getResourceTypeMutex.RLock()
+	defer fake.getResourceTypeMutex.RUnlock()
+	return fake.getResourceTypeArgsForCall[i].resourceTypeName
+}
+
+func (fake *FakeRadarDB) GetResourceTypeReturns(result1 db.SavedResourceType, result2 bool, result3 error) {
+	fake.GetResourceTypeStub = nil
+	fake.getResourceTypeReturns = struct {
+		result1 db.SavedResourceType
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeRadarDB) SaveResourceTypeVersion(resourceType db.SavedResourceType, version atc.Version) error {
+	fake.saveResourceTypeVersionMutex.Lock()
+	fake.saveResourceTypeVersionArgsForCall = append(fake.saveResourceTypeVersionArgsForCall, struct {
+		resourceType db.SavedResourceType
+		version      atc.Version
+	}{resourceType, version})
+	fake.saveResourceTypeVersionMutex.Unlock()
+	if fake.SaveResourceTypeVersionStub != nil {
+		return fake.SaveResourceTypeVersionStub(resourceType, version)
+	} else {
+		return fake.saveResourceTypeVersionReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SaveResourceTypeVersionCallCount() int {
+	fake.saveResourceTypeVersionMutex.RLock()
+	defer fake.saveResourceTypeVersionMutex.RUnlock()
+	return len(fake.saveResourceTypeVersionArgsForCall)
+}
+
+func (fake *FakeRadarDB) SaveResourceTypeVersionArgsForCall(i int) (db.SavedResourceType, atc.Version) {
+	fake.saveResourceTypeVersionMutex.RLock()
+	defer fake.saveResourceTypeVersionMutex.RUnlock()
+	return fake.saveResourceTypeVersionArgsForCall[i].resourceType, fake.saveResourceTypeVersionArgsForCall[i].version
+}
+
+func (fake *FakeRadarDB) SaveResourceTypeVersionReturns(result1 error) {
+	fake.SaveResourceTypeVersionStub = nil
+	fake.saveResourceTypeVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) SetResourceTypeCheckError(resourceType db.SavedResourceType, err error) error {
+	fake.setResourceTypeCheckErrorMutex.Lock()
+	fake.setResourceTypeCheckErrorArgsForCall = append(fake.setResourceTypeCheckErrorArgsForCall, struct {
+		resourceType db.SavedResourceType
+		err          error
+	}{resourceType, err})
+	fake.setResourceTypeCheckErrorMutex.Unlock()
+	if fake.SetResourceTypeCheckErrorStub != nil {
+		return fake.SetResourceTypeCheckErrorStub(resourceType, err)
+	} else {
+		return fake.setResourceTypeCheckErrorReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SetResourceTypeCheckErrorCallCount() int {
+	fake.setResourceTypeCheckErrorMutex.RLock()
+	defer fake.setResourceTypeCheckErrorMutex.RUnlock()
+	return len(fake.setResourceTypeCheckErrorArgsForCall)
+}
+
+func (fake *FakeRadarDB) SetResourceTypeCheckErrorArgsForCall(i int) (db.SavedResourceType, error) {
+	fake.setResourceTypeCheckErrorMutex.RLock()
+	defer fake.setResourceTypeCheckErrorMutex.RUnlock()
+	return fake.setResourceTypeCheckErrorArgsForCall[i].resourceType, fake.setResourceTypeCheckErrorArgsForCall[i].err
+}
+
+func (fake *FakeRadarDB) SetResourceTypeCheckErrorReturns(result1 error) {
+	fake.SetResourceTypeCheckErrorStub = nil
+	fake.setResourceTypeCheckErrorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRadarDB) ResolveResourceTypeVersions(arg1 atc.ResourceTypes) (atc.ResourceTypes, error) {
+	fake.resolveResourceTypeVersionsMutex.Lock()
+	fake.resolveResourceTypeVersionsArgsForCall = append(fake.resolveResourceTypeVersionsArgsForCall, struct {
+		arg1 atc.ResourceTypes
+	}{arg1})
+	fake.resolveResourceTypeVersionsMutex.Unlock()
+	if fake.ResolveResourceTypeVersionsStub != nil {
+		return fake.ResolveResourceTypeVersionsStub(arg1)
+	} else {
+		return fake.resolveResourceTypeVersionsReturns.result1, fake.resolveResourceTypeVersionsReturns.result2
+	}
+}
+
+func (fake *FakeRadarDB) ResolveResourceTypeVersionsCallCount() int {
+	fake.resolveResourceTypeVersionsMutex.RLock()
+	defer fake.resolveResourceTypeVersionsMutex.RUnlock()
+	return len(fake.resolveResourceTypeVersionsArgsForCall)
+}
+
+func (fake *FakeRadarDB) ResolveResourceTypeVersionsArgsForCall(i int) atc.ResourceTypes {
+	fake.resolveResourceTypeVersionsMutex.RLock()
+	defer fake.resolveResourceTypeVersionsMutex.RUnlock()
+	return fake.resolveResourceTypeVersionsArgsForCall[i].arg1
+}
+
+func (fake *FakeRadarDB) ResolveResourceTypeVersionsReturns(result1 atc.ResourceTypes, result2 error) {
+	fake.ResolveResourceTypeVersionsStub = nil
+	fake.resolveResourceTypeVersionsReturns = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRadarDB) LeaseResourceTypeChecking(resourceType string, interval time.Duration, immediate bool) (db.Lease, bool, error) {
+	fake.leaseResourceTypeCheckingMutex.Lock()
+	fake.leaseResourceTypeCheckingArgsForCall = append(fake.leaseResourceTypeCheckingArgsForCall, struct {
+		resourceType string
+		interval     time.Duration
+		immediate    bool
+	}{resourceType, interval, immediate})
+	fake.leaseResourceTypeCheckingMutex.Unlock()
+	if fake.LeaseResourceTypeCheckingStub != nil {
+		return fake.LeaseResourceTypeCheckingStub(resourceType, interval, immediate)
+	} else {
+		return fake.leaseResourceTypeCheckingReturns.result1, fake.leaseResourceTypeCheckingReturns.result2, fake.leaseResourceTypeCheckingReturns.result3
+	}
+}
+
+func (fake *FakeRadarDB) LeaseResourceTypeCheckingCallCount() int {
+	fake.leaseResourceTypeCheckingMutex.RLock()
+	defer fake.leaseResourceTypeCheckingMutex.RUnlock()
+	return len(fake.leaseResourceTypeCheckingArgsForCall)
+}
+
+func (fake *FakeRadarDB) LeaseResourceTypeCheckingArgsForCall(i int) (string, time.Duration, bool) {
+	fake.leaseResourceTypeCheckingMutex.RLock()
+	defer fake.leaseResourceTypeCheckingMutex.RUnlock()
+	return fake.leaseResourceTypeCheckingArgsForCall[i].resourceType, fake.leaseResourceTypeCheckingArgsForCall[i].interval, fake.leaseResourceTypeCheckingArgsForCall[i].immediate
+}
+
+func (fake *FakeRadarDB) LeaseResourceTypeCheckingReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.LeaseResourceTypeCheckingStub = nil
+	fake.leaseResourceTypeCheckingReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeRadarDB) GetPipelineTeamID() int {
+	fake.getPipelineTeamIDMutex.Lock()
+	fake.getPipelineTeamIDArgsForCall = append(fake.getPipelineTeamIDArgsForCall, struct{}{})
+	fake.getPipelineTeamIDMutex.Unlock()
+	if fake.GetPipelineTeamIDStub != nil {
+		return fake.GetPipelineTeamIDStub()
+	} else {
+		return fake.getPipelineTeamIDReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) GetPipelineTeamIDCallCount() int {
+	fake.getPipelineTeamIDMutex.RLock()
+	defer fake.getPipelineTeamIDMutex.RUnlock()
+	return len(fake.getPipelineTeamIDArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetPipelineTeamIDReturns(result1 int) {
+	fake.GetPipelineTeamIDStub = nil
+	fake.getPipelineTeamIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
 var _ radar.RadarDB = new(FakeRadarDB)
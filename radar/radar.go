@@ -1,8 +1,10 @@
 package radar
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math/rand"
 	"os"
 	"time"
 
@@ -24,10 +26,27 @@ func (err ResourceNotConfiguredError) Error() string {
 	return fmt.Sprintf("resource '%s' was not found in config", err.ResourceName)
 }
 
+type ResourceTypeNotConfiguredError struct {
+	ResourceTypeName string
+}
+
+func (err ResourceTypeNotConfiguredError) Error() string {
+	return fmt.Sprintf("resource type '%s' was not found in config", err.ResourceTypeName)
+}
+
+type CheckTimedOutError struct {
+	Timeout time.Duration
+}
+
+func (err CheckTimedOutError) Error() string {
+	return fmt.Sprintf("check timed out after %s", err.Timeout)
+}
+
 //go:generate counterfeiter . RadarDB
 
 type RadarDB interface {
 	GetPipelineName() string
+	GetPipelineTeamID() int
 	ScopedName(string) string
 
 	IsPaused() (bool, error)
@@ -40,14 +59,27 @@ type RadarDB interface {
 	UnpauseResource(resourceName string) error
 
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
+	ShareResourceVersions(resource db.SavedResource, config atc.ResourceConfig, from atc.Version, versions []atc.Version) error
 	SetResourceCheckError(resource db.SavedResource, err error) error
+	SetResourceCheckBackoff(resource db.SavedResource, failures int, backoff time.Duration, nextCheck time.Time) error
+	SaveResourceCheck(resource db.SavedResource, check db.ResourceCheck) error
 	LeaseResourceChecking(resource string, interval time.Duration, immediate bool) (db.Lease, bool, error)
+
+	GetResourceType(resourceTypeName string) (db.SavedResourceType, bool, error)
+	SaveResourceTypeVersion(resourceType db.SavedResourceType, version atc.Version) error
+	SetResourceTypeCheckError(resourceType db.SavedResourceType, err error) error
+	ResolveResourceTypeVersions(atc.ResourceTypes) (atc.ResourceTypes, error)
+	LeaseResourceTypeChecking(resourceType string, interval time.Duration, immediate bool) (db.Lease, bool, error)
 }
 
 type Radar struct {
 	logger          lager.Logger
 	tracker         resource.Tracker
 	defaultInterval time.Duration
+	maxBackoff      time.Duration
+	defaultTimeout  time.Duration
+	initialJitter   time.Duration
+	limiter         *CheckLimiter
 	db              RadarDB
 	clock           clock.Clock
 	externalURL     string
@@ -56,6 +88,10 @@ type Radar struct {
 func NewRadar(
 	tracker resource.Tracker,
 	defaultInterval time.Duration,
+	maxBackoff time.Duration,
+	defaultTimeout time.Duration,
+	initialJitter time.Duration,
+	limiter *CheckLimiter,
 	db RadarDB,
 	clock clock.Clock,
 	externalURL string,
@@ -63,6 +99,10 @@ func NewRadar(
 	return &Radar{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
+		maxBackoff:      maxBackoff,
+		defaultTimeout:  defaultTimeout,
+		initialJitter:   initialJitter,
+		limiter:         limiter,
 		db:              db,
 		clock:           clock,
 		externalURL:     externalURL,
@@ -71,8 +111,9 @@ func NewRadar(
 
 func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
-		// do an immediate initial check
-		var interval time.Duration = 0
+		// do an initial check soon, spread out so that every scanner starting
+		// at once does not check at once
+		interval := radar.initialDelay()
 
 		close(ready)
 
@@ -100,7 +141,7 @@ func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runn
 					return err
 				}
 
-				interval, err = radar.checkInterval(resourceConfig)
+				checkInterval, err := radar.checkInterval(resourceConfig)
 				if err != nil {
 					setErr := radar.db.SetResourceCheckError(savedResource, err)
 					if setErr != nil {
@@ -110,11 +151,20 @@ func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runn
 					return err
 				}
 
+				// wake up at the regular interval even while backing off, and
+				// leave the backoff to the lease. the failure count is read fresh
+				// each time, so the backoff always reflects the latest check, and a
+				// manual check that resets it takes effect on the next wakeup
+				// rather than after the old backoff has run out.
+				interval = checkInterval
+
+				backoff := radar.backoff(checkInterval, savedResource.CheckFailures)
+
 				leaseLogger := logger.Session("lease", lager.Data{
 					"resource": resourceName,
 				})
 
-				lease, leased, err := radar.db.LeaseResourceChecking(resourceName, interval, false)
+				lease, leased, err := radar.db.LeaseResourceChecking(resourceName, backoff, false)
 
 				if err != nil {
 					leaseLogger.Error("failed-to-get-lease", err, lager.Data{
@@ -128,10 +178,87 @@ func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runn
 					break
 				}
 
-				err = radar.scan(logger.Session("tick"), resourceConfig, resourceTypes, savedResource)
+				leaseSignals := radar.newLeaseSignals(signals, backoff)
+
+				err = radar.scan(logger.Session("tick"), leaseSignals.Signals(), resourceConfig, resourceTypes, savedResource, checkInterval)
+
+				signalled := leaseSignals.Stop()
+
+				lease.Break()
+
+				if signalled {
+					return nil
+				}
+
+				if err == resource.ErrAborted {
+					leaseLogger.Info("lease-expired-before-check")
+					break
+				}
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+	})
+}
+
+func (radar *Radar) ResourceTypeScanner(logger lager.Logger, resourceTypeName string) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		// do an initial check soon, spread out so that every scanner starting
+		// at once does not check at once
+		interval := radar.initialDelay()
+
+		close(ready)
+
+		for {
+			timer := radar.clock.NewTimer(interval)
+
+			select {
+			case <-signals:
+				timer.Stop()
+				return nil
+
+			case <-timer.C():
+				interval = radar.defaultInterval
+
+				resourceType, resourceTypes, err := radar.getResourceTypeConfig(logger, resourceTypeName)
+				if err != nil {
+					return err
+				}
+
+				leaseLogger := logger.Session("lease", lager.Data{
+					"resource-type": resourceTypeName,
+				})
+
+				lease, leased, err := radar.db.LeaseResourceTypeChecking(resourceTypeName, interval, false)
+				if err != nil {
+					leaseLogger.Error("failed-to-get-lease", err)
+					break
+				}
+
+				if !leased {
+					leaseLogger.Debug("did-not-get-lease")
+					break
+				}
+
+				leaseSignals := radar.newLeaseSignals(signals, interval)
+
+				err = radar.scanResourceType(logger.Session("tick"), leaseSignals.Signals(), resourceType, resourceTypes)
+
+				signalled := leaseSignals.Stop()
 
 				lease.Break()
 
+				if signalled {
+					return nil
+				}
+
+				if err == resource.ErrAborted {
+					leaseLogger.Info("lease-expired-before-check")
+					break
+				}
+
 				if err != nil {
 					return err
 				}
@@ -186,10 +313,16 @@ func (radar *Radar) Scan(logger lager.Logger, resourceName string) error {
 		break
 	}
 
-	return radar.scan(logger, resourceConfig, resourceTypes, savedResource)
+	// a manual check starts backing off from scratch
+	savedResource.CheckFailures = 0
+
+	leaseSignals := radar.newLeaseSignals(nil, interval)
+	defer leaseSignals.Stop()
+
+	return radar.scan(logger, leaseSignals.Signals(), resourceConfig, resourceTypes, savedResource, interval)
 }
 
-func (radar *Radar) scan(logger lager.Logger, resourceConfig atc.ResourceConfig, resourceTypes atc.ResourceTypes, savedResource db.SavedResource) error {
+func (radar *Radar) scan(logger lager.Logger, signals <-chan os.Signal, resourceConfig atc.ResourceConfig, resourceTypes atc.ResourceTypes, savedResource db.SavedResource, interval time.Duration) error {
 	pipelinePaused, err := radar.db.IsPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
@@ -206,6 +339,13 @@ func (radar *Radar) scan(logger lager.Logger, resourceConfig atc.ResourceConfig,
 		return nil
 	}
 
+	if !radar.acquireCheckSlot(logger, signals) {
+		logger.Info("interrupted-waiting-for-check-slot")
+		return resource.ErrAborted
+	}
+
+	defer radar.limiter.Release()
+
 	pipelineName := radar.db.GetPipelineName()
 
 	session := resource.Session{
@@ -218,12 +358,14 @@ func (radar *Radar) scan(logger lager.Logger, resourceConfig atc.ResourceConfig,
 		Metadata: worker.Metadata{
 			Type:         db.ContainerTypeCheck,
 			PipelineName: pipelineName,
+			TeamID:       radar.db.GetPipelineTeamID(),
 		},
 		Ephemeral: true,
 	}
 
 	res, err := radar.tracker.Init(
 		logger,
+		signals,
 		resource.TrackerMetadata{
 			ResourceName: resourceConfig.Name,
 			PipelineName: pipelineName,
@@ -235,6 +377,11 @@ func (radar *Radar) scan(logger lager.Logger, resourceConfig atc.ResourceConfig,
 		resourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
+	if err == resource.ErrAborted {
+		logger.Info("interrupted-waiting-for-worker-capacity")
+		return err
+	}
+
 	if err != nil {
 		logger.Error("failed-to-initialize-new-resource", err)
 		return err
@@ -257,19 +404,160 @@ func (radar *Radar) scan(logger lager.Logger, resourceConfig atc.ResourceConfig,
 		"from": from,
 	})
 
-	newVersions, err := res.Check(resourceConfig.Source, atc.Version(from))
+	startTime := radar.clock.Now()
+
+	stderr := new(bytes.Buffer)
+
+	newVersions, err := radar.check(res, resource.IOConfig{Stderr: stderr}, resourceConfig, atc.Version(from))
+
+	radar.saveCheck(logger, res, savedResource, startTime, newVersions, stderr.String(), err)
 
 	setErr := radar.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
 		logger.Error("failed-to-set-check-error", err)
 	}
 
+	radar.setCheckBackoff(logger, savedResource, interval, err)
+
+	if err != nil {
+		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
+			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
+			return nil
+		}
+
+		if tErr, ok := err.(CheckTimedOutError); ok {
+			logger.Info("check-timed-out", lager.Data{"timeout": tErr.Timeout.String()})
+			return nil
+		}
+
+		logger.Error("failed-to-check", err)
+		return err
+	}
+
+	if len(newVersions) == 0 {
+		logger.Debug("no-new-versions")
+	} else {
+		logger.Info("versions-found", lager.Data{
+			"versions": newVersions,
+			"total":    len(newVersions),
+		})
+
+		err = radar.db.SaveResourceVersions(resourceConfig, newVersions)
+		if err != nil {
+			logger.Error("failed-to-save-versions", err, lager.Data{
+				"versions": newVersions,
+			})
+
+			return nil
+		}
+	}
+
+	err = radar.db.ShareResourceVersions(savedResource, resourceConfig, atc.Version(from), newVersions)
+	if err != nil {
+		logger.Error("failed-to-share-versions", err)
+	}
+
+	return nil
+}
+
+func (radar *Radar) scanResourceType(logger lager.Logger, signals <-chan os.Signal, resourceType atc.ResourceType, resourceTypes atc.ResourceTypes) error {
+	pipelinePaused, err := radar.db.IsPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-paused", err)
+		return err
+	}
+
+	if pipelinePaused {
+		logger.Debug("pipeline-paused")
+		return nil
+	}
+
+	savedResourceType, found, err := radar.db.GetResourceType(resourceType.Name)
+	if err != nil {
+		logger.Error("failed-to-get-resource-type", err)
+		return err
+	}
+
+	if !found {
+		logger.Info("resource-type-not-found")
+		return nil
+	}
+
+	if !radar.acquireCheckSlot(logger, signals) {
+		logger.Info("interrupted-waiting-for-check-slot")
+		return resource.ErrAborted
+	}
+
+	defer radar.limiter.Release()
+
+	pipelineName := radar.db.GetPipelineName()
+
+	session := resource.Session{
+		ID: worker.Identifier{
+			Stage:       db.ContainerStageRun,
+			CheckType:   resourceType.Type,
+			CheckSource: resourceType.Source,
+		},
+		Metadata: worker.Metadata{
+			Type:         db.ContainerTypeCheck,
+			PipelineName: pipelineName,
+			TeamID:       radar.db.GetPipelineTeamID(),
+		},
+		Ephemeral: true,
+	}
+
+	res, err := radar.tracker.Init(
+		logger,
+		signals,
+		resource.TrackerMetadata{
+			ResourceName: resourceType.Name,
+			PipelineName: pipelineName,
+			ExternalURL:  radar.externalURL,
+		},
+		session,
+		resource.ResourceType(resourceType.Type),
+		[]string{},
+		resourceTypes.Without(resourceType.Name),
+		worker.NoopImageFetchingDelegate{},
+	)
+	if err == resource.ErrAborted {
+		logger.Info("interrupted-waiting-for-worker-capacity")
+		return err
+	}
+
+	if err != nil {
+		logger.Error("failed-to-initialize-new-resource", err)
+		return err
+	}
+
+	defer res.Release(nil)
+
+	logger.Debug("checking", lager.Data{
+		"from": savedResourceType.Version,
+	})
+
+	newVersions, err := radar.check(res, resource.IOConfig{}, atc.ResourceConfig{
+		Name:   resourceType.Name,
+		Type:   resourceType.Type,
+		Source: resourceType.Source,
+	}, savedResourceType.Version)
+
+	setErr := radar.db.SetResourceTypeCheckError(savedResourceType, err)
+	if setErr != nil {
+		logger.Error("failed-to-set-check-error", err)
+	}
+
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
 			return nil
 		}
 
+		if tErr, ok := err.(CheckTimedOutError); ok {
+			logger.Info("check-timed-out", lager.Data{"timeout": tErr.Timeout.String()})
+			return nil
+		}
+
 		logger.Error("failed-to-check", err)
 		return err
 	}
@@ -279,21 +567,149 @@ func (radar *Radar) scan(logger lager.Logger, resourceConfig atc.ResourceConfig,
 		return nil
 	}
 
-	logger.Info("versions-found", lager.Data{
-		"versions": newVersions,
-		"total":    len(newVersions),
+	latestVersion := newVersions[len(newVersions)-1]
+
+	logger.Info("version-found", lager.Data{
+		"version": latestVersion,
 	})
 
-	err = radar.db.SaveResourceVersions(resourceConfig, newVersions)
+	err = radar.db.SaveResourceTypeVersion(savedResourceType, latestVersion)
 	if err != nil {
-		logger.Error("failed-to-save-versions", err, lager.Data{
-			"versions": newVersions,
+		logger.Error("failed-to-save-version", err, lager.Data{
+			"version": latestVersion,
 		})
 	}
 
 	return nil
 }
 
+// acquireCheckSlot waits until the limiter permits another check to run,
+// returning false if it is signalled first.
+func (radar *Radar) acquireCheckSlot(logger lager.Logger, signals <-chan os.Signal) bool {
+	start := radar.clock.Now()
+
+	acquired := radar.limiter.Acquire(signals)
+
+	if waited := radar.clock.Now().Sub(start); waited > 0 {
+		logger.Debug("waited-for-check-slot", lager.Data{"duration": waited.String()})
+	}
+
+	return acquired
+}
+
+// leaseSignals relays signals to a check for as long as the lease it holds
+// lasts, and interrupts it once the lease has run out, so that it does not go
+// on waiting for a check slot or worker capacity without the lease.
+type leaseSignals struct {
+	signals chan os.Signal
+	timer   clock.Timer
+
+	expired   chan struct{}
+	signalled chan struct{}
+	done      chan struct{}
+}
+
+func (radar *Radar) newLeaseSignals(signals <-chan os.Signal, lease time.Duration) *leaseSignals {
+	ls := &leaseSignals{
+		signals: make(chan os.Signal, 1),
+		timer:   radar.clock.NewTimer(lease),
+
+		expired:   make(chan struct{}),
+		signalled: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go func() {
+		select {
+		case sig := <-signals:
+			close(ls.signalled)
+			ls.signals <- sig
+		case <-ls.timer.C():
+			close(ls.expired)
+			ls.signals <- os.Interrupt
+		case <-ls.done:
+		}
+	}()
+
+	return ls
+}
+
+// Signals returns the channel to pass to the check.
+func (ls *leaseSignals) Signals() <-chan os.Signal {
+	return ls.signals
+}
+
+// Stop stops relaying signals. It returns true if a signal was relayed, in
+// which case the caller should exit as it would have had it received it.
+func (ls *leaseSignals) Stop() bool {
+	ls.timer.Stop()
+	close(ls.done)
+
+	select {
+	case <-ls.signalled:
+		return true
+	default:
+		return false
+	}
+}
+
+// initialDelay returns a random delay of up to the configured jitter before a
+// scanner's first check.
+func (radar *Radar) initialDelay() time.Duration {
+	if radar.initialJitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(radar.initialJitter)))
+}
+
+// check runs the resource's check, interrupting it if it takes longer than
+// the resource's check timeout.
+func (radar *Radar) check(res resource.Resource, ioConfig resource.IOConfig, resourceConfig atc.ResourceConfig, from atc.Version) ([]atc.Version, error) {
+	timeout := radar.defaultTimeout
+	if resourceConfig.CheckTimeout != "" {
+		configuredTimeout, err := time.ParseDuration(resourceConfig.CheckTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		timeout = configuredTimeout
+	}
+
+	if timeout <= 0 {
+		return res.Check(ioConfig, resourceConfig.Source, from, nil)
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	timedOut := make(chan struct{})
+
+	timer := radar.clock.NewTimer(timeout)
+	defer timer.Stop()
+
+	go func() {
+		select {
+		case <-timer.C():
+			close(timedOut)
+			signals <- os.Interrupt
+		case <-done:
+		}
+	}()
+
+	versions, err := res.Check(ioConfig, resourceConfig.Source, from, signals)
+	close(done)
+
+	select {
+	case <-timedOut:
+		if err == resource.ErrAborted {
+			return nil, CheckTimedOutError{Timeout: timeout}
+		}
+	default:
+	}
+
+	return versions, err
+}
+
 func (radar *Radar) checkInterval(resourceConfig atc.ResourceConfig) (time.Duration, error) {
 	interval := radar.defaultInterval
 	if resourceConfig.CheckEvery != "" {
@@ -308,6 +724,69 @@ func (radar *Radar) checkInterval(resourceConfig atc.ResourceConfig) (time.Durat
 	return interval, nil
 }
 
+func (radar *Radar) saveCheck(logger lager.Logger, res resource.Resource, savedResource db.SavedResource, startTime time.Time, versions []atc.Version, stderr string, checkErr error) {
+	check := db.ResourceCheck{
+		StartTime:     startTime,
+		EndTime:       radar.clock.Now(),
+		WorkerName:    res.WorkerName(),
+		VersionsFound: len(versions),
+		Stderr:        stderr,
+	}
+
+	switch cause := checkErr.(type) {
+	case nil:
+		exitStatus := 0
+		check.ExitStatus = &exitStatus
+	case resource.ErrResourceScriptFailed:
+		exitStatus := cause.ExitStatus
+		check.ExitStatus = &exitStatus
+		check.Error = cause.Error()
+	default:
+		check.Error = cause.Error()
+	}
+
+	err := radar.db.SaveResourceCheck(savedResource, check)
+	if err != nil {
+		logger.Error("failed-to-save-check", err)
+	}
+}
+
+// backoff returns how long to wait between checks of a resource whose last
+// checks have failed, doubling the interval for each failure up to the
+// configured maximum. A maximum of zero disables backing off.
+func (radar *Radar) backoff(interval time.Duration, failures int) time.Duration {
+	backoff := interval
+
+	for i := 0; i < failures && backoff < radar.maxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > radar.maxBackoff && interval < radar.maxBackoff {
+		backoff = radar.maxBackoff
+	}
+
+	return backoff
+}
+
+func (radar *Radar) setCheckBackoff(logger lager.Logger, savedResource db.SavedResource, interval time.Duration, checkErr error) {
+	failures := 0
+	if checkErr != nil {
+		failures = savedResource.CheckFailures + 1
+	}
+
+	next := radar.backoff(interval, failures)
+
+	var backoff time.Duration
+	if failures > 0 {
+		backoff = next
+	}
+
+	err := radar.db.SetResourceCheckBackoff(savedResource, failures, backoff, radar.clock.Now().Add(next))
+	if err != nil {
+		logger.Error("failed-to-set-check-backoff", err)
+	}
+}
+
 var errPipelineRemoved = errors.New("pipeline removed")
 
 func (radar *Radar) getResourceConfig(logger lager.Logger, resourceName string) (atc.ResourceConfig, atc.ResourceTypes, error) {
@@ -328,5 +807,38 @@ func (radar *Radar) getResourceConfig(logger lager.Logger, resourceName string)
 		return resourceConfig, nil, ResourceNotConfiguredError{ResourceName: resourceName}
 	}
 
-	return resourceConfig, config.ResourceTypes, nil
+	resourceTypes, err := radar.db.ResolveResourceTypeVersions(config.ResourceTypes)
+	if err != nil {
+		logger.Error("failed-to-resolve-resource-type-versions", err)
+		return atc.ResourceConfig{}, nil, err
+	}
+
+	return resourceConfig, resourceTypes, nil
+}
+
+func (radar *Radar) getResourceTypeConfig(logger lager.Logger, resourceTypeName string) (atc.ResourceType, atc.ResourceTypes, error) {
+	config, _, found, err := radar.db.GetConfig()
+	if err != nil {
+		logger.Error("failed-to-get-config", err)
+		return atc.ResourceType{}, nil, err
+	}
+
+	if !found {
+		logger.Info("pipeline-removed")
+		return atc.ResourceType{}, nil, errPipelineRemoved
+	}
+
+	resourceType, found := config.ResourceTypes.Lookup(resourceTypeName)
+	if !found {
+		logger.Info("resource-type-removed-from-configuration")
+		return resourceType, nil, ResourceTypeNotConfiguredError{ResourceTypeName: resourceTypeName}
+	}
+
+	resourceTypes, err := radar.db.ResolveResourceTypeVersions(config.ResourceTypes)
+	if err != nil {
+		logger.Error("failed-to-resolve-resource-type-versions", err)
+		return atc.ResourceType{}, nil, err
+	}
+
+	return resourceType, resourceTypes, nil
 }
@@ -2,6 +2,7 @@ package radar_test
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager"
 	"github.com/pivotal-golang/lager/lagertest"
 	"github.com/tedsuo/ifrit"
 
@@ -29,6 +31,10 @@ var _ = Describe("Radar", func() {
 		fakeRadarDB *fakes.FakeRadarDB
 		fakeClock   *fakeclock.FakeClock
 		interval    time.Duration
+		maxBackoff  time.Duration
+		timeout     time.Duration
+		jitter      time.Duration
+		limiter     *CheckLimiter
 
 		radar *Radar
 
@@ -46,9 +52,13 @@ var _ = Describe("Radar", func() {
 		fakeRadarDB = new(fakes.FakeRadarDB)
 		fakeClock = fakeclock.NewFakeClock(epoch)
 		interval = 1 * time.Minute
+		maxBackoff = 5 * time.Minute
+		timeout = 1 * time.Hour
+		jitter = 0
+		limiter = NewCheckLimiter(0)
 
 		fakeRadarDB.GetPipelineNameReturns("some-pipeline")
-		radar = NewRadar(fakeTracker, interval, fakeRadarDB, fakeClock, "https://www.example.com")
+		fakeRadarDB.GetPipelineTeamIDReturns(7)
 
 		resourceConfig = atc.ResourceConfig{
 			Name:   "some-resource",
@@ -84,6 +94,14 @@ var _ = Describe("Radar", func() {
 		fakeLease = &dbfakes.FakeLease{}
 
 		fakeRadarDB.GetResourceReturns(savedResource, nil)
+
+		fakeRadarDB.ResolveResourceTypeVersionsStub = func(resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+			return resourceTypes, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		radar = NewRadar(fakeTracker, interval, maxBackoff, timeout, jitter, limiter, fakeRadarDB, fakeClock, "https://www.example.com")
 	})
 
 	Describe("Scanner", func() {
@@ -99,7 +117,7 @@ var _ = Describe("Radar", func() {
 
 			times = make(chan time.Time, 100)
 
-			fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+			fakeResource.CheckStub = func(resource.IOConfig, atc.Source, atc.Version, <-chan os.Signal) ([]atc.Version, error) {
 				times <- fakeClock.Now()
 				return nil, nil
 			}
@@ -136,10 +154,124 @@ var _ = Describe("Radar", func() {
 				Expect(<-times).To(Equal(epoch.Add(interval)))
 			})
 
+			Context("when there is jitter on the initial check", func() {
+				BeforeEach(func() {
+					jitter = 30 * time.Second
+				})
+
+				It("checks for the first time within the jitter", func() {
+					fakeClock.WaitForWatcherAndIncrement(jitter)
+
+					var checkedAt time.Time
+					Eventually(times).Should(Receive(&checkedAt))
+					Expect(checkedAt).To(BeTemporally("<=", epoch.Add(jitter)))
+				})
+			})
+
+			Context("when the maximum number of checks are in flight", func() {
+				BeforeEach(func() {
+					limiter = NewCheckLimiter(1)
+					limiter.Acquire(nil)
+				})
+
+				It("waits for a check to finish before checking", func() {
+					Consistently(times).ShouldNot(Receive())
+
+					limiter.Release()
+
+					Eventually(times).Should(Receive())
+				})
+
+				It("frees up its slot once it has checked", func() {
+					limiter.Release()
+
+					Eventually(times).Should(Receive())
+					Eventually(limiter.InFlight).Should(BeZero())
+				})
+
+				Context("when the scanner is interrupted while waiting for a slot", func() {
+					It("exits without error, giving up its lease", func() {
+						Eventually(fakeRadarDB.LeaseResourceCheckingCallCount).Should(Equal(1))
+
+						process.Signal(os.Interrupt)
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+
+						Expect(fakeTracker.InitCallCount()).To(BeZero())
+						Expect(fakeLease.BreakCallCount()).To(Equal(1))
+
+						limiter.Release()
+					})
+				})
+
+				Context("when the lease runs out while waiting for a slot", func() {
+					It("gives up on the check and tries again on the next interval", func() {
+						Eventually(fakeRadarDB.LeaseResourceCheckingCallCount).Should(Equal(1))
+
+						fakeClock.WaitForWatcherAndIncrement(interval)
+
+						Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+						Expect(fakeTracker.InitCallCount()).To(BeZero())
+						Consistently(process.Wait()).ShouldNot(Receive())
+
+						limiter.Release()
+
+						fakeClock.WaitForWatcherAndIncrement(interval)
+						Eventually(times).Should(Receive())
+					})
+				})
+			})
+
+			Context("when there is no worker capacity until the lease runs out", func() {
+				BeforeEach(func() {
+					fakeTracker.InitStub = func(_ lager.Logger, signals <-chan os.Signal, _ resource.Metadata, _ resource.Session, _ resource.ResourceType, _ atc.Tags, _ atc.ResourceTypes, _ worker.ImageFetchingDelegate) (resource.Resource, error) {
+						if fakeTracker.InitCallCount() == 1 {
+							<-signals
+							return nil, resource.ErrAborted
+						}
+
+						return fakeResource, nil
+					}
+				})
+
+				It("gives up waiting and tries again on the next interval", func() {
+					Eventually(fakeTracker.InitCallCount).Should(Equal(1))
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+
+					Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+					Expect(times).NotTo(Receive())
+					Consistently(process.Wait()).ShouldNot(Receive())
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+					Eventually(times).Should(Receive())
+				})
+			})
+
+			Context("when the scanner is interrupted while waiting for worker capacity", func() {
+				BeforeEach(func() {
+					limiter = NewCheckLimiter(1)
+
+					fakeTracker.InitStub = func(_ lager.Logger, signals <-chan os.Signal, _ resource.Metadata, _ resource.Session, _ resource.ResourceType, _ atc.Tags, _ atc.ResourceTypes, _ worker.ImageFetchingDelegate) (resource.Resource, error) {
+						<-signals
+						return nil, resource.ErrAborted
+					}
+				})
+
+				It("exits without error, giving up its check slot and lease", func() {
+					Eventually(fakeTracker.InitCallCount).Should(Equal(1))
+
+					process.Signal(os.Interrupt)
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(limiter.InFlight()).To(BeZero())
+					Expect(fakeLease.BreakCallCount()).To(Equal(1))
+				})
+			})
+
 			It("constructs the resource of the correct type", func() {
 				<-times
 
-				_, metadata, session, typ, tags, customTypes, delegate := fakeTracker.InitArgsForCall(0)
+				_, _, metadata, session, typ, tags, customTypes, delegate := fakeTracker.InitArgsForCall(0)
 				Expect(metadata).To(Equal(resource.TrackerMetadata{
 					ResourceName: "some-resource",
 					PipelineName: "some-pipeline",
@@ -156,6 +288,7 @@ var _ = Describe("Radar", func() {
 					Metadata: worker.Metadata{
 						Type:         db.ContainerTypeCheck,
 						PipelineName: "some-pipeline",
+						TeamID:       7,
 					},
 					Ephemeral: true,
 				}))
@@ -172,6 +305,33 @@ var _ = Describe("Radar", func() {
 				Expect(tags).To(BeEmpty()) // This allows the check to run on any worker
 			})
 
+			Context("when the resource types have resolved versions", func() {
+				BeforeEach(func() {
+					fakeRadarDB.ResolveResourceTypeVersionsReturns(atc.ResourceTypes{
+						{
+							Name:    "some-custom-resource",
+							Type:    "docker-image",
+							Source:  atc.Source{"custom": "source"},
+							Version: atc.Version{"some": "version"},
+						},
+					}, nil)
+				})
+
+				It("constructs the resource with them", func() {
+					<-times
+
+					_, _, _, _, _, _, customTypes, _ := fakeTracker.InitArgsForCall(0)
+					Expect(customTypes).To(Equal(atc.ResourceTypes{
+						{
+							Name:    "some-custom-resource",
+							Type:    "docker-image",
+							Source:  atc.Source{"custom": "source"},
+							Version: atc.Version{"some": "version"},
+						},
+					}))
+				})
+			})
+
 			Context("when the resource config has a specified check interval", func() {
 				BeforeEach(func() {
 					resourceConfig.CheckEvery = "10ms"
@@ -247,7 +407,7 @@ var _ = Describe("Radar", func() {
 				It("checks from nil", func() {
 					<-times
 
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -268,7 +428,7 @@ var _ = Describe("Radar", func() {
 				It("checks from it", func() {
 					<-times
 
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 
 					fakeRadarDB.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
@@ -279,7 +439,7 @@ var _ = Describe("Radar", func() {
 					fakeClock.WaitForWatcherAndIncrement(interval)
 					<-times
 
-					_, version = fakeResource.CheckArgsForCall(1)
+					_, _, version, _ = fakeResource.CheckArgsForCall(1)
 					Expect(version).To(Equal(atc.Version{"version": "2"}))
 				})
 			})
@@ -303,7 +463,7 @@ var _ = Describe("Radar", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(_ resource.IOConfig, source atc.Source, from atc.Version, signals <-chan os.Signal) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -344,6 +504,75 @@ var _ = Describe("Radar", func() {
 				It("exits with the failure", func() {
 					Expect(<-process.Wait()).To(Equal(disaster))
 				})
+
+				It("backs off from the check interval", func() {
+					<-process.Wait()
+
+					Expect(fakeRadarDB.SetResourceCheckBackoffCallCount()).To(Equal(1))
+
+					resourceArg, failures, backoff, nextCheck := fakeRadarDB.SetResourceCheckBackoffArgsForCall(0)
+					Expect(resourceArg).To(Equal(savedResource))
+					Expect(failures).To(Equal(1))
+					Expect(backoff).To(Equal(2 * interval))
+					Expect(nextCheck).To(Equal(epoch.Add(2 * interval)))
+				})
+			})
+
+			Context("when the resource has been failing to check", func() {
+				BeforeEach(func() {
+					savedResource.CheckFailures = 2
+					fakeRadarDB.GetResourceReturns(savedResource, nil)
+				})
+
+				It("leases for the backed-off interval", func() {
+					<-times
+
+					_, leaseInterval, _ := fakeRadarDB.LeaseResourceCheckingArgsForCall(0)
+					Expect(leaseInterval).To(Equal(4 * interval))
+				})
+
+				It("wakes up at the check interval and leases for the latest backoff", func() {
+					<-times
+
+					Eventually(fakeRadarDB.SetResourceCheckBackoffCallCount).Should(Equal(1))
+
+					// e.g. a manual check succeeded while the scanner slept
+					savedResource.CheckFailures = 0
+					fakeRadarDB.GetResourceReturns(savedResource, nil)
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+					<-times
+
+					Expect(fakeRadarDB.LeaseResourceCheckingCallCount()).To(Equal(2))
+
+					_, leaseInterval, _ := fakeRadarDB.LeaseResourceCheckingArgsForCall(1)
+					Expect(leaseInterval).To(Equal(interval))
+				})
+
+				It("resets the backoff when the check succeeds", func() {
+					<-times
+
+					Eventually(fakeRadarDB.SetResourceCheckBackoffCallCount).Should(Equal(1))
+
+					_, failures, backoff, nextCheck := fakeRadarDB.SetResourceCheckBackoffArgsForCall(0)
+					Expect(failures).To(BeZero())
+					Expect(backoff).To(BeZero())
+					Expect(nextCheck).To(Equal(epoch.Add(interval)))
+				})
+
+				Context("when backing off would exceed the maximum", func() {
+					BeforeEach(func() {
+						savedResource.CheckFailures = 10
+						fakeRadarDB.GetResourceReturns(savedResource, nil)
+					})
+
+					It("leases for the maximum backoff", func() {
+						<-times
+
+						_, leaseInterval, _ := fakeRadarDB.LeaseResourceCheckingArgsForCall(0)
+						Expect(leaseInterval).To(Equal(maxBackoff))
+					})
+				})
 			})
 
 			Context("when the pipeline is paused", func() {
@@ -435,13 +664,13 @@ var _ = Describe("Radar", func() {
 					It("checks using the new config", func() {
 						<-times
 
-						source, _ := fakeResource.CheckArgsForCall(0)
+						_, source, _, _ := fakeResource.CheckArgsForCall(0)
 						Expect(source).To(Equal(resourceConfig.Source))
 
 						fakeClock.WaitForWatcherAndIncrement(interval)
 						<-times
 
-						source, _ = fakeResource.CheckArgsForCall(1)
+						_, source, _, _ = fakeResource.CheckArgsForCall(1)
 						Expect(source).To(Equal(atc.Source{"uri": "http://example.com/updated-uri"}))
 					})
 				})
@@ -471,7 +700,7 @@ var _ = Describe("Radar", func() {
 						fakeClock.WaitForWatcherAndIncrement(newInterval)
 						Expect(<-times).To(Equal(epoch.Add(interval + newInterval)))
 
-						source, _ := fakeResource.CheckArgsForCall(0)
+						_, source, _, _ := fakeResource.CheckArgsForCall(0)
 						Expect(source).To(Equal(newResource.Source))
 					})
 
@@ -548,7 +777,7 @@ var _ = Describe("Radar", func() {
 
 			Context("when checking takes a while", func() {
 				BeforeEach(func() {
-					fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(resource.IOConfig, atc.Source, atc.Version, <-chan os.Signal) ([]atc.Version, error) {
 						times <- fakeClock.Now()
 						fakeClock.Increment(interval / 2)
 						return nil, nil
@@ -566,6 +795,216 @@ var _ = Describe("Radar", func() {
 		})
 	})
 
+	Describe("ResourceTypeScanner", func() {
+		var (
+			fakeResource *rfakes.FakeResource
+
+			savedResourceType db.SavedResourceType
+
+			times chan time.Time
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeTracker.InitReturns(fakeResource, nil)
+
+			savedResourceType = db.SavedResourceType{
+				ID:         7,
+				Name:       "some-custom-resource",
+				ConfigHash: "some-hash",
+			}
+
+			fakeRadarDB.GetResourceTypeReturns(savedResourceType, true, nil)
+
+			times = make(chan time.Time, 100)
+
+			fakeResource.CheckStub = func(resource.IOConfig, atc.Source, atc.Version, <-chan os.Signal) ([]atc.Version, error) {
+				times <- fakeClock.Now()
+				return nil, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			process = ifrit.Invoke(radar.ResourceTypeScanner(lagertest.NewTestLogger("test"), "some-custom-resource"))
+		})
+
+		AfterEach(func() {
+			process.Signal(os.Interrupt)
+			<-process.Wait()
+		})
+
+		Context("when the lease cannot be acquired", func() {
+			BeforeEach(func() {
+				fakeRadarDB.LeaseResourceTypeCheckingReturns(nil, false, nil)
+			})
+
+			It("does not check", func() {
+				Consistently(times).ShouldNot(Receive())
+			})
+		})
+
+		Context("when the lease can be acquired", func() {
+			BeforeEach(func() {
+				fakeRadarDB.LeaseResourceTypeCheckingReturns(fakeLease, true, nil)
+			})
+
+			It("checks immediately and then on the default interval", func() {
+				Expect(<-times).To(Equal(epoch))
+
+				fakeClock.WaitForWatcherAndIncrement(interval)
+				Expect(<-times).To(Equal(epoch.Add(interval)))
+
+				name, leaseInterval, immediate := fakeRadarDB.LeaseResourceTypeCheckingArgsForCall(0)
+				Expect(name).To(Equal("some-custom-resource"))
+				Expect(leaseInterval).To(Equal(interval))
+				Expect(immediate).To(BeFalse())
+			})
+
+			It("constructs the resource of the resource type's own type", func() {
+				<-times
+
+				_, _, metadata, session, typ, tags, customTypes, _ := fakeTracker.InitArgsForCall(0)
+				Expect(metadata).To(Equal(resource.TrackerMetadata{
+					ResourceName: "some-custom-resource",
+					PipelineName: "some-pipeline",
+					ExternalURL:  "https://www.example.com",
+				}))
+
+				Expect(session).To(Equal(resource.Session{
+					ID: worker.Identifier{
+						Stage:       db.ContainerStageRun,
+						CheckType:   "docker-image",
+						CheckSource: atc.Source{"custom": "source"},
+					},
+					Metadata: worker.Metadata{
+						Type:         db.ContainerTypeCheck,
+						PipelineName: "some-pipeline",
+						TeamID:       7,
+					},
+					Ephemeral: true,
+				}))
+
+				Expect(typ).To(Equal(resource.ResourceType("docker-image")))
+				Expect(tags).To(BeEmpty())
+				Expect(customTypes).To(BeEmpty())
+			})
+
+			It("breaks the lease after checking", func() {
+				<-times
+				Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+			})
+
+			Context("when there is no worker capacity until the lease runs out", func() {
+				BeforeEach(func() {
+					fakeTracker.InitStub = func(_ lager.Logger, signals <-chan os.Signal, _ resource.Metadata, _ resource.Session, _ resource.ResourceType, _ atc.Tags, _ atc.ResourceTypes, _ worker.ImageFetchingDelegate) (resource.Resource, error) {
+						if fakeTracker.InitCallCount() == 1 {
+							<-signals
+							return nil, resource.ErrAborted
+						}
+
+						return fakeResource, nil
+					}
+				})
+
+				It("gives up waiting and tries again on the next interval", func() {
+					Eventually(fakeTracker.InitCallCount).Should(Equal(1))
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+
+					Eventually(fakeLease.BreakCallCount).Should(Equal(1))
+					Expect(times).NotTo(Receive())
+					Consistently(process.Wait()).ShouldNot(Receive())
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+					Eventually(times).Should(Receive())
+				})
+			})
+
+			Context("when the resource type has a saved version", func() {
+				BeforeEach(func() {
+					savedResourceType.Version = atc.Version{"digest": "some-digest"}
+					fakeRadarDB.GetResourceTypeReturns(savedResourceType, true, nil)
+				})
+
+				It("checks from it", func() {
+					<-times
+
+					_, source, version, _ := fakeResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"custom": "source"}))
+					Expect(version).To(Equal(atc.Version{"digest": "some-digest"}))
+				})
+			})
+
+			Context("when the check returns versions", func() {
+				BeforeEach(func() {
+					fakeResource.CheckStub = func(resource.IOConfig, atc.Source, atc.Version, <-chan os.Signal) ([]atc.Version, error) {
+						times <- fakeClock.Now()
+						return []atc.Version{{"digest": "a"}, {"digest": "b"}}, nil
+					}
+				})
+
+				It("saves the latest one", func() {
+					<-times
+
+					Eventually(fakeRadarDB.SaveResourceTypeVersionCallCount).Should(Equal(1))
+
+					resourceType, version := fakeRadarDB.SaveResourceTypeVersionArgsForCall(0)
+					Expect(resourceType).To(Equal(savedResourceType))
+					Expect(version).To(Equal(atc.Version{"digest": "b"}))
+				})
+
+				It("clears the check error", func() {
+					<-times
+
+					Eventually(fakeRadarDB.SetResourceTypeCheckErrorCallCount).Should(Equal(1))
+
+					_, err := fakeRadarDB.SetResourceTypeCheckErrorArgsForCall(0)
+					Expect(err).To(BeNil())
+				})
+			})
+
+			Context("when checking fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeResource.CheckReturns(nil, disaster)
+					fakeResource.CheckStub = nil
+				})
+
+				It("sets the check error and exits with the failure", func() {
+					Expect(<-process.Wait()).To(Equal(disaster))
+
+					Expect(fakeRadarDB.SetResourceTypeCheckErrorCallCount()).To(Equal(1))
+
+					_, err := fakeRadarDB.SetResourceTypeCheckErrorArgsForCall(0)
+					Expect(err).To(Equal(disaster))
+
+					Expect(fakeRadarDB.SaveResourceTypeVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline is paused", func() {
+				BeforeEach(func() {
+					fakeRadarDB.IsPausedReturns(true, nil)
+				})
+
+				It("does not check", func() {
+					Consistently(times).ShouldNot(Receive())
+				})
+			})
+
+			Context("when the resource type is removed from the config", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetConfigReturns(atc.Config{}, 1, true, nil)
+				})
+
+				It("exits with the correct error", func() {
+					Expect(<-process.Wait()).To(Equal(ResourceTypeNotConfiguredError{ResourceTypeName: "some-custom-resource"}))
+				})
+			})
+		})
+	})
+
 	Describe("Scan", func() {
 		var (
 			fakeResource *rfakes.FakeResource
@@ -591,8 +1030,90 @@ var _ = Describe("Radar", func() {
 				Expect(scanErr).NotTo(HaveOccurred())
 			})
 
+			Context("when there is no check slot free until the lease runs out", func() {
+				BeforeEach(func() {
+					limiter = NewCheckLimiter(1)
+					limiter.Acquire(nil)
+
+					go fakeClock.WaitForWatcherAndIncrement(interval)
+				})
+
+				AfterEach(func() {
+					limiter.Release()
+				})
+
+				It("gives up on the check", func() {
+					Expect(scanErr).To(Equal(resource.ErrAborted))
+					Expect(fakeTracker.InitCallCount()).To(BeZero())
+					Expect(limiter.InFlight()).To(Equal(1))
+				})
+			})
+
+			Context("when there is no worker capacity until the lease runs out", func() {
+				BeforeEach(func() {
+					fakeTracker.InitStub = func(_ lager.Logger, signals <-chan os.Signal, _ resource.Metadata, _ resource.Session, _ resource.ResourceType, _ atc.Tags, _ atc.ResourceTypes, _ worker.ImageFetchingDelegate) (resource.Resource, error) {
+						fakeClock.Increment(interval)
+						<-signals
+						return nil, resource.ErrAborted
+					}
+				})
+
+				It("gives up on the check", func() {
+					Expect(scanErr).To(Equal(resource.ErrAborted))
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the check succeeds", func() {
+				BeforeEach(func() {
+					fakeResource.WorkerNameReturns("some-worker")
+					fakeResource.CheckStub = func(ioConfig resource.IOConfig, _ atc.Source, _ atc.Version, _ <-chan os.Signal) ([]atc.Version, error) {
+						fmt.Fprint(ioConfig.Stderr, "some-stderr")
+						return []atc.Version{{"version": "1"}, {"version": "2"}}, nil
+					}
+				})
+
+				It("records the check in the resource's history", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+					resourceArg, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+					Expect(resourceArg).To(Equal(savedResource))
+					Expect(check.StartTime).To(Equal(epoch))
+					Expect(check.EndTime).To(Equal(epoch))
+					Expect(check.WorkerName).To(Equal("some-worker"))
+					Expect(check.VersionsFound).To(Equal(2))
+					Expect(check.ExitStatus).NotTo(BeNil())
+					Expect(*check.ExitStatus).To(Equal(0))
+					Expect(check.Stderr).To(Equal("some-stderr"))
+					Expect(check.Error).To(BeEmpty())
+				})
+			})
+
+			Context("when the check script fails", func() {
+				BeforeEach(func() {
+					fakeResource.CheckStub = func(ioConfig resource.IOConfig, _ atc.Source, _ atc.Version, _ <-chan os.Signal) ([]atc.Version, error) {
+						fmt.Fprint(ioConfig.Stderr, "some-stderr")
+						return nil, resource.ErrResourceScriptFailed{
+							Path:       "/opt/resource/check",
+							ExitStatus: 2,
+							Stderr:     "some-stderr",
+						}
+					}
+				})
+
+				It("records the exit status and stderr", func() {
+					Expect(fakeRadarDB.SaveResourceCheckCallCount()).To(Equal(1))
+
+					_, check := fakeRadarDB.SaveResourceCheckArgsForCall(0)
+					Expect(check.ExitStatus).NotTo(BeNil())
+					Expect(*check.ExitStatus).To(Equal(2))
+					Expect(check.Stderr).To(Equal("some-stderr"))
+					Expect(check.Error).To(ContainSubstring("exit status 2"))
+				})
+			})
+
 			It("constructs the resource of the correct type", func() {
-				_, metadata, session, typ, tags, _, _ := fakeTracker.InitArgsForCall(0)
+				_, _, metadata, session, typ, tags, _, _ := fakeTracker.InitArgsForCall(0)
 				Expect(metadata).To(Equal(resource.TrackerMetadata{
 					ResourceName: "some-resource",
 					PipelineName: "some-pipeline",
@@ -609,6 +1130,7 @@ var _ = Describe("Radar", func() {
 					Metadata: worker.Metadata{
 						Type:         db.ContainerTypeCheck,
 						PipelineName: "some-pipeline",
+						TeamID:       7,
 					},
 					Ephemeral: true,
 				}))
@@ -732,7 +1254,7 @@ var _ = Describe("Radar", func() {
 				})
 
 				It("checks from nil", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -767,7 +1289,7 @@ var _ = Describe("Radar", func() {
 				})
 
 				It("checks from it", func() {
-					_, version := fakeResource.CheckArgsForCall(0)
+					_, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 			})
@@ -791,7 +1313,7 @@ var _ = Describe("Radar", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(_ resource.IOConfig, source atc.Source, from atc.Version, signals <-chan os.Signal) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -821,6 +1343,47 @@ var _ = Describe("Radar", func() {
 					}))
 
 				})
+
+				It("shares them with identically configured resources", func() {
+					Expect(fakeRadarDB.ShareResourceVersionsCallCount()).To(Equal(1))
+
+					resourceArg, configArg, from, versions := fakeRadarDB.ShareResourceVersionsArgsForCall(0)
+					Expect(resourceArg).To(Equal(savedResource))
+					Expect(configArg).To(Equal(resourceConfig))
+					Expect(from).To(BeNil())
+					Expect(versions).To(Equal(nextVersions))
+				})
+
+				Context("when saving the versions fails", func() {
+					BeforeEach(func() {
+						fakeRadarDB.SaveResourceVersionsReturns(errors.New("nope"))
+					})
+
+					It("does not share them", func() {
+						Expect(fakeRadarDB.ShareResourceVersionsCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the check returns no new versions", func() {
+				BeforeEach(func() {
+					fakeRadarDB.GetLatestVersionedResourceReturns(
+						db.SavedVersionedResource{
+							VersionedResource: db.VersionedResource{
+								Version: db.Version{"version": "1"},
+							},
+						}, true, nil)
+
+					fakeResource.CheckReturns([]atc.Version{}, nil)
+				})
+
+				It("still shares the check with identically configured resources", func() {
+					Expect(fakeRadarDB.ShareResourceVersionsCallCount()).To(Equal(1))
+
+					_, _, from, versions := fakeRadarDB.ShareResourceVersionsArgsForCall(0)
+					Expect(from).To(Equal(atc.Version{"version": "1"}))
+					Expect(versions).To(BeEmpty())
+				})
 			})
 
 			Context("when checking fails", func() {
@@ -834,6 +1397,10 @@ var _ = Describe("Radar", func() {
 					Expect(scanErr).To(Equal(disaster))
 				})
 
+				It("does not share the check", func() {
+					Expect(fakeRadarDB.ShareResourceVersionsCallCount()).To(BeZero())
+				})
+
 				It("sets the resource's check error", func() {
 					Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
 
@@ -841,6 +1408,69 @@ var _ = Describe("Radar", func() {
 					Expect(savedResourceArg).To(Equal(savedResource))
 					Expect(err).To(Equal(disaster))
 				})
+
+				Context("when the resource has already been failing to check", func() {
+					BeforeEach(func() {
+						savedResource.CheckFailures = 3
+						fakeRadarDB.GetResourceReturns(savedResource, nil)
+					})
+
+					It("starts backing off from scratch", func() {
+						Expect(fakeRadarDB.SetResourceCheckBackoffCallCount()).To(Equal(1))
+
+						_, failures, backoff, _ := fakeRadarDB.SetResourceCheckBackoffArgsForCall(0)
+						Expect(failures).To(Equal(1))
+						Expect(backoff).To(Equal(2 * interval))
+					})
+				})
+			})
+
+			Context("when checking takes longer than the timeout", func() {
+				BeforeEach(func() {
+					fakeResource.CheckStub = func(_ resource.IOConfig, source atc.Source, from atc.Version, signals <-chan os.Signal) ([]atc.Version, error) {
+						fakeClock.Increment(timeout)
+						<-signals
+						return nil, resource.ErrAborted
+					}
+				})
+
+				It("succeeds", func() {
+					Expect(scanErr).NotTo(HaveOccurred())
+				})
+
+				It("sets the resource's check error to the timeout", func() {
+					Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+					_, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+					Expect(err).To(Equal(CheckTimedOutError{Timeout: timeout}))
+				})
+
+				It("releases the lease", func() {
+					Expect(fakeLease.BreakCallCount()).To(Equal(1))
+				})
+
+				Context("when the resource config has a specified check timeout", func() {
+					BeforeEach(func() {
+						resourceConfig.CheckTimeout = "30s"
+
+						fakeRadarDB.GetConfigReturns(atc.Config{
+							Resources: atc.ResourceConfigs{
+								resourceConfig,
+							},
+						}, 1, true, nil)
+
+						fakeResource.CheckStub = func(_ resource.IOConfig, source atc.Source, from atc.Version, signals <-chan os.Signal) ([]atc.Version, error) {
+							fakeClock.Increment(30 * time.Second)
+							<-signals
+							return nil, resource.ErrAborted
+						}
+					})
+
+					It("times out after the configured timeout", func() {
+						_, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(err).To(Equal(CheckTimedOutError{Timeout: 30 * time.Second}))
+					})
+				})
 			})
 		})
 	})
@@ -14,6 +14,7 @@ import (
 
 type ScannerFactory interface {
 	Scanner(lager.Logger, string) ifrit.Runner
+	ResourceTypeScanner(lager.Logger, string) ifrit.Runner
 }
 
 type Runner struct {
@@ -121,17 +122,36 @@ func (runner *Runner) tick(scanning map[string]bool, insertScanner chan<- groupe
 		scanning[scopedName] = true
 
 		logger := runner.logger.Session("scan", lager.Data{
-			"pipeline:resource": runner.db.ScopedName(resource.Name),
+			"pipeline:resource": scopedName,
 		})
-		runner := runner.scannerFactory.Scanner(logger, resource.Name)
-
-		// avoid deadlock if exit event is blocked; inserting in this case
-		// will block on the event being consumed (which is in this select)
-		go func(name string) {
-			insertScanner <- grouper.Member{
-				Name:   name,
-				Runner: runner,
-			}
-		}(scopedName)
+
+		runner.insert(insertScanner, scopedName, runner.scannerFactory.Scanner(logger, resource.Name))
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		scopedName := runner.db.ScopedName("resource-type:" + resourceType.Name)
+
+		if scanning[scopedName] {
+			continue
+		}
+
+		scanning[scopedName] = true
+
+		logger := runner.logger.Session("scan-resource-type", lager.Data{
+			"pipeline:resource-type": runner.db.ScopedName(resourceType.Name),
+		})
+
+		runner.insert(insertScanner, scopedName, runner.scannerFactory.ResourceTypeScanner(logger, resourceType.Name))
 	}
 }
+
+func (runner *Runner) insert(insertScanner chan<- grouper.Member, name string, scanner ifrit.Runner) {
+	// avoid deadlock if exit event is blocked; inserting in this case
+	// will block on the event being consumed (which is in this select)
+	go func() {
+		insertScanner <- grouper.Member{
+			Name:   name,
+			Runner: scanner,
+		}
+	}()
+}
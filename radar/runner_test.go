@@ -85,6 +85,30 @@ var _ = Describe("Runner", func() {
 		Expect(resource).To(Equal("some-other-resource"))
 	})
 
+	Context("when resource types are configured", func() {
+		BeforeEach(func() {
+			initialConfig.ResourceTypes = atc.ResourceTypes{
+				{
+					Name: "some-resource-type",
+					Type: "docker-image",
+				},
+			}
+
+			pipelineDB.GetConfigReturns(initialConfig, 1, true, nil)
+
+			scannerFactory.ResourceTypeScannerStub = scannerFactory.ScannerStub
+		})
+
+		It("scans for every configured resource type", func() {
+			Eventually(scannerFactory.ResourceTypeScannerCallCount).Should(Equal(1))
+
+			_, resourceType := scannerFactory.ResourceTypeScannerArgsForCall(0)
+			Expect(resourceType).To(Equal("some-resource-type"))
+
+			Consistently(scannerFactory.ResourceTypeScannerCallCount).Should(Equal(1))
+		})
+	})
+
 	Context("when new resources are configured", func() {
 		var updateConfig chan<- atc.Config
 
@@ -10,4 +10,17 @@ type Resource struct {
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
+	CheckBackoff   string `json:"check_backoff,omitempty"`
+	NextCheck      int64  `json:"next_check,omitempty"`
+}
+
+type ResourceCheck struct {
+	ID            int    `json:"id"`
+	StartTime     int64  `json:"start_time"`
+	EndTime       int64  `json:"end_time"`
+	WorkerName    string `json:"worker_name,omitempty"`
+	VersionsFound int    `json:"versions_found"`
+	ExitStatus    *int   `json:"exit_status,omitempty"`
+	Stderr        string `json:"stderr,omitempty"`
+	Error         string `json:"error,omitempty"`
 }
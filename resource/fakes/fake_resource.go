@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"os"
 	"sync"
 	"time"
 
@@ -33,16 +34,6 @@ type FakeResource struct {
 	putReturns struct {
 		result1 resource.VersionedSource
 	}
-	CheckStub        func(atc.Source, atc.Version) ([]atc.Version, error)
-	checkMutex       sync.RWMutex
-	checkArgsForCall []struct {
-		arg1 atc.Source
-		arg2 atc.Version
-	}
-	checkReturns struct {
-		result1 []atc.Version
-		result2 error
-	}
 	ReleaseStub        func(*time.Duration)
 	releaseMutex       sync.RWMutex
 	releaseArgsForCall []struct {
@@ -55,6 +46,24 @@ type FakeResource struct {
 		result1 baggageclaim.Volume
 		result2 bool
 	}
+	CheckStub        func(arg1 resource.IOConfig, arg2 atc.Source, arg3 atc.Version, arg4 <-chan os.Signal) ([]atc.Version, error)
+	checkMutex       sync.RWMutex
+	checkArgsForCall []struct {
+		arg1 resource.IOConfig
+		arg2 atc.Source
+		arg3 atc.Version
+		arg4 <-chan os.Signal
+	}
+	checkReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns     struct {
+		result1 string
+	}
 }
 
 func (fake *FakeResource) Get(arg1 resource.IOConfig, arg2 atc.Source, arg3 atc.Params, arg4 atc.Version) resource.VersionedSource {
@@ -127,40 +136,6 @@ func (fake *FakeResource) PutReturns(result1 resource.VersionedSource) {
 	}{result1}
 }
 
-func (fake *FakeResource) Check(arg1 atc.Source, arg2 atc.Version) ([]atc.Version, error) {
-	fake.checkMutex.Lock()
-	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
-		arg1 atc.Source
-		arg2 atc.Version
-	}{arg1, arg2})
-	fake.checkMutex.Unlock()
-	if fake.CheckStub != nil {
-		return fake.CheckStub(arg1, arg2)
-	} else {
-		return fake.checkReturns.result1, fake.checkReturns.result2
-	}
-}
-
-func (fake *FakeResource) CheckCallCount() int {
-	fake.checkMutex.RLock()
-	defer fake.checkMutex.RUnlock()
-	return len(fake.checkArgsForCall)
-}
-
-func (fake *FakeResource) CheckArgsForCall(i int) (atc.Source, atc.Version) {
-	fake.checkMutex.RLock()
-	defer fake.checkMutex.RUnlock()
-	return fake.checkArgsForCall[i].arg1, fake.checkArgsForCall[i].arg2
-}
-
-func (fake *FakeResource) CheckReturns(result1 []atc.Version, result2 error) {
-	fake.CheckStub = nil
-	fake.checkReturns = struct {
-		result1 []atc.Version
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeResource) Release(arg1 *time.Duration) {
 	fake.releaseMutex.Lock()
 	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
@@ -209,4 +184,64 @@ func (fake *FakeResource) CacheVolumeReturns(result1 baggageclaim.Volume, result
 	}{result1, result2}
 }
 
+func (fake *FakeResource) Check(arg1 resource.IOConfig, arg2 atc.Source, arg3 atc.Version, arg4 <-chan os.Signal) ([]atc.Version, error) {
+	fake.checkMutex.Lock()
+	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
+		arg1 resource.IOConfig
+		arg2 atc.Source
+		arg3 atc.Version
+		arg4 <-chan os.Signal
+	}{arg1, arg2, arg3, arg4})
+	fake.checkMutex.Unlock()
+	if fake.CheckStub != nil {
+		return fake.CheckStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.checkReturns.result1, fake.checkReturns.result2
+	}
+}
+
+func (fake *FakeResource) CheckCallCount() int {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	return len(fake.checkArgsForCall)
+}
+
+func (fake *FakeResource) CheckArgsForCall(i int) (resource.IOConfig, atc.Source, atc.Version, <-chan os.Signal) {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	return fake.checkArgsForCall[i].arg1, fake.checkArgsForCall[i].arg2, fake.checkArgsForCall[i].arg3, fake.checkArgsForCall[i].arg4
+}
+
+func (fake *FakeResource) CheckReturns(result1 []atc.Version, result2 error) {
+	fake.CheckStub = nil
+	fake.checkReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	} else {
+		return fake.workerNameReturns.result1
+	}
+}
+
+func (fake *FakeResource) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeResource) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 var _ resource.Resource = new(FakeResource)
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"os"
 	"sync"
 
 	"github.com/concourse/atc"
@@ -11,49 +12,52 @@ import (
 )
 
 type FakeTracker struct {
-	InitStub        func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, error)
+	InitStub        func(lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, error)
 	initMutex       sync.RWMutex
 	initArgsForCall []struct {
 		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 atc.ResourceTypes
-		arg7 worker.ImageFetchingDelegate
+		arg2 <-chan os.Signal
+		arg3 resource.Metadata
+		arg4 resource.Session
+		arg5 resource.ResourceType
+		arg6 atc.Tags
+		arg7 atc.ResourceTypes
+		arg8 worker.ImageFetchingDelegate
 	}
 	initReturns struct {
 		result1 resource.Resource
 		result2 error
 	}
-	InitWithCacheStub        func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, resource.CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, resource.Cache, error)
+	InitWithCacheStub        func(lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, resource.CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, resource.Cache, error)
 	initWithCacheMutex       sync.RWMutex
 	initWithCacheArgsForCall []struct {
 		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 resource.CacheIdentifier
-		arg7 atc.ResourceTypes
-		arg8 worker.ImageFetchingDelegate
+		arg2 <-chan os.Signal
+		arg3 resource.Metadata
+		arg4 resource.Session
+		arg5 resource.ResourceType
+		arg6 atc.Tags
+		arg7 resource.CacheIdentifier
+		arg8 atc.ResourceTypes
+		arg9 worker.ImageFetchingDelegate
 	}
 	initWithCacheReturns struct {
 		result1 resource.Resource
 		result2 resource.Cache
 		result3 error
 	}
-	InitWithSourcesStub        func(lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error)
+	InitWithSourcesStub        func(lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (resource.Resource, []string, error)
 	initWithSourcesMutex       sync.RWMutex
 	initWithSourcesArgsForCall []struct {
 		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 map[string]resource.ArtifactSource
-		arg7 atc.ResourceTypes
-		arg8 worker.ImageFetchingDelegate
+		arg2 <-chan os.Signal
+		arg3 resource.Metadata
+		arg4 resource.Session
+		arg5 resource.ResourceType
+		arg6 atc.Tags
+		arg7 map[string]resource.ArtifactSource
+		arg8 atc.ResourceTypes
+		arg9 worker.ImageFetchingDelegate
 	}
 	initWithSourcesReturns struct {
 		result1 resource.Resource
@@ -62,20 +66,21 @@ type FakeTracker struct {
 	}
 }
 
-func (fake *FakeTracker) Init(arg1 lager.Logger, arg2 resource.Metadata, arg3 resource.Session, arg4 resource.ResourceType, arg5 atc.Tags, arg6 atc.ResourceTypes, arg7 worker.ImageFetchingDelegate) (resource.Resource, error) {
+func (fake *FakeTracker) Init(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 resource.Metadata, arg4 resource.Session, arg5 resource.ResourceType, arg6 atc.Tags, arg7 atc.ResourceTypes, arg8 worker.ImageFetchingDelegate) (resource.Resource, error) {
 	fake.initMutex.Lock()
 	fake.initArgsForCall = append(fake.initArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 atc.ResourceTypes
-		arg7 worker.ImageFetchingDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+		arg2 <-chan os.Signal
+		arg3 resource.Metadata
+		arg4 resource.Session
+		arg5 resource.ResourceType
+		arg6 atc.Tags
+		arg7 atc.ResourceTypes
+		arg8 worker.ImageFetchingDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.initMutex.Unlock()
 	if fake.InitStub != nil {
-		return fake.InitStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+		return fake.InitStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	} else {
 		return fake.initReturns.result1, fake.initReturns.result2
 	}
@@ -87,10 +92,10 @@ func (fake *FakeTracker) InitCallCount() int {
 	return len(fake.initArgsForCall)
 }
 
-func (fake *FakeTracker) InitArgsForCall(i int) (lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, atc.ResourceTypes, worker.ImageFetchingDelegate) {
+func (fake *FakeTracker) InitArgsForCall(i int) (lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, atc.ResourceTypes, worker.ImageFetchingDelegate) {
 	fake.initMutex.RLock()
 	defer fake.initMutex.RUnlock()
-	return fake.initArgsForCall[i].arg1, fake.initArgsForCall[i].arg2, fake.initArgsForCall[i].arg3, fake.initArgsForCall[i].arg4, fake.initArgsForCall[i].arg5, fake.initArgsForCall[i].arg6, fake.initArgsForCall[i].arg7
+	return fake.initArgsForCall[i].arg1, fake.initArgsForCall[i].arg2, fake.initArgsForCall[i].arg3, fake.initArgsForCall[i].arg4, fake.initArgsForCall[i].arg5, fake.initArgsForCall[i].arg6, fake.initArgsForCall[i].arg7, fake.initArgsForCall[i].arg8
 }
 
 func (fake *FakeTracker) InitReturns(result1 resource.Resource, result2 error) {
@@ -101,21 +106,22 @@ func (fake *FakeTracker) InitReturns(result1 resource.Resource, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeTracker) InitWithCache(arg1 lager.Logger, arg2 resource.Metadata, arg3 resource.Session, arg4 resource.ResourceType, arg5 atc.Tags, arg6 resource.CacheIdentifier, arg7 atc.ResourceTypes, arg8 worker.ImageFetchingDelegate) (resource.Resource, resource.Cache, error) {
+func (fake *FakeTracker) InitWithCache(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 resource.Metadata, arg4 resource.Session, arg5 resource.ResourceType, arg6 atc.Tags, arg7 resource.CacheIdentifier, arg8 atc.ResourceTypes, arg9 worker.ImageFetchingDelegate) (resource.Resource, resource.Cache, error) {
 	fake.initWithCacheMutex.Lock()
 	fake.initWithCacheArgsForCall = append(fake.initWithCacheArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 resource.CacheIdentifier
-		arg7 atc.ResourceTypes
-		arg8 worker.ImageFetchingDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+		arg2 <-chan os.Signal
+		arg3 resource.Metadata
+		arg4 resource.Session
+		arg5 resource.ResourceType
+		arg6 atc.Tags
+		arg7 resource.CacheIdentifier
+		arg8 atc.ResourceTypes
+		arg9 worker.ImageFetchingDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
 	fake.initWithCacheMutex.Unlock()
 	if fake.InitWithCacheStub != nil {
-		return fake.InitWithCacheStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+		return fake.InitWithCacheStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	} else {
 		return fake.initWithCacheReturns.result1, fake.initWithCacheReturns.result2, fake.initWithCacheReturns.result3
 	}
@@ -127,10 +133,10 @@ func (fake *FakeTracker) InitWithCacheCallCount() int {
 	return len(fake.initWithCacheArgsForCall)
 }
 
-func (fake *FakeTracker) InitWithCacheArgsForCall(i int) (lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, resource.CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) {
+func (fake *FakeTracker) InitWithCacheArgsForCall(i int) (lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, resource.CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) {
 	fake.initWithCacheMutex.RLock()
 	defer fake.initWithCacheMutex.RUnlock()
-	return fake.initWithCacheArgsForCall[i].arg1, fake.initWithCacheArgsForCall[i].arg2, fake.initWithCacheArgsForCall[i].arg3, fake.initWithCacheArgsForCall[i].arg4, fake.initWithCacheArgsForCall[i].arg5, fake.initWithCacheArgsForCall[i].arg6, fake.initWithCacheArgsForCall[i].arg7, fake.initWithCacheArgsForCall[i].arg8
+	return fake.initWithCacheArgsForCall[i].arg1, fake.initWithCacheArgsForCall[i].arg2, fake.initWithCacheArgsForCall[i].arg3, fake.initWithCacheArgsForCall[i].arg4, fake.initWithCacheArgsForCall[i].arg5, fake.initWithCacheArgsForCall[i].arg6, fake.initWithCacheArgsForCall[i].arg7, fake.initWithCacheArgsForCall[i].arg8, fake.initWithCacheArgsForCall[i].arg9
 }
 
 func (fake *FakeTracker) InitWithCacheReturns(result1 resource.Resource, result2 resource.Cache, result3 error) {
@@ -142,21 +148,22 @@ func (fake *FakeTracker) InitWithCacheReturns(result1 resource.Resource, result2
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTracker) InitWithSources(arg1 lager.Logger, arg2 resource.Metadata, arg3 resource.Session, arg4 resource.ResourceType, arg5 atc.Tags, arg6 map[string]resource.ArtifactSource, arg7 atc.ResourceTypes, arg8 worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
+func (fake *FakeTracker) InitWithSources(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 resource.Metadata, arg4 resource.Session, arg5 resource.ResourceType, arg6 atc.Tags, arg7 map[string]resource.ArtifactSource, arg8 atc.ResourceTypes, arg9 worker.ImageFetchingDelegate) (resource.Resource, []string, error) {
 	fake.initWithSourcesMutex.Lock()
 	fake.initWithSourcesArgsForCall = append(fake.initWithSourcesArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 resource.Metadata
-		arg3 resource.Session
-		arg4 resource.ResourceType
-		arg5 atc.Tags
-		arg6 map[string]resource.ArtifactSource
-		arg7 atc.ResourceTypes
-		arg8 worker.ImageFetchingDelegate
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+		arg2 <-chan os.Signal
+		arg3 resource.Metadata
+		arg4 resource.Session
+		arg5 resource.ResourceType
+		arg6 atc.Tags
+		arg7 map[string]resource.ArtifactSource
+		arg8 atc.ResourceTypes
+		arg9 worker.ImageFetchingDelegate
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9})
 	fake.initWithSourcesMutex.Unlock()
 	if fake.InitWithSourcesStub != nil {
-		return fake.InitWithSourcesStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+		return fake.InitWithSourcesStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	} else {
 		return fake.initWithSourcesReturns.result1, fake.initWithSourcesReturns.result2, fake.initWithSourcesReturns.result3
 	}
@@ -168,10 +175,10 @@ func (fake *FakeTracker) InitWithSourcesCallCount() int {
 	return len(fake.initWithSourcesArgsForCall)
 }
 
-func (fake *FakeTracker) InitWithSourcesArgsForCall(i int) (lager.Logger, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) {
+func (fake *FakeTracker) InitWithSourcesArgsForCall(i int) (lager.Logger, <-chan os.Signal, resource.Metadata, resource.Session, resource.ResourceType, atc.Tags, map[string]resource.ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) {
 	fake.initWithSourcesMutex.RLock()
 	defer fake.initWithSourcesMutex.RUnlock()
-	return fake.initWithSourcesArgsForCall[i].arg1, fake.initWithSourcesArgsForCall[i].arg2, fake.initWithSourcesArgsForCall[i].arg3, fake.initWithSourcesArgsForCall[i].arg4, fake.initWithSourcesArgsForCall[i].arg5, fake.initWithSourcesArgsForCall[i].arg6, fake.initWithSourcesArgsForCall[i].arg7, fake.initWithSourcesArgsForCall[i].arg8
+	return fake.initWithSourcesArgsForCall[i].arg1, fake.initWithSourcesArgsForCall[i].arg2, fake.initWithSourcesArgsForCall[i].arg3, fake.initWithSourcesArgsForCall[i].arg4, fake.initWithSourcesArgsForCall[i].arg5, fake.initWithSourcesArgsForCall[i].arg6, fake.initWithSourcesArgsForCall[i].arg7, fake.initWithSourcesArgsForCall[i].arg8, fake.initWithSourcesArgsForCall[i].arg9
 }
 
 func (fake *FakeTracker) InitWithSourcesReturns(result1 resource.Resource, result2 []string, result3 error) {
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"io"
+	"os"
 	"path/filepath"
 	"sync"
 	"time"
@@ -17,11 +18,13 @@ import (
 type Resource interface {
 	Get(IOConfig, atc.Source, atc.Params, atc.Version) VersionedSource
 	Put(IOConfig, atc.Source, atc.Params, ArtifactSource) VersionedSource
-	Check(atc.Source, atc.Version) ([]atc.Version, error)
+	Check(atc.Source, atc.Version, <-chan os.Signal) ([]atc.Version, error)
 
 	Release(*time.Duration)
 
 	CacheVolume() (baggageclaim.Volume, bool)
+
+	WorkerName() string
 }
 
 type IOConfig struct {
@@ -93,3 +96,7 @@ func (resource *resource) CacheVolume() (baggageclaim.Volume, bool) {
 
 	return nil, false
 }
+
+func (resource *resource) WorkerName() string {
+	return resource.container.WorkerName()
+}
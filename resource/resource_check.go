@@ -1,6 +1,10 @@
 package resource
 
 import (
+	"bytes"
+	"io"
+	"os"
+
 	"github.com/concourse/atc"
 	"github.com/tedsuo/ifrit"
 )
@@ -10,21 +14,46 @@ type checkRequest struct {
 	Version atc.Version `json:"version"`
 }
 
-func (resource *resource) Check(source atc.Source, fromVersion atc.Version) ([]atc.Version, error) {
+// Check runs the resource's check script. Signals received while the script
+// is running are forwarded to it, in which case ErrAborted is returned.
+//
+// The script's stderr is written to the IOConfig's Stderr whatever its exit
+// status, and is also included in the error if the script fails.
+func (resource *resource) Check(ioConfig IOConfig, source atc.Source, fromVersion atc.Version, signals <-chan os.Signal) ([]atc.Version, error) {
 	var versions []atc.Version
 
+	stderr := new(bytes.Buffer)
+
+	var logDest io.Writer = stderr
+	if ioConfig.Stderr != nil {
+		logDest = io.MultiWriter(stderr, ioConfig.Stderr)
+	}
+
 	checking := ifrit.Invoke(resource.runScript(
 		"/opt/resource/check",
 		nil,
 		checkRequest{source, fromVersion},
 		&versions,
-		nil,
+		logDest,
 		nil,
 		nil,
 		false,
 	))
 
-	err := <-checking.Wait()
+	var err error
+
+	select {
+	case err = <-checking.Wait():
+	case sig := <-signals:
+		checking.Signal(sig)
+		err = <-checking.Wait()
+	}
+
+	if scriptErr, ok := err.(ErrResourceScriptFailed); ok {
+		scriptErr.Stderr = stderr.String()
+		err = scriptErr
+	}
+
 	if err != nil {
 		return nil, err
 	}
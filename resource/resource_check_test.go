@@ -1,12 +1,15 @@
 package resource_test
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
+	"os"
 
 	"github.com/cloudfoundry-incubator/garden"
 	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
 	"github.com/concourse/atc"
+	. "github.com/concourse/atc/resource"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -24,6 +27,9 @@ var _ = Describe("Resource Check", func() {
 
 		checkScriptProcess *gfakes.FakeProcess
 
+		checkSignals chan os.Signal
+		checkStderr  *bytes.Buffer
+
 		checkResult []atc.Version
 		checkErr    error
 	)
@@ -42,6 +48,9 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptExitStatus, nil
 		}
 
+		checkSignals = make(chan os.Signal, 1)
+		checkStderr = new(bytes.Buffer)
+
 		checkResult = nil
 		checkErr = nil
 	})
@@ -61,7 +70,7 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptProcess, nil
 		}
 
-		checkResult, checkErr = resource.Check(source, version)
+		checkResult, checkErr = resource.Check(IOConfig{Stderr: checkStderr}, source, version, checkSignals)
 	})
 
 	It("runs /opt/resource/check the request on stdin", func() {
@@ -94,6 +103,29 @@ var _ = Describe("Resource Check", func() {
 		})
 	})
 
+	Context("when signaled while the check is running", func() {
+		BeforeEach(func() {
+			waiting := make(chan struct{})
+
+			checkScriptProcess.WaitStub = func() (int, error) {
+				<-waiting
+				return 0, nil
+			}
+
+			fakeContainer.StopStub = func(bool) error {
+				close(waiting)
+				return nil
+			}
+
+			checkSignals <- os.Interrupt
+		})
+
+		It("stops the container and returns ErrAborted", func() {
+			Expect(checkErr).To(Equal(ErrAborted))
+			Expect(fakeContainer.StopCallCount()).To(Equal(1))
+		})
+	})
+
 	Context("when running /opt/resource/check fails", func() {
 		disaster := errors.New("oh no!")
 
@@ -118,6 +150,21 @@ var _ = Describe("Resource Check", func() {
 			Expect(checkErr.Error()).To(ContainSubstring("exit status 9"))
 			Expect(checkErr.Error()).To(ContainSubstring("some-stderr"))
 		})
+
+		It("writes stderr of the process to the given writer", func() {
+			Expect(checkStderr.String()).To(Equal("some-stderr"))
+		})
+	})
+
+	Context("when /opt/resource/check writes to stderr and succeeds", func() {
+		BeforeEach(func() {
+			checkScriptStderr = "some-stderr"
+		})
+
+		It("writes stderr of the process to the given writer", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+			Expect(checkStderr.String()).To(Equal("some-stderr"))
+		})
 	})
 
 	Context("when the output of /opt/resource/check is malformed", func() {
@@ -21,6 +21,13 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("WorkerName", func() {
+		It("returns the name of the container's worker", func() {
+			fakeContainer.WorkerNameReturns("some-worker")
+			Expect(resource.WorkerName()).To(Equal("some-worker"))
+		})
+	})
+
 	Describe("CacheVolume", func() {
 		Context("when the container has a volume mount for /tmp/build/get", func() {
 			var vol1 *bfakes.FakeVolume
@@ -1,10 +1,13 @@
 package resource
 
 import (
+	"os"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/baggageclaim"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -26,9 +29,9 @@ type TrackerDB interface {
 //go:generate counterfeiter . Tracker
 
 type Tracker interface {
-	Init(lager.Logger, Metadata, Session, ResourceType, atc.Tags, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, error)
-	InitWithCache(lager.Logger, Metadata, Session, ResourceType, atc.Tags, CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, Cache, error)
-	InitWithSources(lager.Logger, Metadata, Session, ResourceType, atc.Tags, map[string]ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, []string, error)
+	Init(lager.Logger, <-chan os.Signal, Metadata, Session, ResourceType, atc.Tags, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, error)
+	InitWithCache(lager.Logger, <-chan os.Signal, Metadata, Session, ResourceType, atc.Tags, CacheIdentifier, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, Cache, error)
+	InitWithSources(lager.Logger, <-chan os.Signal, Metadata, Session, ResourceType, atc.Tags, map[string]ArtifactSource, atc.ResourceTypes, worker.ImageFetchingDelegate) (Resource, []string, error)
 }
 
 //go:generate counterfeiter . Cache
@@ -45,6 +48,7 @@ type Metadata interface {
 type tracker struct {
 	workerClient worker.Client
 	db           TrackerDB
+	clock        clock.Clock
 }
 
 type TrackerFactory struct {
@@ -59,6 +63,7 @@ func NewTracker(workerClient worker.Client, db TrackerDB) Tracker {
 	return &tracker{
 		workerClient: workerClient,
 		db:           db,
+		clock:        clock.NewClock(),
 	}
 }
 
@@ -69,6 +74,7 @@ type VolumeMount struct {
 
 func (tracker *tracker) InitWithSources(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	metadata Metadata,
 	session Session,
 	typ ResourceType,
@@ -104,10 +110,20 @@ func (tracker *tracker) InitWithSources(
 		Type:      string(typ),
 		Ephemeral: session.Ephemeral,
 		Tags:      tags,
+		TeamID:    session.Metadata.TeamID,
 		Env:       metadata.Env(),
 	}
 
-	compatibleWorkers, err := tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), customTypes)
+	var compatibleWorkers []worker.Worker
+	err = worker.WaitForCapacity(logger, tracker.clock, imageFetchingDelegate.Stderr(), signals, func() error {
+		var err error
+		compatibleWorkers, err = tracker.workerClient.AllSatisfying(resourceSpec.WorkerSpec(), customTypes)
+		return err
+	})
+	if err == worker.ErrInterruptedWaitingForCapacity {
+		return nil, nil, ErrAborted
+	}
+
 	if err != nil {
 		return nil, nil, err
 	}
@@ -179,6 +195,7 @@ func (tracker *tracker) InitWithSources(
 
 func (tracker *tracker) Init(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	metadata Metadata,
 	session Session,
 	typ ResourceType,
@@ -204,20 +221,29 @@ func (tracker *tracker) Init(
 
 	logger.Debug("creating-container")
 
-	container, err = tracker.workerClient.CreateContainer(
-		logger,
-		nil,
-		imageFetchingDelegate,
-		session.ID,
-		session.Metadata,
-		worker.ResourceTypeContainerSpec{
-			Type:      string(typ),
-			Ephemeral: session.Ephemeral,
-			Tags:      tags,
-			Env:       metadata.Env(),
-		},
-		customTypes,
-	)
+	err = worker.WaitForCapacity(logger, tracker.clock, imageFetchingDelegate.Stderr(), signals, func() error {
+		var err error
+		container, err = tracker.workerClient.CreateContainer(
+			logger,
+			nil,
+			imageFetchingDelegate,
+			session.ID,
+			session.Metadata,
+			worker.ResourceTypeContainerSpec{
+				Type:      string(typ),
+				Ephemeral: session.Ephemeral,
+				Tags:      tags,
+				TeamID:    session.Metadata.TeamID,
+				Env:       metadata.Env(),
+			},
+			customTypes,
+		)
+		return err
+	})
+	if err == worker.ErrInterruptedWaitingForCapacity {
+		return nil, ErrAborted
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -229,6 +255,7 @@ func (tracker *tracker) Init(
 
 func (tracker *tracker) InitWithCache(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	metadata Metadata,
 	session Session,
 	typ ResourceType,
@@ -271,9 +298,19 @@ func (tracker *tracker) InitWithCache(
 	resourceSpec := worker.WorkerSpec{
 		ResourceType: string(typ),
 		Tags:         tags,
+		TeamID:       session.Metadata.TeamID,
+	}
+
+	var chosenWorker worker.Worker
+	err = worker.WaitForCapacity(logger, tracker.clock, imageFetchingDelegate.Stderr(), signals, func() error {
+		var err error
+		chosenWorker, err = tracker.workerClient.Satisfying(resourceSpec, customTypes)
+		return err
+	})
+	if err == worker.ErrInterruptedWaitingForCapacity {
+		return nil, nil, ErrAborted
 	}
 
-	chosenWorker, err := tracker.workerClient.Satisfying(resourceSpec, customTypes)
 	if err != nil {
 		logger.Info("no-workers-satisfying-spec", lager.Data{
 			"error": err.Error(),
@@ -295,6 +332,7 @@ func (tracker *tracker) InitWithCache(
 				Type:      string(typ),
 				Ephemeral: session.Ephemeral,
 				Tags:      tags,
+				TeamID:    session.Metadata.TeamID,
 				Env:       metadata.Env(),
 			},
 			customTypes,
@@ -357,6 +395,7 @@ func (tracker *tracker) InitWithCache(
 			Type:      string(typ),
 			Ephemeral: session.Ephemeral,
 			Tags:      tags,
+			TeamID:    session.Metadata.TeamID,
 			Env:       metadata.Env(),
 			Cache: worker.VolumeMount{
 				Volume:    cachedVolume,
@@ -3,10 +3,12 @@ package resource_test
 import (
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -29,12 +31,14 @@ var _ = Describe("Tracker", func() {
 		fakeDB      *fakes.FakeTrackerDB
 		tracker     Tracker
 		customTypes atc.ResourceTypes
+		signals     chan os.Signal
 	)
 
 	var session = Session{
 		ID: worker.Identifier{},
 		Metadata: worker.Metadata{
 			WorkerName:           "some-worker",
+			TeamID:               42,
 			EnvironmentVariables: []string{"some=value"},
 		},
 		Ephemeral: true,
@@ -43,6 +47,7 @@ var _ = Describe("Tracker", func() {
 	BeforeEach(func() {
 		fakeDB = new(fakes.FakeTrackerDB)
 		tracker = NewTracker(workerClient, fakeDB)
+		signals = make(chan os.Signal, 1)
 		customTypes = atc.ResourceTypes{
 			{
 				Name:   "custom-type-a",
@@ -78,7 +83,7 @@ var _ = Describe("Tracker", func() {
 		})
 
 		JustBeforeEach(func() {
-			initResource, initErr = tracker.Init(logger, metadata, session, initType, []string{"resource", "tags"}, customTypes, delegate)
+			initResource, initErr = tracker.Init(logger, signals, metadata, session, initType, []string{"resource", "tags"}, customTypes, delegate)
 		})
 
 		Context("when a container does not exist for the session", func() {
@@ -102,6 +107,7 @@ var _ = Describe("Tracker", func() {
 				Expect(resourceSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 				Expect(resourceSpec.Ephemeral).To(Equal(true))
 				Expect(resourceSpec.Tags).To(ConsistOf("resource", "tags"))
+				Expect(resourceSpec.TeamID).To(Equal(42))
 				Expect(resourceSpec.Cache).To(BeZero())
 
 				Expect(actualCustomTypes).To(Equal(customTypes))
@@ -119,6 +125,28 @@ var _ = Describe("Tracker", func() {
 					Expect(initResource).To(BeNil())
 				})
 			})
+
+			Context("when every worker is at capacity and the check is interrupted", func() {
+				var stderr *gbytes.Buffer
+
+				BeforeEach(func() {
+					stderr = gbytes.NewBuffer()
+
+					fakeDelegate := new(wfakes.FakeImageFetchingDelegate)
+					fakeDelegate.StderrReturns(stderr)
+					delegate = fakeDelegate
+
+					workerClient.CreateContainerReturns(nil, worker.NoAvailableWorkersError{})
+					signals <- os.Interrupt
+				})
+
+				It("stops waiting and returns ErrAborted", func() {
+					Expect(stderr).To(gbytes.Say("retrying in"))
+					Expect(initErr).To(Equal(ErrAborted))
+					Expect(initResource).To(BeNil())
+					Expect(workerClient.CreateContainerCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		Context("when looking up the container fails for some reason", func() {
@@ -181,6 +209,7 @@ var _ = Describe("Tracker", func() {
 		JustBeforeEach(func() {
 			initResource, initCache, initErr = tracker.InitWithCache(
 				logger,
+				signals,
 				metadata,
 				session,
 				initType,
@@ -241,6 +270,7 @@ var _ = Describe("Tracker", func() {
 								worker.WorkerSpec{
 									ResourceType: "type1",
 									Tags:         []string{"resource", "tags"},
+									TeamID:       42,
 								},
 							))
 							Expect(actualCustomTypes).To(Equal(customTypes))
@@ -263,6 +293,7 @@ var _ = Describe("Tracker", func() {
 							Expect(resourceSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 							Expect(resourceSpec.Ephemeral).To(Equal(true))
 							Expect(resourceSpec.Tags).To(ConsistOf("resource", "tags"))
+							Expect(resourceSpec.TeamID).To(Equal(42))
 							Expect(resourceSpec.Cache).To(Equal(worker.VolumeMount{
 								Volume:    foundVolume,
 								MountPath: "/tmp/build/get",
@@ -377,6 +408,7 @@ var _ = Describe("Tracker", func() {
 								worker.WorkerSpec{
 									ResourceType: "type1",
 									Tags:         []string{"resource", "tags"},
+									TeamID:       42,
 								},
 							))
 							Expect(actualCustomTypes).To(Equal(customTypes))
@@ -399,6 +431,7 @@ var _ = Describe("Tracker", func() {
 							Expect(resourceSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 							Expect(resourceSpec.Ephemeral).To(Equal(true))
 							Expect(resourceSpec.Tags).To(ConsistOf("resource", "tags"))
+							Expect(resourceSpec.TeamID).To(Equal(42))
 							Expect(resourceSpec.Cache).To(Equal(worker.VolumeMount{
 								Volume:    createdVolume,
 								MountPath: "/tmp/build/get",
@@ -494,6 +527,7 @@ var _ = Describe("Tracker", func() {
 						Expect(resourceSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 						Expect(resourceSpec.Ephemeral).To(Equal(true))
 						Expect(resourceSpec.Tags).To(ConsistOf("resource", "tags"))
+						Expect(resourceSpec.TeamID).To(Equal(42))
 						Expect(resourceSpec.Cache).To(BeZero())
 
 						Expect(actualCustomTypes).To(Equal(customTypes))
@@ -526,6 +560,28 @@ var _ = Describe("Tracker", func() {
 					Expect(initResource).To(BeNil())
 				})
 			})
+
+			Context("when every worker is at capacity and the step is interrupted", func() {
+				var stderr *gbytes.Buffer
+
+				BeforeEach(func() {
+					stderr = gbytes.NewBuffer()
+
+					fakeDelegate := new(wfakes.FakeImageFetchingDelegate)
+					fakeDelegate.StderrReturns(stderr)
+					delegate = fakeDelegate
+
+					workerClient.SatisfyingReturns(nil, worker.NoAvailableWorkersError{})
+					signals <- os.Interrupt
+				})
+
+				It("stops waiting and returns ErrAborted", func() {
+					Expect(stderr).To(gbytes.Say("retrying in"))
+					Expect(initErr).To(Equal(ErrAborted))
+					Expect(initResource).To(BeNil())
+					Expect(workerClient.SatisfyingCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		Context("when looking up the container fails for some reason", func() {
@@ -695,6 +751,7 @@ var _ = Describe("Tracker", func() {
 		JustBeforeEach(func() {
 			initResource, missingSources, initErr = tracker.InitWithSources(
 				logger,
+				signals,
 				metadata,
 				session,
 				initType,
@@ -747,6 +804,7 @@ var _ = Describe("Tracker", func() {
 							worker.WorkerSpec{
 								ResourceType: "type1",
 								Tags:         []string{"resource", "tags"},
+								TeamID:       42,
 							},
 						))
 						Expect(actualCustomTypes).To(Equal(customTypes))
@@ -778,6 +836,7 @@ var _ = Describe("Tracker", func() {
 						Expect(resourceSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 						Expect(resourceSpec.Ephemeral).To(BeTrue())
 						Expect(resourceSpec.Tags).To(ConsistOf("resource", "tags"))
+						Expect(resourceSpec.TeamID).To(Equal(42))
 						Expect(resourceSpec.Mounts).To(ConsistOf([]worker.VolumeMount{
 							{
 								Volume:    inputVolume1,
@@ -835,6 +894,7 @@ var _ = Describe("Tracker", func() {
 						Expect(resourceSpec.Env).To(Equal([]string{"a=1", "b=2"}))
 						Expect(resourceSpec.Ephemeral).To(Equal(true))
 						Expect(resourceSpec.Tags).To(ConsistOf("resource", "tags"))
+						Expect(resourceSpec.TeamID).To(Equal(42))
 						Expect(resourceSpec.Cache).To(BeZero())
 
 						Expect(actualCustomTypes).To(Equal(customTypes))
@@ -965,6 +1025,28 @@ var _ = Describe("Tracker", func() {
 					Expect(initResource).To(BeNil())
 				})
 			})
+
+			Context("when every worker is at capacity and the step is interrupted", func() {
+				var stderr *gbytes.Buffer
+
+				BeforeEach(func() {
+					stderr = gbytes.NewBuffer()
+
+					fakeDelegate := new(wfakes.FakeImageFetchingDelegate)
+					fakeDelegate.StderrReturns(stderr)
+					delegate = fakeDelegate
+
+					workerClient.AllSatisfyingReturns(nil, worker.NoAvailableWorkersError{})
+					signals <- os.Interrupt
+				})
+
+				It("stops waiting and returns ErrAborted", func() {
+					Expect(stderr).To(gbytes.Say("retrying in"))
+					Expect(initErr).To(Equal(ErrAborted))
+					Expect(initResource).To(BeNil())
+					Expect(workerClient.AllSatisfyingCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		Context("when looking up the container fails for some reason", func() {
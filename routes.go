@@ -13,7 +13,11 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	RerunBuild          = "RerunBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	GetBuildTestResults = "GetBuildTestResults"
+	GetBuildLog         = "GetBuildLog"
+	GetBuildPlanGraph   = "GetBuildPlanGraph"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -25,23 +29,33 @@ const (
 	UnpauseJob     = "UnpauseJob"
 	GetVersionsDB  = "GetVersionsDB"
 
+	ListPendingBuilds      = "ListPendingBuilds"
+	PrioritizePendingBuild = "PrioritizePendingBuild"
+	CancelPendingBuild     = "CancelPendingBuild"
+
 	ListResources   = "ListResources"
 	GetResource     = "GetResource"
 	PauseResource   = "PauseResource"
 	UnpauseResource = "UnpauseResource"
 
+	ListResourceChecks = "ListResourceChecks"
+
+	PinResourceTypeVersion   = "PinResourceTypeVersion"
+	UnpinResourceTypeVersion = "UnpinResourceTypeVersion"
+
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 
-	ListPipelines   = "ListPipelines"
-	GetPipeline     = "GetPipeline"
-	DeletePipeline  = "DeletePipeline"
-	OrderPipelines  = "OrderPipelines"
-	PausePipeline   = "PausePipeline"
-	UnpausePipeline = "UnpausePipeline"
+	ListPipelines    = "ListPipelines"
+	GetPipeline      = "GetPipeline"
+	DeletePipeline   = "DeletePipeline"
+	OrderPipelines   = "OrderPipelines"
+	PausePipeline    = "PausePipeline"
+	UnpausePipeline  = "UnpausePipeline"
+	GetPipelineGraph = "GetPipelineGraph"
 
 	CreatePipe = "CreatePipe"
 	WritePipe  = "WritePipe"
@@ -78,7 +92,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/rerun", Method: "POST", Name: RerunBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/test-results", Method: "GET", Name: GetBuildTestResults},
+	{Path: "/api/v1/builds/:build_id/log", Method: "GET", Name: GetBuildLog},
+	{Path: "/api/v1/builds/:build_id/plan/graph", Method: "GET", Name: GetBuildPlanGraph},
 
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
@@ -96,11 +114,20 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
 	{Path: "/api/v1/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
+	{Path: "/api/v1/pipelines/:pipeline_name/graph", Method: "GET", Name: GetPipelineGraph},
+
+	{Path: "/api/v1/pipelines/:pipeline_name/pending-builds", Method: "GET", Name: ListPendingBuilds},
+	{Path: "/api/v1/pipelines/:pipeline_name/pending-builds/:build_id/prioritize", Method: "PUT", Name: PrioritizePendingBuild},
+	{Path: "/api/v1/pipelines/:pipeline_name/pending-builds/:build_id", Method: "DELETE", Name: CancelPendingBuild},
 
 	{Path: "/api/v1/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/checks", Method: "GET", Name: ListResourceChecks},
+
+	{Path: "/api/v1/pipelines/:pipeline_name/resource-types/:resource_type_name/pin", Method: "PUT", Name: PinResourceTypeVersion},
+	{Path: "/api/v1/pipelines/:pipeline_name/resource-types/:resource_type_name/unpin", Method: "PUT", Name: UnpinResourceTypeVersion},
 
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
@@ -0,0 +1,136 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleConfig restricts when builds may be started. A build may start if
+// the current time falls within one of the Allow windows (or there are none)
+// and within none of the Forbid windows.
+type ScheduleConfig struct {
+	Location string             `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+	Allow    []TimeWindowConfig `yaml:"allow,omitempty" json:"allow,omitempty" mapstructure:"allow"`
+	Forbid   []TimeWindowConfig `yaml:"forbid,omitempty" json:"forbid,omitempty" mapstructure:"forbid"`
+}
+
+// TimeWindowConfig is a daily window between Start and Stop, given as
+// "15:04". A window whose Stop is not after its Start runs past midnight. If
+// Days is given, the window only opens on those days of the week.
+type TimeWindowConfig struct {
+	Days  []string `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	Start string   `yaml:"start" json:"start" mapstructure:"start"`
+	Stop  string   `yaml:"stop" json:"stop" mapstructure:"stop"`
+}
+
+func (schedule ScheduleConfig) Permits(t time.Time) (bool, error) {
+	location, err := time.LoadLocation(schedule.Location)
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(location)
+
+	for _, window := range schedule.Forbid {
+		contains, err := window.Contains(t)
+		if err != nil {
+			return false, err
+		}
+
+		if contains {
+			return false, nil
+		}
+	}
+
+	if len(schedule.Allow) == 0 {
+		return true, nil
+	}
+
+	for _, window := range schedule.Allow {
+		contains, err := window.Contains(t)
+		if err != nil {
+			return false, err
+		}
+
+		if contains {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Contains reports whether the window is open at the given time, in the
+// time's own location.
+func (window TimeWindowConfig) Contains(t time.Time) (bool, error) {
+	start, err := ParseTimeOfDay(window.Start)
+	if err != nil {
+		return false, err
+	}
+
+	stop, err := ParseTimeOfDay(window.Stop)
+	if err != nil {
+		return false, err
+	}
+
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+
+	if start < stop {
+		if sinceMidnight < start || sinceMidnight >= stop {
+			return false, nil
+		}
+
+		return window.opensOn(t.Weekday())
+	}
+
+	if sinceMidnight >= start {
+		return window.opensOn(t.Weekday())
+	}
+
+	if sinceMidnight < stop {
+		return window.opensOn(t.AddDate(0, 0, -1).Weekday())
+	}
+
+	return false, nil
+}
+
+func (window TimeWindowConfig) opensOn(day time.Weekday) (bool, error) {
+	if len(window.Days) == 0 {
+		return true, nil
+	}
+
+	for _, name := range window.Days {
+		windowDay, err := ParseWeekday(name)
+		if err != nil {
+			return false, err
+		}
+
+		if windowDay == day {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ParseTimeOfDay parses a time such as "17:30" into the duration since
+// midnight.
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day '%s'; expected HH:MM", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func ParseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown day '%s'", name)
+}
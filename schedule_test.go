@@ -0,0 +1,110 @@
+package atc_test
+
+import (
+	"time"
+
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScheduleConfig", func() {
+	var schedule ScheduleConfig
+
+	// 2016-03-07 was a Monday
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2016, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	permits := func(t time.Time) bool {
+		permitted, err := schedule.Permits(t)
+		Expect(err).NotTo(HaveOccurred())
+		return permitted
+	}
+
+	BeforeEach(func() {
+		schedule = ScheduleConfig{}
+	})
+
+	It("permits everything when no windows are configured", func() {
+		Expect(permits(at(7, 12, 0))).To(BeTrue())
+	})
+
+	Context("with forbidden windows", func() {
+		BeforeEach(func() {
+			schedule.Forbid = []TimeWindowConfig{
+				{Days: []string{"Monday", "tue"}, Start: "09:00", Stop: "17:00"},
+			}
+		})
+
+		It("forbids times within the window", func() {
+			Expect(permits(at(7, 9, 0))).To(BeFalse())
+			Expect(permits(at(8, 16, 59))).To(BeFalse())
+		})
+
+		It("permits times outside of the window", func() {
+			Expect(permits(at(7, 8, 59))).To(BeTrue())
+			Expect(permits(at(7, 17, 0))).To(BeTrue())
+			Expect(permits(at(9, 12, 0))).To(BeTrue())
+		})
+	})
+
+	Context("with allowed windows", func() {
+		BeforeEach(func() {
+			schedule.Allow = []TimeWindowConfig{
+				{Start: "22:00", Stop: "06:00"},
+			}
+		})
+
+		It("permits times within the window, including past midnight", func() {
+			Expect(permits(at(7, 23, 0))).To(BeTrue())
+			Expect(permits(at(8, 5, 59))).To(BeTrue())
+		})
+
+		It("forbids times outside of every window", func() {
+			Expect(permits(at(7, 12, 0))).To(BeFalse())
+			Expect(permits(at(8, 6, 0))).To(BeFalse())
+		})
+
+		Context("when a forbidden window overlaps", func() {
+			BeforeEach(func() {
+				schedule.Forbid = []TimeWindowConfig{
+					{Days: []string{"Monday"}, Start: "23:00", Stop: "02:00"},
+				}
+			})
+
+			It("forbids the overlap, including the part on the next day", func() {
+				Expect(permits(at(7, 22, 30))).To(BeTrue())
+				Expect(permits(at(7, 23, 30))).To(BeFalse())
+				Expect(permits(at(8, 1, 0))).To(BeFalse())
+				Expect(permits(at(8, 23, 30))).To(BeTrue())
+			})
+		})
+	})
+
+	Context("with a location", func() {
+		BeforeEach(func() {
+			schedule.Location = "America/New_York"
+			schedule.Forbid = []TimeWindowConfig{
+				{Start: "09:00", Stop: "17:00"},
+			}
+		})
+
+		It("evaluates the windows in that location", func() {
+			Expect(permits(at(7, 12, 0))).To(BeTrue())
+			Expect(permits(at(7, 15, 0))).To(BeFalse())
+		})
+	})
+
+	Context("when the location is unknown", func() {
+		BeforeEach(func() {
+			schedule.Location = "Nowhere/Special"
+		})
+
+		It("returns an error", func() {
+			_, err := schedule.Permits(at(7, 12, 0))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
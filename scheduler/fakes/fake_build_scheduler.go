@@ -12,37 +12,35 @@ import (
 )
 
 type FakeBuildScheduler struct {
-	TryNextPendingBuildStub        func(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) scheduler.Waiter
+	TryNextPendingBuildStub        func(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) scheduler.Waiter
 	tryNextPendingBuildMutex       sync.RWMutex
 	tryNextPendingBuildArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 *algorithm.VersionsDB
 		arg3 atc.JobConfig
-		arg4 atc.ResourceConfigs
-		arg5 atc.ResourceTypes
+		arg4 atc.Config
 	}
 	tryNextPendingBuildReturns struct {
 		result1 scheduler.Waiter
 	}
-	BuildLatestInputsStub        func(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) error
+	BuildLatestInputsStub        func(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) error
 	buildLatestInputsMutex       sync.RWMutex
 	buildLatestInputsArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 *algorithm.VersionsDB
 		arg3 atc.JobConfig
-		arg4 atc.ResourceConfigs
-		arg5 atc.ResourceTypes
+		arg4 atc.Config
 	}
 	buildLatestInputsReturns struct {
 		result1 error
 	}
-	TriggerImmediatelyStub        func(lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) (db.Build, scheduler.Waiter, error)
+	TriggerImmediatelyStub        func(arg1 lager.Logger, arg2 atc.JobConfig, arg3 atc.Config, arg4 bool) (db.Build, scheduler.Waiter, error)
 	triggerImmediatelyMutex       sync.RWMutex
 	triggerImmediatelyArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 atc.JobConfig
-		arg3 atc.ResourceConfigs
-		arg4 atc.ResourceTypes
+		arg3 atc.Config
+		arg4 bool
 	}
 	triggerImmediatelyReturns struct {
 		result1 db.Build
@@ -51,18 +49,17 @@ type FakeBuildScheduler struct {
 	}
 }
 
-func (fake *FakeBuildScheduler) TryNextPendingBuild(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 atc.JobConfig, arg4 atc.ResourceConfigs, arg5 atc.ResourceTypes) scheduler.Waiter {
+func (fake *FakeBuildScheduler) TryNextPendingBuild(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 atc.JobConfig, arg4 atc.Config) scheduler.Waiter {
 	fake.tryNextPendingBuildMutex.Lock()
 	fake.tryNextPendingBuildArgsForCall = append(fake.tryNextPendingBuildArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 *algorithm.VersionsDB
 		arg3 atc.JobConfig
-		arg4 atc.ResourceConfigs
-		arg5 atc.ResourceTypes
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg4 atc.Config
+	}{arg1, arg2, arg3, arg4})
 	fake.tryNextPendingBuildMutex.Unlock()
 	if fake.TryNextPendingBuildStub != nil {
-		return fake.TryNextPendingBuildStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.TryNextPendingBuildStub(arg1, arg2, arg3, arg4)
 	} else {
 		return fake.tryNextPendingBuildReturns.result1
 	}
@@ -74,10 +71,10 @@ func (fake *FakeBuildScheduler) TryNextPendingBuildCallCount() int {
 	return len(fake.tryNextPendingBuildArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) TryNextPendingBuildArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+func (fake *FakeBuildScheduler) TryNextPendingBuildArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) {
 	fake.tryNextPendingBuildMutex.RLock()
 	defer fake.tryNextPendingBuildMutex.RUnlock()
-	return fake.tryNextPendingBuildArgsForCall[i].arg1, fake.tryNextPendingBuildArgsForCall[i].arg2, fake.tryNextPendingBuildArgsForCall[i].arg3, fake.tryNextPendingBuildArgsForCall[i].arg4, fake.tryNextPendingBuildArgsForCall[i].arg5
+	return fake.tryNextPendingBuildArgsForCall[i].arg1, fake.tryNextPendingBuildArgsForCall[i].arg2, fake.tryNextPendingBuildArgsForCall[i].arg3, fake.tryNextPendingBuildArgsForCall[i].arg4
 }
 
 func (fake *FakeBuildScheduler) TryNextPendingBuildReturns(result1 scheduler.Waiter) {
@@ -87,18 +84,17 @@ func (fake *FakeBuildScheduler) TryNextPendingBuildReturns(result1 scheduler.Wai
 	}{result1}
 }
 
-func (fake *FakeBuildScheduler) BuildLatestInputs(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 atc.JobConfig, arg4 atc.ResourceConfigs, arg5 atc.ResourceTypes) error {
+func (fake *FakeBuildScheduler) BuildLatestInputs(arg1 lager.Logger, arg2 *algorithm.VersionsDB, arg3 atc.JobConfig, arg4 atc.Config) error {
 	fake.buildLatestInputsMutex.Lock()
 	fake.buildLatestInputsArgsForCall = append(fake.buildLatestInputsArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 *algorithm.VersionsDB
 		arg3 atc.JobConfig
-		arg4 atc.ResourceConfigs
-		arg5 atc.ResourceTypes
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg4 atc.Config
+	}{arg1, arg2, arg3, arg4})
 	fake.buildLatestInputsMutex.Unlock()
 	if fake.BuildLatestInputsStub != nil {
-		return fake.BuildLatestInputsStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.BuildLatestInputsStub(arg1, arg2, arg3, arg4)
 	} else {
 		return fake.buildLatestInputsReturns.result1
 	}
@@ -110,10 +106,10 @@ func (fake *FakeBuildScheduler) BuildLatestInputsCallCount() int {
 	return len(fake.buildLatestInputsArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) BuildLatestInputsArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+func (fake *FakeBuildScheduler) BuildLatestInputsArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) {
 	fake.buildLatestInputsMutex.RLock()
 	defer fake.buildLatestInputsMutex.RUnlock()
-	return fake.buildLatestInputsArgsForCall[i].arg1, fake.buildLatestInputsArgsForCall[i].arg2, fake.buildLatestInputsArgsForCall[i].arg3, fake.buildLatestInputsArgsForCall[i].arg4, fake.buildLatestInputsArgsForCall[i].arg5
+	return fake.buildLatestInputsArgsForCall[i].arg1, fake.buildLatestInputsArgsForCall[i].arg2, fake.buildLatestInputsArgsForCall[i].arg3, fake.buildLatestInputsArgsForCall[i].arg4
 }
 
 func (fake *FakeBuildScheduler) BuildLatestInputsReturns(result1 error) {
@@ -123,13 +119,13 @@ func (fake *FakeBuildScheduler) BuildLatestInputsReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediately(arg1 lager.Logger, arg2 atc.JobConfig, arg3 atc.ResourceConfigs, arg4 atc.ResourceTypes) (db.Build, scheduler.Waiter, error) {
+func (fake *FakeBuildScheduler) TriggerImmediately(arg1 lager.Logger, arg2 atc.JobConfig, arg3 atc.Config, arg4 bool) (db.Build, scheduler.Waiter, error) {
 	fake.triggerImmediatelyMutex.Lock()
 	fake.triggerImmediatelyArgsForCall = append(fake.triggerImmediatelyArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 atc.JobConfig
-		arg3 atc.ResourceConfigs
-		arg4 atc.ResourceTypes
+		arg3 atc.Config
+		arg4 bool
 	}{arg1, arg2, arg3, arg4})
 	fake.triggerImmediatelyMutex.Unlock()
 	if fake.TriggerImmediatelyStub != nil {
@@ -145,7 +141,7 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyCallCount() int {
 	return len(fake.triggerImmediatelyArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
+func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.Config, bool) {
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	return fake.triggerImmediatelyArgsForCall[i].arg1, fake.triggerImmediatelyArgsForCall[i].arg2, fake.triggerImmediatelyArgsForCall[i].arg3, fake.triggerImmediatelyArgsForCall[i].arg4
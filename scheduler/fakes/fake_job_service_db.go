@@ -4,6 +4,7 @@ package fakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
@@ -20,27 +21,6 @@ type FakeJobServiceDB struct {
 		result1 db.SavedJob
 		result2 error
 	}
-	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
-	getRunningBuildsBySerialGroupMutex       sync.RWMutex
-	getRunningBuildsBySerialGroupArgsForCall []struct {
-		jobName      string
-		serialGroups []string
-	}
-	getRunningBuildsBySerialGroupReturns struct {
-		result1 []db.Build
-		result2 error
-	}
-	GetNextPendingBuildBySerialGroupStub        func(jobName string, serialGroups []string) (db.Build, bool, error)
-	getNextPendingBuildBySerialGroupMutex       sync.RWMutex
-	getNextPendingBuildBySerialGroupArgsForCall []struct {
-		jobName      string
-		serialGroups []string
-	}
-	getNextPendingBuildBySerialGroupReturns struct {
-		result1 db.Build
-		result2 bool
-		result3 error
-	}
 	UpdateBuildPreparationStub        func(prep db.BuildPreparation) error
 	updateBuildPreparationMutex       sync.RWMutex
 	updateBuildPreparationArgsForCall []struct {
@@ -84,6 +64,26 @@ type FakeJobServiceDB struct {
 	useInputsForBuildReturns struct {
 		result1 error
 	}
+	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	getRunningBuildsBySerialGroupMutex       sync.RWMutex
+	getRunningBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups map[string]int
+	}
+	getRunningBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetNextPendingBuildsBySerialGroupStub        func(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	getNextPendingBuildsBySerialGroupMutex       sync.RWMutex
+	getNextPendingBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups map[string]int
+	}
+	getNextPendingBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
 }
 
 func (fake *FakeJobServiceDB) GetJob(job string) (db.SavedJob, error) {
@@ -119,75 +119,6 @@ func (fake *FakeJobServiceDB) GetJobReturns(result1 db.SavedJob, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
-	fake.getRunningBuildsBySerialGroupMutex.Lock()
-	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
-		jobName      string
-		serialGroups []string
-	}{jobName, serialGroups})
-	fake.getRunningBuildsBySerialGroupMutex.Unlock()
-	if fake.GetRunningBuildsBySerialGroupStub != nil {
-		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
-	} else {
-		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
-	}
-}
-
-func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroupCallCount() int {
-	fake.getRunningBuildsBySerialGroupMutex.RLock()
-	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
-	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
-}
-
-func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, []string) {
-	fake.getRunningBuildsBySerialGroupMutex.RLock()
-	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
-	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
-}
-
-func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
-	fake.GetRunningBuildsBySerialGroupStub = nil
-	fake.getRunningBuildsBySerialGroupReturns = struct {
-		result1 []db.Build
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeJobServiceDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error) {
-	fake.getNextPendingBuildBySerialGroupMutex.Lock()
-	fake.getNextPendingBuildBySerialGroupArgsForCall = append(fake.getNextPendingBuildBySerialGroupArgsForCall, struct {
-		jobName      string
-		serialGroups []string
-	}{jobName, serialGroups})
-	fake.getNextPendingBuildBySerialGroupMutex.Unlock()
-	if fake.GetNextPendingBuildBySerialGroupStub != nil {
-		return fake.GetNextPendingBuildBySerialGroupStub(jobName, serialGroups)
-	} else {
-		return fake.getNextPendingBuildBySerialGroupReturns.result1, fake.getNextPendingBuildBySerialGroupReturns.result2, fake.getNextPendingBuildBySerialGroupReturns.result3
-	}
-}
-
-func (fake *FakeJobServiceDB) GetNextPendingBuildBySerialGroupCallCount() int {
-	fake.getNextPendingBuildBySerialGroupMutex.RLock()
-	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
-	return len(fake.getNextPendingBuildBySerialGroupArgsForCall)
-}
-
-func (fake *FakeJobServiceDB) GetNextPendingBuildBySerialGroupArgsForCall(i int) (string, []string) {
-	fake.getNextPendingBuildBySerialGroupMutex.RLock()
-	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
-	return fake.getNextPendingBuildBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildBySerialGroupArgsForCall[i].serialGroups
-}
-
-func (fake *FakeJobServiceDB) GetNextPendingBuildBySerialGroupReturns(result1 db.Build, result2 bool, result3 error) {
-	fake.GetNextPendingBuildBySerialGroupStub = nil
-	fake.getNextPendingBuildBySerialGroupReturns = struct {
-		result1 db.Build
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
-}
-
 func (fake *FakeJobServiceDB) UpdateBuildPreparation(prep db.BuildPreparation) error {
 	fake.updateBuildPreparationMutex.Lock()
 	fake.updateBuildPreparationArgsForCall = append(fake.updateBuildPreparationArgsForCall, struct {
@@ -339,4 +270,72 @@ func (fake *FakeJobServiceDB) UseInputsForBuildReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error) {
+	fake.getRunningBuildsBySerialGroupMutex.Lock()
+	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups map[string]int
+	}{jobName, serialGroups})
+	fake.getRunningBuildsBySerialGroupMutex.Unlock()
+	if fake.GetRunningBuildsBySerialGroupStub != nil {
+		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroupCallCount() int {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, map[string]int) {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakeJobServiceDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsBySerialGroupStub = nil
+	fake.getRunningBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobServiceDB) GetNextPendingBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error) {
+	fake.getNextPendingBuildsBySerialGroupMutex.Lock()
+	fake.getNextPendingBuildsBySerialGroupArgsForCall = append(fake.getNextPendingBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups map[string]int
+	}{jobName, serialGroups})
+	fake.getNextPendingBuildsBySerialGroupMutex.Unlock()
+	if fake.GetNextPendingBuildsBySerialGroupStub != nil {
+		return fake.GetNextPendingBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getNextPendingBuildsBySerialGroupReturns.result1, fake.getNextPendingBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakeJobServiceDB) GetNextPendingBuildsBySerialGroupCallCount() int {
+	fake.getNextPendingBuildsBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getNextPendingBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakeJobServiceDB) GetNextPendingBuildsBySerialGroupArgsForCall(i int) (string, map[string]int) {
+	fake.getNextPendingBuildsBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildsBySerialGroupMutex.RUnlock()
+	return fake.getNextPendingBuildsBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakeJobServiceDB) GetNextPendingBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetNextPendingBuildsBySerialGroupStub = nil
+	fake.getNextPendingBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 var _ scheduler.JobServiceDB = new(FakeJobServiceDB)
@@ -21,27 +21,6 @@ type FakePipelineDB struct {
 		result1 db.SavedJob
 		result2 error
 	}
-	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
-	getRunningBuildsBySerialGroupMutex       sync.RWMutex
-	getRunningBuildsBySerialGroupArgsForCall []struct {
-		jobName      string
-		serialGroups []string
-	}
-	getRunningBuildsBySerialGroupReturns struct {
-		result1 []db.Build
-		result2 error
-	}
-	GetNextPendingBuildBySerialGroupStub        func(jobName string, serialGroups []string) (db.Build, bool, error)
-	getNextPendingBuildBySerialGroupMutex       sync.RWMutex
-	getNextPendingBuildBySerialGroupArgsForCall []struct {
-		jobName      string
-		serialGroups []string
-	}
-	getNextPendingBuildBySerialGroupReturns struct {
-		result1 db.Build
-		result2 bool
-		result3 error
-	}
 	UpdateBuildPreparationStub        func(prep db.BuildPreparation) error
 	updateBuildPreparationMutex       sync.RWMutex
 	updateBuildPreparationArgsForCall []struct {
@@ -143,6 +122,43 @@ type FakePipelineDB struct {
 	saveResourceVersionsReturns struct {
 		result1 error
 	}
+	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	getRunningBuildsBySerialGroupMutex       sync.RWMutex
+	getRunningBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups map[string]int
+	}
+	getRunningBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetNextPendingBuildsBySerialGroupStub        func(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	getNextPendingBuildsBySerialGroupMutex       sync.RWMutex
+	getNextPendingBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups map[string]int
+	}
+	getNextPendingBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	OverrideBuildScheduleStub        func(buildID int) error
+	overrideBuildScheduleMutex       sync.RWMutex
+	overrideBuildScheduleArgsForCall []struct {
+		buildID int
+	}
+	overrideBuildScheduleReturns struct {
+		result1 error
+	}
+	ResolveResourceTypeVersionsStub        func(arg1 atc.ResourceTypes) (atc.ResourceTypes, error)
+	resolveResourceTypeVersionsMutex       sync.RWMutex
+	resolveResourceTypeVersionsArgsForCall []struct {
+		arg1 atc.ResourceTypes
+	}
+	resolveResourceTypeVersionsReturns struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}
 }
 
 func (fake *FakePipelineDB) GetJob(job string) (db.SavedJob, error) {
@@ -178,75 +194,6 @@ func (fake *FakePipelineDB) GetJobReturns(result1 db.SavedJob, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
-	fake.getRunningBuildsBySerialGroupMutex.Lock()
-	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
-		jobName      string
-		serialGroups []string
-	}{jobName, serialGroups})
-	fake.getRunningBuildsBySerialGroupMutex.Unlock()
-	if fake.GetRunningBuildsBySerialGroupStub != nil {
-		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
-	} else {
-		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
-	}
-}
-
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupCallCount() int {
-	fake.getRunningBuildsBySerialGroupMutex.RLock()
-	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
-	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
-}
-
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, []string) {
-	fake.getRunningBuildsBySerialGroupMutex.RLock()
-	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
-	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
-}
-
-func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
-	fake.GetRunningBuildsBySerialGroupStub = nil
-	fake.getRunningBuildsBySerialGroupReturns = struct {
-		result1 []db.Build
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error) {
-	fake.getNextPendingBuildBySerialGroupMutex.Lock()
-	fake.getNextPendingBuildBySerialGroupArgsForCall = append(fake.getNextPendingBuildBySerialGroupArgsForCall, struct {
-		jobName      string
-		serialGroups []string
-	}{jobName, serialGroups})
-	fake.getNextPendingBuildBySerialGroupMutex.Unlock()
-	if fake.GetNextPendingBuildBySerialGroupStub != nil {
-		return fake.GetNextPendingBuildBySerialGroupStub(jobName, serialGroups)
-	} else {
-		return fake.getNextPendingBuildBySerialGroupReturns.result1, fake.getNextPendingBuildBySerialGroupReturns.result2, fake.getNextPendingBuildBySerialGroupReturns.result3
-	}
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupCallCount() int {
-	fake.getNextPendingBuildBySerialGroupMutex.RLock()
-	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
-	return len(fake.getNextPendingBuildBySerialGroupArgsForCall)
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupArgsForCall(i int) (string, []string) {
-	fake.getNextPendingBuildBySerialGroupMutex.RLock()
-	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
-	return fake.getNextPendingBuildBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildBySerialGroupArgsForCall[i].serialGroups
-}
-
-func (fake *FakePipelineDB) GetNextPendingBuildBySerialGroupReturns(result1 db.Build, result2 bool, result3 error) {
-	fake.GetNextPendingBuildBySerialGroupStub = nil
-	fake.getNextPendingBuildBySerialGroupReturns = struct {
-		result1 db.Build
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
-}
-
 func (fake *FakePipelineDB) UpdateBuildPreparation(prep db.BuildPreparation) error {
 	fake.updateBuildPreparationMutex.Lock()
 	fake.updateBuildPreparationArgsForCall = append(fake.updateBuildPreparationArgsForCall, struct {
@@ -600,4 +547,137 @@ func (fake *FakePipelineDB) SaveResourceVersionsReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error) {
+	fake.getRunningBuildsBySerialGroupMutex.Lock()
+	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups map[string]int
+	}{jobName, serialGroups})
+	fake.getRunningBuildsBySerialGroupMutex.Unlock()
+	if fake.GetRunningBuildsBySerialGroupStub != nil {
+		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupCallCount() int {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, map[string]int) {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakePipelineDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsBySerialGroupStub = nil
+	fake.getRunningBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error) {
+	fake.getNextPendingBuildsBySerialGroupMutex.Lock()
+	fake.getNextPendingBuildsBySerialGroupArgsForCall = append(fake.getNextPendingBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups map[string]int
+	}{jobName, serialGroups})
+	fake.getNextPendingBuildsBySerialGroupMutex.Unlock()
+	if fake.GetNextPendingBuildsBySerialGroupStub != nil {
+		return fake.GetNextPendingBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getNextPendingBuildsBySerialGroupReturns.result1, fake.getNextPendingBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroupCallCount() int {
+	fake.getNextPendingBuildsBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getNextPendingBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroupArgsForCall(i int) (string, map[string]int) {
+	fake.getNextPendingBuildsBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildsBySerialGroupMutex.RUnlock()
+	return fake.getNextPendingBuildsBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakePipelineDB) GetNextPendingBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetNextPendingBuildsBySerialGroupStub = nil
+	fake.getNextPendingBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) OverrideBuildSchedule(buildID int) error {
+	fake.overrideBuildScheduleMutex.Lock()
+	fake.overrideBuildScheduleArgsForCall = append(fake.overrideBuildScheduleArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.overrideBuildScheduleMutex.Unlock()
+	if fake.OverrideBuildScheduleStub != nil {
+		return fake.OverrideBuildScheduleStub(buildID)
+	} else {
+		return fake.overrideBuildScheduleReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) OverrideBuildScheduleCallCount() int {
+	fake.overrideBuildScheduleMutex.RLock()
+	defer fake.overrideBuildScheduleMutex.RUnlock()
+	return len(fake.overrideBuildScheduleArgsForCall)
+}
+
+func (fake *FakePipelineDB) OverrideBuildScheduleArgsForCall(i int) int {
+	fake.overrideBuildScheduleMutex.RLock()
+	defer fake.overrideBuildScheduleMutex.RUnlock()
+	return fake.overrideBuildScheduleArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) OverrideBuildScheduleReturns(result1 error) {
+	fake.OverrideBuildScheduleStub = nil
+	fake.overrideBuildScheduleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersions(arg1 atc.ResourceTypes) (atc.ResourceTypes, error) {
+	fake.resolveResourceTypeVersionsMutex.Lock()
+	fake.resolveResourceTypeVersionsArgsForCall = append(fake.resolveResourceTypeVersionsArgsForCall, struct {
+		arg1 atc.ResourceTypes
+	}{arg1})
+	fake.resolveResourceTypeVersionsMutex.Unlock()
+	if fake.ResolveResourceTypeVersionsStub != nil {
+		return fake.ResolveResourceTypeVersionsStub(arg1)
+	} else {
+		return fake.resolveResourceTypeVersionsReturns.result1, fake.resolveResourceTypeVersionsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersionsCallCount() int {
+	fake.resolveResourceTypeVersionsMutex.RLock()
+	defer fake.resolveResourceTypeVersionsMutex.RUnlock()
+	return len(fake.resolveResourceTypeVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersionsArgsForCall(i int) atc.ResourceTypes {
+	fake.resolveResourceTypeVersionsMutex.RLock()
+	defer fake.resolveResourceTypeVersionsMutex.RUnlock()
+	return fake.resolveResourceTypeVersionsArgsForCall[i].arg1
+}
+
+func (fake *FakePipelineDB) ResolveResourceTypeVersionsReturns(result1 atc.ResourceTypes, result2 error) {
+	fake.ResolveResourceTypeVersionsStub = nil
+	fake.resolveResourceTypeVersionsReturns = struct {
+		result1 atc.ResourceTypes
+		result2 error
+	}{result1, result2}
+}
+
 var _ scheduler.PipelineDB = new(FakePipelineDB)
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -15,8 +16,8 @@ import (
 
 type JobServiceDB interface {
 	GetJob(job string) (db.SavedJob, error)
-	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error)
-	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error)
+	GetRunningBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error)
+	GetNextPendingBuildsBySerialGroup(jobName string, serialGroups map[string]int) ([]db.Build, error)
 	UpdateBuildPreparation(prep db.BuildPreparation) error
 	IsPaused() (bool, error)
 
@@ -32,23 +33,29 @@ type JobService interface {
 }
 
 type jobService struct {
-	JobConfig atc.JobConfig
-	DBJob     db.SavedJob
-	DB        JobServiceDB
-	Scanner   Scanner
+	JobConfig         atc.JobConfig
+	SerialGroupLimits map[string]int
+	PipelineSchedule  *atc.ScheduleConfig
+	DBJob             db.SavedJob
+	DB                JobServiceDB
+	Scanner           Scanner
+	Clock             clock.Clock
 }
 
-func NewJobService(config atc.JobConfig, jobServiceDB JobServiceDB, scanner Scanner) (JobService, error) {
+func NewJobService(config atc.JobConfig, pipelineConfig atc.Config, jobServiceDB JobServiceDB, scanner Scanner, clock clock.Clock) (JobService, error) {
 	job, err := jobServiceDB.GetJob(config.Name)
 	if err != nil {
 		return jobService{}, err
 	}
 
 	return jobService{
-		JobConfig: config,
-		DBJob:     job,
-		DB:        jobServiceDB,
-		Scanner:   scanner,
+		JobConfig:         config,
+		SerialGroupLimits: pipelineConfig.SerialGroupLimits(config),
+		PipelineSchedule:  pipelineConfig.Schedule,
+		DBJob:             job,
+		DB:                jobServiceDB,
+		Scanner:           scanner,
+		Clock:             clock,
 	}, nil
 }
 
@@ -192,6 +199,20 @@ func (s jobService) CanBuildBeScheduled(logger lager.Logger, build db.Build, bui
 	}
 
 	buildPrep.PausedJob = db.BuildPreparationStatusNotBlocking
+
+	if !build.ScheduleOverridden {
+		permitted, err := s.schedulePermits()
+		if err != nil {
+			return []db.BuildInput{}, false, "failed-to-evaluate-schedule", err
+		}
+
+		if !permitted {
+			buildPrep.Schedule = db.BuildPreparationStatusBlocking
+			return s.updateBuildPrepAndReturn(buildPrep, false, "schedule-forbids")
+		}
+	}
+
+	buildPrep.Schedule = db.BuildPreparationStatusNotBlocking
 	err = s.DB.UpdateBuildPreparation(buildPrep)
 	if err != nil {
 		return []db.BuildInput{}, false, "update-build-prep-db-failed-job-not-paused", err
@@ -212,29 +233,27 @@ func (s jobService) CanBuildBeScheduled(logger lager.Logger, build db.Build, bui
 		return []db.BuildInput{}, false, "update-build-prep-db-failed-pipeline-not-paused", err
 	}
 
-	maxInFlight := s.JobConfig.MaxInFlight()
-
-	if maxInFlight > 0 {
-		builds, err := s.DB.GetRunningBuildsBySerialGroup(s.DBJob.Name, s.JobConfig.GetSerialGroups())
+	if len(s.SerialGroupLimits) > 0 {
+		builds, err := s.DB.GetRunningBuildsBySerialGroup(s.DBJob.Name, s.SerialGroupLimits)
 		if err != nil {
 			return []db.BuildInput{}, false, "db-failed", err
 		}
 
-		if len(builds) >= maxInFlight {
+		if len(builds) > 0 {
 			buildPrep.MaxRunningBuilds = db.BuildPreparationStatusBlocking
 			return s.updateBuildPrepAndReturn(buildPrep, false, "max-in-flight-reached")
 		}
 
-		nextMostPendingBuild, found, err := s.DB.GetNextPendingBuildBySerialGroup(s.DBJob.Name, s.JobConfig.GetSerialGroups())
+		nextPendingBuilds, err := s.DB.GetNextPendingBuildsBySerialGroup(s.DBJob.Name, s.SerialGroupLimits)
 		if err != nil {
 			return []db.BuildInput{}, false, "db-failed", err
 		}
 
-		if !found {
+		if len(nextPendingBuilds) == 0 {
 			return []db.BuildInput{}, false, "no-pending-build", nil
 		}
 
-		if nextMostPendingBuild.ID != build.ID {
+		if !containsBuild(nextPendingBuilds, build.ID) {
 			return []db.BuildInput{}, false, "not-next-most-pending", nil
 		}
 	}
@@ -248,6 +267,33 @@ func (s jobService) CanBuildBeScheduled(logger lager.Logger, build db.Build, bui
 	return buildInputs, true, "can-be-scheduled", nil
 }
 
+func (s jobService) schedulePermits() (bool, error) {
+	now := s.Clock.Now()
+
+	for _, schedule := range []*atc.ScheduleConfig{s.PipelineSchedule, s.JobConfig.Schedule} {
+		if schedule == nil {
+			continue
+		}
+
+		permitted, err := schedule.Permits(now)
+		if err != nil || !permitted {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func containsBuild(builds []db.Build, buildID int) bool {
+	for _, build := range builds {
+		if build.ID == buildID {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Turns out that counterfieter clones the pointer in the build prep so when
 // the build prep gets modified, so does the copy in the fake. This clone is
 // done to get around this. God damn it counterfeiter.
@@ -257,6 +303,7 @@ func (s *jobService) cloneBuildPrep(buildPrep db.BuildPreparation) db.BuildPrepa
 		PausedPipeline:   buildPrep.PausedPipeline,
 		PausedJob:        buildPrep.PausedJob,
 		MaxRunningBuilds: buildPrep.MaxRunningBuilds,
+		Schedule:         buildPrep.Schedule,
 		Inputs:           map[string]db.BuildPreparationStatus{},
 		InputsSatisfied:  buildPrep.InputsSatisfied,
 	}
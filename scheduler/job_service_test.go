@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
@@ -9,6 +10,7 @@ import (
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/fakes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/pivotal-golang/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
@@ -18,11 +20,13 @@ import (
 var _ = Describe("JobService", func() {
 	var fakeDB *fakes.FakeJobServiceDB
 	var fakeScanner *fakes.FakeScanner
+	var fakeClock *fakeclock.FakeClock
 
 	Describe("NewJobService", func() {
 		BeforeEach(func() {
 			fakeDB = new(fakes.FakeJobServiceDB)
 			fakeScanner = new(fakes.FakeScanner)
+			fakeClock = fakeclock.NewFakeClock(time.Now())
 		})
 
 		It("sets the JobConfig and the DBJob", func() {
@@ -34,7 +38,7 @@ var _ = Describe("JobService", func() {
 
 			fakeDB.GetJobReturns(dbJob, nil)
 
-			_, err := scheduler.NewJobService(atc.JobConfig{Name: "a-job"}, fakeDB, fakeScanner)
+			_, err := scheduler.NewJobService(atc.JobConfig{Name: "a-job"}, atc.Config{}, fakeDB, fakeScanner, fakeClock)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeDB.GetJobCallCount()).To(Equal(1))
 			Expect(fakeDB.GetJobArgsForCall(0)).To(Equal("a-job"))
@@ -43,17 +47,19 @@ var _ = Describe("JobService", func() {
 		Context("when the GetJob lookup fails", func() {
 			It("returns an error", func() {
 				fakeDB.GetJobReturns(db.SavedJob{}, errors.New("disaster"))
-				_, err := scheduler.NewJobService(atc.JobConfig{}, fakeDB, fakeScanner)
+				_, err := scheduler.NewJobService(atc.JobConfig{}, atc.Config{}, fakeDB, fakeScanner, fakeClock)
 				Expect(err).To(HaveOccurred())
 			})
 		})
+
 	})
 
 	Describe("CanBuildBeScheduled", func() {
 		var (
-			service    scheduler.JobService
-			dbSavedJob db.SavedJob
-			jobConfig  atc.JobConfig
+			service        scheduler.JobService
+			dbSavedJob     db.SavedJob
+			jobConfig      atc.JobConfig
+			pipelineConfig atc.Config
 
 			logger       *lagertest.TestLogger
 			dbBuild      db.Build
@@ -70,6 +76,11 @@ var _ = Describe("JobService", func() {
 			fakeDB = new(fakes.FakeJobServiceDB)
 			fakeScanner = new(fakes.FakeScanner)
 
+			// a Monday
+			fakeClock = fakeclock.NewFakeClock(time.Date(2016, 3, 7, 12, 0, 0, 0, time.UTC))
+
+			pipelineConfig = atc.Config{}
+
 			jobConfig = atc.JobConfig{
 				Name: "some-job",
 
@@ -125,7 +136,7 @@ var _ = Describe("JobService", func() {
 
 		JustBeforeEach(func() {
 			fakeDB.GetJobReturns(dbSavedJob, nil)
-			service, err = scheduler.NewJobService(jobConfig, fakeDB, fakeScanner)
+			service, err = scheduler.NewJobService(jobConfig, pipelineConfig, fakeDB, fakeScanner, fakeClock)
 			Expect(err).NotTo(HaveOccurred())
 
 			buildInputs, canBuildBeScheduled, reason, err = service.CanBuildBeScheduled(logger, dbBuild, buildPrep, someVersions)
@@ -207,6 +218,81 @@ var _ = Describe("JobService", func() {
 						Expect(buildPrep.PausedJob).To(Equal(db.BuildPreparationStatusNotBlocking))
 					})
 
+					It("marks the build prep schedule to not blocking", func() {
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeDB.UpdateBuildPreparationCallCount()).To(BeNumerically(">=", 3))
+						buildPrep = fakeDB.UpdateBuildPreparationArgsForCall(2)
+						Expect(buildPrep.Schedule).To(Equal(db.BuildPreparationStatusNotBlocking))
+					})
+
+					Context("when the job's schedule forbids starting builds", func() {
+						BeforeEach(func() {
+							jobConfig.Schedule = &atc.ScheduleConfig{
+								Forbid: []atc.TimeWindowConfig{
+									{Days: []string{"Monday"}, Start: "09:00", Stop: "17:00"},
+								},
+							}
+						})
+
+						It("returns false", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(reason).To(Equal("schedule-forbids"))
+							Expect(canBuildBeScheduled).To(BeFalse())
+						})
+
+						It("marks the build prep schedule to blocking", func() {
+							Expect(fakeDB.UpdateBuildPreparationCallCount()).To(Equal(3))
+							buildPrep = fakeDB.UpdateBuildPreparationArgsForCall(2)
+							Expect(buildPrep.Schedule).To(Equal(db.BuildPreparationStatusBlocking))
+						})
+
+						Context("when the build overrides the schedule", func() {
+							BeforeEach(func() {
+								dbBuild.ScheduleOverridden = true
+								dbBuild.Status = db.StatusStarted
+							})
+
+							It("ignores the schedule", func() {
+								Expect(err).NotTo(HaveOccurred())
+								Expect(reason).To(Equal("build-not-pending"))
+							})
+						})
+					})
+
+					Context("when the pipeline's schedule only allows other times", func() {
+						BeforeEach(func() {
+							pipelineConfig = atc.Config{
+								Schedule: &atc.ScheduleConfig{
+									Location: "America/New_York",
+									Allow: []atc.TimeWindowConfig{
+										{Start: "09:00", Stop: "17:00"},
+									},
+								},
+							}
+						})
+
+						It("returns false", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(reason).To(Equal("schedule-forbids"))
+							Expect(canBuildBeScheduled).To(BeFalse())
+						})
+					})
+
+					Context("when the schedule cannot be evaluated", func() {
+						BeforeEach(func() {
+							jobConfig.Schedule = &atc.ScheduleConfig{
+								Location: "Nowhere/Special",
+							}
+						})
+
+						It("returns an error", func() {
+							Expect(err).To(HaveOccurred())
+							Expect(reason).To(Equal("failed-to-evaluate-schedule"))
+							Expect(canBuildBeScheduled).To(BeFalse())
+						})
+					})
+
 					Context("when the build status is NOT pending", func() {
 						BeforeEach(func() {
 							dbBuild.Status = db.StatusStarted
@@ -427,6 +513,13 @@ var _ = Describe("JobService", func() {
 									jobConfig.Serial = true
 								})
 
+								It("allows one build at a time in the job's own serial group", func() {
+									Expect(fakeDB.GetRunningBuildsBySerialGroupCallCount()).To(Equal(1))
+									jobName, serialGroups := fakeDB.GetRunningBuildsBySerialGroupArgsForCall(0)
+									Expect(jobName).To(Equal("some-job"))
+									Expect(serialGroups).To(Equal(map[string]int{"some-job": 1}))
+								})
+
 								Context("when the call to get running builds throws an error", func() {
 									BeforeEach(func() {
 										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{}, errors.New("disaster"))
@@ -470,7 +563,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when the call to get next pending build fails", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{}, false, errors.New("disaster"))
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns(nil, errors.New("disaster"))
 										})
 
 										It("returns false and the error", func() {
@@ -482,7 +575,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when it is not the next most pending build", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: 3}, true, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{{ID: 3}}, nil)
 										})
 
 										It("returns false", func() {
@@ -494,7 +587,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when there is no pending build", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{}, false, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{}, nil)
 										})
 
 										It("returns false", func() {
@@ -506,7 +599,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when it is the next most pending build", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID}, true, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{{ID: dbBuild.ID}}, nil)
 										})
 
 										It("returns true", func() {
@@ -526,84 +619,88 @@ var _ = Describe("JobService", func() {
 								})
 							})
 
-							Context("when the job has a max-in-flight of 3", func() {
+							Context("when the job is in serial groups declared with a max in flight", func() {
 								BeforeEach(func() {
-									jobConfig.RawMaxInFlight = 3
+									jobConfig.SerialGroups = []string{"environments", "undeclared"}
+
+									pipelineConfig = atc.Config{
+										SerialGroups: atc.SerialGroupConfigs{
+											{Name: "environments", MaxInFlight: 3},
+										},
+									}
 								})
 
-								Context("when the call to get running builds throws an error", func() {
+								It("uses the declared limit for each serial group", func() {
+									Expect(fakeDB.GetRunningBuildsBySerialGroupCallCount()).To(Equal(1))
+									_, serialGroups := fakeDB.GetRunningBuildsBySerialGroupArgsForCall(0)
+									Expect(serialGroups).To(Equal(map[string]int{
+										"environments": 3,
+										"undeclared":   1,
+									}))
+								})
+
+								Context("when one of the serial groups is full", func() {
 									BeforeEach(func() {
-										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{}, errors.New("disaster"))
+										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{
+											{Name: "Some-build"},
+										}, nil)
 									})
 
-									It("returns the error", func() {
-										Expect(err).To(HaveOccurred())
-										Expect(reason).To(Equal("db-failed"))
+									It("returns false", func() {
+										Expect(err).NotTo(HaveOccurred())
+										Expect(reason).To(Equal("max-in-flight-reached"))
 										Expect(canBuildBeScheduled).To(BeFalse())
 									})
 								})
 
-								Context("when 1 build is running", func() {
+								Context("when the build is within the free slots of every group", func() {
 									BeforeEach(func() {
-										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{
-											{
-												Name: "Some-build",
-											},
-										}, nil)
+										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{}, nil)
+										fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{{ID: dbBuild.ID}}, nil)
 									})
 
-									Context("when the build is the next in line", func() {
-										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID}, true, nil)
-										})
-
-										It("returns true", func() {
-											Expect(err).NotTo(HaveOccurred())
-											Expect(reason).To(Equal("can-be-scheduled"))
-											Expect(canBuildBeScheduled).To(BeTrue())
-										})
+									It("returns true", func() {
+										Expect(err).NotTo(HaveOccurred())
+										Expect(reason).To(Equal("can-be-scheduled"))
+										Expect(canBuildBeScheduled).To(BeTrue())
 									})
+								})
+							})
 
-									Context("when the build is not next in line", func() {
-										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID - 1}, true, nil)
-										})
+							Context("when the job has a max-in-flight of 3", func() {
+								BeforeEach(func() {
+									jobConfig.RawMaxInFlight = 3
+								})
 
-										It("returns false", func() {
-											Expect(err).NotTo(HaveOccurred())
-											Expect(reason).To(Equal("not-next-most-pending"))
-											Expect(canBuildBeScheduled).To(BeFalse())
-										})
+								Context("when the call to get running builds throws an error", func() {
+									BeforeEach(func() {
+										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{}, errors.New("disaster"))
 									})
 
-									Context("when there is no pending build", func() {
-										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{}, false, nil)
-										})
-
-										It("returns false", func() {
-											Expect(err).NotTo(HaveOccurred())
-											Expect(reason).To(Equal("no-pending-build"))
-											Expect(canBuildBeScheduled).To(BeFalse())
-										})
+									It("returns the error", func() {
+										Expect(err).To(HaveOccurred())
+										Expect(reason).To(Equal("db-failed"))
+										Expect(canBuildBeScheduled).To(BeFalse())
 									})
 								})
 
-								Context("when 2 builds are running", func() {
+								It("allows three builds at a time in the job's own serial group", func() {
+									Expect(fakeDB.GetRunningBuildsBySerialGroupCallCount()).To(Equal(1))
+									_, serialGroups := fakeDB.GetRunningBuildsBySerialGroupArgsForCall(0)
+									Expect(serialGroups).To(Equal(map[string]int{"some-job": 3}))
+								})
+
+								Context("when the serial group still has free slots", func() {
 									BeforeEach(func() {
-										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{
-											{
-												Name: "Some-build",
-											},
-											{
-												Name: "Some-other-build",
-											},
-										}, nil)
+										fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{}, nil)
 									})
 
-									Context("when the build is the next in line", func() {
+									Context("when the build is within the free slots", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID}, true, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{
+												{ID: dbBuild.ID - 1},
+												{ID: dbBuild.ID},
+											}, nil)
 										})
 
 										It("returns true", func() {
@@ -613,9 +710,12 @@ var _ = Describe("JobService", func() {
 										})
 									})
 
-									Context("when the build is not next in line", func() {
+									Context("when the build is not within the free slots", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID - 1}, true, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{
+												{ID: dbBuild.ID - 2},
+												{ID: dbBuild.ID - 1},
+											}, nil)
 										})
 
 										It("returns false", func() {
@@ -624,18 +724,6 @@ var _ = Describe("JobService", func() {
 											Expect(canBuildBeScheduled).To(BeFalse())
 										})
 									})
-
-									Context("when there is no pending build", func() {
-										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{}, false, nil)
-										})
-
-										It("returns false", func() {
-											Expect(err).NotTo(HaveOccurred())
-											Expect(reason).To(Equal("no-pending-build"))
-											Expect(canBuildBeScheduled).To(BeFalse())
-										})
-									})
 								})
 
 								Context("when the max-in-flight is already reached", func() {
@@ -667,7 +755,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when the call to get next pending build fails", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{}, false, errors.New("disaster"))
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns(nil, errors.New("disaster"))
 										})
 
 										It("returns false and the error", func() {
@@ -679,7 +767,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when it is not the next most pending build", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: 3}, true, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{{ID: 3}}, nil)
 										})
 
 										It("returns false", func() {
@@ -691,7 +779,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when there is no pending build", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{}, false, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{}, nil)
 										})
 
 										It("returns false", func() {
@@ -703,7 +791,7 @@ var _ = Describe("JobService", func() {
 
 									Context("when it is the next most pending build", func() {
 										BeforeEach(func() {
-											fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID}, true, nil)
+											fakeDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{{ID: dbBuild.ID}}, nil)
 										})
 
 										It("returns true", func() {
@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+type SerialGroupQueue struct {
+	SerialGroup   string
+	RunningBuilds []db.Build
+	PendingBuilds []QueuedBuild
+}
+
+type QueuedBuild struct {
+	Build            db.Build
+	Reason           atc.PendingBuildReason
+	BlockingBuildIDs []int
+}
+
+// SerialGroupQueues explains why each pending build is still waiting, using
+// the same rules as CanBuildBeScheduled. The pending builds must be given in
+// the order they will be picked up by the scheduler.
+func SerialGroupQueues(config atc.Config, running []db.Build, pending []db.Build) []SerialGroupQueue {
+	groupNames := []string{}
+	groupJobs := map[string]map[string]bool{}
+
+	for _, job := range config.Jobs {
+		for _, group := range job.GetSerialGroups() {
+			if _, found := groupJobs[group]; !found {
+				groupNames = append(groupNames, group)
+				groupJobs[group] = map[string]bool{}
+			}
+
+			groupJobs[group][job.Name] = true
+		}
+	}
+
+	runningByGroup := map[string][]db.Build{}
+	pendingByGroup := map[string][]db.Build{}
+
+	for _, group := range groupNames {
+		runningByGroup[group] = []db.Build{}
+		for _, build := range running {
+			if groupJobs[group][build.JobName] {
+				runningByGroup[group] = append(runningByGroup[group], build)
+			}
+		}
+
+		pendingByGroup[group] = []db.Build{}
+		for _, build := range pending {
+			if groupJobs[group][build.JobName] {
+				pendingByGroup[group] = append(pendingByGroup[group], build)
+			}
+		}
+	}
+
+	queued := map[int]QueuedBuild{}
+
+	for _, build := range pending {
+		job, found := config.Jobs.Lookup(build.JobName)
+		if !found || len(job.GetSerialGroups()) == 0 {
+			continue
+		}
+
+		queued[build.ID] = explainPendingBuild(
+			job,
+			config.SerialGroupLimits(job),
+			build,
+			runningByGroup,
+			pendingByGroup,
+		)
+	}
+
+	queues := []SerialGroupQueue{}
+
+	for _, group := range groupNames {
+		queue := SerialGroupQueue{
+			SerialGroup:   group,
+			RunningBuilds: runningByGroup[group],
+			PendingBuilds: []QueuedBuild{},
+		}
+
+		for _, build := range pendingByGroup[group] {
+			queue.PendingBuilds = append(queue.PendingBuilds, queued[build.ID])
+		}
+
+		queues = append(queues, queue)
+	}
+
+	return queues
+}
+
+func explainPendingBuild(
+	job atc.JobConfig,
+	limits map[string]int,
+	build db.Build,
+	runningByGroup map[string][]db.Build,
+	pendingByGroup map[string][]db.Build,
+) QueuedBuild {
+	if !build.InputsDetermined {
+		return QueuedBuild{
+			Build:  build,
+			Reason: atc.PendingBuildReasonInputsNotDetermined,
+		}
+	}
+
+	holding := []int{}
+	for _, group := range job.GetSerialGroups() {
+		if len(runningByGroup[group]) < limits[group] {
+			continue
+		}
+
+		for _, runningBuild := range runningByGroup[group] {
+			holding = appendBuildID(holding, runningBuild.ID)
+		}
+	}
+
+	if len(holding) > 0 {
+		reason := atc.PendingBuildReasonMaxInFlightReached
+		if len(job.SerialGroups) > 0 {
+			reason = atc.PendingBuildReasonSerialGroupHeld
+		}
+
+		return QueuedBuild{
+			Build:            build,
+			Reason:           reason,
+			BlockingBuildIDs: holding,
+		}
+	}
+
+	ahead := []int{}
+	for _, group := range job.GetSerialGroups() {
+		freeSlots := limits[group] - len(runningByGroup[group])
+
+		window := []int{}
+		inWindow := false
+
+		for _, pendingBuild := range pendingByGroup[group] {
+			if !pendingBuild.InputsDetermined {
+				continue
+			}
+
+			if len(window) == freeSlots {
+				break
+			}
+
+			if pendingBuild.ID == build.ID {
+				inWindow = true
+				break
+			}
+
+			window = append(window, pendingBuild.ID)
+		}
+
+		if !inWindow {
+			for _, id := range window {
+				ahead = appendBuildID(ahead, id)
+			}
+		}
+	}
+
+	if len(ahead) > 0 {
+		return QueuedBuild{
+			Build:            build,
+			Reason:           atc.PendingBuildReasonQueued,
+			BlockingBuildIDs: ahead,
+		}
+	}
+
+	return QueuedBuild{
+		Build:  build,
+		Reason: atc.PendingBuildReasonNextInLine,
+	}
+}
+
+func appendBuildID(ids []int, id int) []int {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+
+	return append(ids, id)
+}
@@ -0,0 +1,192 @@
+package scheduler_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SerialGroupQueues", func() {
+	var (
+		config  atc.Config
+		running []db.Build
+		pending []db.Build
+
+		queues []scheduler.SerialGroupQueue
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name:         "job-a",
+					SerialGroups: []string{"deploy"},
+				},
+				{
+					Name:         "job-b",
+					SerialGroups: []string{"deploy", "smoke"},
+				},
+				{
+					Name:           "job-c",
+					RawMaxInFlight: 2,
+				},
+				{
+					Name: "unlimited-job",
+				},
+			},
+		}
+
+		running = []db.Build{}
+		pending = []db.Build{}
+	})
+
+	JustBeforeEach(func() {
+		queues = scheduler.SerialGroupQueues(config, running, pending)
+	})
+
+	It("returns a queue for every serial group, in config order", func() {
+		groups := []string{}
+		for _, queue := range queues {
+			groups = append(groups, queue.SerialGroup)
+		}
+
+		Expect(groups).To(Equal([]string{"deploy", "smoke", "job-c"}))
+	})
+
+	Context("when a serial group is held by a running build", func() {
+		BeforeEach(func() {
+			running = []db.Build{
+				{ID: 1, JobName: "job-a", Status: db.StatusStarted},
+			}
+
+			pending = []db.Build{
+				{ID: 2, JobName: "job-b", Status: db.StatusPending, InputsDetermined: true},
+				{ID: 3, JobName: "job-a", Status: db.StatusPending},
+			}
+		})
+
+		It("explains why each build is waiting", func() {
+			Expect(queues[0].SerialGroup).To(Equal("deploy"))
+			Expect(queues[0].RunningBuilds).To(Equal(running))
+			Expect(queues[0].PendingBuilds).To(Equal([]scheduler.QueuedBuild{
+				{
+					Build:            pending[0],
+					Reason:           atc.PendingBuildReasonSerialGroupHeld,
+					BlockingBuildIDs: []int{1},
+				},
+				{
+					Build:  pending[1],
+					Reason: atc.PendingBuildReasonInputsNotDetermined,
+				},
+			}))
+		})
+
+		It("lists builds in every group their job belongs to", func() {
+			Expect(queues[1].SerialGroup).To(Equal("smoke"))
+			Expect(queues[1].RunningBuilds).To(BeEmpty())
+			Expect(queues[1].PendingBuilds).To(HaveLen(1))
+			Expect(queues[1].PendingBuilds[0].Build.ID).To(Equal(2))
+		})
+	})
+
+	Context("when the serial group is free", func() {
+		BeforeEach(func() {
+			pending = []db.Build{
+				{ID: 4, JobName: "job-b", Status: db.StatusPending},
+				{ID: 5, JobName: "job-a", Status: db.StatusPending, InputsDetermined: true},
+				{ID: 6, JobName: "job-b", Status: db.StatusPending, InputsDetermined: true},
+			}
+		})
+
+		It("marks the first build with determined inputs as next in line", func() {
+			Expect(queues[0].PendingBuilds).To(Equal([]scheduler.QueuedBuild{
+				{
+					Build:  pending[0],
+					Reason: atc.PendingBuildReasonInputsNotDetermined,
+				},
+				{
+					Build:  pending[1],
+					Reason: atc.PendingBuildReasonNextInLine,
+				},
+				{
+					Build:            pending[2],
+					Reason:           atc.PendingBuildReasonQueued,
+					BlockingBuildIDs: []int{5},
+				},
+			}))
+		})
+	})
+
+	Context("when a job's max in flight is reached", func() {
+		BeforeEach(func() {
+			running = []db.Build{
+				{ID: 7, JobName: "job-c", Status: db.StatusStarted},
+				{ID: 8, JobName: "job-c", Status: db.StatusPending, Scheduled: true},
+			}
+
+			pending = []db.Build{
+				{ID: 9, JobName: "job-c", Status: db.StatusPending, InputsDetermined: true},
+				{ID: 10, JobName: "unlimited-job", Status: db.StatusPending, InputsDetermined: true},
+			}
+		})
+
+		It("reports the running builds as blocking", func() {
+			Expect(queues[2].SerialGroup).To(Equal("job-c"))
+			Expect(queues[2].PendingBuilds).To(Equal([]scheduler.QueuedBuild{
+				{
+					Build:            pending[0],
+					Reason:           atc.PendingBuildReasonMaxInFlightReached,
+					BlockingBuildIDs: []int{7, 8},
+				},
+			}))
+		})
+	})
+
+	Context("when a serial group is declared with a max in flight", func() {
+		BeforeEach(func() {
+			config.SerialGroups = atc.SerialGroupConfigs{
+				{Name: "deploy", MaxInFlight: 2},
+			}
+
+			running = []db.Build{
+				{ID: 11, JobName: "job-a", Status: db.StatusStarted},
+			}
+
+			pending = []db.Build{
+				{ID: 12, JobName: "job-a", Status: db.StatusPending, InputsDetermined: true},
+				{ID: 13, JobName: "job-a", Status: db.StatusPending, InputsDetermined: true},
+			}
+		})
+
+		It("lets builds take the remaining slots", func() {
+			Expect(queues[0].PendingBuilds).To(Equal([]scheduler.QueuedBuild{
+				{
+					Build:  pending[0],
+					Reason: atc.PendingBuildReasonNextInLine,
+				},
+				{
+					Build:            pending[1],
+					Reason:           atc.PendingBuildReasonQueued,
+					BlockingBuildIDs: []int{12},
+				},
+			}))
+		})
+
+		Context("when every slot is taken", func() {
+			BeforeEach(func() {
+				running = append(running, db.Build{ID: 14, JobName: "job-b", Status: db.StatusStarted})
+			})
+
+			It("reports every running build in the group as blocking", func() {
+				Expect(queues[0].PendingBuilds[0]).To(Equal(scheduler.QueuedBuild{
+					Build:            pending[0],
+					Reason:           atc.PendingBuildReasonSerialGroupHeld,
+					BlockingBuildIDs: []int{11, 14},
+				}))
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"time"
 
@@ -15,9 +16,9 @@ import (
 //go:generate counterfeiter . BuildScheduler
 
 type BuildScheduler interface {
-	TryNextPendingBuild(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) Waiter
-	BuildLatestInputs(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) error
-	TriggerImmediately(lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) (db.Build, Waiter, error)
+	TryNextPendingBuild(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) Waiter
+	BuildLatestInputs(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) error
+	TriggerImmediately(lager.Logger, atc.JobConfig, atc.Config, bool) (db.Build, Waiter, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
@@ -118,7 +119,9 @@ func (runner *Runner) tick(logger lager.Logger) error {
 
 		jStart := time.Now()
 
-		runner.schedule(sLog, versions, job, config.Resources, config.ResourceTypes)
+		runner.pauseAfterFailures(sLog, job)
+
+		runner.schedule(sLog, versions, job, config)
 
 		metric.SchedulingJobDuration{
 			PipelineName: runner.DB.GetPipelineName(),
@@ -130,10 +133,48 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	return nil
 }
 
-func (runner *Runner) schedule(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, resources atc.ResourceConfigs, resourceTypes atc.ResourceTypes) {
-	runner.Scheduler.TryNextPendingBuild(logger, versions, job, resources, resourceTypes).Wait()
+func (runner *Runner) pauseAfterFailures(logger lager.Logger, job atc.JobConfig) {
+	if job.PauseAfterFailures <= 0 {
+		return
+	}
+
+	savedJob, err := runner.DB.GetJob(job.Name)
+	if err != nil {
+		logger.Error("failed-to-get-job", err)
+		return
+	}
+
+	if savedJob.Paused {
+		return
+	}
+
+	failures, err := runner.DB.GetConsecutiveFailures(job.Name)
+	if err != nil {
+		logger.Error("failed-to-get-consecutive-failures", err)
+		return
+	}
+
+	if failures < job.PauseAfterFailures {
+		return
+	}
+
+	err = runner.DB.PauseJobWithReason(job.Name, fmt.Sprintf("paused after %d consecutive failed builds", failures))
+	if err != nil {
+		logger.Error("failed-to-pause-job", err)
+		return
+	}
+
+	metric.JobPausedAfterFailures{
+		PipelineName:        runner.DB.GetPipelineName(),
+		JobName:             job.Name,
+		ConsecutiveFailures: failures,
+	}.Emit(logger)
+}
+
+func (runner *Runner) schedule(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, config atc.Config) {
+	runner.Scheduler.TryNextPendingBuild(logger, versions, job, config).Wait()
 
-	err := runner.Scheduler.BuildLatestInputs(logger, versions, job, resources, resourceTypes)
+	err := runner.Scheduler.BuildLatestInputs(logger, versions, job, config)
 	if err != nil {
 		logger.Error("failed-to-build-from-latest-inputs", err)
 	}
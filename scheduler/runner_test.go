@@ -64,7 +64,7 @@ var _ = Describe("Runner", func() {
 
 		pipelineDB.LoadVersionsDBReturns(someVersions, nil)
 
-		scheduler.TryNextPendingBuildStub = func(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) Waiter {
+		scheduler.TryNextPendingBuildStub = func(lager.Logger, *algorithm.VersionsDB, atc.JobConfig, atc.Config) Waiter {
 			return new(sync.WaitGroup)
 		}
 
@@ -148,33 +148,83 @@ var _ = Describe("Runner", func() {
 	It("schedules pending builds", func() {
 		Eventually(scheduler.TryNextPendingBuildCallCount).Should(Equal(2))
 
-		_, versions, job, resources, resourceTypes := scheduler.TryNextPendingBuildArgsForCall(0)
+		_, versions, job, config := scheduler.TryNextPendingBuildArgsForCall(0)
 		Expect(versions).To(Equal(someVersions))
 		Expect(job).To(Equal(atc.JobConfig{Name: "some-job"}))
-		Expect(resources).To(Equal(initialConfig.Resources))
-		Expect(resourceTypes).To(Equal(initialConfig.ResourceTypes))
+		Expect(config).To(Equal(initialConfig))
 
-		_, versions, job, resources, resourceTypes = scheduler.TryNextPendingBuildArgsForCall(1)
+		_, versions, job, config = scheduler.TryNextPendingBuildArgsForCall(1)
 		Expect(versions).To(Equal(someVersions))
 		Expect(job).To(Equal(atc.JobConfig{Name: "some-other-job"}))
-		Expect(resources).To(Equal(initialConfig.Resources))
-		Expect(resourceTypes).To(Equal(initialConfig.ResourceTypes))
+		Expect(config).To(Equal(initialConfig))
 	})
 
 	It("schedules builds for new inputs using the given versions dataset", func() {
 		Eventually(scheduler.BuildLatestInputsCallCount).Should(Equal(2))
 
-		_, versions, job, resources, resourceTypes := scheduler.BuildLatestInputsArgsForCall(0)
+		_, versions, job, config := scheduler.BuildLatestInputsArgsForCall(0)
 		Expect(versions).To(Equal(someVersions))
 		Expect(job).To(Equal(atc.JobConfig{Name: "some-job"}))
-		Expect(resources).To(Equal(initialConfig.Resources))
-		Expect(resourceTypes).To(Equal(initialConfig.ResourceTypes))
+		Expect(config).To(Equal(initialConfig))
 
-		_, versions, job, resources, resourceTypes = scheduler.BuildLatestInputsArgsForCall(1)
+		_, versions, job, config = scheduler.BuildLatestInputsArgsForCall(1)
 		Expect(versions).To(Equal(someVersions))
 		Expect(job).To(Equal(atc.JobConfig{Name: "some-other-job"}))
-		Expect(resources).To(Equal(initialConfig.Resources))
-		Expect(resourceTypes).To(Equal(initialConfig.ResourceTypes))
+		Expect(config).To(Equal(initialConfig))
+	})
+
+	Context("when a job pauses after failures", func() {
+		BeforeEach(func() {
+			initialConfig.Jobs[0].PauseAfterFailures = 3
+			pipelineDB.GetConfigReturns(initialConfig, 1, true, nil)
+		})
+
+		Context("when the job has failed too many times in a row", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConsecutiveFailuresReturns(3, nil)
+			})
+
+			It("pauses the job and records why", func() {
+				Eventually(pipelineDB.PauseJobWithReasonCallCount).Should(BeNumerically(">=", 1))
+				jobName, reason := pipelineDB.PauseJobWithReasonArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(reason).To(Equal("paused after 3 consecutive failed builds"))
+			})
+
+			It("only counts failures for jobs that pause after failures", func() {
+				Eventually(pipelineDB.GetConsecutiveFailuresCallCount).Should(BeNumerically(">=", 1))
+				Expect(pipelineDB.GetConsecutiveFailuresArgsForCall(0)).To(Equal("some-job"))
+				Consistently(func() []string {
+					jobs := []string{}
+					for i := 0; i < pipelineDB.GetConsecutiveFailuresCallCount(); i++ {
+						jobs = append(jobs, pipelineDB.GetConsecutiveFailuresArgsForCall(i))
+					}
+					return jobs
+				}).ShouldNot(ContainElement("some-other-job"))
+			})
+
+			Context("when the job is already paused", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{Paused: true}, nil)
+				})
+
+				It("does not pause it again", func() {
+					Eventually(scheduler.TryNextPendingBuildCallCount).Should(BeNumerically(">=", 2))
+					Expect(pipelineDB.PauseJobWithReasonCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the job has not failed enough times in a row", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConsecutiveFailuresReturns(2, nil)
+			})
+
+			It("does not pause the job", func() {
+				Eventually(scheduler.TryNextPendingBuildCallCount).Should(BeNumerically(">=", 2))
+				Expect(pipelineDB.PauseJobWithReasonCallCount()).To(BeZero())
+			})
+		})
 	})
 
 	Context("when in noop mode", func() {
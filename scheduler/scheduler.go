@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 
 	"github.com/concourse/atc"
@@ -24,7 +25,10 @@ type PipelineDB interface {
 	GetJobBuildForInputs(job string, inputs []db.BuildInput) (db.Build, bool, error)
 	GetNextPendingBuild(job string) (db.Build, bool, error)
 
+	OverrideBuildSchedule(buildID int) error
+
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
+	ResolveResourceTypeVersions(atc.ResourceTypes) (atc.ResourceTypes, error)
 }
 
 //go:generate counterfeiter . BuildsDB
@@ -59,9 +63,10 @@ type Scheduler struct {
 	Factory    BuildFactory
 	Engine     engine.Engine
 	Scanner    Scanner
+	Clock      clock.Clock
 }
 
-func (s *Scheduler) BuildLatestInputs(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, resources atc.ResourceConfigs, resourceTypes atc.ResourceTypes) error {
+func (s *Scheduler) BuildLatestInputs(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, pipelineConfig atc.Config) error {
 	logger = logger.Session("build-latest")
 
 	inputs := config.JobInputs(job)
@@ -129,7 +134,7 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, versions *algorithm.V
 
 	logger.Debug("created-build", lager.Data{"build": build.ID})
 
-	jobService, err := NewJobService(job, s.PipelineDB, s.Scanner)
+	jobService, err := NewJobService(job, pipelineConfig, s.PipelineDB, s.Scanner, s.Clock)
 	if err != nil {
 		logger.Error("failed-to-get-job-service", err)
 		return nil
@@ -138,12 +143,12 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, versions *algorithm.V
 	// NOTE: this is intentionally serial within a scheduler tick, so that
 	// multiple ATCs don't do redundant work to determine a build's inputs.
 
-	s.ScheduleAndResumePendingBuild(logger, versions, build, job, resources, resourceTypes, jobService)
+	s.ScheduleAndResumePendingBuild(logger, versions, build, job, pipelineConfig.Resources, pipelineConfig.ResourceTypes, jobService)
 
 	return nil
 }
 
-func (s *Scheduler) TryNextPendingBuild(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, resources atc.ResourceConfigs, resourceTypes atc.ResourceTypes) Waiter {
+func (s *Scheduler) TryNextPendingBuild(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig, pipelineConfig atc.Config) Waiter {
 	logger = logger.Session("try-next-pending")
 
 	wg := new(sync.WaitGroup)
@@ -162,19 +167,19 @@ func (s *Scheduler) TryNextPendingBuild(logger lager.Logger, versions *algorithm
 			return
 		}
 
-		jobService, err := NewJobService(job, s.PipelineDB, s.Scanner)
+		jobService, err := NewJobService(job, pipelineConfig, s.PipelineDB, s.Scanner, s.Clock)
 		if err != nil {
 			logger.Error("failed-to-get-job-service", err)
 			return
 		}
 
-		s.ScheduleAndResumePendingBuild(logger, versions, build, job, resources, resourceTypes, jobService)
+		s.ScheduleAndResumePendingBuild(logger, versions, build, job, pipelineConfig.Resources, pipelineConfig.ResourceTypes, jobService)
 	}()
 
 	return wg
 }
 
-func (s *Scheduler) TriggerImmediately(logger lager.Logger, job atc.JobConfig, resources atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, Waiter, error) {
+func (s *Scheduler) TriggerImmediately(logger lager.Logger, job atc.JobConfig, pipelineConfig atc.Config, overrideSchedule bool) (db.Build, Waiter, error) {
 	logger = logger.Session("trigger-immediately")
 
 	build, err := s.PipelineDB.CreateJobBuild(job.Name)
@@ -183,7 +188,17 @@ func (s *Scheduler) TriggerImmediately(logger lager.Logger, job atc.JobConfig, r
 		return db.Build{}, nil, err
 	}
 
-	jobService, err := NewJobService(job, s.PipelineDB, s.Scanner)
+	if overrideSchedule {
+		err := s.PipelineDB.OverrideBuildSchedule(build.ID)
+		if err != nil {
+			logger.Error("failed-to-override-build-schedule", err)
+			return db.Build{}, nil, err
+		}
+
+		build.ScheduleOverridden = true
+	}
+
+	jobService, err := NewJobService(job, pipelineConfig, s.PipelineDB, s.Scanner, s.Clock)
 	if err != nil {
 		return db.Build{}, nil, err
 	}
@@ -194,7 +209,7 @@ func (s *Scheduler) TriggerImmediately(logger lager.Logger, job atc.JobConfig, r
 	// do not block request on scanning input versions
 	go func() {
 		defer wg.Done()
-		s.ScheduleAndResumePendingBuild(logger, nil, build, job, resources, resourceTypes, jobService)
+		s.ScheduleAndResumePendingBuild(logger, nil, build, job, pipelineConfig.Resources, pipelineConfig.ResourceTypes, jobService)
 	}()
 
 	return build, wg, nil
@@ -276,7 +291,18 @@ func (s *Scheduler) ScheduleAndResumePendingBuild(
 		return nil
 	}
 
-	plan, err := s.Factory.Create(job, resources, resourceTypes, inputs)
+	resolvedResourceTypes, err := s.PipelineDB.ResolveResourceTypeVersions(resourceTypes)
+	if err != nil {
+		logger.Error("failed-to-resolve-resource-type-versions", err)
+
+		err := s.BuildsDB.FinishBuild(build.ID, db.StatusErrored)
+		if err != nil {
+			logger.Error("failed-to-mark-build-as-errored", err)
+		}
+		return nil
+	}
+
+	plan, err := s.Factory.Create(job, resources, resolvedResourceTypes, inputs)
 	if err != nil {
 		// Don't use ErrorBuild because it logs a build event, and this build hasn't started
 		err := s.BuildsDB.FinishBuild(build.ID, db.StatusErrored)
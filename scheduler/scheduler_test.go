@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
@@ -12,6 +13,7 @@ import (
 	enginefakes "github.com/concourse/atc/engine/fakes"
 	. "github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/fakes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/pivotal-golang/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
@@ -31,9 +33,10 @@ var _ = Describe("Scheduler", func() {
 
 		createdPlan atc.Plan
 
-		job           atc.JobConfig
-		resources     atc.ResourceConfigs
-		resourceTypes atc.ResourceTypes
+		job            atc.JobConfig
+		resources      atc.ResourceConfigs
+		resourceTypes  atc.ResourceTypes
+		pipelineConfig atc.Config
 
 		scheduler *Scheduler
 
@@ -86,6 +89,7 @@ var _ = Describe("Scheduler", func() {
 			Factory:    factory,
 			Engine:     fakeEngine,
 			Scanner:    fakeScanner,
+			Clock:      fakeclock.NewFakeClock(time.Date(2016, 3, 7, 12, 0, 0, 0, time.UTC)),
 		}
 
 		logger = lagertest.NewTestLogger("test")
@@ -152,6 +156,12 @@ var _ = Describe("Scheduler", func() {
 			},
 		}
 
+		pipelineConfig = atc.Config{
+			Jobs:          atc.JobConfigs{job},
+			Resources:     resources,
+			ResourceTypes: resourceTypes,
+		}
+
 		lease = new(dbfakes.FakeLease)
 		fakeBuildsDB.LeaseBuildSchedulingReturns(lease, true, nil)
 	})
@@ -163,12 +173,12 @@ var _ = Describe("Scheduler", func() {
 			})
 
 			It("returns no error", func() {
-				err := scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+				err := scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("does not trigger a build", func() {
-				scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+				scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
 			})
 		})
@@ -179,7 +189,7 @@ var _ = Describe("Scheduler", func() {
 
 			BeforeEach(func() {
 				fakePipelineDB.GetLatestInputVersionsReturns(nil, false, disaster)
-				err = scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+				err = scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 			})
 
 			It("returns the error", func() {
@@ -194,7 +204,7 @@ var _ = Describe("Scheduler", func() {
 		Context("when the job has no inputs", func() {
 			BeforeEach(func() {
 				job.Plan = atc.PlanSequence{}
-				err := scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+				err := scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -226,7 +236,7 @@ var _ = Describe("Scheduler", func() {
 			})
 
 			JustBeforeEach(func() {
-				err = scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+				err = scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 			})
 
 			Context("loading versions db", func() {
@@ -241,7 +251,7 @@ var _ = Describe("Scheduler", func() {
 					fakeBuildsDB.GetBuildPreparationReturns(buildPrep, true, nil)
 					fakePipelineDB.CreateJobBuildForCandidateInputsReturns(pendingBuild, true, nil)
 					fakePipelineDB.GetNextPendingBuildReturns(pendingBuild, true, nil)
-					fakePipelineDB.GetNextPendingBuildBySerialGroupReturns(pendingBuild, true, nil)
+					fakePipelineDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{pendingBuild}, nil)
 					fakePipelineDB.UpdateBuildToScheduledReturns(true, nil)
 				})
 
@@ -362,7 +372,7 @@ var _ = Describe("Scheduler", func() {
 					})
 
 					It("does not start a build", func() {
-						scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+						scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 						Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
 					})
 				})
@@ -377,7 +387,7 @@ var _ = Describe("Scheduler", func() {
 					})
 
 					It("does not start a build", func() {
-						scheduler.BuildLatestInputs(logger, someVersions, job, resources, resourceTypes)
+						scheduler.BuildLatestInputs(logger, someVersions, job, pipelineConfig)
 						Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
 					})
 				})
@@ -415,7 +425,7 @@ var _ = Describe("Scheduler", func() {
 
 	Describe("TryNextPendingBuild", func() {
 		JustBeforeEach(func() {
-			scheduler.TryNextPendingBuild(logger, someVersions, job, resources, resourceTypes).Wait()
+			scheduler.TryNextPendingBuild(logger, someVersions, job, pipelineConfig).Wait()
 		})
 
 		Context("when a pending build is found", func() {
@@ -435,7 +445,7 @@ var _ = Describe("Scheduler", func() {
 
 				fakeBuildsDB.GetBuildPreparationReturns(buildPrep, true, nil)
 				fakePipelineDB.CreateJobBuildReturns(pendingBuild, nil)
-				fakePipelineDB.GetNextPendingBuildBySerialGroupReturns(pendingBuild, true, nil)
+				fakePipelineDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{pendingBuild}, nil)
 				fakePipelineDB.UpdateBuildToScheduledReturns(true, nil)
 			})
 
@@ -454,7 +464,7 @@ var _ = Describe("Scheduler", func() {
 			})
 
 			It("does not start a build", func() {
-				scheduler.TryNextPendingBuild(logger, someVersions, job, resources, resourceTypes)
+				scheduler.TryNextPendingBuild(logger, someVersions, job, pipelineConfig)
 				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
 			})
 		})
@@ -467,7 +477,7 @@ var _ = Describe("Scheduler", func() {
 			})
 
 			It("does not start a build", func() {
-				scheduler.TryNextPendingBuild(logger, someVersions, job, resources, resourceTypes)
+				scheduler.TryNextPendingBuild(logger, someVersions, job, pipelineConfig)
 				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
 			})
 		})
@@ -484,12 +494,12 @@ var _ = Describe("Scheduler", func() {
 
 			fakeBuildsDB.GetBuildPreparationReturns(buildPrep, true, nil)
 			fakePipelineDB.CreateJobBuildReturns(dbBuild, nil)
-			fakePipelineDB.GetNextPendingBuildBySerialGroupReturns(dbBuild, true, nil)
+			fakePipelineDB.GetNextPendingBuildsBySerialGroupReturns([]db.Build{dbBuild}, nil)
 			fakePipelineDB.UpdateBuildToScheduledReturns(true, nil)
 		})
 
 		It("creates a build without any specific inputs", func() {
-			_, wg, err := scheduler.TriggerImmediately(logger, job, resources, resourceTypes)
+			_, wg, err := scheduler.TriggerImmediately(logger, job, pipelineConfig, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			wg.Wait()
@@ -500,6 +510,56 @@ var _ = Describe("Scheduler", func() {
 			Expect(jobName).To(Equal("some-job"))
 
 			Expect(fakePipelineDB.LoadVersionsDBCallCount()).To(Equal(1))
+			Expect(fakePipelineDB.OverrideBuildScheduleCallCount()).To(Equal(0))
+		})
+
+		Context("when the job's schedule forbids starting builds", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{
+					Forbid: []atc.TimeWindowConfig{
+						{Start: "09:00", Stop: "17:00"},
+					},
+				}
+			})
+
+			It("leaves the build pending", func() {
+				_, wg, err := scheduler.TriggerImmediately(logger, job, pipelineConfig, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				wg.Wait()
+
+				Expect(fakePipelineDB.LoadVersionsDBCallCount()).To(Equal(0))
+				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
+
+				updateCount := fakePipelineDB.UpdateBuildPreparationCallCount()
+				Expect(updateCount).To(BeNumerically(">", 0))
+				Expect(fakePipelineDB.UpdateBuildPreparationArgsForCall(updateCount - 1).Schedule).To(Equal(db.BuildPreparationStatusBlocking))
+			})
+
+			Context("when the schedule is overridden", func() {
+				It("marks the build as overriding the schedule and carries on", func() {
+					_, wg, err := scheduler.TriggerImmediately(logger, job, pipelineConfig, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					wg.Wait()
+
+					Expect(fakePipelineDB.OverrideBuildScheduleCallCount()).To(Equal(1))
+					Expect(fakePipelineDB.LoadVersionsDBCallCount()).To(Equal(1))
+				})
+
+				Context("when overriding the schedule fails", func() {
+					disaster := errors.New("oh no!")
+
+					BeforeEach(func() {
+						fakePipelineDB.OverrideBuildScheduleReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						_, _, err := scheduler.TriggerImmediately(logger, job, pipelineConfig, true)
+						Expect(err).To(Equal(disaster))
+					})
+				})
+			})
 		})
 
 		Context("when creating the build fails", func() {
@@ -510,12 +570,12 @@ var _ = Describe("Scheduler", func() {
 			})
 
 			It("returns the error", func() {
-				_, _, err := scheduler.TriggerImmediately(logger, job, resources, resourceTypes)
+				_, _, err := scheduler.TriggerImmediately(logger, job, pipelineConfig, false)
 				Expect(err).To(Equal(disaster))
 			})
 
 			It("does not start a build", func() {
-				scheduler.TriggerImmediately(logger, job, resources, resourceTypes)
+				scheduler.TriggerImmediately(logger, job, pipelineConfig, false)
 				Expect(fakeEngine.CreateBuildCallCount()).To(Equal(0))
 			})
 		})
@@ -589,20 +649,52 @@ var _ = Describe("Scheduler", func() {
 					})
 
 					Context("when the build is successfully marked as scheduled", func() {
+						var resolvedResourceTypes atc.ResourceTypes
+
 						BeforeEach(func() {
 							fakePipelineDB.UpdateBuildToScheduledReturns(true, nil)
+
+							resolvedResourceTypes = atc.ResourceTypes{
+								{
+									Name:    "some-custom-resource",
+									Type:    "custom-type",
+									Source:  atc.Source{"custom": "source"},
+									Version: atc.Version{"some": "version"},
+								},
+							}
+
+							fakePipelineDB.ResolveResourceTypeVersionsReturns(resolvedResourceTypes, nil)
 						})
 
-						It("creates a plan", func() {
+						It("creates a plan with the resolved versions of the resource types", func() {
+							Expect(fakePipelineDB.ResolveResourceTypeVersionsCallCount()).To(Equal(1))
+							Expect(fakePipelineDB.ResolveResourceTypeVersionsArgsForCall(0)).To(Equal(resourceTypes))
+
 							Expect(factory.CreateCallCount()).To(Equal(1))
 
 							passedJob, passedResources, passedResourceTypes, passedInputs := factory.CreateArgsForCall(0)
 							Expect(passedJob).To(Equal(job))
 							Expect(passedResources).To(Equal(resources))
-							Expect(passedResourceTypes).To(Equal(resourceTypes))
+							Expect(passedResourceTypes).To(Equal(resolvedResourceTypes))
 							Expect(passedInputs).To(ConsistOf(passedInputs))
 						})
 
+						Context("when resolving the resource type versions fails", func() {
+							BeforeEach(func() {
+								fakePipelineDB.ResolveResourceTypeVersionsReturns(nil, errors.New("nope"))
+							})
+
+							It("marks the build as errored without creating a plan", func() {
+								Expect(factory.CreateCallCount()).To(BeZero())
+
+								Expect(fakeBuildsDB.FinishBuildCallCount()).To(Equal(1))
+
+								buildID, status := fakeBuildsDB.FinishBuildArgsForCall(0)
+								Expect(buildID).To(Equal(build.ID))
+								Expect(status).To(Equal(db.StatusErrored))
+							})
+						})
+
 						Context("when making a plan for the build fails due to an error", func() {
 							BeforeEach(func() {
 								factory.CreateReturns(atc.Plan{}, errors.New("to err is human"))
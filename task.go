@@ -36,6 +36,9 @@ type TaskConfig struct {
 type TaskImageConfig struct {
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+
+	// Version, if set, is fetched instead of checking for the latest version.
+	Version Version `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
 func LoadTaskConfig(configBytes []byte) (TaskConfig, error) {
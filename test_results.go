@@ -0,0 +1,24 @@
+package atc
+
+// TestResults summarizes the test reports produced by a build's tasks.
+type TestResults struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+
+	FailedTests []string `json:"failed_tests"`
+}
+
+// Add returns the combined results of two sets of reports.
+func (results TestResults) Add(other TestResults) TestResults {
+	failedTests := make([]string, 0, len(results.FailedTests)+len(other.FailedTests))
+	failedTests = append(failedTests, results.FailedTests...)
+	failedTests = append(failedTests, other.FailedTests...)
+
+	return TestResults{
+		Passed:      results.Passed + other.Passed,
+		Failed:      results.Failed + other.Failed,
+		Skipped:     results.Skipped + other.Skipped,
+		FailedTests: failedTests,
+	}
+}
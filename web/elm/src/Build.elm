@@ -19,6 +19,7 @@ import Concourse.Build exposing (Build, BuildDuration)
 import Concourse.BuildPrep exposing (BuildPrep, BuildPrepStatus)
 import Concourse.BuildStatus exposing (BuildStatus)
 import Concourse.Pagination exposing (Paginated)
+import Concourse.TestResults exposing (TestResults)
 import LoadingIndicator
 import BuildDuration
 import Scroll
@@ -29,6 +30,7 @@ type alias Model =
   , buildId : Int
   , build : Maybe Build
   , buildPrep: Maybe BuildPrep
+  , testResults : Maybe TestResults
   , history : List Build
   , status : BuildStatus
   , autoScroll : Bool
@@ -47,6 +49,7 @@ type Action
   = Noop
   | BuildFetched (Result Http.Error Build)
   | BuildPrepFetched (Result Http.Error BuildPrep)
+  | TestResultsFetched (Result Http.Error TestResults)
   | BuildHistoryFetched (Result Http.Error (Paginated Build))
   | BuildOutputAction BuildOutput.Action
   | BuildStatus BuildStatus Date
@@ -66,6 +69,7 @@ init redirect actions buildId =
       , output = Nothing
       , build = Nothing
       , buildPrep = Nothing
+      , testResults = Nothing
       , history = []
       , autoScroll = True
       , status = Concourse.BuildStatus.Pending
@@ -108,6 +112,17 @@ update action model =
       Debug.log ("failed to fetch build preparation: " ++ toString err) <|
         (model, Effects.none)
 
+    TestResultsFetched (Ok testResults) ->
+      ({ model | testResults = Just testResults }, Effects.none)
+
+    TestResultsFetched (Err (Http.BadResponse 404 _)) ->
+      -- none of the build's tasks had any reports
+      (model, Effects.none)
+
+    TestResultsFetched (Err err) ->
+      Debug.log ("failed to fetch test results: " ++ toString err) <|
+        (model, Effects.none)
+
     BuildOutputAction action ->
       case model.output of
         Just output ->
@@ -125,7 +140,10 @@ update action model =
             { model | status = status }
           else
             model
-      , Effects.none
+      , if Concourse.BuildStatus.isRunning status then
+          Effects.none
+        else
+          fetchTestResults model.buildId
       )
 
     BuildHistoryFetched (Err err) ->
@@ -258,6 +276,7 @@ view actions model =
         [ viewBuildHeader actions build model
         , Html.div (id "build-body" :: paddingClass build)
           [ viewBuildPrep model.buildPrep
+          , viewTestResults model.testResults
           , Html.Lazy.lazy (viewBuildOutput actions) model.output
           ]
         ]
@@ -308,6 +327,38 @@ viewBuildPrep prep =
     Nothing ->
       Html.div [] []
 
+viewTestResults : Maybe TestResults -> Html
+viewTestResults testResults =
+  case testResults of
+    Just results ->
+      Html.div [class "build-step"]
+        [ Html.div [class "header"]
+            [ Html.i [class "left fa fa-fw fa-list-alt"] []
+            , Html.h3 []
+                [ Html.text <|
+                    toString results.passed ++ " passed, " ++
+                    toString results.failed ++ " failed, " ++
+                    toString results.skipped ++ " skipped"
+                ]
+            ]
+        , Html.div []
+            [ Html.ul [class "test-results-list"]
+                (List.map viewFailedTest results.failedTests)
+            ]
+        ]
+
+    Nothing ->
+      Html.div [] []
+
+viewFailedTest : String -> Html
+viewFailedTest name =
+  Html.li [class "failed-test"]
+    [ Html.span [class "marker"]
+        [ Html.i [class "fa fa-fw fa-times", title "failed"] [] ]
+    , Html.span []
+        [ Html.text name ]
+    ]
+
 viewBuildPrepInputs : Dict String BuildPrepStatus -> List Html
 viewBuildPrepInputs inputs =
   List.map viewBuildPrepInput (Dict.toList inputs)
@@ -437,6 +488,13 @@ fetchBuildPrep delay buildId =
     |> Task.map BuildPrepFetched
     |> Effects.task
 
+fetchTestResults : Int -> Effects Action
+fetchTestResults buildId =
+  Concourse.TestResults.fetch buildId
+    |> Task.toResult
+    |> Task.map TestResultsFetched
+    |> Effects.task
+
 fetchBuildHistory : Concourse.Build.BuildJob -> Maybe Concourse.Pagination.Page -> Effects Action
 fetchBuildHistory job page =
   Concourse.Build.fetchJobBuilds job page
@@ -140,8 +140,13 @@ handleEventsAction action model =
 handleEvent : Concourse.BuildEvents.BuildEvent -> Model -> (Model, Effects Action)
 handleEvent event model =
   case event of
-    Concourse.BuildEvents.Log origin output ->
-      ( updateStep origin.id (setRunning << appendStepLog output) model
+    Concourse.BuildEvents.Log origin output time ->
+      ( updateStep origin.id (setRunning << appendStepLog output time) model
+      , Effects.none
+      )
+
+    Concourse.BuildEvents.LogTruncated origin scope limit ->
+      ( updateStep origin.id (setLogTruncated scope limit) model
       , Effects.none
       )
 
@@ -170,6 +175,11 @@ handleEvent event model =
       , Effects.none
       )
 
+    Concourse.BuildEvents.StartGet origin ->
+      ( updateStep origin.id setRunning model
+      , Effects.none
+      )
+
     Concourse.BuildEvents.FinishGet origin exitStatus version metadata ->
       ( updateStep origin.id (finishStep exitStatus << setResourceInfo version metadata) model
       , Effects.none
@@ -180,11 +190,23 @@ handleEvent event model =
       , Effects.none
       )
 
+    Concourse.BuildEvents.StartPut origin ->
+      ( updateStep origin.id setRunning model
+      , Effects.none
+      )
+
     Concourse.BuildEvents.FinishPut origin exitStatus version metadata ->
       ( updateStep origin.id (finishStep exitStatus << setResourceInfo version metadata) model
       , Effects.none
       )
 
+    Concourse.BuildEvents.StartStep _ ->
+      -- the step tree is rendered from the plan, so there is nothing to update
+      (model, Effects.none)
+
+    Concourse.BuildEvents.FinishStep _ ->
+      (model, Effects.none)
+
     Concourse.BuildEvents.BuildStatus status date ->
       let
         finishSteps =
@@ -216,9 +238,17 @@ updateStep id update model =
 setRunning : StepTree -> StepTree
 setRunning = setStepState StepTree.StepStateRunning
 
-appendStepLog : String -> StepTree -> StepTree
-appendStepLog output tree =
-  StepTree.map (\step -> { step | log = Ansi.Log.update output step.log }) tree
+appendStepLog : String -> Maybe Date -> StepTree -> StepTree
+appendStepLog output time tree =
+  StepTree.map (StepTree.appendLog output time) tree
+
+setLogTruncated : String -> Int -> StepTree -> StepTree
+setLogTruncated scope limit tree =
+  let
+    message =
+      "log truncated: " ++ scope ++ " output exceeded " ++ toString limit ++ " bytes; the rest was discarded"
+  in
+    StepTree.map (\step -> { step | logTruncated = Just message }) tree
 
 setStepError : String -> StepTree -> StepTree
 setStepError message tree =
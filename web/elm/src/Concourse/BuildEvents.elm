@@ -16,10 +16,15 @@ type BuildEvent
   | StartTask Origin
   | FinishTask Origin Int
   | InitializeGet Origin
+  | StartGet Origin
   | FinishGet Origin Int Version Metadata
   | InitializePut Origin
+  | StartPut Origin
   | FinishPut Origin Int Version Metadata
-  | Log Origin String
+  | StartStep Origin
+  | FinishStep Origin
+  | Log Origin String (Maybe Date)
+  | LogTruncated Origin String Int
   | Error Origin String
   | BuildError String
 
@@ -86,7 +91,10 @@ decodeEvent e =
       Json.Decode.decodeValue (Json.Decode.object2 BuildStatus ("status" := Concourse.BuildStatus.decode) ("time" := Json.Decode.map dateFromSeconds Json.Decode.float)) e.value
 
     "log" ->
-      Json.Decode.decodeValue (Json.Decode.object2 Log ("origin" := decodeOrigin) ("payload" := Json.Decode.string)) e.value
+      Json.Decode.decodeValue (Json.Decode.object3 Log ("origin" := decodeOrigin) ("payload" := Json.Decode.string) (Json.Decode.maybe ("time" := Json.Decode.map dateFromSeconds Json.Decode.float))) e.value
+
+    "log-truncated" ->
+      Json.Decode.decodeValue (Json.Decode.object3 LogTruncated ("origin" := decodeOrigin) ("scope" := Json.Decode.string) ("limit" := Json.Decode.int)) e.value
 
     "error" ->
       Json.Decode.decodeValue decodeErrorEvent e.value
@@ -103,15 +111,27 @@ decodeEvent e =
     "initialize-get" ->
       Json.Decode.decodeValue (Json.Decode.object1 InitializeGet ("origin" := decodeOrigin)) e.value
 
+    "start-get" ->
+      Json.Decode.decodeValue (Json.Decode.object1 StartGet ("origin" := decodeOrigin)) e.value
+
     "finish-get" ->
       Json.Decode.decodeValue (decodeFinishResource FinishGet) e.value
 
     "initialize-put" ->
       Json.Decode.decodeValue (Json.Decode.object1 InitializePut ("origin" := decodeOrigin)) e.value
 
+    "start-put" ->
+      Json.Decode.decodeValue (Json.Decode.object1 StartPut ("origin" := decodeOrigin)) e.value
+
     "finish-put" ->
       Json.Decode.decodeValue (decodeFinishResource FinishPut) e.value
 
+    "start-step" ->
+      Json.Decode.decodeValue (Json.Decode.object1 StartStep ("origin" := decodeOrigin)) e.value
+
+    "finish-step" ->
+      Json.Decode.decodeValue (Json.Decode.object1 FinishStep ("origin" := decodeOrigin)) e.value
+
     unknown ->
       Err ("unknown event type: " ++ unknown)
 
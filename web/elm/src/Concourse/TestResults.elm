@@ -0,0 +1,26 @@
+module Concourse.TestResults where
+
+import Http
+import Json.Decode exposing ((:=))
+import Task exposing (Task)
+
+import Concourse.Build exposing (BuildId)
+
+type alias TestResults =
+  { passed : Int
+  , failed : Int
+  , skipped : Int
+  , failedTests : List String
+  }
+
+fetch : BuildId -> Task Http.Error TestResults
+fetch buildId =
+  Http.get decode ("/api/v1/builds/" ++ toString buildId ++ "/test-results")
+
+decode : Json.Decode.Decoder TestResults
+decode =
+  Json.Decode.object4 TestResults
+    ("passed" := Json.Decode.int)
+    ("failed" := Json.Decode.int)
+    ("skipped" := Json.Decode.int)
+    ("failed_tests" := Json.Decode.list Json.Decode.string)
@@ -6,9 +6,11 @@ module StepTree
   , StepID
   , StepName
   , StepState(..)
+  , TimestampMode(..)
   , Action(..)
   , init
   , map
+  , appendLog
   , view
   , update
   , updateAt
@@ -17,15 +19,19 @@ module StepTree
 import Debug
 import Ansi.Log
 import Array exposing (Array)
+import Date exposing (Date)
+import Date.Format
 import Dict exposing (Dict)
 import Focus exposing (Focus, (=>))
 import Html exposing (Html)
 import Html.Events exposing (onClick, onMouseDown)
 import Html.Attributes exposing (class, classList)
+import String
 
 import Concourse.BuildPlan exposing (BuildPlan)
 import Concourse.BuildResources exposing (BuildResources)
 import DictView
+import Duration
 
 type StepTree
   = Task Step
@@ -49,6 +55,7 @@ type Action
   = ToggleStep StepID
   | Finished
   | SwitchTab StepID Int
+  | ToggleTimestamps
 
 type alias HookedStep =
   { step : StepTree
@@ -60,6 +67,8 @@ type alias Step =
   , name : StepName
   , state : StepState
   , log : Ansi.Log.Model
+  , logTimestamps : Dict Int Date
+  , logTruncated : Maybe String
   , error : Maybe String
   , expanded : Maybe Bool
   , version : Maybe Version
@@ -78,6 +87,10 @@ type StepState
   | StepStateFailed
   | StepStateErrored
 
+type TimestampMode
+  = AbsoluteTimestamps
+  | RelativeTimestamps
+
 type alias StepFocus =
   Focus StepTree StepTree
 
@@ -85,6 +98,7 @@ type alias Model =
   { tree : StepTree
   , foci : Dict StepID StepFocus
   , finished : Bool
+  , timestamps : TimestampMode
   }
 
 type alias Version =
@@ -118,7 +132,7 @@ init resources plan =
         wrappedSubFoci = Array.indexedMap wrapMultiStep subFoci
         foci = Array.foldr Dict.union Dict.empty wrappedSubFoci
       in
-        Model (Aggregate trees) foci False
+        Model (Aggregate trees) foci False AbsoluteTimestamps
 
     Concourse.BuildPlan.Do plans ->
       let
@@ -128,7 +142,7 @@ init resources plan =
         wrappedSubFoci = Array.indexedMap wrapMultiStep subFoci
         foci = Array.foldr Dict.union Dict.empty wrappedSubFoci
       in
-        Model (Do trees) foci False
+        Model (Do trees) foci False AbsoluteTimestamps
 
     Concourse.BuildPlan.OnSuccess hookedPlan ->
       initHookedStep resources OnSuccess hookedPlan
@@ -151,7 +165,7 @@ init resources plan =
         selfFoci = Dict.singleton plan.id (Focus.create identity identity)
         foci = Array.foldr Dict.union selfFoci wrappedSubFoci
       in
-        Model (Retry plan.id trees 1 Auto) foci False
+        Model (Retry plan.id trees 1 Auto) foci False AbsoluteTimestamps
 
     Concourse.BuildPlan.Timeout plan ->
       initWrappedStep resources Timeout plan
@@ -230,6 +244,18 @@ update action root =
     SwitchTab id tab ->
       updateAt id (focusRetry tab) root
 
+    ToggleTimestamps ->
+      { root | timestamps = toggleTimestamps root.timestamps }
+
+toggleTimestamps : TimestampMode -> TimestampMode
+toggleTimestamps mode =
+  case mode of
+    AbsoluteTimestamps ->
+      RelativeTimestamps
+
+    RelativeTimestamps ->
+      AbsoluteTimestamps
+
 toggleExpanded : Step -> Maybe Bool
 toggleExpanded {expanded, state} =
   Just <| not <| Maybe.withDefault (autoExpanded state) expanded
@@ -252,6 +278,39 @@ updateAt id update root =
     Just focus ->
       { root | tree = Focus.update focus update root.tree }
 
+appendLog : String -> Maybe Date -> Step -> Step
+appendLog output time step =
+  let
+    log = Ansi.Log.update output step.log
+  in
+    { step
+    | log = log
+    , logTimestamps =
+        case time of
+          Nothing ->
+            step.logTimestamps
+
+          Just date ->
+            stampLines date (Dict.size step.logTimestamps) (stampableLines output log) step.logTimestamps
+    }
+
+-- a linebreak leaves an empty line for the cursor, which is begun by whatever
+-- output comes next
+stampableLines : String -> Ansi.Log.Model -> Int
+stampableLines output log =
+  if String.endsWith "\n" output then
+    Array.length log.lines - 1
+  else
+    Array.length log.lines
+
+-- each line is stamped with the time of the output that began it
+stampLines : Date -> Int -> Int -> Dict Int Date -> Dict Int Date
+stampLines date from to stamps =
+  if from >= to then
+    stamps
+  else
+    stampLines date (from + 1) to (Dict.insert from date stamps)
+
 map : (Step -> Step) -> StepTree -> StepTree
 map f tree =
   case tree of
@@ -279,6 +338,8 @@ initBottom create id name =
       , name = name
       , state = StepStatePending
       , log = Ansi.Log.init Ansi.Log.Cooked
+      , logTimestamps = Dict.empty
+      , logTruncated = Nothing
       , error = Nothing
       , expanded = Nothing
       , version = Nothing
@@ -289,6 +350,7 @@ initBottom create id name =
     { tree = create step
     , foci = Dict.singleton id (Focus.create identity identity)
     , finished = False
+    , timestamps = AbsoluteTimestamps
     }
 
 initWrappedStep : BuildResources -> (StepTree -> StepTree) -> BuildPlan -> Model
@@ -299,6 +361,7 @@ initWrappedStep resources create plan =
     { tree = create tree
     , foci = Dict.map wrapStep foci
     , finished = False
+    , timestamps = AbsoluteTimestamps
     }
 
 initHookedStep : BuildResources -> (HookedStep -> StepTree) -> Concourse.BuildPlan.HookedPlan -> Model
@@ -312,6 +375,7 @@ initHookedStep resources create hookedPlan =
         (Dict.map wrapStep stepModel.foci)
         (Dict.map wrapHook hookModel.foci)
     , finished = stepModel.finished
+    , timestamps = stepModel.timestamps
     }
 
 wrapMultiStep : Int -> Dict StepID StepFocus -> Dict StepID StepFocus
@@ -444,7 +508,24 @@ setMultiStepIndex idx update tree =
       Debug.crash "impossible"
 
 view : Signal.Address Action -> Model -> Html
-view actions model = viewTree actions model model.tree
+view actions model =
+  Html.div [class "step-tree"]
+    [ viewTimestampToggle actions model.timestamps
+    , viewTree actions model model.tree
+    ]
+
+viewTimestampToggle : Signal.Address Action -> TimestampMode -> Html
+viewTimestampToggle actions mode =
+  Html.div [class "timestamp-toggle", onClick actions ToggleTimestamps]
+    [ Html.i [class "fa fa-fw fa-clock-o"] []
+    , Html.text <|
+        case mode of
+          AbsoluteTimestamps ->
+            "absolute times"
+
+          RelativeTimestamps ->
+            "relative times"
+    ]
 
 viewTree : Signal.Address Action -> Model -> StepTree -> Html
 viewTree actions model tree =
@@ -524,7 +605,7 @@ autoExpanded : StepState -> Bool
 autoExpanded state = isActive state && state /= StepStateSucceeded
 
 viewStep : Signal.Address Action -> Model -> Step -> String -> Html
-viewStep actions model {id, name, log, state, error, expanded, version, metadata, firstOccurrence} icon =
+viewStep actions model {id, name, log, logTimestamps, logTruncated, state, error, expanded, version, metadata, firstOccurrence} icon =
   Html.div
     [ classList
       [ ("build-step", True)
@@ -547,7 +628,12 @@ viewStep actions model {id, name, log, state, error, expanded, version, metadata
         ] <|
         if Maybe.withDefault (autoExpanded state) (Maybe.map (always True) expanded) then
           [ viewMetadata metadata
-          , Ansi.Log.view log
+          , viewLog model.timestamps logTimestamps log
+          , case logTruncated of
+              Nothing ->
+                Html.span [] []
+              Just msg ->
+                Html.span [class "log-truncated"] [ Html.pre [] [Html.text msg] ]
           , case error of
               Nothing ->
                 Html.span [] []
@@ -558,6 +644,36 @@ viewStep actions model {id, name, log, state, error, expanded, version, metadata
           []
     ]
 
+viewLog : TimestampMode -> Dict Int Date -> Ansi.Log.Model -> Html
+viewLog mode timestamps log =
+  case Dict.get 0 timestamps of
+    Nothing ->
+      Ansi.Log.view log
+
+    Just start ->
+      Html.div [class "timestamped-log"]
+        [ Html.pre [class "timestamps"] <|
+            List.map
+              (viewTimestamp mode start << flip Dict.get timestamps)
+              [0 .. Array.length log.lines - 1]
+        , Ansi.Log.view log
+        ]
+
+viewTimestamp : TimestampMode -> Date -> Maybe Date -> Html
+viewTimestamp mode start stamp =
+  Html.div []
+    [ Html.text <|
+        case (mode, stamp) of
+          (_, Nothing) ->
+            " "
+
+          (AbsoluteTimestamps, Just date) ->
+            Date.Format.format "%H:%M:%S" date
+
+          (RelativeTimestamps, Just date) ->
+            "+" ++ Duration.format (Duration.between (Date.toTime start) (Date.toTime date))
+    ]
+
 viewVersion : Maybe Version -> Html
 viewVersion version =
   DictView.view << Dict.map (\_ s -> Html.text s) <|
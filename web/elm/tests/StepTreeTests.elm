@@ -1,6 +1,7 @@
 module StepTreeTests where
 
 import Array
+import Date
 import Dict
 import ElmTest exposing (..)
 import Focus
@@ -26,6 +27,7 @@ all =
     , initEnsure
     , initTry
     , initTimeout
+    , appendLog
     ]
 
 someStep : StepTree.StepID -> StepTree.StepName -> StepTree.StepState -> StepTree.Step
@@ -37,6 +39,8 @@ someVersionedStep version id name state =
   , name = name
   , state = state
   , log = cookedLog
+  , logTimestamps = Dict.empty
+  , logTruncated = Nothing
   , error = Nothing
   , expanded = Nothing
   , version = version
@@ -348,6 +352,37 @@ initTimeout =
       ]
 
 
+appendLog : Test
+appendLog =
+  let
+    first = Date.fromTime 1000
+    second = Date.fromTime 2000
+    step =
+      someStep "some-id" "some-name" StepTree.StepStateRunning
+        |> StepTree.appendLog "some " (Just first)
+        |> StepTree.appendLog "output\nmore" (Just second)
+  in
+    suite "appendLog"
+      [ test "the log" <|
+          assertEqual
+            (Ansi.Log.update "output\nmore" (Ansi.Log.update "some " cookedLog))
+            step.log
+      , test "stamping each line with the time of the output that began it" <|
+          assertEqual
+            (Dict.fromList [(0, first), (1, second)])
+            step.logTimestamps
+      , test "stamping the line after a trailing linebreak with the output that begins it" <|
+          assertEqual
+            (Dict.fromList [(0, first), (1, second)])
+            (someStep "some-id" "some-name" StepTree.StepStateRunning
+              |> StepTree.appendLog "some output\n" (Just first)
+              |> StepTree.appendLog "more output" (Just second)).logTimestamps
+      , test "without a time" <|
+          assertEqual
+            Dict.empty
+            (StepTree.appendLog "untimed" Nothing (someStep "some-id" "some-name" StepTree.StepStateRunning)).logTimestamps
+      ]
+
 updateStep : (StepTree.Step -> StepTree.Step) -> StepTree.StepTree -> StepTree.StepTree
 updateStep f tree =
   case tree of
@@ -8,11 +8,22 @@ type Worker struct {
 
 	ActiveContainers int `json:"active_containers"`
 
+	// MaxContainers is how many containers the worker can run at once, or 0
+	// if it has no limit.
+	MaxContainers int `json:"max_containers,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform string   `json:"platform"`
 	Tags     []string `json:"tags"`
 	Name     string   `json:"name"`
+
+	// Team restricts the worker to containers of the named team's pipelines.
+	Team string `json:"team,omitempty"`
+
+	// Unhealthy is set while the worker is out of rotation after repeatedly
+	// failing to create containers.
+	Unhealthy bool `json:"unhealthy,omitempty"`
 }
 
 type WorkerResourceType struct {
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const initialCapacityRetryDelay = 5 * time.Second
+const maxCapacityRetryDelay = time.Minute
+
+var ErrInterruptedWaitingForCapacity = errors.New("interrupted while waiting for worker capacity")
+
+// WaitForCapacity calls attempt until it stops failing with a
+// NoAvailableWorkersError, backing off exponentially in between. Each wait is
+// noted on stderr, so that the build log explains why the step has not
+// started yet. Capacity is only as current as the workers' last heartbeats;
+// see isSaturated.
+//
+// Waiting stops with ErrInterruptedWaitingForCapacity once signals fires;
+// a nil channel waits until there is capacity.
+func WaitForCapacity(
+	logger lager.Logger,
+	clock clock.Clock,
+	stderr io.Writer,
+	signals <-chan os.Signal,
+	attempt func() error,
+) error {
+	delay := initialCapacityRetryDelay
+
+	for {
+		err := attempt()
+		if _, ok := err.(NoAvailableWorkersError); !ok {
+			return err
+		}
+
+		timer := clock.NewTimer(delay)
+
+		logger.Info("waiting-for-worker-capacity", lager.Data{"delay": delay.String()})
+		fmt.Fprintf(stderr, "%s; retrying in %s\n", err, delay)
+
+		select {
+		case <-timer.C():
+		case <-signals:
+			timer.Stop()
+			return ErrInterruptedWaitingForCapacity
+		}
+
+		delay *= 2
+		if delay > maxCapacityRetryDelay {
+			delay = maxCapacityRetryDelay
+		}
+	}
+}
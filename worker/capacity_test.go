@@ -0,0 +1,114 @@
+package worker_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	. "github.com/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+)
+
+var _ = Describe("WaitForCapacity", func() {
+	var (
+		fakeClock *fakeclock.FakeClock
+		stderr    *gbytes.Buffer
+		signals   chan os.Signal
+
+		attemptErrs []error
+		attempts    chan struct{}
+
+		waitErr chan error
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		stderr = gbytes.NewBuffer()
+		signals = make(chan os.Signal, 1)
+
+		attemptErrs = nil
+		attempts = make(chan struct{}, 10)
+	})
+
+	JustBeforeEach(func() {
+		waitErr = make(chan error, 1)
+
+		go func() {
+			waitErr <- WaitForCapacity(
+				lagertest.NewTestLogger("test"),
+				fakeClock,
+				stderr,
+				signals,
+				func() error {
+					attempts <- struct{}{}
+
+					if len(attemptErrs) == 0 {
+						return nil
+					}
+
+					err := attemptErrs[0]
+					attemptErrs = attemptErrs[1:]
+					return err
+				},
+			)
+		}()
+	})
+
+	Context("when the attempt succeeds", func() {
+		It("returns immediately", func() {
+			Eventually(waitErr).Should(Receive(BeNil()))
+			Expect(attempts).To(HaveLen(1))
+		})
+	})
+
+	Context("when the attempt fails for another reason", func() {
+		BeforeEach(func() {
+			attemptErrs = []error{errors.New("nope")}
+		})
+
+		It("returns the error without retrying", func() {
+			Eventually(waitErr).Should(Receive(Equal(errors.New("nope"))))
+			Expect(attempts).To(HaveLen(1))
+		})
+	})
+
+	Context("when every worker is at capacity", func() {
+		BeforeEach(func() {
+			attemptErrs = []error{
+				NoAvailableWorkersError{Spec: WorkerSpec{Platform: "linux"}},
+				NoAvailableWorkersError{Spec: WorkerSpec{Platform: "linux"}},
+			}
+		})
+
+		It("writes a message and retries with backoff until there is room", func() {
+			Eventually(attempts).Should(Receive())
+			Eventually(stderr).Should(gbytes.Say("all workers satisfying platform 'linux' are at capacity; retrying in 5s"))
+
+			fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+			Eventually(attempts).Should(Receive())
+			Eventually(stderr).Should(gbytes.Say("retrying in 10s"))
+
+			fakeClock.WaitForWatcherAndIncrement(9 * time.Second)
+			Consistently(attempts).ShouldNot(Receive())
+
+			fakeClock.Increment(time.Second)
+			Eventually(attempts).Should(Receive())
+
+			Eventually(waitErr).Should(Receive(BeNil()))
+		})
+
+		Context("when signalled while waiting", func() {
+			It("returns ErrInterruptedWaitingForCapacity", func() {
+				Eventually(stderr).Should(gbytes.Say("retrying in 5s"))
+
+				signals <- os.Interrupt
+
+				Eventually(waitErr).Should(Receive(Equal(ErrInterruptedWaitingForCapacity)))
+			})
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// CircuitBreakerPolicy determines when a worker that keeps failing to create
+// containers is taken out of rotation, and for how long.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is how many failures in a row mark the worker as
+	// unhealthy. If 0, workers are never marked as unhealthy.
+	FailureThreshold int
+
+	// CoolDown is how long an unhealthy worker is excluded from placing new
+	// containers.
+	CoolDown time.Duration
+}
+
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+
+	failures     map[string]int
+	failuresLock sync.Mutex
+}
+
+func newCircuitBreaker(policy CircuitBreakerPolicy) *circuitBreaker {
+	return &circuitBreaker{
+		policy:   policy,
+		failures: map[string]int{},
+	}
+}
+
+// Record notes the result of a call to the named worker, and returns true
+// once the worker has failed enough times in a row that it should be marked
+// as unhealthy.
+func (breaker *circuitBreaker) Record(workerName string, err error) bool {
+	if breaker.policy.FailureThreshold <= 0 {
+		return false
+	}
+
+	breaker.failuresLock.Lock()
+	defer breaker.failuresLock.Unlock()
+
+	if err == nil {
+		delete(breaker.failures, workerName)
+		return false
+	}
+
+	breaker.failures[workerName]++
+
+	if breaker.failures[workerName] < breaker.policy.FailureThreshold {
+		return false
+	}
+
+	delete(breaker.failures, workerName)
+
+	return true
+}
@@ -44,6 +44,8 @@ type Container interface {
 
 	Volumes() []Volume
 	VolumeMounts() []VolumeMount
+
+	WorkerName() string
 }
 
 type Identifier db.ContainerIdentifier
@@ -24,7 +24,8 @@ type gardenWorkerContainer struct {
 	volumes      []Volume
 	volumeMounts []VolumeMount
 
-	user string
+	user       string
+	workerName string
 
 	clock clock.Clock
 
@@ -42,12 +43,14 @@ func newGardenWorkerContainer(
 	db GardenWorkerDB,
 	clock clock.Clock,
 	volumeFactory VolumeFactory,
+	workerName string,
 ) (Container, error) {
 	workerContainer := &gardenWorkerContainer{
 		Container: container,
 
 		gardenClient: gardenClient,
 		db:           db,
+		workerName:   workerName,
 
 		clock: clock,
 
@@ -116,6 +119,10 @@ func (container *gardenWorkerContainer) VolumeMounts() []VolumeMount {
 	return container.volumeMounts
 }
 
+func (container *gardenWorkerContainer) WorkerName() string {
+	return container.workerName
+}
+
 func (container *gardenWorkerContainer) initializeVolumes(
 	logger lager.Logger,
 	properties garden.Properties,
@@ -16,6 +16,10 @@ type WorkerSpec struct {
 	Platform     string
 	ResourceType string
 	Tags         []string
+
+	// TeamID restricts the spec to workers that are global or owned by the
+	// team.
+	TeamID int
 }
 
 func (spec WorkerSpec) Description() string {
@@ -41,6 +45,7 @@ type ResourceTypeContainerSpec struct {
 	ImageResourcePointer *atc.TaskImageConfig
 	Ephemeral            bool
 	Tags                 []string
+	TeamID               int
 	Env                  []string
 
 	// Not Copy-on-Write. Used for a single mount in Get containers.
@@ -54,6 +59,7 @@ func (spec ResourceTypeContainerSpec) WorkerSpec() WorkerSpec {
 	return WorkerSpec{
 		ResourceType: spec.Type,
 		Tags:         spec.Tags,
+		TeamID:       spec.TeamID,
 	}
 }
 
@@ -71,6 +77,7 @@ type TaskContainerSpec struct {
 	ImageResourcePointer *atc.TaskImageConfig
 	Privileged           bool
 	Tags                 []string
+	TeamID               int
 	Inputs               []VolumeMount
 	Outputs              []VolumeMount
 }
@@ -79,6 +86,7 @@ func (spec TaskContainerSpec) WorkerSpec() WorkerSpec {
 	return WorkerSpec{
 		Platform: spec.Platform,
 		Tags:     spec.Tags,
+		TeamID:   spec.TeamID,
 	}
 }
 
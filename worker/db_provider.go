@@ -2,16 +2,17 @@ package worker
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	gclient "github.com/cloudfoundry-incubator/garden/client"
 	gconn "github.com/cloudfoundry-incubator/garden/client/connection"
 	"github.com/concourse/baggageclaim"
-	bclient "github.com/concourse/baggageclaim/client"
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 )
 
 //go:generate counterfeiter . WorkerDB
@@ -19,6 +20,7 @@ import (
 type WorkerDB interface {
 	Workers() ([]db.SavedWorker, error)
 	GetWorker(string) (db.SavedWorker, bool, error)
+	MarkWorkerUnhealthy(workerName string, coolDown time.Duration) error
 	CreateContainer(db.Container, time.Duration) (db.Container, error)
 	GetContainer(string) (db.Container, bool, error)
 	FindContainerByIdentifier(db.ContainerIdentifier) (db.Container, bool, error)
@@ -39,6 +41,11 @@ type dbProvider struct {
 	dialer       gconn.DialerFunc
 	retryPolicy  RetryPolicy
 	imageFetcher ImageFetcher
+	breaker      *circuitBreaker
+
+	localOwner        string
+	localRuntimes     map[string]Runtime
+	localRuntimesLock sync.Mutex
 }
 
 func NewDBWorkerProvider(
@@ -47,6 +54,8 @@ func NewDBWorkerProvider(
 	dialer gconn.DialerFunc,
 	retryPolicy RetryPolicy,
 	imageFetcher ImageFetcher,
+	circuitBreakerPolicy CircuitBreakerPolicy,
+	localOwner string,
 ) WorkerProvider {
 	return &dbProvider{
 		logger:       logger,
@@ -54,6 +63,10 @@ func NewDBWorkerProvider(
 		dialer:       dialer,
 		retryPolicy:  retryPolicy,
 		imageFetcher: imageFetcher,
+		breaker:      newCircuitBreaker(circuitBreakerPolicy),
+
+		localOwner:    localOwner,
+		localRuntimes: map[string]Runtime{},
 	}
 }
 
@@ -65,10 +78,27 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 
 	tikTok := clock.NewClock()
 
-	workers := make([]Worker, len(savedWorkers))
+	workers := []Worker{}
+
+	for _, savedWorker := range savedWorkers {
+		if savedWorker.UnhealthyFor > 0 {
+			provider.logger.Debug("skipping-unhealthy-worker", lager.Data{
+				"worker":        savedWorker.Name,
+				"unhealthy-for": savedWorker.UnhealthyFor.String(),
+			})
+
+			continue
+		}
+
+		if !provider.reachable(savedWorker) {
+			provider.logger.Debug("skipping-other-atcs-local-worker", lager.Data{
+				"worker": savedWorker.Name,
+			})
 
-	for i, savedWorker := range savedWorkers {
-		workers[i] = provider.newGardenWorker(tikTok, savedWorker)
+			continue
+		}
+
+		workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
 	}
 
 	return workers, nil
@@ -80,7 +110,7 @@ func (provider *dbProvider) GetWorker(name string) (Worker, bool, error) {
 		return nil, false, err
 	}
 
-	if !found {
+	if !found || !provider.reachable(savedWorker) {
 		return nil, false, nil
 	}
 
@@ -91,6 +121,33 @@ func (provider *dbProvider) GetWorker(name string) (Worker, bool, error) {
 	return worker, found, nil
 }
 
+func (provider *dbProvider) RecordWorkerResult(workerName string, err error) {
+	if !provider.breaker.Record(workerName, err) {
+		return
+	}
+
+	coolDown := provider.breaker.policy.CoolDown
+
+	logger := provider.logger.Session("circuit-breaker", lager.Data{
+		"worker": workerName,
+	})
+
+	logger.Info("marking-worker-unhealthy", lager.Data{
+		"error":     err.Error(),
+		"cool-down": coolDown.String(),
+	})
+
+	metric.WorkerUnhealthy{
+		WorkerName: workerName,
+		CoolDown:   coolDown,
+	}.Emit(logger)
+
+	markErr := provider.db.MarkWorkerUnhealthy(workerName, coolDown)
+	if markErr != nil {
+		logger.Error("failed-to-mark-worker-unhealthy", markErr)
+	}
+}
+
 func (provider *dbProvider) FindContainerForIdentifier(id Identifier) (db.Container, bool, error) {
 	return provider.db.FindContainerByIdentifier(db.ContainerIdentifier(id))
 }
@@ -104,6 +161,33 @@ func (provider *dbProvider) ReapContainer(handle string) error {
 }
 
 func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.SavedWorker) Worker {
+	volumeFactory := NewVolumeFactory(
+		provider.db,
+		tikTok,
+	)
+
+	return NewGardenWorker(
+		provider.runtimeFor(tikTok, savedWorker),
+		volumeFactory,
+		provider.imageFetcher,
+		provider.db,
+		provider,
+		tikTok,
+		savedWorker.ActiveContainers,
+		savedWorker.MaxContainers,
+		savedWorker.ResourceTypes,
+		savedWorker.Platform,
+		savedWorker.Tags,
+		savedWorker.Name,
+		savedWorker.TeamID,
+	)
+}
+
+func (provider *dbProvider) runtimeFor(tikTok clock.Clock, savedWorker db.SavedWorker) Runtime {
+	if _, dir, ok := parseLocalAddr(savedWorker.GardenAddr); ok {
+		return provider.localRuntime(tikTok, dir)
+	}
+
 	workerLog := provider.logger.Session("worker-connection", lager.Data{
 		"addr": savedWorker.GardenAddr,
 	})
@@ -123,26 +207,38 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.S
 
 	var bClient baggageclaim.Client
 	if savedWorker.BaggageclaimURL != "" {
-		bClient = bclient.New(savedWorker.BaggageclaimURL)
+		bClient = NewAddressableClient(savedWorker.BaggageclaimURL)
 	}
 
-	volumeFactory := NewVolumeFactory(
-		provider.db,
-		tikTok,
-	)
+	return NewRuntime(gclient.New(gardenConn), bClient)
+}
 
-	return NewGardenWorker(
-		gclient.New(gardenConn),
-		bClient,
-		volumeFactory,
-		provider.imageFetcher,
-		provider.db,
-		provider,
-		tikTok,
-		savedWorker.ActiveContainers,
-		savedWorker.ResourceTypes,
-		savedWorker.Platform,
-		savedWorker.Tags,
-		savedWorker.Name,
-	)
+// localRuntime returns the same runtime for every lookup of a local worker,
+// as it is what keeps track of the worker's running processes.
+func (provider *dbProvider) localRuntime(tikTok clock.Clock, dir string) Runtime {
+	provider.localRuntimesLock.Lock()
+	defer provider.localRuntimesLock.Unlock()
+
+	runtime, found := provider.localRuntimes[dir]
+	if !found {
+		runtime = NewLocalRuntime(provider.logger.Session("local-runtime", lager.Data{
+			"dir": dir,
+		}), tikTok, dir)
+
+		provider.localRuntimes[dir] = runtime
+	}
+
+	return runtime
+}
+
+// reachable reports whether this ATC can use the worker. Local workers run on
+// the host of the ATC that registered them, so no other ATC can reach them.
+func (provider *dbProvider) reachable(savedWorker db.SavedWorker) bool {
+	if !IsLocalAddr(savedWorker.GardenAddr) {
+		return true
+	}
+
+	owner, _, ok := parseLocalAddr(savedWorker.GardenAddr)
+
+	return ok && owner == provider.localOwner
 }
@@ -3,7 +3,10 @@ package worker_test
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
@@ -59,7 +62,10 @@ var _ = Describe("DBProvider", func() {
 		fakeImageFetcher = new(fakes.FakeImageFetcher)
 		fakeImageFetchingDelegate = new(fakes.FakeImageFetchingDelegate)
 
-		provider = NewDBWorkerProvider(logger, fakeDB, nil, immediateRetryPolicy{}, fakeImageFetcher)
+		provider = NewDBWorkerProvider(logger, fakeDB, nil, immediateRetryPolicy{}, fakeImageFetcher, CircuitBreakerPolicy{
+			FailureThreshold: 3,
+			CoolDown:         time.Minute,
+		}, "http://some-atc")
 	})
 
 	AfterEach(func() {
@@ -265,6 +271,109 @@ var _ = Describe("DBProvider", func() {
 			})
 		})
 
+		Context("when the database yields an unhealthy worker", func() {
+			BeforeEach(func() {
+				fakeDB.WorkersReturns([]db.SavedWorker{
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-worker",
+							GardenAddr: workerAddr,
+						},
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-unhealthy-worker",
+							GardenAddr: workerAddr,
+						},
+						UnhealthyFor: 30 * time.Second,
+					},
+				}, nil)
+			})
+
+			It("excludes it", func() {
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(workers).To(HaveLen(1))
+				Expect(workers[0].Name()).To(Equal("some-worker"))
+			})
+		})
+
+		Context("when the database yields a local worker", func() {
+			var localDir string
+
+			BeforeEach(func() {
+				var err error
+				localDir, err = ioutil.TempDir("", "local-worker")
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeDB.WorkersReturns([]db.SavedWorker{
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-local-worker",
+							GardenAddr: LocalAddr("http://some-atc", localDir),
+							ResourceTypes: []atc.WorkerResourceType{
+								{Type: "some-resource", Image: "some-image"},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(localDir)).To(Succeed())
+			})
+
+			It("constructs it with a volume manager without connecting to anything", func() {
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(workers).To(HaveLen(1))
+
+				vm, ok := workers[0].VolumeManager()
+				Expect(ok).To(BeTrue())
+				Expect(vm).NotTo(BeNil())
+			})
+
+			It("runs its containers in the local directory", func() {
+				container, err := workers[0].CreateContainer(
+					logger,
+					nil,
+					fakeImageFetchingDelegate,
+					Identifier{},
+					Metadata{},
+					ResourceTypeContainerSpec{Type: "some-resource"},
+					nil,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer container.Release(nil)
+
+				Expect(filepath.Join(localDir, "containers", container.Handle())).To(BeADirectory())
+			})
+		})
+
+		Context("when the database yields another ATC's local worker", func() {
+			BeforeEach(func() {
+				fakeDB.WorkersReturns([]db.SavedWorker{
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-worker",
+							GardenAddr: workerAddr,
+						},
+					},
+					{
+						WorkerInfo: db.WorkerInfo{
+							Name:       "some-other-atcs-local-worker",
+							GardenAddr: LocalAddr("http://some-other-atc", "/some/dir"),
+						},
+					},
+				}, nil)
+			})
+
+			It("excludes it", func() {
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(workers).To(HaveLen(1))
+				Expect(workers[0].Name()).To(Equal("some-worker"))
+			})
+		})
+
 		Context("when the database fails to return workers", func() {
 			disaster := errors.New("nope")
 
@@ -305,6 +414,59 @@ var _ = Describe("DBProvider", func() {
 		})
 	})
 
+	Describe("RecordWorkerResult", func() {
+		disaster := errors.New("connection refused")
+
+		Context("when a worker fails fewer times in a row than the threshold", func() {
+			BeforeEach(func() {
+				provider.RecordWorkerResult("some-worker", disaster)
+				provider.RecordWorkerResult("some-worker", disaster)
+				provider.RecordWorkerResult("some-worker", nil)
+				provider.RecordWorkerResult("some-worker", disaster)
+				provider.RecordWorkerResult("some-other-worker", disaster)
+			})
+
+			It("does not mark it as unhealthy", func() {
+				Expect(fakeDB.MarkWorkerUnhealthyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a worker fails as many times in a row as the threshold", func() {
+			BeforeEach(func() {
+				provider.RecordWorkerResult("some-worker", disaster)
+				provider.RecordWorkerResult("some-worker", disaster)
+				provider.RecordWorkerResult("some-worker", disaster)
+			})
+
+			It("marks it as unhealthy for the cool-down period", func() {
+				Expect(fakeDB.MarkWorkerUnhealthyCallCount()).To(Equal(1))
+
+				workerName, coolDown := fakeDB.MarkWorkerUnhealthyArgsForCall(0)
+				Expect(workerName).To(Equal("some-worker"))
+				Expect(coolDown).To(Equal(time.Minute))
+			})
+
+			It("starts counting again afterwards", func() {
+				provider.RecordWorkerResult("some-worker", disaster)
+				Expect(fakeDB.MarkWorkerUnhealthyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the threshold is 0", func() {
+			BeforeEach(func() {
+				provider = NewDBWorkerProvider(logger, fakeDB, nil, immediateRetryPolicy{}, fakeImageFetcher, CircuitBreakerPolicy{}, "http://some-atc")
+
+				for i := 0; i < 10; i++ {
+					provider.RecordWorkerResult("some-worker", disaster)
+				}
+			})
+
+			It("never marks the worker as unhealthy", func() {
+				Expect(fakeDB.MarkWorkerUnhealthyCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("when we call to get a container info by identifier", func() {
 		It("calls through to the db object", func() {
 			provider.FindContainerForIdentifier(Identifier{
@@ -211,6 +211,12 @@ type FakeContainer struct {
 	volumeMountsReturns     struct {
 		result1 []worker.VolumeMount
 	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns     struct {
+		result1 string
+	}
 }
 
 func (fake *FakeContainer) Handle() string {
@@ -965,4 +971,28 @@ func (fake *FakeContainer) VolumeMountsReturns(result1 []worker.VolumeMount) {
 	}{result1}
 }
 
+func (fake *FakeContainer) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	} else {
+		return fake.workerNameReturns.result1
+	}
+}
+
+func (fake *FakeContainer) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeContainer) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 var _ worker.Container = new(FakeContainer)
@@ -0,0 +1,77 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+)
+
+type FakeRuntime struct {
+	ContainersStub        func() garden.Client
+	containersMutex       sync.RWMutex
+	containersArgsForCall []struct{}
+	containersReturns     struct {
+		result1 garden.Client
+	}
+	VolumesStub        func() (baggageclaim.Client, bool)
+	volumesMutex       sync.RWMutex
+	volumesArgsForCall []struct{}
+	volumesReturns     struct {
+		result1 baggageclaim.Client
+		result2 bool
+	}
+}
+
+func (fake *FakeRuntime) Containers() garden.Client {
+	fake.containersMutex.Lock()
+	fake.containersArgsForCall = append(fake.containersArgsForCall, struct{}{})
+	fake.containersMutex.Unlock()
+	if fake.ContainersStub != nil {
+		return fake.ContainersStub()
+	} else {
+		return fake.containersReturns.result1
+	}
+}
+
+func (fake *FakeRuntime) ContainersCallCount() int {
+	fake.containersMutex.RLock()
+	defer fake.containersMutex.RUnlock()
+	return len(fake.containersArgsForCall)
+}
+
+func (fake *FakeRuntime) ContainersReturns(result1 garden.Client) {
+	fake.ContainersStub = nil
+	fake.containersReturns = struct {
+		result1 garden.Client
+	}{result1}
+}
+
+func (fake *FakeRuntime) Volumes() (baggageclaim.Client, bool) {
+	fake.volumesMutex.Lock()
+	fake.volumesArgsForCall = append(fake.volumesArgsForCall, struct{}{})
+	fake.volumesMutex.Unlock()
+	if fake.VolumesStub != nil {
+		return fake.VolumesStub()
+	} else {
+		return fake.volumesReturns.result1, fake.volumesReturns.result2
+	}
+}
+
+func (fake *FakeRuntime) VolumesCallCount() int {
+	fake.volumesMutex.RLock()
+	defer fake.volumesMutex.RUnlock()
+	return len(fake.volumesArgsForCall)
+}
+
+func (fake *FakeRuntime) VolumesReturns(result1 baggageclaim.Client, result2 bool) {
+	fake.VolumesStub = nil
+	fake.volumesReturns = struct {
+		result1 baggageclaim.Client
+		result2 bool
+	}{result1, result2}
+}
+
+var _ worker.Runtime = new(FakeRuntime)
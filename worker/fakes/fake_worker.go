@@ -103,6 +103,12 @@ type FakeWorker struct {
 		result1 baggageclaim.Client
 		result2 bool
 	}
+	MaxContainersStub        func() int
+	maxContainersMutex       sync.RWMutex
+	maxContainersArgsForCall []struct{}
+	maxContainersReturns     struct {
+		result1 int
+	}
 }
 
 func (fake *FakeWorker) CreateContainer(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 worker.ImageFetchingDelegate, arg4 worker.Identifier, arg5 worker.Metadata, arg6 worker.ContainerSpec, arg7 atc.ResourceTypes) (worker.Container, error) {
@@ -412,4 +418,28 @@ func (fake *FakeWorker) VolumeManagerReturns(result1 baggageclaim.Client, result
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) MaxContainers() int {
+	fake.maxContainersMutex.Lock()
+	fake.maxContainersArgsForCall = append(fake.maxContainersArgsForCall, struct{}{})
+	fake.maxContainersMutex.Unlock()
+	if fake.MaxContainersStub != nil {
+		return fake.MaxContainersStub()
+	} else {
+		return fake.maxContainersReturns.result1
+	}
+}
+
+func (fake *FakeWorker) MaxContainersCallCount() int {
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	return len(fake.maxContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxContainersReturns(result1 int) {
+	fake.MaxContainersStub = nil
+	fake.maxContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
 var _ worker.Worker = new(FakeWorker)
@@ -100,6 +100,15 @@ type FakeWorkerDB struct {
 	setVolumeTTLReturns struct {
 		result1 error
 	}
+	MarkWorkerUnhealthyStub        func(workerName string, coolDown time.Duration) error
+	markWorkerUnhealthyMutex       sync.RWMutex
+	markWorkerUnhealthyArgsForCall []struct {
+		workerName string
+		coolDown   time.Duration
+	}
+	markWorkerUnhealthyReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeWorkerDB) Workers() ([]db.SavedWorker, error) {
@@ -426,4 +435,37 @@ func (fake *FakeWorkerDB) SetVolumeTTLReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorkerDB) MarkWorkerUnhealthy(workerName string, coolDown time.Duration) error {
+	fake.markWorkerUnhealthyMutex.Lock()
+	fake.markWorkerUnhealthyArgsForCall = append(fake.markWorkerUnhealthyArgsForCall, struct {
+		workerName string
+		coolDown   time.Duration
+	}{workerName, coolDown})
+	fake.markWorkerUnhealthyMutex.Unlock()
+	if fake.MarkWorkerUnhealthyStub != nil {
+		return fake.MarkWorkerUnhealthyStub(workerName, coolDown)
+	} else {
+		return fake.markWorkerUnhealthyReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) MarkWorkerUnhealthyCallCount() int {
+	fake.markWorkerUnhealthyMutex.RLock()
+	defer fake.markWorkerUnhealthyMutex.RUnlock()
+	return len(fake.markWorkerUnhealthyArgsForCall)
+}
+
+func (fake *FakeWorkerDB) MarkWorkerUnhealthyArgsForCall(i int) (string, time.Duration) {
+	fake.markWorkerUnhealthyMutex.RLock()
+	defer fake.markWorkerUnhealthyMutex.RUnlock()
+	return fake.markWorkerUnhealthyArgsForCall[i].workerName, fake.markWorkerUnhealthyArgsForCall[i].coolDown
+}
+
+func (fake *FakeWorkerDB) MarkWorkerUnhealthyReturns(result1 error) {
+	fake.MarkWorkerUnhealthyStub = nil
+	fake.markWorkerUnhealthyReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ worker.WorkerDB = new(FakeWorkerDB)
@@ -54,6 +54,12 @@ type FakeWorkerProvider struct {
 	reapContainerReturns struct {
 		result1 error
 	}
+	RecordWorkerResultStub        func(workerName string, err error)
+	recordWorkerResultMutex       sync.RWMutex
+	recordWorkerResultArgsForCall []struct {
+		workerName string
+		err        error
+	}
 }
 
 func (fake *FakeWorkerProvider) Workers() ([]worker.Worker, error) {
@@ -215,4 +221,28 @@ func (fake *FakeWorkerProvider) ReapContainerReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorkerProvider) RecordWorkerResult(workerName string, err error) {
+	fake.recordWorkerResultMutex.Lock()
+	fake.recordWorkerResultArgsForCall = append(fake.recordWorkerResultArgsForCall, struct {
+		workerName string
+		err        error
+	}{workerName, err})
+	fake.recordWorkerResultMutex.Unlock()
+	if fake.RecordWorkerResultStub != nil {
+		fake.RecordWorkerResultStub(workerName, err)
+	}
+}
+
+func (fake *FakeWorkerProvider) RecordWorkerResultCallCount() int {
+	fake.recordWorkerResultMutex.RLock()
+	defer fake.recordWorkerResultMutex.RUnlock()
+	return len(fake.recordWorkerResultArgsForCall)
+}
+
+func (fake *FakeWorkerProvider) RecordWorkerResultArgsForCall(i int) (string, error) {
+	fake.recordWorkerResultMutex.RLock()
+	defer fake.recordWorkerResultMutex.RUnlock()
+	return fake.recordWorkerResultArgsForCall[i].workerName, fake.recordWorkerResultArgsForCall[i].err
+}
+
 var _ worker.WorkerProvider = new(FakeWorkerProvider)
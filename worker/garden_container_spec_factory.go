@@ -63,8 +63,9 @@ func (factory *gardenContainerSpecFactory) BuildContainerSpec(
 			customTypes = customTypes.Without(resourceTypeContainerSpec.Type)
 
 			resourceTypeContainerSpec.ImageResourcePointer = &atc.TaskImageConfig{
-				Source: customType.Source,
-				Type:   customType.Type,
+				Source:  customType.Source,
+				Type:    customType.Type,
+				Version: customType.Version,
 			}
 
 			spec = resourceTypeContainerSpec
@@ -61,51 +61,24 @@ func (fetcher Fetcher) FetchImage(
 	tracker := fetcher.trackerFactory.TrackerFor(worker)
 	resourceType := resource.ResourceType(imageConfig.Type)
 
-	checkSess := resource.Session{
-		ID:       identifier,
-		Metadata: metadata,
-	}
-
-	checkSess.ID.Stage = db.ContainerStageCheck
-	checkSess.ID.ImageResourceType = imageConfig.Type
-	checkSess.ID.ImageResourceSource = imageConfig.Source
-	checkSess.Metadata.Type = db.ContainerTypeCheck
-	checkSess.Metadata.WorkingDirectory = ""
-	checkSess.Metadata.EnvironmentVariables = nil
-
-	checkingResource, err := tracker.Init(
-		logger.Session("check-image"),
-		resource.EmptyMetadata{},
-		checkSess,
-		resourceType,
-		workerTags,
-		customTypes,
-		delegate,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	defer checkingResource.Release(nil)
-
-	versions, err := checkingResource.Check(imageConfig.Source, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(versions) == 0 {
-		return nil, ErrImageUnavailable
+	version := imageConfig.Version
+	if version == nil {
+		var err error
+		version, err = checkLatestVersion(logger, tracker, imageConfig, signals, identifier, metadata, delegate, workerTags, customTypes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	cacheID := resource.ResourceCacheIdentifier{
 		Type:    resourceType,
-		Version: versions[0],
+		Version: version,
 		Source:  imageConfig.Source,
 	}
 
 	volumeID := cacheID.VolumeIdentifier()
 
-	err = delegate.ImageVersionDetermined(volumeID)
+	err := delegate.ImageVersionDetermined(volumeID)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +97,7 @@ func (fetcher Fetcher) FetchImage(
 
 	getResource, cache, err := tracker.InitWithCache(
 		logger.Session("init-image"),
+		signals,
 		resource.EmptyMetadata{},
 		getSess,
 		resourceType,
@@ -147,7 +121,7 @@ func (fetcher Fetcher) FetchImage(
 		},
 		imageConfig.Source,
 		nil,
-		versions[0],
+		version,
 	)
 
 	if !isInitialized {
@@ -179,6 +153,59 @@ func (fetcher Fetcher) FetchImage(
 	}, nil
 }
 
+// checkLatestVersion determines the version of the image to fetch when the
+// config does not specify one.
+func checkLatestVersion(
+	logger lager.Logger,
+	tracker resource.Tracker,
+	imageConfig atc.TaskImageConfig,
+	signals <-chan os.Signal,
+	identifier worker.Identifier,
+	metadata worker.Metadata,
+	delegate worker.ImageFetchingDelegate,
+	workerTags atc.Tags,
+	customTypes atc.ResourceTypes,
+) (atc.Version, error) {
+	checkSess := resource.Session{
+		ID:       identifier,
+		Metadata: metadata,
+	}
+
+	checkSess.ID.Stage = db.ContainerStageCheck
+	checkSess.ID.ImageResourceType = imageConfig.Type
+	checkSess.ID.ImageResourceSource = imageConfig.Source
+	checkSess.Metadata.Type = db.ContainerTypeCheck
+	checkSess.Metadata.WorkingDirectory = ""
+	checkSess.Metadata.EnvironmentVariables = nil
+
+	checkingResource, err := tracker.Init(
+		logger.Session("check-image"),
+		signals,
+		resource.EmptyMetadata{},
+		checkSess,
+		resource.ResourceType(imageConfig.Type),
+		workerTags,
+		customTypes,
+		delegate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer checkingResource.Release(nil)
+
+	versions, err := checkingResource.Check(resource.IOConfig{}, imageConfig.Source, nil, signals)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrImageUnavailable
+	}
+
+	return versions[0], nil
+}
+
 type resourceImage struct {
 	volume   worker.Volume
 	metadata worker.ImageMetadata
@@ -207,7 +207,7 @@ var _ = Describe("Fetcher", func() {
 
 									It("created the 'check' resource with the correct session, with the currently fetching type removed from the set", func() {
 										Expect(fakeImageTracker.InitCallCount()).To(Equal(1))
-										_, metadata, session, resourceType, tags, actualCustomTypes, delegate := fakeImageTracker.InitArgsForCall(0)
+										_, _, metadata, session, resourceType, tags, actualCustomTypes, delegate := fakeImageTracker.InitArgsForCall(0)
 										Expect(metadata).To(Equal(resource.EmptyMetadata{}))
 										Expect(session).To(Equal(resource.Session{
 											ID: worker.Identifier{
@@ -233,7 +233,7 @@ var _ = Describe("Fetcher", func() {
 
 									It("ran 'check' with the right config", func() {
 										Expect(fakeCheckResource.CheckCallCount()).To(Equal(1))
-										checkSource, checkVersion := fakeCheckResource.CheckArgsForCall(0)
+										_, checkSource, checkVersion, _ := fakeCheckResource.CheckArgsForCall(0)
 										Expect(checkVersion).To(BeNil())
 										Expect(checkSource).To(Equal(imageConfig.Source))
 									})
@@ -257,7 +257,7 @@ var _ = Describe("Fetcher", func() {
 
 									It("created the 'get' resource with the correct session", func() {
 										Expect(fakeImageTracker.InitWithCacheCallCount()).To(Equal(1))
-										_, metadata, session, resourceType, tags, cacheID, actualCustomTypes, delegate := fakeImageTracker.InitWithCacheArgsForCall(0)
+										_, _, metadata, session, resourceType, tags, cacheID, actualCustomTypes, delegate := fakeImageTracker.InitWithCacheArgsForCall(0)
 										Expect(metadata).To(Equal(resource.EmptyMetadata{}))
 										Expect(session).To(Equal(resource.Session{
 											ID: worker.Identifier{
@@ -508,6 +508,47 @@ var _ = Describe("Fetcher", func() {
 			Expect(fakeImageTracker.InitWithCacheCallCount()).To(Equal(0))
 		})
 	})
+
+	Context("when the image config specifies a version", func() {
+		var (
+			fakeGetResource     *rfakes.FakeResource
+			fakeCache           *rfakes.FakeCache
+			fakeVersionedSource *rfakes.FakeVersionedSource
+		)
+
+		BeforeEach(func() {
+			imageConfig.Version = atc.Version{"v": "pinned"}
+
+			fakeGetResource = new(rfakes.FakeResource)
+			fakeCache = new(rfakes.FakeCache)
+			fakeImageTracker.InitWithCacheReturns(fakeGetResource, fakeCache, nil)
+
+			fakeVersionedSource = new(rfakes.FakeVersionedSource)
+			fakeVersionedSource.StreamOutReturns(tarStreamWith(`{}`), nil)
+			fakeGetResource.GetReturns(fakeVersionedSource)
+			fakeGetResource.CacheVolumeReturns(new(wfakes.FakeVolume), true)
+		})
+
+		It("succeeds", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+		})
+
+		It("does not check for the latest version", func() {
+			Expect(fakeImageTracker.InitCallCount()).To(BeZero())
+		})
+
+		It("fetches the specified version", func() {
+			_, _, _, _, _, _, cacheID, _, _ := fakeImageTracker.InitWithCacheArgsForCall(0)
+			Expect(cacheID).To(Equal(resource.ResourceCacheIdentifier{
+				Type:    "docker",
+				Version: atc.Version{"v": "pinned"},
+				Source:  atc.Source{"some": "source"},
+			}))
+
+			_, _, _, version := fakeGetResource.GetArgsForCall(0)
+			Expect(version).To(Equal(atc.Version{"v": "pinned"}))
+		})
+	})
 })
 
 func tarStreamWith(metadata string) io.ReadCloser {
@@ -0,0 +1,161 @@
+package local
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func extractTar(stream io.Reader, destination string) error {
+	tarReader := tar.NewReader(stream)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(destination, header.Name)
+		if target != destination && !strings.HasPrefix(target, destination+string(filepath.Separator)) {
+			return fmt.Errorf("tar entry escapes destination: %s", header.Name)
+		}
+
+		mode := os.FileMode(header.Mode)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, mode)
+
+		case tar.TypeReg, tar.TypeRegA:
+			err = extractFile(tarReader, target, mode)
+
+		case tar.TypeSymlink:
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err == nil {
+				os.Remove(target)
+				err = os.Symlink(header.Linkname, target)
+			}
+
+		case tar.TypeLink:
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err == nil {
+				os.Remove(target)
+				err = os.Link(filepath.Join(destination, header.Linkname), target)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(source io.Reader, target string, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, source)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// writeTar archives the source path the same way Garden streams out: a
+// trailing slash streams the directory's contents, otherwise the directory
+// itself is included by name.
+func writeTar(destination io.Writer, source string, contentsOnly bool) error {
+	resolved, err := filepath.EvalSymlinks(source)
+	if err != nil {
+		return err
+	}
+
+	prefix := filepath.Base(source)
+	if contentsOnly {
+		prefix = "."
+	}
+
+	tarWriter := tar.NewWriter(destination)
+
+	err = filepath.Walk(resolved, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(resolved, path)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(filepath.Join(prefix, relative))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tarWriter.Close()
+}
+
+func copyDir(source string, destination string) error {
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(writeTar(writer, source+"/", true))
+	}()
+
+	err := extractTar(reader, destination)
+	reader.CloseWithError(err)
+
+	return err
+}
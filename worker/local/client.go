@@ -0,0 +1,425 @@
+// Package local implements the Garden and Baggageclaim APIs on the ATC's own
+// host. Containers are plain directories and their processes are ordinary
+// child processes of the ATC, so nothing is isolated. It is only meant for
+// development mode and integration tests.
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/nu7hatch/gouuid"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+var ErrHandleInUse = errors.New("container handle already in use")
+
+const metadataFileName = "metadata.json"
+const rootDirName = "root"
+
+type containerMetadata struct {
+	Properties garden.Properties `json:"properties"`
+	Env        []string          `json:"env"`
+	RootFSPath string            `json:"rootfs_path"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+}
+
+type client struct {
+	logger lager.Logger
+	clock  clock.Clock
+	dir    string
+
+	lock      sync.Mutex
+	processes map[string]map[string]*process
+}
+
+// NewClient returns a Garden client whose containers live in directories
+// under dir. Processes see the container's paths mapped beneath its
+// directory, and bind mounts are symlinks to their source paths.
+func NewClient(logger lager.Logger, clock clock.Clock, dir string) garden.Client {
+	return &client{
+		logger: logger,
+		clock:  clock,
+		dir:    dir,
+
+		processes: map[string]map[string]*process{},
+	}
+}
+
+func (client *client) Ping() error {
+	_, err := os.Stat(client.dir)
+	return err
+}
+
+func (client *client) Capacity() (garden.Capacity, error) {
+	return garden.Capacity{}, nil
+}
+
+func (client *client) Create(spec garden.ContainerSpec) (garden.Container, error) {
+	client.reapExpiredContainers()
+
+	handle := spec.Handle
+	if handle == "" {
+		guid, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		handle = guid.String()
+	}
+
+	logger := client.logger.Session("create", lager.Data{"handle": handle})
+
+	containerDir := filepath.Join(client.dir, handle)
+
+	_, err := os.Stat(containerDir)
+	if err == nil {
+		return nil, ErrHandleInUse
+	}
+
+	root := filepath.Join(containerDir, rootDirName)
+
+	err = os.MkdirAll(root, 0755)
+	if err != nil {
+		logger.Error("failed-to-create-container-dir", err)
+		return nil, err
+	}
+
+	for _, mount := range spec.BindMounts {
+		err := bindMount(root, mount)
+		if err != nil {
+			logger.Error("failed-to-bind-mount", err, lager.Data{"dst-path": mount.DstPath})
+			os.RemoveAll(containerDir)
+			return nil, err
+		}
+	}
+
+	metadata := containerMetadata{
+		Properties: spec.Properties,
+		Env:        spec.Env,
+		RootFSPath: spec.RootFSPath,
+	}
+
+	if metadata.Properties == nil {
+		metadata.Properties = garden.Properties{}
+	}
+
+	if spec.GraceTime > 0 {
+		metadata.ExpiresAt = client.clock.Now().Add(spec.GraceTime)
+	}
+
+	err = writeMetadata(containerDir, metadata)
+	if err != nil {
+		logger.Error("failed-to-write-metadata", err)
+		os.RemoveAll(containerDir)
+		return nil, err
+	}
+
+	return &container{
+		handle: handle,
+		dir:    containerDir,
+		client: client,
+	}, nil
+}
+
+func (client *client) Destroy(handle string) error {
+	_, err := client.Lookup(handle)
+	if err != nil {
+		return err
+	}
+
+	client.lock.Lock()
+	processes := client.processes[handle]
+	delete(client.processes, handle)
+	client.lock.Unlock()
+
+	for _, process := range processes {
+		process.Signal(garden.SignalKill)
+	}
+
+	return os.RemoveAll(filepath.Join(client.dir, handle))
+}
+
+func (client *client) Containers(properties garden.Properties) ([]garden.Container, error) {
+	client.reapExpiredContainers()
+
+	handles, err := client.handles()
+	if err != nil {
+		return nil, err
+	}
+
+	containers := []garden.Container{}
+
+	for _, handle := range handles {
+		containerDir := filepath.Join(client.dir, handle)
+
+		metadata, err := readMetadata(containerDir)
+		if err != nil {
+			continue
+		}
+
+		if !propertiesMatch(metadata.Properties, properties) {
+			continue
+		}
+
+		containers = append(containers, &container{
+			handle: handle,
+			dir:    containerDir,
+			client: client,
+		})
+	}
+
+	return containers, nil
+}
+
+func (client *client) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	entries := map[string]garden.ContainerInfoEntry{}
+
+	for _, handle := range handles {
+		container, err := client.Lookup(handle)
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := container.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		entries[handle] = garden.ContainerInfoEntry{Info: info}
+	}
+
+	return entries, nil
+}
+
+func (client *client) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	entries := map[string]garden.ContainerMetricsEntry{}
+
+	for _, handle := range handles {
+		_, err := client.Lookup(handle)
+		if err != nil {
+			return nil, err
+		}
+
+		entries[handle] = garden.ContainerMetricsEntry{}
+	}
+
+	return entries, nil
+}
+
+func (client *client) Lookup(handle string) (garden.Container, error) {
+	containerDir := filepath.Join(client.dir, handle)
+
+	_, err := os.Stat(filepath.Join(containerDir, metadataFileName))
+	if os.IsNotExist(err) {
+		return nil, garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &container{
+		handle: handle,
+		dir:    containerDir,
+		client: client,
+	}, nil
+}
+
+func (client *client) handles() ([]string, error) {
+	entries, err := ioutil.ReadDir(client.dir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	handles := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			handles = append(handles, entry.Name())
+		}
+	}
+
+	return handles, nil
+}
+
+func (client *client) reapExpiredContainers() {
+	logger := client.logger.Session("reap-expired-containers")
+
+	handles, err := client.handles()
+	if err != nil {
+		logger.Error("failed-to-list-containers", err)
+		return
+	}
+
+	now := client.clock.Now()
+
+	for _, handle := range handles {
+		metadata, err := readMetadata(filepath.Join(client.dir, handle))
+		if err != nil {
+			continue
+		}
+
+		if metadata.ExpiresAt.IsZero() || metadata.ExpiresAt.After(now) {
+			continue
+		}
+
+		if client.hasRunningProcesses(handle) {
+			continue
+		}
+
+		logger.Info("reaping", lager.Data{"handle": handle})
+
+		err = client.Destroy(handle)
+		if err != nil {
+			logger.Error("failed-to-reap", err, lager.Data{"handle": handle})
+		}
+	}
+}
+
+func (client *client) hasRunningProcesses(handle string) bool {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	for _, process := range client.processes[handle] {
+		if !process.hasExited() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (client *client) trackProcess(handle string, process *process) {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	if client.processes[handle] == nil {
+		client.processes[handle] = map[string]*process{}
+	}
+
+	client.processes[handle][process.ID()] = process
+}
+
+func (client *client) lookupProcess(handle string, processID string) (*process, bool) {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	process, found := client.processes[handle][processID]
+	return process, found
+}
+
+func (client *client) containerProcesses(handle string) []*process {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	processes := []*process{}
+	for _, process := range client.processes[handle] {
+		processes = append(processes, process)
+	}
+
+	return processes
+}
+
+// updateMetadata serializes changes to containers' metadata files, since
+// they are read, modified, and written back as a whole.
+func (client *client) updateMetadata(containerDir string, update func(*containerMetadata)) error {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	metadata, err := readMetadata(containerDir)
+	if err != nil {
+		return err
+	}
+
+	update(&metadata)
+
+	return writeMetadata(containerDir, metadata)
+}
+
+func bindMount(root string, mount garden.BindMount) error {
+	dstPath := filepath.Join(root, mount.DstPath)
+
+	err := os.MkdirAll(filepath.Dir(dstPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.Symlink(mount.SrcPath, dstPath)
+}
+
+func propertiesMatch(properties garden.Properties, filter garden.Properties) bool {
+	for name, value := range filter {
+		if properties[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func readMetadata(containerDir string) (containerMetadata, error) {
+	var metadata containerMetadata
+
+	payload, err := ioutil.ReadFile(filepath.Join(containerDir, metadataFileName))
+	if err != nil {
+		return containerMetadata{}, err
+	}
+
+	err = json.Unmarshal(payload, &metadata)
+	if err != nil {
+		return containerMetadata{}, fmt.Errorf("malformed container metadata: %s", err)
+	}
+
+	return metadata, nil
+}
+
+func writeMetadata(containerDir string, metadata containerMetadata) error {
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(filepath.Join(containerDir, metadataFileName), payload)
+}
+
+// writeFileAtomically replaces the file by renaming a temporary file over it,
+// so that concurrent readers never see it partially written.
+func writeFileAtomically(path string, payload []byte) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpFile.Write(payload)
+	if err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = os.Chmod(tmpFile.Name(), 0644)
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
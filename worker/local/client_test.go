@@ -0,0 +1,282 @@
+package local_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/concourse/atc/worker/local"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		fakeClock *fakeclock.FakeClock
+		dir       string
+
+		client garden.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "local-client")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		client = local.NewClient(lagertest.NewTestLogger("test"), fakeClock, dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("Create", func() {
+		It("can look up the container by its handle", func() {
+			container, err := client.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(container.Handle()).To(Equal("some-handle"))
+
+			foundContainer, err := client.Lookup("some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(foundContainer.Handle()).To(Equal("some-handle"))
+		})
+
+		It("generates a handle if none is given", func() {
+			container, err := client.Create(garden.ContainerSpec{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(container.Handle()).NotTo(BeEmpty())
+		})
+
+		It("gives the container its properties", func() {
+			container, err := client.Create(garden.ContainerSpec{
+				Properties: garden.Properties{"some": "property"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(container.Property("some")).To(Equal("property"))
+		})
+
+		Context("when the handle is already in use", func() {
+			BeforeEach(func() {
+				_, err := client.Create(garden.ContainerSpec{Handle: "some-handle"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				_, err := client.Create(garden.ContainerSpec{Handle: "some-handle"})
+				Expect(err).To(Equal(local.ErrHandleInUse))
+			})
+		})
+
+		Context("with bind mounts", func() {
+			var srcPath string
+
+			BeforeEach(func() {
+				var err error
+				srcPath, err = ioutil.TempDir("", "local-bind-mount")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(srcPath, "some-file"), []byte("some-content"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(srcPath)).To(Succeed())
+			})
+
+			It("makes the source path's contents available at the destination", func() {
+				container, err := client.Create(garden.ContainerSpec{
+					BindMounts: []garden.BindMount{
+						{SrcPath: srcPath, DstPath: "/some/mount"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				stdout := gbytes.NewBuffer()
+
+				process, err := container.Run(garden.ProcessSpec{
+					Path: "cat",
+					Args: []string{"some-file"},
+					Dir:  "/some/mount",
+				}, garden.ProcessIO{
+					Stdout: stdout,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(process.Wait()).To(Equal(0))
+				Expect(stdout).To(gbytes.Say("some-content"))
+			})
+		})
+	})
+
+	Describe("Lookup", func() {
+		Context("when the container does not exist", func() {
+			It("returns a ContainerNotFoundError", func() {
+				_, err := client.Lookup("bogus-handle")
+				Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "bogus-handle"}))
+			})
+		})
+	})
+
+	Describe("Destroy", func() {
+		It("removes the container", func() {
+			_, err := client.Create(garden.ContainerSpec{Handle: "some-handle"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.Destroy("some-handle")).To(Succeed())
+
+			_, err = client.Lookup("some-handle")
+			Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+		})
+	})
+
+	Describe("Containers", func() {
+		BeforeEach(func() {
+			_, err := client.Create(garden.ContainerSpec{
+				Handle:     "handle-a",
+				Properties: garden.Properties{"a": "b"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Create(garden.ContainerSpec{
+				Handle:     "handle-b",
+				Properties: garden.Properties{"a": "c"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the containers with matching properties", func() {
+			containers, err := client.Containers(garden.Properties{"a": "c"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Handle()).To(Equal("handle-b"))
+		})
+
+		It("reaps containers whose grace time has passed", func() {
+			container, err := client.Lookup("handle-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(container.SetGraceTime(time.Minute)).To(Succeed())
+
+			fakeClock.Increment(2 * time.Minute)
+
+			containers, err := client.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Handle()).To(Equal("handle-b"))
+		})
+	})
+
+	Describe("a container", func() {
+		var container garden.Container
+
+		BeforeEach(func() {
+			var err error
+			container, err = client.Create(garden.ContainerSpec{
+				Env: []string{"SOME_VAR=container-value", "OTHER_VAR=container-value"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("runs processes with the container's and the process's env", func() {
+			stdout := gbytes.NewBuffer()
+
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "echo $SOME_VAR $OTHER_VAR"},
+				Env:  []string{"OTHER_VAR=process-value"},
+			}, garden.ProcessIO{
+				Stdout: stdout,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(process.Wait()).To(Equal(0))
+			Expect(stdout).To(gbytes.Say("container-value process-value"))
+		})
+
+		It("returns the exit status of processes", func() {
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "exit 3"},
+			}, garden.ProcessIO{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(process.Wait()).To(Equal(3))
+		})
+
+		It("can attach to running processes", func() {
+			process, err := container.Run(garden.ProcessSpec{
+				Path: "sh",
+				Args: []string{"-c", "read line; echo $line"},
+			}, garden.ProcessIO{
+				Stdin: bytes.NewBufferString("hello\n"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			attached, err := container.Attach(process.ID(), garden.ProcessIO{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(attached.Wait()).To(Equal(0))
+		})
+
+		It("streams files in and out", func() {
+			buffer := new(bytes.Buffer)
+			tarWriter := tar.NewWriter(buffer)
+
+			err := tarWriter.WriteHeader(&tar.Header{
+				Name: "some-file",
+				Mode: 0644,
+				Size: int64(len("some-content")),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tarWriter.Write([]byte("some-content"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tarWriter.Close()).To(Succeed())
+
+			err = container.StreamIn(garden.StreamInSpec{
+				Path:      "/some/dir",
+				TarStream: buffer,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			stream, err := container.StreamOut(garden.StreamOutSpec{
+				Path: "/some/dir/some-file",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			defer stream.Close()
+
+			tarReader := tar.NewReader(stream)
+
+			header, err := tarReader.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Name).To(Equal("some-file"))
+
+			Expect(ioutil.ReadAll(tarReader)).To(Equal([]byte("some-content")))
+		})
+
+		It("can set and remove properties", func() {
+			Expect(container.SetProperty("some", "property")).To(Succeed())
+			Expect(container.Property("some")).To(Equal("property"))
+
+			Expect(container.RemoveProperty("some")).To(Succeed())
+
+			_, err := container.Property("some")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
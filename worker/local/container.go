@@ -0,0 +1,275 @@
+package local
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+type container struct {
+	handle string
+	dir    string
+
+	client *client
+}
+
+func (container *container) Handle() string {
+	return container.handle
+}
+
+func (container *container) Stop(kill bool) error {
+	signal := garden.SignalTerminate
+	if kill {
+		signal = garden.SignalKill
+	}
+
+	for _, process := range container.client.containerProcesses(container.handle) {
+		if process.hasExited() {
+			continue
+		}
+
+		err := process.Signal(signal)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (container *container) Info() (garden.ContainerInfo, error) {
+	metadata, err := readMetadata(container.dir)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	return garden.ContainerInfo{
+		State:         "active",
+		ContainerPath: container.root(),
+		Properties:    metadata.Properties,
+	}, nil
+}
+
+func (container *container) StreamIn(spec garden.StreamInSpec) error {
+	destination := container.path(spec.Path)
+
+	err := os.MkdirAll(destination, 0755)
+	if err != nil {
+		return err
+	}
+
+	return extractTar(spec.TarStream, destination)
+}
+
+func (container *container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	source := container.path(spec.Path)
+
+	_, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	contentsOnly := strings.HasSuffix(spec.Path, "/")
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(writeTar(writer, source, contentsOnly))
+	}()
+
+	return reader, nil
+}
+
+func (container *container) LimitBandwidth(limits garden.BandwidthLimits) error {
+	return nil
+}
+
+func (container *container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return garden.BandwidthLimits{}, nil
+}
+
+func (container *container) LimitCPU(limits garden.CPULimits) error {
+	return nil
+}
+
+func (container *container) CurrentCPULimits() (garden.CPULimits, error) {
+	return garden.CPULimits{}, nil
+}
+
+func (container *container) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return garden.DiskLimits{}, nil
+}
+
+func (container *container) LimitMemory(limits garden.MemoryLimits) error {
+	return nil
+}
+
+func (container *container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	return garden.MemoryLimits{}, nil
+}
+
+// NetIn maps nothing, since processes already listen on the host's network.
+func (container *container) NetIn(hostPort uint32, containerPort uint32) (uint32, uint32, error) {
+	return containerPort, containerPort, nil
+}
+
+func (container *container) NetOut(netOutRule garden.NetOutRule) error {
+	return nil
+}
+
+func (container *container) Run(spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	metadata, err := readMetadata(container.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := container.root()
+	if spec.Dir != "" {
+		dir = container.path(spec.Dir)
+	}
+
+	process, err := startProcess(
+		container.resolveExecutable(metadata, dir, spec.Path),
+		spec.Args,
+		dir,
+		mergeEnv(metadata.Env, spec.Env),
+		processIO,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	container.client.trackProcess(container.handle, process)
+
+	return process, nil
+}
+
+func (container *container) Attach(processID string, processIO garden.ProcessIO) (garden.Process, error) {
+	process, found := container.client.lookupProcess(container.handle, processID)
+	if !found {
+		return nil, fmt.Errorf("unknown process: %s", processID)
+	}
+
+	process.attach(processIO)
+
+	return process, nil
+}
+
+func (container *container) Metrics() (garden.Metrics, error) {
+	return garden.Metrics{}, nil
+}
+
+func (container *container) SetGraceTime(graceTime time.Duration) error {
+	expiresAt := time.Time{}
+	if graceTime > 0 {
+		expiresAt = container.client.clock.Now().Add(graceTime)
+	}
+
+	return container.client.updateMetadata(container.dir, func(metadata *containerMetadata) {
+		metadata.ExpiresAt = expiresAt
+	})
+}
+
+func (container *container) Properties() (garden.Properties, error) {
+	metadata, err := readMetadata(container.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.Properties, nil
+}
+
+func (container *container) Property(name string) (string, error) {
+	properties, err := container.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	value, found := properties[name]
+	if !found {
+		return "", fmt.Errorf("property does not exist: %s", name)
+	}
+
+	return value, nil
+}
+
+func (container *container) SetProperty(name string, value string) error {
+	return container.client.updateMetadata(container.dir, func(metadata *containerMetadata) {
+		if metadata.Properties == nil {
+			metadata.Properties = garden.Properties{}
+		}
+
+		metadata.Properties[name] = value
+	})
+}
+
+func (container *container) RemoveProperty(name string) error {
+	return container.client.updateMetadata(container.dir, func(metadata *containerMetadata) {
+		delete(metadata.Properties, name)
+	})
+}
+
+func (container *container) root() string {
+	return filepath.Join(container.dir, rootDirName)
+}
+
+func (container *container) path(containerPath string) string {
+	return filepath.Join(container.root(), containerPath)
+}
+
+// resolveExecutable finds the host path for a process's executable. Absolute
+// paths are looked up in the container and then in its rootfs, if that is a
+// directory on the host; e.g. a resource type's image. Relative paths with a
+// directory component are relative to the working directory, and bare names
+// are left to be found on the host's $PATH.
+func (container *container) resolveExecutable(metadata containerMetadata, dir string, path string) string {
+	if filepath.IsAbs(path) {
+		inContainer := container.path(path)
+		if _, err := os.Stat(inContainer); err == nil {
+			return inContainer
+		}
+
+		rootFS := strings.TrimPrefix(metadata.RootFSPath, "raw://")
+		if rootFS != "" {
+			inRootFS := filepath.Join(rootFS, path)
+			if _, err := os.Stat(inRootFS); err == nil {
+				return inRootFS
+			}
+		}
+
+		return path
+	}
+
+	if strings.Contains(path, "/") {
+		return filepath.Join(dir, path)
+	}
+
+	return path
+}
+
+// mergeEnv layers each list of NAME=value pairs over the ones before it,
+// starting from the host's $PATH so that bare executable names resolve.
+func mergeEnv(envs ...[]string) []string {
+	merged := []string{"PATH=" + os.Getenv("PATH")}
+	indices := map[string]int{"PATH": 0}
+
+	for _, env := range envs {
+		for _, pair := range env {
+			name := strings.SplitN(pair, "=", 2)[0]
+
+			if i, found := indices[name]; found {
+				merged[i] = pair
+			} else {
+				indices[name] = len(merged)
+				merged = append(merged, pair)
+			}
+		}
+	}
+
+	return merged
+}
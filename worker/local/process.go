@@ -0,0 +1,135 @@
+package local
+
+import (
+	"io"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/nu7hatch/gouuid"
+)
+
+type process struct {
+	id  string
+	cmd *exec.Cmd
+
+	stdout *attachableWriter
+	stderr *attachableWriter
+
+	exited     chan struct{}
+	exitStatus int
+	exitErr    error
+}
+
+func startProcess(path string, args []string, dir string, env []string, processIO garden.ProcessIO) (*process, error) {
+	guid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+
+	process := &process{
+		id:  guid.String(),
+		cmd: cmd,
+
+		stdout: &attachableWriter{writer: processIO.Stdout},
+		stderr: &attachableWriter{writer: processIO.Stderr},
+
+		exited: make(chan struct{}),
+	}
+
+	cmd.Stdin = processIO.Stdin
+	cmd.Stdout = process.stdout
+	cmd.Stderr = process.stderr
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	go process.wait()
+
+	return process, nil
+}
+
+func (process *process) ID() string {
+	return process.id
+}
+
+func (process *process) Wait() (int, error) {
+	<-process.exited
+	return process.exitStatus, process.exitErr
+}
+
+// SetTTY does nothing, as processes are never given a TTY.
+func (process *process) SetTTY(garden.TTYSpec) error {
+	return nil
+}
+
+func (process *process) Signal(signal garden.Signal) error {
+	if signal == garden.SignalKill {
+		return process.cmd.Process.Kill()
+	}
+
+	return process.cmd.Process.Signal(syscall.SIGTERM)
+}
+
+func (process *process) hasExited() bool {
+	select {
+	case <-process.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// attach redirects the process's output to the given IO. Anything it wrote
+// before then went to whoever ran or last attached to it.
+func (process *process) attach(processIO garden.ProcessIO) {
+	process.stdout.setWriter(processIO.Stdout)
+	process.stderr.setWriter(processIO.Stderr)
+}
+
+func (process *process) wait() {
+	defer close(process.exited)
+
+	err := process.cmd.Wait()
+	if err == nil {
+		return
+	}
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			process.exitStatus = status.ExitStatus()
+			return
+		}
+	}
+
+	process.exitErr = err
+}
+
+type attachableWriter struct {
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+func (writer *attachableWriter) Write(p []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if writer.writer == nil {
+		return len(p), nil
+	}
+
+	return writer.writer.Write(p)
+}
+
+func (writer *attachableWriter) setWriter(w io.Writer) {
+	writer.lock.Lock()
+	writer.writer = w
+	writer.lock.Unlock()
+}
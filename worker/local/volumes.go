@@ -0,0 +1,303 @@
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/concourse/baggageclaim"
+	"github.com/nu7hatch/gouuid"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const volumeDataDirName = "volume"
+
+type volumeMetadata struct {
+	Properties baggageclaim.VolumeProperties `json:"properties"`
+	TTL        time.Duration                 `json:"ttl"`
+	ExpiresAt  time.Time                     `json:"expires_at"`
+}
+
+type volumeClient struct {
+	clock clock.Clock
+	dir   string
+
+	lock sync.Mutex
+}
+
+// NewVolumeClient returns a Baggageclaim client whose volumes are
+// directories under dir. Copy-on-write volumes are full copies of their
+// parent.
+func NewVolumeClient(clock clock.Clock, dir string) baggageclaim.Client {
+	return &volumeClient{
+		clock: clock,
+		dir:   dir,
+	}
+}
+
+func (client *volumeClient) CreateVolume(logger lager.Logger, spec baggageclaim.VolumeSpec) (baggageclaim.Volume, error) {
+	client.reapExpiredVolumes(logger)
+
+	guid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	handle := guid.String()
+	volumeDir := filepath.Join(client.dir, handle)
+	dataDir := filepath.Join(volumeDir, volumeDataDirName)
+
+	err = os.MkdirAll(dataDir, 0755)
+	if err != nil {
+		logger.Error("failed-to-create-volume-dir", err)
+		return nil, err
+	}
+
+	switch strategy := spec.Strategy.(type) {
+	case nil:
+	case baggageclaim.COWStrategy:
+		err = copyDir(strategy.Parent.Path(), dataDir)
+	default:
+		err = fmt.Errorf("unsupported volume strategy: %T", strategy)
+	}
+	if err != nil {
+		logger.Error("failed-to-initialize-volume", err)
+		os.RemoveAll(volumeDir)
+		return nil, err
+	}
+
+	metadata := volumeMetadata{
+		Properties: spec.Properties,
+		TTL:        spec.TTL,
+	}
+
+	if metadata.Properties == nil {
+		metadata.Properties = baggageclaim.VolumeProperties{}
+	}
+
+	if spec.TTL > 0 {
+		metadata.ExpiresAt = client.clock.Now().Add(spec.TTL)
+	}
+
+	err = writeVolumeMetadata(volumeDir, metadata)
+	if err != nil {
+		logger.Error("failed-to-write-metadata", err)
+		os.RemoveAll(volumeDir)
+		return nil, err
+	}
+
+	return &volume{
+		handle: handle,
+		dir:    volumeDir,
+		client: client,
+	}, nil
+}
+
+func (client *volumeClient) ListVolumes(logger lager.Logger, properties baggageclaim.VolumeProperties) (baggageclaim.Volumes, error) {
+	client.reapExpiredVolumes(logger)
+
+	entries, err := client.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := baggageclaim.Volumes{}
+
+	for _, entry := range entries {
+		volumeDir := filepath.Join(client.dir, entry.Name())
+
+		metadata, err := readVolumeMetadata(volumeDir)
+		if err != nil {
+			continue
+		}
+
+		if !volumePropertiesMatch(metadata.Properties, properties) {
+			continue
+		}
+
+		volumes = append(volumes, &volume{
+			handle: entry.Name(),
+			dir:    volumeDir,
+			client: client,
+		})
+	}
+
+	return volumes, nil
+}
+
+func (client *volumeClient) LookupVolume(logger lager.Logger, handle string) (baggageclaim.Volume, bool, error) {
+	volumeDir := filepath.Join(client.dir, handle)
+
+	_, err := os.Stat(filepath.Join(volumeDir, metadataFileName))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &volume{
+		handle: handle,
+		dir:    volumeDir,
+		client: client,
+	}, true, nil
+}
+
+func (client *volumeClient) reapExpiredVolumes(logger lager.Logger) {
+	logger = logger.Session("reap-expired-volumes")
+
+	entries, err := client.entries()
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		return
+	}
+
+	now := client.clock.Now()
+
+	for _, entry := range entries {
+		volumeDir := filepath.Join(client.dir, entry.Name())
+
+		metadata, err := readVolumeMetadata(volumeDir)
+		if err != nil {
+			continue
+		}
+
+		if metadata.ExpiresAt.IsZero() || metadata.ExpiresAt.After(now) {
+			continue
+		}
+
+		logger.Info("reaping", lager.Data{"handle": entry.Name()})
+
+		err = os.RemoveAll(volumeDir)
+		if err != nil {
+			logger.Error("failed-to-reap", err, lager.Data{"handle": entry.Name()})
+		}
+	}
+}
+
+func (client *volumeClient) entries() ([]os.FileInfo, error) {
+	entries, err := ioutil.ReadDir(client.dir)
+	if os.IsNotExist(err) {
+		return []os.FileInfo{}, nil
+	}
+
+	return entries, err
+}
+
+func (client *volumeClient) updateVolumeMetadata(volumeDir string, update func(*volumeMetadata)) error {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	metadata, err := readVolumeMetadata(volumeDir)
+	if err != nil {
+		return err
+	}
+
+	update(&metadata)
+
+	return writeVolumeMetadata(volumeDir, metadata)
+}
+
+func readVolumeMetadata(volumeDir string) (volumeMetadata, error) {
+	var metadata volumeMetadata
+
+	payload, err := ioutil.ReadFile(filepath.Join(volumeDir, metadataFileName))
+	if err != nil {
+		return volumeMetadata{}, err
+	}
+
+	err = json.Unmarshal(payload, &metadata)
+	if err != nil {
+		return volumeMetadata{}, fmt.Errorf("malformed volume metadata: %s", err)
+	}
+
+	return metadata, nil
+}
+
+func writeVolumeMetadata(volumeDir string, metadata volumeMetadata) error {
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(filepath.Join(volumeDir, metadataFileName), payload)
+}
+
+func volumePropertiesMatch(properties baggageclaim.VolumeProperties, filter baggageclaim.VolumeProperties) bool {
+	for name, value := range filter {
+		if properties[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+type volume struct {
+	handle string
+	dir    string
+
+	client *volumeClient
+}
+
+func (volume *volume) Handle() string {
+	return volume.handle
+}
+
+func (volume *volume) Path() string {
+	return filepath.Join(volume.dir, volumeDataDirName)
+}
+
+func (volume *volume) SetTTL(ttl time.Duration) error {
+	expiresAt := time.Time{}
+	if ttl > 0 {
+		expiresAt = volume.client.clock.Now().Add(ttl)
+	}
+
+	return volume.client.updateVolumeMetadata(volume.dir, func(metadata *volumeMetadata) {
+		metadata.TTL = ttl
+		metadata.ExpiresAt = expiresAt
+	})
+}
+
+func (volume *volume) SetProperty(name string, value string) error {
+	return volume.client.updateVolumeMetadata(volume.dir, func(metadata *volumeMetadata) {
+		if metadata.Properties == nil {
+			metadata.Properties = baggageclaim.VolumeProperties{}
+		}
+
+		metadata.Properties[name] = value
+	})
+}
+
+func (volume *volume) Expiration() (time.Duration, time.Time, error) {
+	metadata, err := readVolumeMetadata(volume.dir)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return metadata.TTL, metadata.ExpiresAt, nil
+}
+
+func (volume *volume) Properties() (baggageclaim.VolumeProperties, error) {
+	metadata, err := readVolumeMetadata(volume.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.Properties, nil
+}
+
+// Release applies the final TTL, if any. Volumes are not heartbeated, so
+// otherwise they keep whatever TTL they were last given.
+func (volume *volume) Release(finalTTL *time.Duration) {
+	if finalTTL != nil {
+		volume.SetTTL(*finalTTL)
+	}
+}
@@ -0,0 +1,133 @@
+package local_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/atc/worker/local"
+	"github.com/concourse/baggageclaim"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VolumeClient", func() {
+	var (
+		logger    *lagertest.TestLogger
+		fakeClock *fakeclock.FakeClock
+		dir       string
+
+		volumeClient baggageclaim.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "local-volumes")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		volumeClient = local.NewVolumeClient(fakeClock, dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("CreateVolume", func() {
+		It("creates an empty volume that can be looked up", func() {
+			volume, err := volumeClient.CreateVolume(logger, baggageclaim.VolumeSpec{
+				Properties: baggageclaim.VolumeProperties{"some": "property"},
+				TTL:        time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ioutil.ReadDir(volume.Path())).To(BeEmpty())
+
+			foundVolume, found, err := volumeClient.LookupVolume(logger, volume.Handle())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundVolume.Path()).To(Equal(volume.Path()))
+
+			Expect(foundVolume.Properties()).To(Equal(baggageclaim.VolumeProperties{"some": "property"}))
+
+			ttl, expiresAt, err := foundVolume.Expiration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttl).To(Equal(time.Minute))
+			Expect(expiresAt).To(BeTemporally("==", fakeClock.Now().Add(time.Minute)))
+		})
+
+		Context("with a copy-on-write strategy", func() {
+			It("copies the parent volume's contents", func() {
+				parent, err := volumeClient.CreateVolume(logger, baggageclaim.VolumeSpec{})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(parent.Path(), "some-file"), []byte("some-content"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				child, err := volumeClient.CreateVolume(logger, baggageclaim.VolumeSpec{
+					Strategy: baggageclaim.COWStrategy{Parent: parent},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(ioutil.ReadFile(filepath.Join(child.Path(), "some-file"))).To(Equal([]byte("some-content")))
+
+				err = ioutil.WriteFile(filepath.Join(child.Path(), "some-file"), []byte("other-content"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(ioutil.ReadFile(filepath.Join(parent.Path(), "some-file"))).To(Equal([]byte("some-content")))
+			})
+		})
+	})
+
+	Describe("LookupVolume", func() {
+		Context("when the volume does not exist", func() {
+			It("returns false", func() {
+				_, found, err := volumeClient.LookupVolume(logger, "bogus-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ListVolumes", func() {
+		var volumeA baggageclaim.Volume
+
+		BeforeEach(func() {
+			var err error
+			volumeA, err = volumeClient.CreateVolume(logger, baggageclaim.VolumeSpec{
+				Properties: baggageclaim.VolumeProperties{"a": "b"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = volumeClient.CreateVolume(logger, baggageclaim.VolumeSpec{
+				Properties: baggageclaim.VolumeProperties{"a": "c"},
+				TTL:        time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the volumes with matching properties", func() {
+			volumes, err := volumeClient.ListVolumes(logger, baggageclaim.VolumeProperties{"a": "b"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Handle()).To(Equal(volumeA.Handle()))
+		})
+
+		It("reaps volumes whose TTL has passed", func() {
+			fakeClock.Increment(2 * time.Minute)
+
+			volumes, err := volumeClient.ListVolumes(logger, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].Handle()).To(Equal(volumeA.Handle()))
+		})
+	})
+})
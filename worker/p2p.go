@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/concourse/baggageclaim"
+	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/pivotal-golang/lager"
+)
+
+// ErrP2PStreamingUnsupported is returned when either end of a transfer cannot
+// take part in streaming a volume directly between workers.
+var ErrP2PStreamingUnsupported = errors.New("p2p volume streaming is not supported")
+
+// AddressableClient is a Baggageclaim client that knows the URL its server
+// is reachable at, so that other workers can be pointed at its volumes.
+type AddressableClient interface {
+	baggageclaim.Client
+
+	URL() string
+}
+
+type addressableClient struct {
+	baggageclaim.Client
+
+	url string
+}
+
+// NewAddressableClient returns a Baggageclaim client for the server at the
+// given URL.
+func NewAddressableClient(baggageclaimURL string) AddressableClient {
+	return &addressableClient{
+		Client: bclient.New(baggageclaimURL),
+		url:    strings.TrimRight(baggageclaimURL, "/"),
+	}
+}
+
+func (client *addressableClient) URL() string {
+	return client.url
+}
+
+// StreamVolumeP2P copies the contents of the source volume at the given path
+// into the destination volume by having the destination worker's
+// Baggageclaim pull them straight from the source worker's. The ATC only
+// tells the destination where to pull from; none of the data passes through
+// it.
+//
+// ErrP2PStreamingUnsupported is returned if either client's server address
+// is unknown, or if the destination's Baggageclaim does not support pulling
+// volumes.
+func StreamVolumeP2P(
+	logger lager.Logger,
+	sourceClient baggageclaim.Client,
+	source baggageclaim.Volume,
+	path string,
+	destinationClient baggageclaim.Client,
+	destination baggageclaim.Volume,
+) error {
+	sourceAddr, ok := sourceClient.(AddressableClient)
+	if !ok {
+		return ErrP2PStreamingUnsupported
+	}
+
+	destinationAddr, ok := destinationClient.(AddressableClient)
+	if !ok {
+		return ErrP2PStreamingUnsupported
+	}
+
+	streamOutURL := sourceAddr.URL() + "/volumes/" + source.Handle() + "/stream-out?" + url.Values{
+		"path": {path},
+	}.Encode()
+
+	pullURL := destinationAddr.URL() + "/volumes/" + destination.Handle() + "/stream-p2p-in?" + url.Values{
+		"path":   {"."},
+		"source": {streamOutURL},
+	}.Encode()
+
+	logger.Debug("streaming", lager.Data{
+		"source":      source.Handle(),
+		"destination": destination.Handle(),
+	})
+
+	request, err := http.NewRequest("PUT", pullURL, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		logger.Error("failed-to-reach-destination", err)
+		return err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return ErrP2PStreamingUnsupported
+	default:
+		body, _ := ioutil.ReadAll(response.Body)
+		return fmt.Errorf("destination failed to pull volume (%d): %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
@@ -0,0 +1,108 @@
+package worker_test
+
+import (
+	"net/http"
+	"net/url"
+
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+	bfakes "github.com/concourse/baggageclaim/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/pivotal-golang/lager/lagertest"
+)
+
+var _ = Describe("StreamVolumeP2P", func() {
+	var (
+		destinationServer *ghttp.Server
+
+		sourceClient      baggageclaim.Client
+		sourceVolume      *bfakes.FakeVolume
+		destinationClient baggageclaim.Client
+		destinationVolume *bfakes.FakeVolume
+
+		streamErr error
+	)
+
+	BeforeEach(func() {
+		destinationServer = ghttp.NewServer()
+
+		sourceClient = NewAddressableClient("http://source-worker:7788/")
+		sourceVolume = new(bfakes.FakeVolume)
+		sourceVolume.HandleReturns("source-handle")
+
+		destinationClient = NewAddressableClient(destinationServer.URL())
+		destinationVolume = new(bfakes.FakeVolume)
+		destinationVolume.HandleReturns("destination-handle")
+	})
+
+	AfterEach(func() {
+		destinationServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		streamErr = StreamVolumeP2P(
+			lagertest.NewTestLogger("test"),
+			sourceClient,
+			sourceVolume,
+			"some/path",
+			destinationClient,
+			destinationVolume,
+		)
+	})
+
+	Context("when the destination pulls the volume", func() {
+		BeforeEach(func() {
+			destinationServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/destination-handle/stream-p2p-in", url.Values{
+						"path":   {"."},
+						"source": {"http://source-worker:7788/volumes/source-handle/stream-out?path=some%2Fpath"},
+					}.Encode()),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("tells the destination worker to pull from the source worker", func() {
+			Expect(streamErr).NotTo(HaveOccurred())
+			Expect(destinationServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the destination cannot reach the source", func() {
+		BeforeEach(func() {
+			destinationServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusBadGateway, "connection refused\n"),
+			)
+		})
+
+		It("returns the destination's error", func() {
+			Expect(streamErr).To(MatchError("destination failed to pull volume (502): connection refused"))
+		})
+	})
+
+	Context("when the destination does not support pulling volumes", func() {
+		BeforeEach(func() {
+			destinationServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, nil),
+			)
+		})
+
+		It("returns ErrP2PStreamingUnsupported", func() {
+			Expect(streamErr).To(Equal(ErrP2PStreamingUnsupported))
+		})
+	})
+
+	Context("when the source's address is unknown", func() {
+		BeforeEach(func() {
+			sourceClient = new(bfakes.FakeClient)
+		})
+
+		It("returns ErrP2PStreamingUnsupported without contacting the destination", func() {
+			Expect(streamErr).To(Equal(ErrP2PStreamingUnsupported))
+			Expect(destinationServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -20,6 +20,11 @@ type WorkerProvider interface {
 	FindContainerForIdentifier(Identifier) (db.Container, bool, error)
 	GetContainer(string) (db.Container, bool, error)
 	ReapContainer(string) error
+
+	// RecordWorkerResult reports whether a call to the named worker
+	// succeeded, so that a worker which keeps failing can be taken out of
+	// rotation for a while.
+	RecordWorkerResult(workerName string, err error)
 }
 
 var (
@@ -45,6 +50,17 @@ func (err NoCompatibleWorkersError) Error() string {
 	)
 }
 
+// NoAvailableWorkersError is returned when there are workers satisfying the
+// spec, but every one of them is already running as many containers as it
+// can.
+type NoAvailableWorkersError struct {
+	Spec WorkerSpec
+}
+
+func (err NoAvailableWorkersError) Error() string {
+	return fmt.Sprintf("all workers satisfying %s are at capacity", err.Spec.Description())
+}
+
 type pool struct {
 	provider WorkerProvider
 
@@ -103,9 +119,32 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes
 		}
 	}
 
-	shuffleWorkers(compatibleWorkers)
+	availableWorkers := []Worker{}
+	for _, worker := range compatibleWorkers {
+		if !isSaturated(worker) {
+			availableWorkers = append(availableWorkers, worker)
+		}
+	}
+
+	if len(availableWorkers) == 0 {
+		return nil, NoAvailableWorkersError{
+			Spec: spec,
+		}
+	}
+
+	shuffleWorkers(availableWorkers)
+
+	return availableWorkers, nil
+}
 
-	return compatibleWorkers, nil
+// isSaturated judges saturation by the container count the worker reported
+// in its last heartbeat, which may be up to a heartbeat interval stale. Several
+// ATCs can therefore pick a worker that only just has room, briefly taking it
+// over its limit, and a worker that has just freed up can look full until it
+// next heartbeats.
+func isSaturated(worker Worker) bool {
+	maxContainers := worker.MaxContainers()
+	return maxContainers > 0 && worker.ActiveContainers() >= maxContainers
 }
 
 func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
@@ -282,6 +282,42 @@ var _ = Describe("Pool", func() {
 					}))
 				})
 			})
+
+			Context("when a satisfying worker is at capacity", func() {
+				BeforeEach(func() {
+					workerA.MaxContainersReturns(10)
+					workerA.ActiveContainersReturns(10)
+				})
+
+				It("returns only the workers with room for more containers", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(ConsistOf(workerB))
+				})
+
+				Context("when every satisfying worker is at capacity", func() {
+					BeforeEach(func() {
+						workerB.MaxContainersReturns(5)
+						workerB.ActiveContainersReturns(7)
+					})
+
+					It("returns a NoAvailableWorkersError", func() {
+						Expect(satisfyingErr).To(Equal(NoAvailableWorkersError{
+							Spec: spec,
+						}))
+					})
+				})
+			})
+
+			Context("when a satisfying worker has no container limit", func() {
+				BeforeEach(func() {
+					workerA.MaxContainersReturns(0)
+					workerA.ActiveContainersReturns(1000)
+				})
+
+				It("returns it", func() {
+					Expect(satisfyingWorkers).To(ContainElement(workerA))
+				})
+			})
 		})
 
 		Context("with no workers", func() {
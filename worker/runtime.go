@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/garden"
+	"github.com/concourse/atc/worker/local"
+	"github.com/concourse/baggageclaim"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+const localAddrPrefix = "local:"
+
+//go:generate counterfeiter . Runtime
+
+// Runtime is what a worker creates containers on, runs their processes in,
+// and optionally keeps volumes on. Usually these are Garden and Baggageclaim
+// servers, but anything that speaks their APIs will do.
+type Runtime interface {
+	Containers() garden.Client
+	Volumes() (baggageclaim.Client, bool)
+}
+
+type clientRuntime struct {
+	gardenClient       garden.Client
+	baggageclaimClient baggageclaim.Client
+}
+
+// NewRuntime returns a runtime backed by the given clients. The Baggageclaim
+// client may be nil, in which case the worker does not manage volumes.
+func NewRuntime(gardenClient garden.Client, baggageclaimClient baggageclaim.Client) Runtime {
+	return &clientRuntime{
+		gardenClient:       gardenClient,
+		baggageclaimClient: baggageclaimClient,
+	}
+}
+
+// NewLocalRuntime returns a runtime that runs containers as plain processes
+// in directories under dir on the ATC's host. See package local.
+func NewLocalRuntime(logger lager.Logger, clock clock.Clock, dir string) Runtime {
+	return NewRuntime(
+		local.NewClient(logger, clock, filepath.Join(dir, "containers")),
+		local.NewVolumeClient(clock, filepath.Join(dir, "volumes")),
+	)
+}
+
+// LocalAddr is the Garden address to register a worker with so that it uses
+// a local runtime in dir. Only the ATC running it can reach such a worker, so
+// the address names that ATC by owner, e.g. its peer URL, and every other ATC
+// ignores the worker.
+func LocalAddr(owner string, dir string) string {
+	return localAddrPrefix + url.QueryEscape(owner) + ":" + dir
+}
+
+// IsLocalAddr reports whether the Garden address refers to a local runtime.
+// Workers registering through the API must not be allowed to use these, as
+// they would run on the ATC's host.
+func IsLocalAddr(gardenAddr string) bool {
+	return strings.HasPrefix(gardenAddr, localAddrPrefix)
+}
+
+// parseLocalAddr returns the owner and directory of a local runtime's
+// address.
+func parseLocalAddr(gardenAddr string) (string, string, bool) {
+	if !IsLocalAddr(gardenAddr) {
+		return "", "", false
+	}
+
+	segs := strings.SplitN(strings.TrimPrefix(gardenAddr, localAddrPrefix), ":", 2)
+	if len(segs) != 2 {
+		return "", "", false
+	}
+
+	owner, err := url.QueryUnescape(segs[0])
+	if err != nil {
+		return "", "", false
+	}
+
+	return owner, segs[1], true
+}
+
+func (runtime *clientRuntime) Containers() garden.Client {
+	return runtime.gardenClient
+}
+
+func (runtime *clientRuntime) Volumes() (baggageclaim.Client, bool) {
+	if runtime.baggageclaimClient != nil {
+		return runtime.baggageclaimClient, true
+	}
+
+	return nil, false
+}
@@ -18,6 +18,7 @@ import (
 var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 var ErrIncompatiblePlatform = errors.New("incompatible platform")
 var ErrMismatchedTags = errors.New("mismatched tags")
+var ErrMismatchedTeam = errors.New("mismatched team")
 
 const containerKeepalive = 30 * time.Second
 const containerTTL = 5 * time.Minute
@@ -29,6 +30,7 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	MaxContainers() int
 
 	Description() string
 	Name() string
@@ -56,28 +58,33 @@ type gardenWorker struct {
 	clock clock.Clock
 
 	activeContainers int
+	maxContainers    int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             atc.Tags
 	name             string
+	teamID           int
 }
 
 func NewGardenWorker(
-	gardenClient garden.Client,
-	baggageclaimClient baggageclaim.Client,
+	runtime Runtime,
 	volumeFactory VolumeFactory,
 	imageFetcher ImageFetcher,
 	db GardenWorkerDB,
 	provider WorkerProvider,
 	clock clock.Clock,
 	activeContainers int,
+	maxContainers int,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags atc.Tags,
 	name string,
+	teamID int,
 ) Worker {
+	baggageclaimClient, _ := runtime.Volumes()
+
 	return &gardenWorker{
-		gardenClient:       gardenClient,
+		gardenClient:       runtime.Containers(),
 		baggageclaimClient: baggageclaimClient,
 		volumeFactory:      volumeFactory,
 		imageFetcher:       imageFetcher,
@@ -86,10 +93,12 @@ func NewGardenWorker(
 		clock:              clock,
 
 		activeContainers: activeContainers,
+		maxContainers:    maxContainers,
 		resourceTypes:    resourceTypes,
 		platform:         platform,
 		tags:             tags,
 		name:             name,
+		teamID:           teamID,
 	}
 }
 
@@ -119,6 +128,7 @@ func (worker *gardenWorker) CreateContainer(
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
+	worker.provider.RecordWorkerResult(worker.name, err)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +155,7 @@ func (worker *gardenWorker) CreateContainer(
 		worker.db,
 		worker.clock,
 		worker.volumeFactory,
+		worker.name,
 	)
 }
 
@@ -200,6 +211,7 @@ func (worker *gardenWorker) LookupContainer(logger lager.Logger, handle string)
 		worker.db,
 		worker.clock,
 		worker.volumeFactory,
+		worker.name,
 	)
 	if err != nil {
 		logger.Error("failed-to-construct-container", err)
@@ -213,6 +225,10 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) MaxContainers() int {
+	return worker.maxContainers
+}
+
 func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
 	if spec.ResourceType != "" {
 		underlyingType := determineUnderlyingTypeName(spec.ResourceType, resourceTypes)
@@ -240,6 +256,10 @@ func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.Resour
 		return nil, ErrMismatchedTags
 	}
 
+	if worker.teamID != 0 && worker.teamID != spec.TeamID {
+		return nil, ErrMismatchedTeam
+	}
+
 	return worker, nil
 }
 
@@ -33,10 +33,12 @@ var _ = Describe("Worker", func() {
 		fakeWorkerProvider     *wfakes.FakeWorkerProvider
 		fakeClock              *fakeclock.FakeClock
 		activeContainers       int
+		maxContainers          int
 		resourceTypes          []atc.WorkerResourceType
 		platform               string
 		tags                   atc.Tags
 		workerName             string
+		teamID                 int
 
 		gardenWorker Worker
 	)
@@ -51,28 +53,31 @@ var _ = Describe("Worker", func() {
 		fakeWorkerProvider = new(wfakes.FakeWorkerProvider)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		activeContainers = 42
+		maxContainers = 0
 		resourceTypes = []atc.WorkerResourceType{
 			{Type: "some-resource", Image: "some-resource-image"},
 		}
 		platform = "some-platform"
 		tags = atc.Tags{"some", "tags"}
 		workerName = "some-worker"
+		teamID = 0
 	})
 
 	BeforeEach(func() {
 		gardenWorker = NewGardenWorker(
-			fakeGardenClient,
-			fakeBaggageclaimClient,
+			NewRuntime(fakeGardenClient, fakeBaggageclaimClient),
 			fakeVolumeFactory,
 			fakeImageFetcher,
 			fakeGardenWorkerDB,
 			fakeWorkerProvider,
 			fakeClock,
 			activeContainers,
+			maxContainers,
 			resourceTypes,
 			platform,
 			tags,
 			workerName,
+			teamID,
 		)
 	})
 
@@ -83,18 +88,19 @@ var _ = Describe("Worker", func() {
 
 		JustBeforeEach(func() {
 			volumeManager, hasVolumeManager = NewGardenWorker(
-				fakeGardenClient,
-				baggageclaimClient,
+				NewRuntime(fakeGardenClient, baggageclaimClient),
 				fakeVolumeFactory,
 				fakeImageFetcher,
 				fakeGardenWorkerDB,
 				fakeWorkerProvider,
 				fakeClock,
 				activeContainers,
+				maxContainers,
 				resourceTypes,
 				platform,
 				tags,
 				workerName,
+				teamID,
 			).VolumeManager()
 		})
 
@@ -204,6 +210,14 @@ var _ = Describe("Worker", func() {
 						Expect(createErr).NotTo(HaveOccurred())
 					})
 
+					It("records that the worker succeeded", func() {
+						Expect(fakeWorkerProvider.RecordWorkerResultCallCount()).To(Equal(1))
+
+						recordedName, recordedErr := fakeWorkerProvider.RecordWorkerResultArgsForCall(0)
+						Expect(recordedName).To(Equal(workerName))
+						Expect(recordedErr).NotTo(HaveOccurred())
+					})
+
 					It("creates the container with the Garden client, ", func() {
 						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
 						By("temporarily using an empty user until we require one to be set")
@@ -564,6 +578,10 @@ var _ = Describe("Worker", func() {
 					})
 
 					Describe("the created container", func() {
+						It("knows which worker it is on", func() {
+							Expect(createdContainer.WorkerName()).To(Equal(workerName))
+						})
+
 						It("can be destroyed", func() {
 							err := createdContainer.Destroy()
 							Expect(err).NotTo(HaveOccurred())
@@ -646,6 +664,14 @@ var _ = Describe("Worker", func() {
 					It("returns the error", func() {
 						Expect(createErr).To(Equal(disaster))
 					})
+
+					It("records that the worker failed", func() {
+						Expect(fakeWorkerProvider.RecordWorkerResultCallCount()).To(Equal(1))
+
+						recordedName, recordedErr := fakeWorkerProvider.RecordWorkerResultArgsForCall(0)
+						Expect(recordedName).To(Equal(workerName))
+						Expect(recordedErr).To(Equal(disaster))
+					})
 				})
 			})
 
@@ -745,6 +771,25 @@ var _ = Describe("Worker", func() {
 							Expect(spec.Env).To(Equal([]string{"A=1", "B=2", "C=3"}))
 						})
 
+						Context("when the custom type has a version", func() {
+							BeforeEach(func() {
+								for i, customType := range customTypes {
+									if customType.Name == "custom-type-c" {
+										customTypes[i].Version = atc.Version{"some": "version"}
+									}
+								}
+							})
+
+							It("fetches that version of the image", func() {
+								_, fetchImageConfig, _, _, _, _, _, _, _ := fakeImageFetcher.FetchImageArgsForCall(0)
+								Expect(fetchImageConfig).To(Equal(atc.TaskImageConfig{
+									Type:    "custom-type-b",
+									Source:  atc.Source{"some": "source"},
+									Version: atc.Version{"some": "version"},
+								}))
+							})
+						})
+
 						Context("after the container is created", func() {
 							BeforeEach(func() {
 								fakeGardenClient.CreateStub = func(garden.ContainerSpec) (garden.Container, error) {
@@ -1391,10 +1436,12 @@ var _ = Describe("Worker", func() {
 									fakeWorkerProvider,
 									fakeClock,
 									activeContainers,
+									maxContainers,
 									resourceTypes,
 									platform,
 									tags,
 									workerName,
+									teamID,
 								)
 							})
 
@@ -1447,10 +1494,12 @@ var _ = Describe("Worker", func() {
 									fakeWorkerProvider,
 									fakeClock,
 									activeContainers,
+									maxContainers,
 									resourceTypes,
 									platform,
 									tags,
 									workerName,
+									teamID,
 								)
 							})
 
@@ -1843,10 +1892,12 @@ var _ = Describe("Worker", func() {
 				fakeWorkerProvider,
 				fakeClock,
 				activeContainers,
+				maxContainers,
 				resourceTypes,
 				platform,
 				tags,
 				workerName,
+				teamID,
 			)
 
 			satisfyingWorker, satisfyingErr = gardenWorker.Satisfying(spec, customTypes)
@@ -2046,6 +2097,57 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the worker belongs to a team", func() {
+			BeforeEach(func() {
+				teamID = 1
+				spec.Tags = []string{"some"}
+			})
+
+			Context("when the spec is for the same team", func() {
+				BeforeEach(func() {
+					spec.TeamID = 1
+				})
+
+				It("returns the worker", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorker).To(Equal(gardenWorker))
+				})
+			})
+
+			Context("when the spec is for another team", func() {
+				BeforeEach(func() {
+					spec.TeamID = 2
+				})
+
+				It("returns ErrMismatchedTeam", func() {
+					Expect(satisfyingErr).To(Equal(ErrMismatchedTeam))
+				})
+			})
+
+			Context("when the spec is for no team", func() {
+				BeforeEach(func() {
+					spec.TeamID = 0
+				})
+
+				It("returns ErrMismatchedTeam", func() {
+					Expect(satisfyingErr).To(Equal(ErrMismatchedTeam))
+				})
+			})
+		})
+
+		Context("when the worker does not belong to a team", func() {
+			BeforeEach(func() {
+				teamID = 0
+				spec.Tags = []string{"some"}
+				spec.TeamID = 1
+			})
+
+			It("returns the worker for any team", func() {
+				Expect(satisfyingErr).NotTo(HaveOccurred())
+				Expect(satisfyingWorker).To(Equal(gardenWorker))
+			})
+		})
+
 		Context("when the type is not supported by the worker", func() {
 			BeforeEach(func() {
 				spec.ResourceType = "some-other-resource"
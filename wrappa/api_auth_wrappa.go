@@ -62,7 +62,13 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.WritePipe,
 			atc.ListVolumes,
 			atc.GetVersionsDB,
-			atc.CreateJobBuild:
+			atc.CreateJobBuild,
+			atc.PrioritizePendingBuild,
+			atc.CancelPendingBuild,
+			atc.RerunBuild,
+			atc.ListResourceChecks,
+			atc.PinResourceTypeVersion,
+			atc.UnpinResourceTypeVersion:
 			newHandler = auth.CheckAuthHandler(handler, rejector)
 
 		// unauthenticated
@@ -87,7 +93,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetPipeline,
 			atc.ListResources,
 			atc.GetBuildPlan,
-			atc.GetBuildPreparation:
+			atc.GetBuildPreparation,
+			atc.GetBuildTestResults,
+			atc.GetBuildLog,
+			atc.GetBuildPlanGraph,
+			atc.GetPipelineGraph,
+			atc.ListPendingBuilds:
 			if !wrappa.PubliclyViewable {
 				newHandler = auth.CheckAuthHandler(handler, rejector)
 			}
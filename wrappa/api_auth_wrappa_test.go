@@ -104,16 +104,27 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.UnpausePipeline:        authed(inputHandlers[atc.UnpausePipeline]),
 					atc.UnpauseResource:        authed(inputHandlers[atc.UnpauseResource]),
 					atc.WritePipe:              authed(inputHandlers[atc.WritePipe]),
+					atc.PrioritizePendingBuild: authed(inputHandlers[atc.PrioritizePendingBuild]),
+					atc.CancelPendingBuild:     authed(inputHandlers[atc.CancelPendingBuild]),
+					atc.RerunBuild:             authed(inputHandlers[atc.RerunBuild]),
+					atc.ListResourceChecks:     authed(inputHandlers[atc.ListResourceChecks]),
+
+					atc.PinResourceTypeVersion:   authed(inputHandlers[atc.PinResourceTypeVersion]),
+					atc.UnpinResourceTypeVersion: authed(inputHandlers[atc.UnpinResourceTypeVersion]),
 
 					atc.BuildEvents:                   unauthed(inputHandlers[atc.BuildEvents]),
 					atc.BuildResources:                unauthed(inputHandlers[atc.BuildResources]),
 					atc.DownloadCLI:                   unauthed(inputHandlers[atc.DownloadCLI]),
 					atc.GetBuild:                      unauthed(inputHandlers[atc.GetBuild]),
 					atc.GetBuildPreparation:           unauthed(inputHandlers[atc.GetBuildPreparation]),
+					atc.GetBuildTestResults:           unauthed(inputHandlers[atc.GetBuildTestResults]),
+					atc.GetBuildLog:                   unauthed(inputHandlers[atc.GetBuildLog]),
+					atc.GetBuildPlanGraph:             unauthed(inputHandlers[atc.GetBuildPlanGraph]),
 					atc.GetJob:                        unauthed(inputHandlers[atc.GetJob]),
 					atc.GetJobBuild:                   unauthed(inputHandlers[atc.GetJobBuild]),
 					atc.GetLogLevel:                   unauthed(inputHandlers[atc.GetLogLevel]),
 					atc.GetPipeline:                   unauthed(inputHandlers[atc.GetPipeline]),
+					atc.GetPipelineGraph:              unauthed(inputHandlers[atc.GetPipelineGraph]),
 					atc.GetResource:                   unauthed(inputHandlers[atc.GetResource]),
 					atc.ListAuthMethods:               unauthed(inputHandlers[atc.ListAuthMethods]),
 					atc.ListBuilds:                    unauthed(inputHandlers[atc.ListBuilds]),
@@ -125,6 +136,7 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.ListResourceVersions:          unauthed(inputHandlers[atc.ListResourceVersions]),
 					atc.ListResources:                 unauthed(inputHandlers[atc.ListResources]),
 					atc.GetBuildPlan:                  unauthed(inputHandlers[atc.GetBuildPlan]),
+					atc.ListPendingBuilds:             unauthed(inputHandlers[atc.ListPendingBuilds]),
 				}
 			})
 
@@ -177,6 +189,13 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.UnpausePipeline:        authed(inputHandlers[atc.UnpausePipeline]),
 					atc.UnpauseResource:        authed(inputHandlers[atc.UnpauseResource]),
 					atc.WritePipe:              authed(inputHandlers[atc.WritePipe]),
+					atc.PrioritizePendingBuild: authed(inputHandlers[atc.PrioritizePendingBuild]),
+					atc.CancelPendingBuild:     authed(inputHandlers[atc.CancelPendingBuild]),
+					atc.RerunBuild:             authed(inputHandlers[atc.RerunBuild]),
+					atc.ListResourceChecks:     authed(inputHandlers[atc.ListResourceChecks]),
+
+					atc.PinResourceTypeVersion:   authed(inputHandlers[atc.PinResourceTypeVersion]),
+					atc.UnpinResourceTypeVersion: authed(inputHandlers[atc.UnpinResourceTypeVersion]),
 
 					atc.ListAuthMethods: unauthed(inputHandlers[atc.ListAuthMethods]),
 
@@ -185,10 +204,14 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.DownloadCLI:                   authed(inputHandlers[atc.DownloadCLI]),
 					atc.GetBuild:                      authed(inputHandlers[atc.GetBuild]),
 					atc.GetBuildPreparation:           authed(inputHandlers[atc.GetBuildPreparation]),
+					atc.GetBuildTestResults:           authed(inputHandlers[atc.GetBuildTestResults]),
+					atc.GetBuildLog:                   authed(inputHandlers[atc.GetBuildLog]),
+					atc.GetBuildPlanGraph:             authed(inputHandlers[atc.GetBuildPlanGraph]),
 					atc.GetJob:                        authed(inputHandlers[atc.GetJob]),
 					atc.GetJobBuild:                   authed(inputHandlers[atc.GetJobBuild]),
 					atc.GetLogLevel:                   authed(inputHandlers[atc.GetLogLevel]),
 					atc.GetPipeline:                   authed(inputHandlers[atc.GetPipeline]),
+					atc.GetPipelineGraph:              authed(inputHandlers[atc.GetPipelineGraph]),
 					atc.GetResource:                   authed(inputHandlers[atc.GetResource]),
 					atc.ListBuilds:                    authed(inputHandlers[atc.ListBuilds]),
 					atc.ListBuildsWithVersionAsInput:  authed(inputHandlers[atc.ListBuildsWithVersionAsInput]),
@@ -199,6 +222,7 @@ var _ = Describe("APIAuthWrappa", func() {
 					atc.ListResourceVersions:          authed(inputHandlers[atc.ListResourceVersions]),
 					atc.ListResources:                 authed(inputHandlers[atc.ListResources]),
 					atc.GetBuildPlan:                  authed(inputHandlers[atc.GetBuildPlan]),
+					atc.ListPendingBuilds:             authed(inputHandlers[atc.ListPendingBuilds]),
 				}
 			})
 